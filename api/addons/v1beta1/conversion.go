@@ -89,6 +89,10 @@ func Convert_Pointer_v1beta1_ResourceSetBinding_To_v1beta2_ResourceSetBinding(in
 	return autoConvert_v1beta1_ResourceSetBinding_To_v1beta2_ResourceSetBinding(*in, out, s)
 }
 
+func Convert_v1beta2_ResourceSetBinding_To_v1beta1_ResourceSetBinding(in *addonsv1.ResourceSetBinding, out *ResourceSetBinding, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta2_ResourceSetBinding_To_v1beta1_ResourceSetBinding(in, out, s)
+}
+
 func Convert_v1beta2_ResourceSetBinding_To_Pointer_v1beta1_ResourceSetBinding(in *addonsv1.ResourceSetBinding, out **ResourceSetBinding, s apimachineryconversion.Scope) error {
 	if in == nil || reflect.DeepEqual(*in, addonsv1.ResourceSetBinding{}) {
 		return nil
@@ -117,6 +121,14 @@ func Convert_v1beta2_ResourceBinding_To_v1beta1_ResourceBinding(in *addonsv1.Res
 	return nil
 }
 
+func Convert_v1beta2_ClusterResourceSetSpec_To_v1beta1_ClusterResourceSetSpec(in *addonsv1.ClusterResourceSetSpec, out *ClusterResourceSetSpec, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta2_ClusterResourceSetSpec_To_v1beta1_ClusterResourceSetSpec(in, out, s)
+}
+
+func Convert_v1beta2_ResourceRef_To_v1beta1_ResourceRef(in *addonsv1.ResourceRef, out *ResourceRef, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta2_ResourceRef_To_v1beta1_ResourceRef(in, out, s)
+}
+
 // Implement local conversion func because conversion-gen is not aware of conversion func in other packages (see https://github.com/kubernetes/code-generator/issues/94)
 
 func Convert_v1_Condition_To_v1beta1_Condition(in *metav1.Condition, out *clusterv1beta1.Condition, s apimachineryconversion.Scope) error {
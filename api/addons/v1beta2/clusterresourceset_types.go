@@ -36,6 +36,13 @@ const (
 	// ClusterResourceSetResourcesNotAppliedReason is the reason used when applying at least one of the resources to one of the matching clusters failed.
 	ClusterResourceSetResourcesNotAppliedReason = "NotApplied"
 
+	// ClusterResourceSetResourcesFetchFailedReason is the reason used when fetching at least one of the resources from a remote source failed.
+	ClusterResourceSetResourcesFetchFailedReason = "FetchFailed"
+
+	// ClusterResourceSetResourcesWaitingForDependenciesReason is the reason used when the resources are not applied to at least one of the
+	// matching clusters because the ClusterResourceSets the ClusterResourceSet depends on are not ready.
+	ClusterResourceSetResourcesWaitingForDependenciesReason = "WaitingForDependencies"
+
 	// ClusterResourceSetResourcesAppliedWrongSecretTypeReason is the reason used when the Secret's type in the resource list is not supported.
 	ClusterResourceSetResourcesAppliedWrongSecretTypeReason = "WrongSecretType"
 
@@ -52,6 +59,8 @@ const (
 )
 
 // ClusterResourceSetSpec defines the desired state of ClusterResourceSet.
+// +kubebuilder:validation:XValidation:rule="!has(self.resources) || self.resources.all(r, has(r.oci) == (r.kind == 'OCI') && has(r.http) == (r.kind == 'HTTP') && has(r.helmChart) == (r.kind == 'HelmChart'))",message="oci, http and helmChart must be set if and only if kind is respectively OCI, HTTP and HelmChart"
+// +kubebuilder:validation:XValidation:rule="!has(self.resources) || self.resources.all(r, r.kind != 'HelmChart' || !has(r.templating) || r.templating == 'None')",message="templating is not supported for HelmChart resources, use helmChart.values instead"
 type ClusterResourceSetSpec struct {
 	// clusterSelector is the label selector for Clusters. The Clusters that are
 	// selected by this will be the ones affected by this ClusterResourceSet.
@@ -60,7 +69,9 @@ type ClusterResourceSetSpec struct {
 	// +required
 	ClusterSelector metav1.LabelSelector `json:"clusterSelector,omitempty,omitzero"`
 
-	// resources is a list of Secrets/ConfigMaps where each contains 1 or more resources to be applied to remote clusters.
+	// resources is a list of sources for the resources to be applied to remote clusters.
+	// Each source is either a Secret/ConfigMap containing 1 or more resources, or a remote source
+	// like an OCI artifact, an HTTP URL or a Helm chart.
 	// +required
 	// +listType=atomic
 	// +kubebuilder:validation:MinItems=1
@@ -68,9 +79,74 @@ type ClusterResourceSetSpec struct {
 	Resources []ResourceRef `json:"resources,omitempty"`
 
 	// strategy is the strategy to be used during applying resources. Defaults to ApplyOnce. This field is immutable.
-	// +kubebuilder:validation:Enum=ApplyOnce;Reconcile
+	// With ApplyOnce, resources are applied only once to each Cluster.
+	// With Reconcile, resources are applied again to each Cluster when their content changes.
+	// With ServerSideApply, resources are periodically applied to each Cluster using server-side apply, restoring objects
+	// that are modified or deleted in the Cluster, and objects removed from the resources are deleted from the Cluster.
+	// +kubebuilder:validation:Enum=ApplyOnce;Reconcile;ServerSideApply
 	// +optional
 	Strategy string `json:"strategy,omitempty"`
+
+	// dependsOn is a list of ClusterResourceSets in the same namespace that must be ready on a Cluster
+	// before the resources of this ClusterResourceSet are applied to it.
+	// A ClusterResourceSet is ready on a Cluster when all its resources are applied to the Cluster
+	// and all its readiness checks pass.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	DependsOn []ClusterResourceSetDependency `json:"dependsOn,omitempty"`
+
+	// readinessChecks is a list of workloads that must be available in a Cluster, after the resources
+	// are applied to it, for this ClusterResourceSet to be ready on the Cluster.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	ReadinessChecks []ClusterResourceSetReadinessCheck `json:"readinessChecks,omitempty"`
+}
+
+// ClusterResourceSetDependency is a reference to a ClusterResourceSet that must be ready on a Cluster
+// before the resources of another ClusterResourceSet are applied to it.
+type ClusterResourceSetDependency struct {
+	// name of the ClusterResourceSet.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+}
+
+// ClusterResourceSetReadinessCheckKind is a string representation of the kind of workload of a readiness check.
+type ClusterResourceSetReadinessCheckKind string
+
+const (
+	// DeploymentClusterResourceSetReadinessCheckKind checks that a Deployment is rolled out and available.
+	DeploymentClusterResourceSetReadinessCheckKind ClusterResourceSetReadinessCheckKind = "Deployment"
+
+	// DaemonSetClusterResourceSetReadinessCheckKind checks that a DaemonSet is rolled out and available on all
+	// the Nodes it is scheduled on.
+	DaemonSetClusterResourceSetReadinessCheckKind ClusterResourceSetReadinessCheckKind = "DaemonSet"
+)
+
+// ClusterResourceSetReadinessCheck identifies a workload in a Cluster that must be available.
+type ClusterResourceSetReadinessCheck struct {
+	// kind of the workload. Supported kinds are: Deployment and DaemonSet.
+	// +required
+	// +kubebuilder:validation:Enum=Deployment;DaemonSet
+	Kind string `json:"kind,omitempty"`
+
+	// namespace of the workload in the Cluster.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
+	// name of the workload.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
 }
 
 // ClusterResourceSetResourceKind is a string representation of a ClusterResourceSet resource kind.
@@ -80,20 +156,168 @@ type ClusterResourceSetResourceKind string
 const (
 	SecretClusterResourceSetResourceKind    ClusterResourceSetResourceKind = "Secret"
 	ConfigMapClusterResourceSetResourceKind ClusterResourceSetResourceKind = "ConfigMap"
+	OCIClusterResourceSetResourceKind       ClusterResourceSetResourceKind = "OCI"
+	HTTPClusterResourceSetResourceKind      ClusterResourceSetResourceKind = "HTTP"
+	HelmChartClusterResourceSetResourceKind ClusterResourceSetResourceKind = "HelmChart"
+)
+
+// ClusterResourceSetResourceTemplating defines how the content of a ClusterResourceSet resource is rendered for each Cluster.
+type ClusterResourceSetResourceTemplating string
+
+const (
+	// ClusterResourceSetResourceTemplatingNone applies the content of the resource as is to all the Clusters.
+	ClusterResourceSetResourceTemplatingNone ClusterResourceSetResourceTemplating = "None"
+
+	// ClusterResourceSetResourceTemplatingGoTemplate renders the content of the resource as a Go template
+	// for each Cluster before applying it.
+	ClusterResourceSetResourceTemplatingGoTemplate ClusterResourceSetResourceTemplating = "GoTemplate"
 )
 
 // ResourceRef specifies a resource.
 type ResourceRef struct {
-	// name of the resource that is in the same namespace with ClusterResourceSet object.
+	// name of the resource.
+	// For Secrets and ConfigMaps, this is the name of the object that is in the same namespace with ClusterResourceSet object;
+	// for the other kinds, this is a name identifying the resource in the ClusterResourceSet.
 	// +required
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=253
 	Name string `json:"name,omitempty"`
 
-	// kind of the resource. Supported kinds are: Secrets and ConfigMaps.
-	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// kind of the resource. Supported kinds are: Secrets, ConfigMaps, OCI, HTTP and HelmChart.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap;OCI;HTTP;HelmChart
 	// +required
 	Kind string `json:"kind,omitempty"`
+
+	// oci defines the OCI artifact containing the resources.
+	// It must be set when kind is OCI.
+	// +optional
+	OCI ResourceOCISource `json:"oci,omitempty,omitzero"`
+
+	// http defines the HTTP URL serving the resources.
+	// It must be set when kind is HTTP.
+	// +optional
+	HTTP ResourceHTTPSource `json:"http,omitempty,omitzero"`
+
+	// helmChart defines the Helm chart to be rendered into the resources.
+	// It must be set when kind is HelmChart.
+	// +optional
+	HelmChart ResourceHelmChartSource `json:"helmChart,omitempty,omitzero"`
+
+	// templating defines how the content of the resource is rendered for each Cluster before being applied.
+	// When set to GoTemplate, the content is rendered as a Go template with the Sprig functions, with the Cluster
+	// available as .Cluster and the values of the Cluster topology variables as .Variables,
+	// e.g. {{ .Cluster.metadata.name }} or {{ .Variables.region }}.
+	// Templating is not supported for HelmChart resources, whose values are always rendered.
+	// Defaults to None.
+	// +kubebuilder:validation:Enum=None;GoTemplate
+	// +optional
+	Templating ClusterResourceSetResourceTemplating `json:"templating,omitempty"`
+}
+
+// ResourceOCISource defines an OCI artifact containing the resources.
+type ResourceOCISource struct {
+	// url of the OCI artifact, e.g. oci://registry.example.com/addons/cni:v1.0.0.
+	// The artifact can be referenced by tag or by digest, e.g. oci://registry.example.com/addons/cni@sha256:<digest>.
+	// Each layer of the artifact must be either a YAML/JSON document or a tar+gzip archive of YAML/JSON documents.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	// +kubebuilder:validation:Pattern=`^oci://`
+	URL string `json:"url,omitempty"`
+
+	// secretRef is a reference to a Secret in the same namespace with ClusterResourceSet object
+	// with the username and password keys to be used to authenticate to the registry.
+	// +optional
+	SecretRef ResourceSourceSecretReference `json:"secretRef,omitempty,omitzero"`
+}
+
+// ResourceHTTPSource defines an HTTP URL serving the resources.
+type ResourceHTTPSource struct {
+	// url serving either a YAML/JSON document or a tar+gzip archive of YAML/JSON documents,
+	// e.g. https://github.com/example/cni/releases/download/v1.0.0/cni.yaml.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url,omitempty"`
+
+	// checksum is the sha256 checksum of the content served at url, in the sha256:<hex> format.
+	// The content is not applied if its checksum does not match.
+	// +required
+	// +kubebuilder:validation:MinLength=71
+	// +kubebuilder:validation:MaxLength=71
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// ResourceHelmChartSource defines a Helm chart to be rendered into the resources.
+type ResourceHelmChartSource struct {
+	// repositoryURL is the URL of the Helm repository; it can be either the URL of an HTTP repository
+	// serving an index.yaml file, e.g. https://charts.example.com, or the URL of an OCI repository,
+	// e.g. oci://registry.example.com/charts.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	// +kubebuilder:validation:Pattern=`^(https?|oci)://`
+	RepositoryURL string `json:"repositoryURL,omitempty"`
+
+	// chart is the name of the Helm chart.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Chart string `json:"chart,omitempty"`
+
+	// version is the exact version of the Helm chart.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	Version string `json:"version,omitempty"`
+
+	// releaseName is the name of the Helm release used when rendering the chart.
+	// Defaults to the name of the resource.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=53
+	ReleaseName string `json:"releaseName,omitempty"`
+
+	// namespace is the namespace in the remote cluster used when rendering the chart.
+	// Defaults to default.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
+	// values is a YAML document with the values used when rendering the chart.
+	// The values are processed as a Go template before being used, and the Cluster the chart is
+	// applied to is available as .Cluster, e.g. {{ .Cluster.metadata.name }}.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=65536
+	Values string `json:"values,omitempty"`
+
+	// secretRef is a reference to a Secret in the same namespace with ClusterResourceSet object
+	// with the username and password keys to be used to authenticate to the repository.
+	// +optional
+	SecretRef ResourceSourceSecretReference `json:"secretRef,omitempty,omitzero"`
+}
+
+// ResourceSourceSecretReference is a reference to a Secret with credentials for a remote source.
+type ResourceSourceSecretReference struct {
+	// name of the Secret.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+}
+
+// IsRemote returns true if the resource is fetched from a remote source instead of a Secret or a ConfigMap.
+func (r ResourceRef) IsRemote() bool {
+	switch ClusterResourceSetResourceKind(r.Kind) {
+	case OCIClusterResourceSetResourceKind, HTTPClusterResourceSetResourceKind, HelmChartClusterResourceSetResourceKind:
+		return true
+	default:
+		return false
+	}
 }
 
 // ClusterResourceSetStrategy is a string representation of a ClusterResourceSet Strategy.
@@ -106,6 +330,10 @@ const (
 	// ClusterResourceSetStrategyReconcile reapplies the resources managed by a ClusterResourceSet
 	// if their normalized hash changes.
 	ClusterResourceSetStrategyReconcile ClusterResourceSetStrategy = "Reconcile"
+	// ClusterResourceSetStrategyServerSideApply periodically applies the resources managed by a ClusterResourceSet
+	// using server-side apply, restoring objects modified or deleted in the cluster, and deletes the objects
+	// that are removed from the resources.
+	ClusterResourceSetStrategyServerSideApply ClusterResourceSetStrategy = "ServerSideApply"
 )
 
 // SetTypedStrategy sets the Strategy field to the string representation of ClusterResourceSetStrategy.
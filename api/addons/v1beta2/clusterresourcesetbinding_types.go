@@ -17,8 +17,6 @@ limitations under the License.
 package v1beta2
 
 import (
-	"reflect"
-
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -34,6 +32,13 @@ type ResourceBinding struct {
 	// +kubebuilder:validation:MaxLength=256
 	Hash string `json:"hash,omitempty"`
 
+	// digest is the digest of the content resolved from a remote source, e.g. the digest of the manifest of an OCI artifact.
+	// It is not set for Secrets and ConfigMaps.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Digest string `json:"digest,omitempty"`
+
 	// lastAppliedTime identifies when this resource was last applied to the cluster.
 	// +optional
 	LastAppliedTime metav1.Time `json:"lastAppliedTime,omitempty,omitzero"`
@@ -41,6 +46,47 @@ type ResourceBinding struct {
 	// applied is to track if a resource is applied to the cluster or not.
 	// +required
 	Applied *bool `json:"applied,omitempty"`
+
+	// objects is the list of objects applied to the cluster from the resource.
+	// It is only set for the "ServerSideApply" ClusterResourceSet.spec.strategy, and it is used to delete
+	// objects from the cluster when they are removed from the resource.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=1000
+	Objects []ResourceBindingObject `json:"objects,omitempty"`
+
+	// lastDriftCorrectedTime identifies when objects of this resource were last found modified or deleted in the cluster
+	// and restored to the content of the resource.
+	// It is only set for the "ServerSideApply" ClusterResourceSet.spec.strategy.
+	// +optional
+	LastDriftCorrectedTime metav1.Time `json:"lastDriftCorrectedTime,omitempty,omitzero"`
+}
+
+// ResourceBindingObject identifies an object applied to the cluster from a resource.
+type ResourceBindingObject struct {
+	// apiVersion of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=317
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// kind of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Kind string `json:"kind,omitempty"`
+
+	// namespace of the object; it is not set for cluster-scoped objects.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
+	// name of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
 }
 
 // ResourceSetBinding keeps info on all of the resources in a ClusterResourceSet.
@@ -56,6 +102,42 @@ type ResourceSetBinding struct {
 	// +listType=atomic
 	// +kubebuilder:validation:MaxItems=100
 	Resources []ResourceBinding `json:"resources,omitempty"`
+
+	// ready is true when all the resources of the ClusterResourceSet are applied to the cluster
+	// and all the readiness checks of the ClusterResourceSet pass.
+	// ClusterResourceSets depending on this ClusterResourceSet are applied to the cluster only when it is ready.
+	// +optional
+	Ready *bool `json:"ready,omitempty"`
+
+	// message explains why the ClusterResourceSet is not ready on the cluster.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=10240
+	Message string `json:"message,omitempty"`
+}
+
+// IsReady returns true if the ClusterResourceSet is ready on the cluster.
+func (r *ResourceSetBinding) IsReady() bool {
+	return deref(r.Ready, false)
+}
+
+// SetReady sets whether the ClusterResourceSet is ready on the cluster, and the message explaining why it is not ready.
+func (r *ResourceSetBinding) SetReady(ready bool, message string) {
+	r.Ready = &ready
+	r.Message = ""
+	if !ready {
+		r.Message = message
+	}
+}
+
+// GetBinding returns the ResourceSetBinding for a ClusterResourceSet if present.
+func (c *ClusterResourceSetBinding) GetBinding(clusterResourceSetName string) *ResourceSetBinding {
+	for i := range c.Spec.Bindings {
+		if c.Spec.Bindings[i].ClusterResourceSetName == clusterResourceSetName {
+			return &c.Spec.Bindings[i]
+		}
+	}
+	return nil
 }
 
 // IsApplied returns true if the resource is applied to the cluster by checking the cluster's binding.
@@ -65,9 +147,10 @@ func (r *ResourceSetBinding) IsApplied(resourceRef ResourceRef) bool {
 }
 
 // GetResource returns a ResourceBinding for a resource ref if present.
+// Note: resources are identified by name and kind, the definition of remote sources is ignored.
 func (r *ResourceSetBinding) GetResource(resourceRef ResourceRef) *ResourceBinding {
 	for _, resource := range r.Resources {
-		if reflect.DeepEqual(resource.ResourceRef, resourceRef) {
+		if resource.Name == resourceRef.Name && resource.Kind == resourceRef.Kind {
 			return &resource
 		}
 	}
@@ -76,9 +159,11 @@ func (r *ResourceSetBinding) GetResource(resourceRef ResourceRef) *ResourceBindi
 
 // SetBinding sets resourceBinding for a resource in ResourceSetBinding either by updating the existing one or
 // creating a new one.
+// Note: the definition of remote sources is not stored in the ResourceSetBinding.
 func (r *ResourceSetBinding) SetBinding(resourceBinding ResourceBinding) {
+	resourceBinding.ResourceRef = ResourceRef{Name: resourceBinding.Name, Kind: resourceBinding.Kind}
 	for i := range r.Resources {
-		if reflect.DeepEqual(r.Resources[i].ResourceRef, resourceBinding.ResourceRef) {
+		if r.Resources[i].Name == resourceBinding.Name && r.Resources[i].Kind == resourceBinding.Kind {
 			r.Resources[i] = resourceBinding
 			return
 		}
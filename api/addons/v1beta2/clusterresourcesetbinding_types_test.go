@@ -220,3 +220,79 @@ func TestSetResourceBinding(t *testing.T) {
 		})
 	}
 }
+
+func TestSetResourceBindingForRemoteSource(t *testing.T) {
+	resourceRef := ResourceRef{
+		Name: "cni",
+		Kind: "HTTP",
+		HTTP: ResourceHTTPSource{
+			URL:      "https://example.com/cni.yaml",
+			Checksum: "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+
+	CRSBinding := &ResourceSetBinding{ClusterResourceSetName: "test-clusterResourceSet"}
+	CRSBinding.SetBinding(ResourceBinding{
+		ResourceRef: resourceRef,
+		Digest:      resourceRef.HTTP.Checksum,
+		Applied:     new(true),
+	})
+
+	if len(CRSBinding.Resources) != 1 {
+		t.Fatalf("Expected 1 resource binding, got %d", len(CRSBinding.Resources))
+	}
+	if CRSBinding.Resources[0].ResourceRef != (ResourceRef{Name: "cni", Kind: "HTTP"}) {
+		t.Fatalf("Expected the definition of the remote source not to be stored, got %v", CRSBinding.Resources[0].ResourceRef)
+	}
+	if !CRSBinding.IsApplied(resourceRef) {
+		t.Fatalf("Expected resource %v to be applied", resourceRef)
+	}
+
+	// Changing the definition of the remote source does not change the identity of the resource.
+	resourceRef.HTTP.URL = "https://example.com/v2/cni.yaml"
+	if b := CRSBinding.GetResource(resourceRef); b == nil || b.Digest != "sha256:0000000000000000000000000000000000000000000000000000000000000000" {
+		t.Fatalf("Expected to find resource binding for %v, got %v", resourceRef, b)
+	}
+}
+
+func TestResourceSetBindingSetReady(t *testing.T) {
+	binding := &ResourceSetBinding{ClusterResourceSetName: "test-clusterResourceSet"}
+	if binding.IsReady() {
+		t.Fatalf("Expected binding without readiness to not be ready")
+	}
+
+	binding.SetReady(false, "Waiting for ClusterResourceSet cni to be ready")
+	if binding.IsReady() || binding.Message != "Waiting for ClusterResourceSet cni to be ready" {
+		t.Fatalf("Expected binding to not be ready with message, got ready %v, message %q", *binding.Ready, binding.Message)
+	}
+
+	binding.SetReady(true, "ignored")
+	if !binding.IsReady() || binding.Message != "" {
+		t.Fatalf("Expected binding to be ready without message, got ready %v, message %q", *binding.Ready, binding.Message)
+	}
+}
+
+func TestClusterResourceSetBindingGetBinding(t *testing.T) {
+	clusterResourceSetBinding := &ClusterResourceSetBinding{
+		Spec: ClusterResourceSetBindingSpec{
+			Bindings: []ResourceSetBinding{
+				{ClusterResourceSetName: "cni"},
+				{ClusterResourceSetName: "csi"},
+			},
+		},
+	}
+
+	binding := clusterResourceSetBinding.GetBinding("csi")
+	if binding == nil || binding.ClusterResourceSetName != "csi" {
+		t.Fatalf("Expected to get the binding of ClusterResourceSet csi, got %v", binding)
+	}
+	// The returned binding can be used to update the ClusterResourceSetBinding.
+	binding.SetReady(true, "")
+	if !clusterResourceSetBinding.Spec.Bindings[1].IsReady() {
+		t.Fatalf("Expected the binding of ClusterResourceSet csi to be updated")
+	}
+
+	if binding := clusterResourceSetBinding.GetBinding("monitoring"); binding != nil {
+		t.Fatalf("Expected no binding for ClusterResourceSet monitoring, got %v", binding)
+	}
+}
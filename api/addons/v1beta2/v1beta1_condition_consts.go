@@ -38,4 +38,8 @@ const (
 
 	// WrongSecretTypeV1Beta1Reason (Severity=Warning) documents at least one of the Secret's type in the resource list is not supported.
 	WrongSecretTypeV1Beta1Reason = "WrongSecretType"
+
+	// WaitingForDependenciesV1Beta1Reason (Severity=Info) documents resources are not applied to at least one of the matching
+	// clusters because the ClusterResourceSets the ClusterResourceSet depends on are not ready.
+	WaitingForDependenciesV1Beta1Reason = "WaitingForDependencies"
 )
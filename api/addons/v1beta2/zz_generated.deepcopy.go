@@ -133,6 +133,21 @@ func (in *ClusterResourceSetBindingSpec) DeepCopy() *ClusterResourceSetBindingSp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourceSetDependency) DeepCopyInto(out *ClusterResourceSetDependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceSetDependency.
+func (in *ClusterResourceSetDependency) DeepCopy() *ClusterResourceSetDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourceSetDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterResourceSetDeprecatedStatus) DeepCopyInto(out *ClusterResourceSetDeprecatedStatus) {
 	*out = *in
@@ -185,6 +200,21 @@ func (in *ClusterResourceSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourceSetReadinessCheck) DeepCopyInto(out *ClusterResourceSetReadinessCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceSetReadinessCheck.
+func (in *ClusterResourceSetReadinessCheck) DeepCopy() *ClusterResourceSetReadinessCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourceSetReadinessCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterResourceSetSpec) DeepCopyInto(out *ClusterResourceSetSpec) {
 	*out = *in
@@ -194,6 +224,16 @@ func (in *ClusterResourceSetSpec) DeepCopyInto(out *ClusterResourceSetSpec) {
 		*out = make([]ResourceRef, len(*in))
 		copy(*out, *in)
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]ClusterResourceSetDependency, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReadinessChecks != nil {
+		in, out := &in.ReadinessChecks, &out.ReadinessChecks
+		*out = make([]ClusterResourceSetReadinessCheck, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceSetSpec.
@@ -265,6 +305,12 @@ func (in *ResourceBinding) DeepCopyInto(out *ResourceBinding) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]ResourceBindingObject, len(*in))
+		copy(*out, *in)
+	}
+	in.LastDriftCorrectedTime.DeepCopyInto(&out.LastDriftCorrectedTime)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBinding.
@@ -277,9 +323,74 @@ func (in *ResourceBinding) DeepCopy() *ResourceBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceBindingObject) DeepCopyInto(out *ResourceBindingObject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBindingObject.
+func (in *ResourceBindingObject) DeepCopy() *ResourceBindingObject {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceBindingObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceHTTPSource) DeepCopyInto(out *ResourceHTTPSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceHTTPSource.
+func (in *ResourceHTTPSource) DeepCopy() *ResourceHTTPSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceHTTPSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceHelmChartSource) DeepCopyInto(out *ResourceHelmChartSource) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceHelmChartSource.
+func (in *ResourceHelmChartSource) DeepCopy() *ResourceHelmChartSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceHelmChartSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceOCISource) DeepCopyInto(out *ResourceOCISource) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceOCISource.
+func (in *ResourceOCISource) DeepCopy() *ResourceOCISource {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceOCISource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
 	*out = *in
+	out.OCI = in.OCI
+	out.HTTP = in.HTTP
+	out.HelmChart = in.HelmChart
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRef.
@@ -302,6 +413,11 @@ func (in *ResourceSetBinding) DeepCopyInto(out *ResourceSetBinding) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Ready != nil {
+		in, out := &in.Ready, &out.Ready
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSetBinding.
@@ -313,3 +429,18 @@ func (in *ResourceSetBinding) DeepCopy() *ResourceSetBinding {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSourceSecretReference) DeepCopyInto(out *ResourceSourceSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSourceSecretReference.
+func (in *ResourceSourceSecretReference) DeepCopy() *ResourceSourceSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSourceSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
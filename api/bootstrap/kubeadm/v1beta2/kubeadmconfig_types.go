@@ -36,6 +36,14 @@ const (
 	Ignition Format = "ignition"
 )
 
+const (
+	// EtcdRestoreAnnotation is the annotation set on a control plane KubeadmConfig to signal that the Machine is
+	// initializing a new etcd cluster from a snapshot; the annotation value is the name of the snapshot.
+	// KubeadmConfigs with this annotation are always generated using kubeadm init, even if the control plane of
+	// the Cluster is already initialized.
+	EtcdRestoreAnnotation = "bootstrap.cluster.x-k8s.io/etcd-restore"
+)
+
 // KubeadmConfigSpec defines the desired state of KubeadmConfig.
 // Either ClusterConfiguration and InitConfiguration should be defined or the JoinConfiguration should be defined.
 // +kubebuilder:validation:MinProperties=1
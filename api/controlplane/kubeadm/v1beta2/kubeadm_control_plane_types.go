@@ -35,6 +35,58 @@ const (
 	RollingUpdateStrategyType KubeadmControlPlaneRolloutStrategyType = "RollingUpdate"
 )
 
+// KubeadmControlPlaneEtcdSnapshotStorageType defines the types of storage for etcd snapshots taken by a KubeadmControlPlane.
+// +kubebuilder:validation:Enum=Secret;S3
+type KubeadmControlPlaneEtcdSnapshotStorageType string
+
+const (
+	// EtcdSnapshotSecretStorageType stores etcd snapshots in Secrets in the management cluster, in the same
+	// namespace of the KubeadmControlPlane.
+	// NOTE: Secrets are limited to 1MiB, so this storage type can be used only for etcd clusters with a small database;
+	// also, snapshots stored in Secrets can't be restored by KCP, because they don't fit the bootstrap data of a Machine.
+	EtcdSnapshotSecretStorageType KubeadmControlPlaneEtcdSnapshotStorageType = "Secret"
+
+	// EtcdSnapshotS3StorageType stores etcd snapshots in a bucket of an S3 compatible object store; KCP keeps track
+	// of snapshots using Secrets without data in the same namespace of the KubeadmControlPlane.
+	EtcdSnapshotS3StorageType KubeadmControlPlaneEtcdSnapshotStorageType = "S3"
+)
+
+// KubeadmControlPlaneCertificateAuthority defines the certificate authorities of a workload cluster
+// managed by a KubeadmControlPlane.
+// +kubebuilder:validation:Enum=Cluster;Etcd;FrontProxy
+type KubeadmControlPlaneCertificateAuthority string
+
+const (
+	// ClusterCertificateAuthority is the certificate authority of the Kubernetes cluster, stored in the <cluster>-ca Secret.
+	ClusterCertificateAuthority KubeadmControlPlaneCertificateAuthority = "Cluster"
+
+	// EtcdCertificateAuthority is the certificate authority of the etcd cluster, stored in the <cluster>-etcd Secret.
+	EtcdCertificateAuthority KubeadmControlPlaneCertificateAuthority = "Etcd"
+
+	// FrontProxyCertificateAuthority is the certificate authority of the front proxy, stored in the <cluster>-proxy Secret.
+	FrontProxyCertificateAuthority KubeadmControlPlaneCertificateAuthority = "FrontProxy"
+)
+
+// KubeadmControlPlaneRotationPhase defines the phases of a rotation of credentials performed by a KubeadmControlPlane.
+// +kubebuilder:validation:Enum=TrustNew;SignWithNew;RemoveOld;Completed
+type KubeadmControlPlaneRotationPhase string
+
+const (
+	// RotationTrustNewPhase is the phase where new credentials are generated and trusted in addition to the old ones,
+	// while the old credentials are still used for signing.
+	RotationTrustNewPhase KubeadmControlPlaneRotationPhase = "TrustNew"
+
+	// RotationSignWithNewPhase is the phase where new credentials are used for signing, while the old
+	// credentials are still trusted.
+	RotationSignWithNewPhase KubeadmControlPlaneRotationPhase = "SignWithNew"
+
+	// RotationRemoveOldPhase is the phase where old credentials are not trusted anymore.
+	RotationRemoveOldPhase KubeadmControlPlaneRotationPhase = "RemoveOld"
+
+	// RotationCompletedPhase is the phase where a rotation is completed.
+	RotationCompletedPhase KubeadmControlPlaneRotationPhase = "Completed"
+)
+
 const (
 	// KubeadmControlPlaneFinalizer is the finalizer applied to KubeadmControlPlane resources
 	// by its managing controller.
@@ -68,6 +120,38 @@ const (
 	// DefaultMinHealthyPeriodSeconds defines the default minimum period before we consider a remediation on a
 	// machine unrelated from the previous remediation.
 	DefaultMinHealthyPeriodSeconds = int32(60 * 60)
+
+	// EtcdSnapshotLabel is the label KCP sets on objects storing etcd snapshots; the label value is the name of
+	// the KubeadmControlPlane that took the snapshot.
+	EtcdSnapshotLabel = "controlplane.cluster.x-k8s.io/etcd-snapshot"
+
+	// EtcdSnapshotMachineAnnotation is the annotation KCP sets on etcd snapshots to track the name of the Machine
+	// whose removal triggered the snapshot.
+	EtcdSnapshotMachineAnnotation = "controlplane.cluster.x-k8s.io/etcd-snapshot-machine"
+
+	// EtcdSnapshotVersionAnnotation is the annotation KCP sets on etcd snapshots to track the version of the etcd
+	// member the snapshot was taken from.
+	EtcdSnapshotVersionAnnotation = "controlplane.cluster.x-k8s.io/etcd-snapshot-version"
+
+	// EtcdSnapshotSHA256Annotation is the annotation KCP sets on etcd snapshots to track the sha256 checksum of
+	// the snapshot; the checksum is used to verify snapshots downloaded from an object store.
+	EtcdSnapshotSHA256Annotation = "controlplane.cluster.x-k8s.io/etcd-snapshot-sha256"
+
+	// EtcdCompactedRevisionAnnotation is the annotation KCP sets on the KubeadmControlPlane to track the revision
+	// etcd has been compacted to while handling NOSPACE alarms; it is used to avoid compacting etcd again until
+	// there are new revisions to compact.
+	EtcdCompactedRevisionAnnotation = "controlplane.cluster.x-k8s.io/etcd-compacted-revision"
+
+	// DefaultEtcdSnapshotRetention defines the default number of etcd snapshots retained by a KubeadmControlPlane.
+	DefaultEtcdSnapshotRetention = int32(3)
+
+	// DefaultEtcdDefragmentationMinDBSizeMebibytes defines the default minimum size of the database of an etcd member
+	// for the member to be defragmented by a KubeadmControlPlane.
+	DefaultEtcdDefragmentationMinDBSizeMebibytes = int32(100)
+
+	// DefaultEtcdDefragmentationMinFragmentationPercent defines the default minimum percentage of the database of an etcd
+	// member not in use for the member to be defragmented by a KubeadmControlPlane.
+	DefaultEtcdDefragmentationMinFragmentationPercent = int32(50)
 )
 
 // KubeadmControlPlane's Available condition and corresponding reasons.
@@ -155,11 +239,122 @@ const (
 	// machines is not healthy.
 	KubeadmControlPlaneEtcdClusterNotHealthyReason = "NotHealthy"
 
+	// KubeadmControlPlaneEtcdClusterQuorumLostReason surfaces when the API server of the workload cluster
+	// reports that the etcd cluster hosted on KubeadmControlPlane machines can't serve requests because it lost quorum.
+	KubeadmControlPlaneEtcdClusterQuorumLostReason = "QuorumLost"
+
 	// KubeadmControlPlaneEtcdClusterHealthUnknownReason surfaces when the health status of the etcd cluster hosted
 	// on KubeadmControlPlane machines is unknown.
 	KubeadmControlPlaneEtcdClusterHealthUnknownReason = "HealthUnknown"
 )
 
+// KubeadmControlPlane's EtcdSnapshotSucceeded condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneEtcdSnapshotSucceededCondition is true if the last etcd snapshot taken before removing an etcd member
+	// succeeded.
+	// Note: this condition is not set when etcd snapshots are not enabled.
+	KubeadmControlPlaneEtcdSnapshotSucceededCondition = "EtcdSnapshotSucceeded"
+
+	// KubeadmControlPlaneEtcdSnapshotSucceededReason surfaces when the last etcd snapshot succeeded.
+	KubeadmControlPlaneEtcdSnapshotSucceededReason = "Succeeded"
+
+	// KubeadmControlPlaneEtcdSnapshotFailedReason surfaces when the last etcd snapshot failed, and the operation
+	// removing an etcd member is blocked.
+	KubeadmControlPlaneEtcdSnapshotFailedReason = "Failed"
+
+	// KubeadmControlPlaneEtcdSnapshotSkippedReason surfaces when etcd snapshots kept failing for longer than the
+	// failure timeout, and an etcd member has been removed without taking a snapshot.
+	KubeadmControlPlaneEtcdSnapshotSkippedReason = "Skipped"
+)
+
+// KubeadmControlPlane's EtcdRestoring condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneEtcdRestoringCondition surfaces details about the restore of the etcd cluster hosted on machines
+	// managed by this object from a snapshot, if requested via spec.etcd.restore.
+	// Note: this condition is not set when no restore is requested.
+	KubeadmControlPlaneEtcdRestoringCondition = "EtcdRestoring"
+
+	// KubeadmControlPlaneEtcdRestoringReason surfaces when KubeadmControlPlane is restoring etcd from a snapshot.
+	KubeadmControlPlaneEtcdRestoringReason = "Restoring"
+
+	// KubeadmControlPlaneEtcdRestoreBlockedReason surfaces when KubeadmControlPlane cannot start restoring etcd from
+	// a snapshot, e.g. because the etcd cluster is still healthy or because the snapshot does not exist.
+	KubeadmControlPlaneEtcdRestoreBlockedReason = "RestoreBlocked"
+
+	// KubeadmControlPlaneEtcdRestoreCompletedReason surfaces when KubeadmControlPlane completed the restore of etcd
+	// from the snapshot defined in spec.etcd.restore.
+	KubeadmControlPlaneEtcdRestoreCompletedReason = "RestoreCompleted"
+
+	// KubeadmControlPlaneEtcdRestoringInternalErrorReason surfaces unexpected failures when restoring etcd from a snapshot.
+	KubeadmControlPlaneEtcdRestoringInternalErrorReason = clusterv1.InternalErrorReason
+)
+
+// KubeadmControlPlane's CertificateAuthorityRotating condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneCertificateAuthorityRotatingCondition surfaces details about the rotation of the certificate
+	// authorities of the workload cluster, if requested via spec.certificateAuthorityRotation.
+	// Note: this condition is not set when no rotation is requested.
+	KubeadmControlPlaneCertificateAuthorityRotatingCondition = "CertificateAuthorityRotating"
+
+	// KubeadmControlPlaneCertificateAuthorityRotatingReason surfaces when KubeadmControlPlane is rotating certificate authorities.
+	KubeadmControlPlaneCertificateAuthorityRotatingReason = "Rotating"
+
+	// KubeadmControlPlaneCertificateAuthorityRotationBlockedReason surfaces when KubeadmControlPlane cannot start rotating
+	// certificate authorities, e.g. because the control plane is not stable.
+	KubeadmControlPlaneCertificateAuthorityRotationBlockedReason = "RotationBlocked"
+
+	// KubeadmControlPlaneCertificateAuthorityRotationCompletedReason surfaces when KubeadmControlPlane completed the rotation
+	// of certificate authorities requested via spec.certificateAuthorityRotation.
+	KubeadmControlPlaneCertificateAuthorityRotationCompletedReason = "RotationCompleted"
+
+	// KubeadmControlPlaneCertificateAuthorityRotatingInternalErrorReason surfaces unexpected failures when rotating certificate authorities.
+	KubeadmControlPlaneCertificateAuthorityRotatingInternalErrorReason = clusterv1.InternalErrorReason
+)
+
+// KubeadmControlPlane's ServiceAccountKeyRotating condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneServiceAccountKeyRotatingCondition surfaces details about the rotation of the service account
+	// signing key of the workload cluster, if requested via spec.serviceAccountKeyRotation.
+	// Note: this condition is not set when no rotation is requested.
+	KubeadmControlPlaneServiceAccountKeyRotatingCondition = "ServiceAccountKeyRotating"
+
+	// KubeadmControlPlaneServiceAccountKeyRotatingReason surfaces when KubeadmControlPlane is rotating the service account signing key.
+	KubeadmControlPlaneServiceAccountKeyRotatingReason = "Rotating"
+
+	// KubeadmControlPlaneServiceAccountKeyRotationBlockedReason surfaces when KubeadmControlPlane cannot start rotating
+	// the service account signing key, e.g. because the key is provided by the user.
+	KubeadmControlPlaneServiceAccountKeyRotationBlockedReason = "RotationBlocked"
+
+	// KubeadmControlPlaneServiceAccountKeyRotationCompletedReason surfaces when KubeadmControlPlane completed the rotation
+	// of the service account signing key requested via spec.serviceAccountKeyRotation.
+	KubeadmControlPlaneServiceAccountKeyRotationCompletedReason = "RotationCompleted"
+
+	// KubeadmControlPlaneServiceAccountKeyRotatingInternalErrorReason surfaces unexpected failures when rotating the service account signing key.
+	KubeadmControlPlaneServiceAccountKeyRotatingInternalErrorReason = clusterv1.InternalErrorReason
+)
+
+// KubeadmControlPlane's EtcdDefragmenting condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneEtcdDefragmentingCondition is true if at least one etcd member hosted on machines managed by
+	// this object must be defragmented, or if KubeadmControlPlane is handling a NOSPACE alarm.
+	// Note: this condition is not set when defragmentation is not enabled via spec.etcd.defragmentation.
+	KubeadmControlPlaneEtcdDefragmentingCondition = "EtcdDefragmenting"
+
+	// KubeadmControlPlaneEtcdDefragmentingReason surfaces when at least one etcd member must be defragmented.
+	KubeadmControlPlaneEtcdDefragmentingReason = "Defragmenting"
+
+	// KubeadmControlPlaneEtcdNotDefragmentingReason surfaces when no etcd member must be defragmented.
+	KubeadmControlPlaneEtcdNotDefragmentingReason = "NotDefragmenting"
+
+	// KubeadmControlPlaneEtcdDefragmentingInspectionFailedReason documents a failure when inspecting the database
+	// of etcd members hosted on KubeadmControlPlane controlled machines.
+	KubeadmControlPlaneEtcdDefragmentingInspectionFailedReason = clusterv1.InspectionFailedReason
+
+	// KubeadmControlPlaneEtcdDefragmentingInternalErrorReason surfaces unexpected failures when defragmenting etcd members
+	// or when handling a NOSPACE alarm.
+	KubeadmControlPlaneEtcdDefragmentingInternalErrorReason = clusterv1.InternalErrorReason
+)
+
 // KubeadmControlPlane's ControlPlaneComponentsHealthy condition and corresponding reasons.
 const (
 	// KubeadmControlPlaneControlPlaneComponentsHealthyCondition surfaces issues to Kubernetes control plane components
@@ -415,6 +610,26 @@ const (
 	KubeadmControlPlaneMachineEtcdMemberDeletingReason = "Deleting"
 )
 
+// EtcdMemberDefragmenting condition and corresponding reasons that will be used for KubeadmControlPlane controlled machines in v1Beta2 API version.
+const (
+	// KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition is true if the etcd member hosted on a KubeadmControlPlane
+	// controlled machine must be defragmented.
+	// Note: this condition is not set when defragmentation is not enabled via spec.etcd.defragmentation.
+	KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition = "EtcdMemberDefragmenting"
+
+	// KubeadmControlPlaneMachineEtcdMemberDefragmentationPendingReason surfaces when the etcd member hosted on a
+	// KubeadmControlPlane controlled machine must be defragmented.
+	KubeadmControlPlaneMachineEtcdMemberDefragmentationPendingReason = "DefragmentationPending"
+
+	// KubeadmControlPlaneMachineEtcdMemberNotDefragmentingReason surfaces when the etcd member hosted on a
+	// KubeadmControlPlane controlled machine must not be defragmented.
+	KubeadmControlPlaneMachineEtcdMemberNotDefragmentingReason = "NotDefragmenting"
+
+	// KubeadmControlPlaneMachineEtcdMemberDefragmentingInspectionFailedReason documents a failure when inspecting the
+	// database of the etcd member hosted on a KubeadmControlPlane controlled machine.
+	KubeadmControlPlaneMachineEtcdMemberDefragmentingInspectionFailedReason = clusterv1.InspectionFailedReason
+)
+
 // NodeKubeadmLabelsAndTaintsSet condition and corresponding reasons that will be used for KubeadmControlPlane controlled machines in v1Beta2 API version.
 const (
 	// KubeadmControlPlaneMachineNodeKubeadmLabelsAndTaintsSetCondition surfaces the status of node labels and taints that should exist for KubeadmControlPlane controlled machine.
@@ -477,6 +692,29 @@ type KubeadmControlPlaneSpec struct {
 	// InfraMachines & KubeadmConfigs will use the same name as the corresponding Machines.
 	// +optional
 	MachineNaming MachineNamingSpec `json:"machineNaming,omitempty,omitzero"`
+
+	// etcd configures the operations KubeadmControlPlane performs on the etcd cluster hosted on control plane Machines.
+	// NOTE: This field cannot be used when using an external etcd.
+	// +optional
+	Etcd KubeadmControlPlaneEtcdSpec `json:"etcd,omitempty,omitzero"`
+
+	// certificateAuthorityRotation requests KubeadmControlPlane to rotate the certificate authorities of the workload cluster.
+	// The rotation is performed in phases: first new certificate authorities are generated and trusted in addition to the
+	// existing ones, then the new certificate authorities are used for signing, and finally the old certificate authorities
+	// are removed; at each phase all the control plane and worker Machines are rolled out, so they pick up the new
+	// certificate authorities, and the kubeconfig Secret is regenerated.
+	// NOTE: Worker Machines are rolled out by setting spec.rollout.after on MachineDeployments and MachinePools; Machines
+	// not controlled by a MachineDeployment or a MachinePool must be replaced by the user.
+	// +optional
+	CertificateAuthorityRotation KubeadmControlPlaneCertificateAuthorityRotationSpec `json:"certificateAuthorityRotation,omitempty,omitzero"`
+
+	// serviceAccountKeyRotation requests KubeadmControlPlane to rotate the key used to sign service account tokens.
+	// The rotation is performed in phases: first a new key is generated and its public key is accepted by kube-apiserver
+	// in addition to the existing one, then the new key is used for signing, and finally, after an overlap period that
+	// allows tokens signed with the old key to be refreshed, the old public key is removed; at each phase all the
+	// control plane Machines are rolled out.
+	// +optional
+	ServiceAccountKeyRotation KubeadmControlPlaneServiceAccountKeyRotationSpec `json:"serviceAccountKeyRotation,omitempty,omitzero"`
 }
 
 // KubeadmControlPlaneMachineTemplate defines the template for Machines
@@ -693,6 +931,186 @@ type MachineNamingSpec struct {
 	Template string `json:"template,omitempty"`
 }
 
+// KubeadmControlPlaneEtcdSpec configures the operations KubeadmControlPlane performs on the etcd cluster
+// hosted on control plane Machines.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdSpec struct {
+	// snapshot configures etcd snapshots taken by KubeadmControlPlane before removing an etcd member,
+	// e.g. when deleting a control plane Machine during a rollout, a scale down or a remediation.
+	// +optional
+	Snapshot KubeadmControlPlaneEtcdSnapshotSpec `json:"snapshot,omitempty,omitzero"`
+
+	// restore requests KubeadmControlPlane to rebuild the etcd cluster from a snapshot.
+	// This operation is intended to recover from a permanent loss of etcd quorum, and it is performed only
+	// when the EtcdClusterHealthy condition is not true.
+	// When restoring, KubeadmControlPlane deletes all the existing control plane Machines, then it creates
+	// a new control plane Machine initializing a single member etcd cluster from the snapshot; once the restore
+	// is completed, the control plane is scaled up to the desired number of replicas.
+	// NOTE: A snapshot can be restored only once; status.etcd.lastRestore keeps track of the last restored snapshot.
+	// +optional
+	Restore KubeadmControlPlaneEtcdRestoreSpec `json:"restore,omitempty,omitzero"`
+
+	// defragmentation configures automatic defragmentation of etcd members and handling of NOSPACE alarms.
+	// +optional
+	Defragmentation KubeadmControlPlaneEtcdDefragmentationSpec `json:"defragmentation,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneEtcdSnapshotSpec configures etcd snapshots taken by a KubeadmControlPlane.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdSnapshotSpec struct {
+	// enabled defines if KubeadmControlPlane takes an etcd snapshot before removing an etcd member.
+	// If a snapshot cannot be taken, the operation removing the etcd member is blocked; if snapshots keep failing
+	// for more than 15 minutes, etcd members are removed without taking a snapshot until a snapshot succeeds again.
+	// +required
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// retention is the number of etcd snapshots to keep; when a new snapshot is taken the oldest snapshots
+	// exceeding this number are deleted.
+	// If not set, this value is defaulted to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Retention int32 `json:"retention,omitempty"`
+
+	// storage defines where etcd snapshots are stored.
+	// +optional
+	Storage KubeadmControlPlaneEtcdSnapshotStorage `json:"storage,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneEtcdSnapshotStorage defines where etcd snapshots are stored.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdSnapshotStorage struct {
+	// type of storage. Supported storage types are "Secret" and "S3".
+	// Default is Secret.
+	// +required
+	Type KubeadmControlPlaneEtcdSnapshotStorageType `json:"type,omitempty"`
+
+	// s3 configures the S3 compatible object store etcd snapshots are stored in.
+	// Required when type is S3.
+	// +optional
+	S3 KubeadmControlPlaneEtcdSnapshotS3Storage `json:"s3,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneEtcdSnapshotS3Storage defines the S3 compatible object store etcd snapshots are stored in.
+// When restoring etcd, the Machine restoring etcd downloads the snapshot from the object store using a pre-signed URL,
+// so the object store must be reachable from control plane Machines.
+type KubeadmControlPlaneEtcdSnapshotS3Storage struct {
+	// endpoint is the URL of the object store, e.g. https://s3.us-east-1.amazonaws.com.
+	// Only https endpoints without path are supported; objects are addressed using path-style URLs, e.g. <endpoint>/<bucket>/<key>.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// region of the object store, used when signing requests.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Region string `json:"region,omitempty"`
+
+	// bucket etcd snapshots are stored in.
+	// +required
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	Bucket string `json:"bucket,omitempty"`
+
+	// prefix is prepended to the object keys of etcd snapshots, e.g. <prefix>/<namespace>/<snapshot name>.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	Prefix string `json:"prefix,omitempty"`
+
+	// credentialsSecretName is the name of a Secret in the same namespace of the KubeadmControlPlane with
+	// the credentials for the object store, stored under the accessKeyID and secretAccessKey keys.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+}
+
+// KubeadmControlPlaneEtcdRestoreSpec defines the etcd snapshot to restore.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdRestoreSpec struct {
+	// snapshotName is the name of the etcd snapshot to restore.
+	// Only snapshots stored in an object store can be restored, i.e. etcd.snapshot.storage.type must be S3.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	SnapshotName string `json:"snapshotName,omitempty"`
+}
+
+// KubeadmControlPlaneEtcdDefragmentationSpec configures automatic defragmentation of etcd members.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdDefragmentationSpec struct {
+	// enabled defines if KubeadmControlPlane defragments etcd members when the size of their database and the
+	// percentage of the database not in use exceed the thresholds defined below.
+	// Members are defragmented one at a time, followers first and the leader last, and only when the control plane
+	// is stable, e.g. not during a rollout or a scale operation.
+	// +required
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// minDBSizeMebibytes is the minimum size of the database of an etcd member, in mebibytes, for the member to be defragmented.
+	// If not set, this value is defaulted to 100.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinDBSizeMebibytes int32 `json:"minDBSizeMebibytes,omitempty"`
+
+	// minFragmentationPercent is the minimum percentage of the database of an etcd member not in use
+	// for the member to be defragmented.
+	// If not set, this value is defaulted to 50.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MinFragmentationPercent int32 `json:"minFragmentationPercent,omitempty"`
+
+	// disarmNoSpaceAlarm defines if KubeadmControlPlane handles NOSPACE alarms, raised when an etcd member exceeds
+	// its storage quota. When handling a NOSPACE alarm, KubeadmControlPlane compacts etcd, defragments all the members
+	// with space not in use after compaction regardless of minFragmentationPercent and minDBSizeMebibytes, and then disarms the alarm.
+	// NOTE: If compaction does not free enough space, etcd raises the alarm again; in this case the etcd storage quota
+	// must be increased.
+	// If not set, this value is defaulted to true.
+	// +optional
+	DisarmNoSpaceAlarm *bool `json:"disarmNoSpaceAlarm,omitempty"`
+}
+
+// KubeadmControlPlaneCertificateAuthorityRotationSpec defines a rotation of the certificate authorities of the workload cluster.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneCertificateAuthorityRotationSpec struct {
+	// requestID identifies a rotation; setting this field to a value different from status.certificateAuthorityRotation.requestID
+	// starts a new rotation as soon as the control plane is stable and the previous rotation, if any, is completed.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	RequestID string `json:"requestID,omitempty"`
+
+	// certificateAuthorities is the list of certificate authorities to rotate.
+	// If not set, all the certificate authorities managed by KubeadmControlPlane are rotated; please note that
+	// the etcd certificate authority cannot be rotated when using an external etcd.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=3
+	CertificateAuthorities []KubeadmControlPlaneCertificateAuthority `json:"certificateAuthorities,omitempty"`
+}
+
+// KubeadmControlPlaneServiceAccountKeyRotationSpec defines a rotation of the service account signing key of the workload cluster.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneServiceAccountKeyRotationSpec struct {
+	// requestID identifies a rotation; setting this field to a value different from status.serviceAccountKeyRotation.requestID
+	// starts a new rotation as soon as the control plane is stable and the previous rotation, if any, is completed.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	RequestID string `json:"requestID,omitempty"`
+
+	// overlapPeriodSeconds is the minimum amount of time the old public key is still accepted after all the control plane
+	// Machines started signing service account tokens with the new key, so tokens signed with the old key can be refreshed.
+	// If not set, it defaults to 3600 seconds, which is enough for tokens projected into Pods by the kubelet.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	OverlapPeriodSeconds *int32 `json:"overlapPeriodSeconds,omitempty"`
+}
+
 // KubeadmControlPlaneStatus defines the observed state of KubeadmControlPlane.
 // +kubebuilder:validation:MinProperties=1
 type KubeadmControlPlaneStatus struct {
@@ -753,6 +1171,18 @@ type KubeadmControlPlaneStatus struct {
 	// +optional
 	LastRemediation LastRemediationStatus `json:"lastRemediation,omitempty,omitzero"`
 
+	// etcd reports the status of the operations KubeadmControlPlane performs on the etcd cluster.
+	// +optional
+	Etcd KubeadmControlPlaneEtcdStatus `json:"etcd,omitempty,omitzero"`
+
+	// certificateAuthorityRotation reports the status of the last rotation of the certificate authorities of the workload cluster.
+	// +optional
+	CertificateAuthorityRotation KubeadmControlPlaneCertificateAuthorityRotationStatus `json:"certificateAuthorityRotation,omitempty,omitzero"`
+
+	// serviceAccountKeyRotation reports the status of the last rotation of the service account signing key of the workload cluster.
+	// +optional
+	ServiceAccountKeyRotation KubeadmControlPlaneServiceAccountKeyRotationStatus `json:"serviceAccountKeyRotation,omitempty,omitzero"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *KubeadmControlPlaneDeprecatedStatus `json:"deprecated,omitempty"`
@@ -854,6 +1284,89 @@ type LastRemediationStatus struct {
 	RetryCount *int32 `json:"retryCount,omitempty"`
 }
 
+// KubeadmControlPlaneEtcdStatus reports the status of the operations KubeadmControlPlane performs on the etcd cluster.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdStatus struct {
+	// lastSnapshot stores info about the last etcd snapshot taken.
+	// +optional
+	LastSnapshot KubeadmControlPlaneEtcdSnapshotStatus `json:"lastSnapshot,omitempty,omitzero"`
+
+	// lastRestore stores info about the last etcd restore completed.
+	// +optional
+	LastRestore KubeadmControlPlaneEtcdRestoreStatus `json:"lastRestore,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneEtcdSnapshotStatus stores info about an etcd snapshot.
+type KubeadmControlPlaneEtcdSnapshotStatus struct {
+	// name of the etcd snapshot.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+
+	// time is when the etcd snapshot was taken. It is represented in RFC3339 form and is in UTC.
+	// +required
+	Time metav1.Time `json:"time,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneEtcdRestoreStatus stores info about an etcd restore.
+type KubeadmControlPlaneEtcdRestoreStatus struct {
+	// snapshotName is the name of the etcd snapshot that has been restored.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	SnapshotName string `json:"snapshotName,omitempty"`
+
+	// time is when the etcd restore completed. It is represented in RFC3339 form and is in UTC.
+	// +required
+	Time metav1.Time `json:"time,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneCertificateAuthorityRotationStatus reports the status of a rotation of the certificate authorities
+// of the workload cluster.
+type KubeadmControlPlaneCertificateAuthorityRotationStatus struct {
+	// requestID of the rotation.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	RequestID string `json:"requestID,omitempty"`
+
+	// certificateAuthorities is the list of certificate authorities being rotated.
+	// +required
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=3
+	CertificateAuthorities []KubeadmControlPlaneCertificateAuthority `json:"certificateAuthorities,omitempty"`
+
+	// phase of the rotation.
+	// +required
+	Phase KubeadmControlPlaneRotationPhase `json:"phase,omitempty"`
+
+	// phaseStartTime is when the current phase started; Machines created before this time are rolled out.
+	// It is represented in RFC3339 form and is in UTC.
+	// +required
+	PhaseStartTime metav1.Time `json:"phaseStartTime,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneServiceAccountKeyRotationStatus reports the status of a rotation of the service account signing key
+// of the workload cluster.
+type KubeadmControlPlaneServiceAccountKeyRotationStatus struct {
+	// requestID of the rotation.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	RequestID string `json:"requestID,omitempty"`
+
+	// phase of the rotation.
+	// +required
+	Phase KubeadmControlPlaneRotationPhase `json:"phase,omitempty"`
+
+	// phaseStartTime is when the current phase started; control plane Machines created before this time are rolled out.
+	// It is represented in RFC3339 form and is in UTC.
+	// +required
+	PhaseStartTime metav1.Time `json:"phaseStartTime,omitempty,omitzero"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=kubeadmcontrolplanes,shortName=kcp,scope=Namespaced,categories=cluster-api
 // +kubebuilder:storageversion
@@ -54,6 +54,47 @@ func (in *KubeadmControlPlane) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneCertificateAuthorityRotationSpec) DeepCopyInto(out *KubeadmControlPlaneCertificateAuthorityRotationSpec) {
+	*out = *in
+	if in.CertificateAuthorities != nil {
+		in, out := &in.CertificateAuthorities, &out.CertificateAuthorities
+		*out = make([]KubeadmControlPlaneCertificateAuthority, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneCertificateAuthorityRotationSpec.
+func (in *KubeadmControlPlaneCertificateAuthorityRotationSpec) DeepCopy() *KubeadmControlPlaneCertificateAuthorityRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneCertificateAuthorityRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneCertificateAuthorityRotationStatus) DeepCopyInto(out *KubeadmControlPlaneCertificateAuthorityRotationStatus) {
+	*out = *in
+	if in.CertificateAuthorities != nil {
+		in, out := &in.CertificateAuthorities, &out.CertificateAuthorities
+		*out = make([]KubeadmControlPlaneCertificateAuthority, len(*in))
+		copy(*out, *in)
+	}
+	in.PhaseStartTime.DeepCopyInto(&out.PhaseStartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneCertificateAuthorityRotationStatus.
+func (in *KubeadmControlPlaneCertificateAuthorityRotationStatus) DeepCopy() *KubeadmControlPlaneCertificateAuthorityRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneCertificateAuthorityRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlaneDeprecatedStatus) DeepCopyInto(out *KubeadmControlPlaneDeprecatedStatus) {
 	*out = *in
@@ -74,6 +115,165 @@ func (in *KubeadmControlPlaneDeprecatedStatus) DeepCopy() *KubeadmControlPlaneDe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdDefragmentationSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdDefragmentationSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisarmNoSpaceAlarm != nil {
+		in, out := &in.DisarmNoSpaceAlarm, &out.DisarmNoSpaceAlarm
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdDefragmentationSpec.
+func (in *KubeadmControlPlaneEtcdDefragmentationSpec) DeepCopy() *KubeadmControlPlaneEtcdDefragmentationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdDefragmentationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdRestoreSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdRestoreSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdRestoreSpec.
+func (in *KubeadmControlPlaneEtcdRestoreSpec) DeepCopy() *KubeadmControlPlaneEtcdRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdRestoreStatus) DeepCopyInto(out *KubeadmControlPlaneEtcdRestoreStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdRestoreStatus.
+func (in *KubeadmControlPlaneEtcdRestoreStatus) DeepCopy() *KubeadmControlPlaneEtcdRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdSnapshotS3Storage) DeepCopyInto(out *KubeadmControlPlaneEtcdSnapshotS3Storage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdSnapshotS3Storage.
+func (in *KubeadmControlPlaneEtcdSnapshotS3Storage) DeepCopy() *KubeadmControlPlaneEtcdSnapshotS3Storage {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdSnapshotS3Storage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdSnapshotSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdSnapshotSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	out.Storage = in.Storage
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdSnapshotSpec.
+func (in *KubeadmControlPlaneEtcdSnapshotSpec) DeepCopy() *KubeadmControlPlaneEtcdSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdSnapshotStatus) DeepCopyInto(out *KubeadmControlPlaneEtcdSnapshotStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdSnapshotStatus.
+func (in *KubeadmControlPlaneEtcdSnapshotStatus) DeepCopy() *KubeadmControlPlaneEtcdSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdSnapshotStorage) DeepCopyInto(out *KubeadmControlPlaneEtcdSnapshotStorage) {
+	*out = *in
+	out.S3 = in.S3
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdSnapshotStorage.
+func (in *KubeadmControlPlaneEtcdSnapshotStorage) DeepCopy() *KubeadmControlPlaneEtcdSnapshotStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdSnapshotStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdSpec) {
+	*out = *in
+	in.Snapshot.DeepCopyInto(&out.Snapshot)
+	out.Restore = in.Restore
+	in.Defragmentation.DeepCopyInto(&out.Defragmentation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdSpec.
+func (in *KubeadmControlPlaneEtcdSpec) DeepCopy() *KubeadmControlPlaneEtcdSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdStatus) DeepCopyInto(out *KubeadmControlPlaneEtcdStatus) {
+	*out = *in
+	in.LastSnapshot.DeepCopyInto(&out.LastSnapshot)
+	in.LastRestore.DeepCopyInto(&out.LastRestore)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdStatus.
+func (in *KubeadmControlPlaneEtcdStatus) DeepCopy() *KubeadmControlPlaneEtcdStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlaneInitializationStatus) DeepCopyInto(out *KubeadmControlPlaneInitializationStatus) {
 	*out = *in
@@ -299,6 +499,42 @@ func (in *KubeadmControlPlaneRolloutStrategyRollingUpdate) DeepCopy() *KubeadmCo
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneServiceAccountKeyRotationSpec) DeepCopyInto(out *KubeadmControlPlaneServiceAccountKeyRotationSpec) {
+	*out = *in
+	if in.OverlapPeriodSeconds != nil {
+		in, out := &in.OverlapPeriodSeconds, &out.OverlapPeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneServiceAccountKeyRotationSpec.
+func (in *KubeadmControlPlaneServiceAccountKeyRotationSpec) DeepCopy() *KubeadmControlPlaneServiceAccountKeyRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneServiceAccountKeyRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneServiceAccountKeyRotationStatus) DeepCopyInto(out *KubeadmControlPlaneServiceAccountKeyRotationStatus) {
+	*out = *in
+	in.PhaseStartTime.DeepCopyInto(&out.PhaseStartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneServiceAccountKeyRotationStatus.
+func (in *KubeadmControlPlaneServiceAccountKeyRotationStatus) DeepCopy() *KubeadmControlPlaneServiceAccountKeyRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneServiceAccountKeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlaneSpec) DeepCopyInto(out *KubeadmControlPlaneSpec) {
 	*out = *in
@@ -312,6 +548,9 @@ func (in *KubeadmControlPlaneSpec) DeepCopyInto(out *KubeadmControlPlaneSpec) {
 	in.Rollout.DeepCopyInto(&out.Rollout)
 	in.Remediation.DeepCopyInto(&out.Remediation)
 	out.MachineNaming = in.MachineNaming
+	in.Etcd.DeepCopyInto(&out.Etcd)
+	in.CertificateAuthorityRotation.DeepCopyInto(&out.CertificateAuthorityRotation)
+	in.ServiceAccountKeyRotation.DeepCopyInto(&out.ServiceAccountKeyRotation)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneSpec.
@@ -356,6 +595,9 @@ func (in *KubeadmControlPlaneStatus) DeepCopyInto(out *KubeadmControlPlaneStatus
 		**out = **in
 	}
 	in.LastRemediation.DeepCopyInto(&out.LastRemediation)
+	in.Etcd.DeepCopyInto(&out.Etcd)
+	in.CertificateAuthorityRotation.DeepCopyInto(&out.CertificateAuthorityRotation)
+	in.ServiceAccountKeyRotation.DeepCopyInto(&out.ServiceAccountKeyRotation)
 	if in.Deprecated != nil {
 		in, out := &in.Deprecated, &out.Deprecated
 		*out = new(KubeadmControlPlaneDeprecatedStatus)
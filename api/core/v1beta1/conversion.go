@@ -1387,6 +1387,10 @@ func Convert_v1beta1_MachinePoolSpec_To_v1beta2_MachinePoolSpec(in *MachinePoolS
 	return autoConvert_v1beta1_MachinePoolSpec_To_v1beta2_MachinePoolSpec(in, out, s)
 }
 
+func Convert_v1beta2_MachinePoolSpec_To_v1beta1_MachinePoolSpec(in *clusterv1.MachinePoolSpec, out *MachinePoolSpec, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta2_MachinePoolSpec_To_v1beta1_MachinePoolSpec(in, out, s)
+}
+
 func Convert_v1beta1_ClusterClassStatusVariableDefinition_To_v1beta2_ClusterClassStatusVariableDefinition(in *ClusterClassStatusVariableDefinition, out *clusterv1.ClusterClassStatusVariableDefinition, s apimachineryconversion.Scope) error {
 	if err := autoConvert_v1beta1_ClusterClassStatusVariableDefinition_To_v1beta2_ClusterClassStatusVariableDefinition(in, out, s); err != nil {
 		return err
@@ -1796,6 +1800,11 @@ func Convert_v1beta2_MachineDeletionStatus_To_v1beta1_MachineDeletionStatus(in *
 	return nil
 }
 
+func Convert_v1beta2_MachineDrainRuleDrainConfig_To_v1beta1_MachineDrainRuleDrainConfig(in *clusterv1.MachineDrainRuleDrainConfig, out *MachineDrainRuleDrainConfig, s apimachineryconversion.Scope) error {
+	// NOTE: EvictAfter and ForceDelete do not exist in v1beta1, they are restored from the conversion annotation.
+	return autoConvert_v1beta2_MachineDrainRuleDrainConfig_To_v1beta1_MachineDrainRuleDrainConfig(in, out, s)
+}
+
 func deref[T any](ptr *T, def T) T {
 	if ptr != nil {
 		return *ptr
@@ -354,6 +354,21 @@ const (
 	ClusterRemoteConnectionProbeSucceededReason = "ProbeSucceeded"
 )
 
+// Cluster's ClusterResourceSetsReady condition and corresponding reasons.
+const (
+	// ClusterClusterResourceSetsReadyCondition is true when all the ClusterResourceSets applied to the Cluster are ready,
+	// i.e. all their resources are applied to the Cluster and all their readiness checks pass.
+	// Note: This condition is added only if at least one ClusterResourceSet is applied to the Cluster.
+	ClusterClusterResourceSetsReadyCondition = "ClusterResourceSetsReady"
+
+	// ClusterClusterResourceSetsReadyReason surfaces when all the ClusterResourceSets applied to the Cluster are ready.
+	ClusterClusterResourceSetsReadyReason = ReadyReason
+
+	// ClusterClusterResourceSetsNotReadyReason surfaces when at least one of the ClusterResourceSets applied to the Cluster
+	// is not ready.
+	ClusterClusterResourceSetsNotReadyReason = NotReadyReason
+)
+
 // Cluster's RollingOut condition and corresponding reasons.
 const (
 	// ClusterRollingOutCondition is the summary of `RollingOut` conditions from ControlPlane, MachineDeployments
@@ -466,6 +481,10 @@ const (
 	// waits for the InfraCluster to be deleted.
 	ClusterDeletingWaitingForInfrastructureDeletionReason = "WaitingForInfrastructureDeletion"
 
+	// ClusterDeletingWaitingForAfterDeleteHookReason surfaces when all the objects of the Cluster have been deleted
+	// and the Cluster deletion waits for the AfterClusterDelete hook to be called.
+	ClusterDeletingWaitingForAfterDeleteHookReason = "WaitingForAfterDeleteHook"
+
 	// ClusterDeletingDeletionCompletedReason surfaces when the Cluster deletion has been completed.
 	// This reason is set right after the `cluster.cluster.x-k8s.io` finalizer is removed.
 	// This means that the object will go away (i.e. be removed from etcd), except if there are other
@@ -518,6 +537,58 @@ type ClusterSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=32
 	AvailabilityGates []ClusterAvailabilityGate `json:"availabilityGates,omitempty"`
+
+	// kubeconfig defines how the kubeconfig of the Cluster is made available to its consumers.
+	// NOTE: It is required to enable the KubeconfigMirroring
+	// feature gate flag to set this field.
+	// +optional
+	Kubeconfig ClusterKubeconfig `json:"kubeconfig,omitempty,omitzero"`
+}
+
+// ClusterKubeconfig defines how the kubeconfig of the Cluster is made available to its consumers.
+// +kubebuilder:validation:MinProperties=1
+type ClusterKubeconfig struct {
+	// mirrors is a list of additional Secrets the kubeconfig of the Cluster is copied to, e.g. to make it
+	// available to tools running in other namespaces.
+	// Mirrored Secrets are kept up to date when the kubeconfig is rotated, and they are deleted when
+	// the corresponding entry is removed from this list or when the Cluster is deleted.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	Mirrors []ClusterKubeconfigMirror `json:"mirrors,omitempty"`
+}
+
+// ClusterKubeconfigMirror defines Secrets the kubeconfig of the Cluster is copied to.
+// +kubebuilder:validation:XValidation:rule="has(self.__namespace__) != has(self.namespaceSelector)",message="exactly one of namespace or namespaceSelector must be set"
+type ClusterKubeconfigMirror struct {
+	// name of the mirrored Secrets.
+	// If not set, the name of the kubeconfig Secret of the Cluster, <cluster-name>-kubeconfig, is used.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+
+	// namespace of the mirrored Secret.
+	// Exactly one of namespace or namespaceSelector must be set.
+	// Namespaces other than the namespace of the Cluster must allow mirrors of the Cluster with the
+	// cluster.x-k8s.io/kubeconfig-mirrors-allowed-from annotation.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
+	// namespaceSelector is a label selector which selects the Namespaces the kubeconfig is mirrored to.
+	// This field follows standard label selector semantics; an empty selector selects all Namespaces.
+	// Exactly one of namespace or namespaceSelector must be set.
+	// Namespaces other than the namespace of the Cluster are selected only if they allow mirrors of the Cluster
+	// with the cluster.x-k8s.io/kubeconfig-mirrors-allowed-from annotation.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// labels are added to the mirrored Secrets, e.g. to allow downstream tools to discover them.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // ConditionPolarity defines the polarity for a metav1.Condition.
@@ -1178,6 +1249,7 @@ type MachineDeploymentTopologyRolloutStrategy struct {
 	// type of rollout. Allowed values are RollingUpdate and OnDelete.
 	// Default is RollingUpdate.
 	// +required
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
 	Type MachineDeploymentRolloutStrategyType `json:"type,omitempty"`
 
 	// rollingUpdate is the rolling update config params. Present only if
@@ -1449,6 +1521,10 @@ type ClusterStatus struct {
 	// +optional
 	Workers *WorkersStatus `json:"workers,omitempty"`
 
+	// kubeconfig groups all the observations about the kubeconfig of the Cluster.
+	// +optional
+	Kubeconfig *ClusterKubeconfigStatus `json:"kubeconfig,omitempty"`
+
 	// failureDomains is a slice of failure domain objects synced from the infrastructure provider.
 	// +optional
 	// +listType=map
@@ -1492,6 +1568,19 @@ type ClusterInitializationStatus struct {
 	ControlPlaneInitialized *bool `json:"controlPlaneInitialized,omitempty"`
 }
 
+// ClusterKubeconfigStatus groups all the observations about the kubeconfig of the Cluster.
+// +kubebuilder:validation:MinProperties=1
+type ClusterKubeconfigStatus struct {
+	// certificateExpirationTime is the time when the client certificate in the kubeconfig of the Cluster expires.
+	// +optional
+	CertificateExpirationTime metav1.Time `json:"certificateExpirationTime,omitempty,omitzero"`
+
+	// lastRotationTime is the last time the Cluster controller observed the kubeconfig of the Cluster being
+	// rotated, i.e. a kubeconfig with a new client certificate.
+	// +optional
+	LastRotationTime metav1.Time `json:"lastRotationTime,omitempty,omitzero"`
+}
+
 // ClusterDeprecatedStatus groups all the status fields that are deprecated and will be removed in a future version.
 // See https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20240916-improve-status-in-CAPI-resources.md for more context.
 type ClusterDeprecatedStatus struct {
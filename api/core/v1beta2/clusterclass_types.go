@@ -722,6 +722,7 @@ type MachineDeploymentClassRolloutStrategy struct {
 	// type of rollout. Allowed values are RollingUpdate and OnDelete.
 	// Default is RollingUpdate.
 	// +required
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
 	Type MachineDeploymentRolloutStrategyType `json:"type,omitempty"`
 
 	// rollingUpdate is the rolling update config params. Present only if
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterKubeconfigRequestNameLabel is the label set on Secrets created for a ClusterKubeconfigRequest.
+	ClusterKubeconfigRequestNameLabel = "cluster.x-k8s.io/kubeconfig-request-name"
+
+	// DefaultClusterKubeconfigRequestTTLSeconds is the default validity of kubeconfigs issued for a ClusterKubeconfigRequest.
+	DefaultClusterKubeconfigRequestTTLSeconds int32 = 3600
+)
+
+// ClusterKubeconfigRequest's Ready condition and corresponding reasons.
+const (
+	// ClusterKubeconfigRequestReadyCondition is true if a valid kubeconfig has been issued for the ClusterKubeconfigRequest.
+	ClusterKubeconfigRequestReadyCondition = ReadyCondition
+
+	// ClusterKubeconfigRequestIssuedReason surfaces when a valid kubeconfig has been issued.
+	ClusterKubeconfigRequestIssuedReason = "Issued"
+
+	// ClusterKubeconfigRequestWaitingForClusterReason surfaces when the Cluster is not yet ready for issuing a kubeconfig,
+	// e.g. because the control plane endpoint or the cluster certificate authority are not yet available.
+	ClusterKubeconfigRequestWaitingForClusterReason = "WaitingForCluster"
+
+	// ClusterKubeconfigRequestExpiredReason surfaces when the kubeconfig issued for a ClusterKubeconfigRequest
+	// expired and it has not been renewed.
+	ClusterKubeconfigRequestExpiredReason = "Expired"
+
+	// ClusterKubeconfigRequestInternalErrorReason surfaces unexpected failures when issuing a kubeconfig.
+	ClusterKubeconfigRequestInternalErrorReason = InternalErrorReason
+)
+
+// ClusterKubeconfigRequestSpec defines the desired state of ClusterKubeconfigRequest.
+type ClusterKubeconfigRequestSpec struct {
+	// clusterName is the name of the Cluster this object belongs to.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// user is the name of the user the kubeconfig is issued for; it is used as the common name
+	// of the client certificate, and it must be set unless oidc is set.
+	// Users with the system: prefix are reserved for Kubernetes components and are not allowed.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	User string `json:"user,omitempty"`
+
+	// groups the user belongs to; they are used as the organizations of the client certificate.
+	// Groups with the system: prefix, e.g. system:masters, and the kubeadm:cluster-admins group
+	// are not allowed.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	Groups []string `json:"groups,omitempty"`
+
+	// ttlSeconds is the validity of the issued kubeconfig.
+	// If not set, it defaults to 3600 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=600
+	// +kubebuilder:validation:Maximum=2592000
+	TTLSeconds *int32 `json:"ttlSeconds,omitempty"`
+
+	// autoRenew defines if the kubeconfig is renewed before it expires; if not set, the kubeconfig is not renewed
+	// and the Secret containing it is deleted when it expires.
+	// +optional
+	AutoRenew *bool `json:"autoRenew,omitempty"`
+
+	// oidc configures the kubeconfig to authenticate using OpenID Connect instead of a client certificate.
+	// The kubeconfig uses the kubelogin credential plugin (kubectl oidc-login), which must be installed
+	// by the user, and the kube-apiserver of the Cluster must be configured to accept tokens from the issuer.
+	// A kubeconfig using OpenID Connect does not contain any credential, so it does not expire.
+	// +optional
+	OIDC ClusterKubeconfigRequestOIDCSpec `json:"oidc,omitempty,omitzero"`
+}
+
+// ClusterKubeconfigRequestOIDCSpec defines the OpenID Connect settings of a kubeconfig.
+// +kubebuilder:validation:MinProperties=1
+type ClusterKubeconfigRequestOIDCSpec struct {
+	// issuerURL is the URL of the OpenID Connect issuer.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	IssuerURL string `json:"issuerURL,omitempty"`
+
+	// clientID is the OpenID Connect client ID.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	ClientID string `json:"clientID,omitempty"`
+
+	// extraScopes are the scopes to request in addition to openid.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	ExtraScopes []string `json:"extraScopes,omitempty"`
+}
+
+// ClusterKubeconfigRequestStatus defines the observed state of ClusterKubeconfigRequest.
+// +kubebuilder:validation:MinProperties=1
+type ClusterKubeconfigRequestStatus struct {
+	// conditions represents the observations of a ClusterKubeconfigRequest's current state.
+	// Known condition types are Ready, Paused.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// secretName is the name of the Secret containing the kubeconfig under the value key.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	SecretName string `json:"secretName,omitempty"`
+
+	// issueTime is when the current kubeconfig has been issued.
+	// +optional
+	IssueTime metav1.Time `json:"issueTime,omitempty,omitzero"`
+
+	// expirationTime is when the current kubeconfig expires; it is not set for kubeconfigs using OpenID Connect.
+	// +optional
+	ExpirationTime metav1.Time `json:"expirationTime,omitempty,omitzero"`
+
+	// observedGeneration is the latest generation observed by the controller.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clusterkubeconfigrequests,shortName=ckr,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster"
+// +kubebuilder:printcolumn:name="User",type="string",JSONPath=".spec.user",description="User the kubeconfig is issued for"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Kubeconfig issued"
+// +kubebuilder:printcolumn:name="Expiration",type="date",JSONPath=".status.expirationTime",description="Expiration time of the kubeconfig"
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=`.status.conditions[?(@.type=="Paused")].status`,description="Reconciliation paused",priority=10
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of ClusterKubeconfigRequest"
+
+// ClusterKubeconfigRequest is the Schema for the clusterkubeconfigrequests API.
+// A ClusterKubeconfigRequest issues a kubeconfig for accessing a workload cluster as a specific user,
+// as an alternative to sharing the admin kubeconfig stored in the <cluster>-kubeconfig Secret.
+// Note: Client certificates cannot be revoked; deleting a ClusterKubeconfigRequest deletes the Secret containing
+// the kubeconfig, but the kubeconfig remains valid until it expires.
+type ClusterKubeconfigRequest struct {
+	metav1.TypeMeta `json:",inline"`
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the desired state of ClusterKubeconfigRequest.
+	// +required
+	Spec ClusterKubeconfigRequestSpec `json:"spec,omitempty,omitzero"`
+
+	// status is the observed state of ClusterKubeconfigRequest.
+	// +optional
+	Status ClusterKubeconfigRequestStatus `json:"status,omitempty,omitzero"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (r *ClusterKubeconfigRequest) GetConditions() []metav1.Condition {
+	return r.Status.Conditions
+}
+
+// SetConditions sets conditions for an API object.
+func (r *ClusterKubeconfigRequest) SetConditions(conditions []metav1.Condition) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterKubeconfigRequestList contains a list of ClusterKubeconfigRequest.
+type ClusterKubeconfigRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	// metadata is the standard list's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#lists-and-simple-kinds
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// items is the list of ClusterKubeconfigRequests.
+	Items []ClusterKubeconfigRequest `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &ClusterKubeconfigRequest{}, &ClusterKubeconfigRequestList{})
+}
@@ -29,6 +29,19 @@ const (
 	// external objects(bootstrap and infrastructure providers).
 	ClusterNameLabel = "cluster.x-k8s.io/cluster-name"
 
+	// KubeconfigMirrorClusterNameLabel is the label set on Secrets mirroring the kubeconfig of a Cluster
+	// to track the name of the Cluster.
+	KubeconfigMirrorClusterNameLabel = "cluster.x-k8s.io/kubeconfig-mirror-cluster-name"
+
+	// KubeconfigMirrorClusterNamespaceLabel is the label set on Secrets mirroring the kubeconfig of a Cluster
+	// to track the namespace of the Cluster.
+	KubeconfigMirrorClusterNamespaceLabel = "cluster.x-k8s.io/kubeconfig-mirror-cluster-namespace"
+
+	// KubeconfigMirrorsAllowedFromAnnotation is the annotation that must be set on a Namespace to allow mirroring
+	// the kubeconfig of Clusters in other namespaces into it. The value is a comma-separated list of namespaces,
+	// allowing all the Clusters in a namespace, or of <namespace>/<name> entries, allowing a single Cluster.
+	KubeconfigMirrorsAllowedFromAnnotation = "cluster.x-k8s.io/kubeconfig-mirrors-allowed-from"
+
 	// ClusterTopologyOwnedLabel is the label set on all the object which are managed as part of a ClusterTopology.
 	ClusterTopologyOwnedLabel = "topology.cluster.x-k8s.io/owned"
 
@@ -141,6 +154,21 @@ const (
 	// RemediateMachineAnnotation request the MachineHealthCheck reconciler to mark a Machine as unhealthy. CAPI builtin remediation will prioritize Machines with the annotation to be remediated.
 	RemediateMachineAnnotation = "cluster.x-k8s.io/remediate-machine"
 
+	// RemediationEscalationAnnotation is used by the MachineHealthCheck reconciler to keep track of the progress
+	// of a remediation escalation for a Machine.
+	// Note: The annotation value is a JSON object; it is set by the MachineHealthCheck reconciler and must not be modified by users.
+	RemediationEscalationAnnotation = "cluster.x-k8s.io/remediation-escalation"
+
+	// MachineHealthCheckFailingProbesAnnotation is used by the MachineHealthCheck reconciler to keep track of the time
+	// when the probes of MachineHealthChecks started failing for a Machine, so probe timeouts are preserved across restarts.
+	// Note: The annotation value is a JSON object; it is set by the MachineHealthCheck reconciler and must not be modified by users.
+	MachineHealthCheckFailingProbesAnnotation = "cluster.x-k8s.io/failing-probes"
+
+	// MachineDrainPodStagesAnnotation is used by the Machine controller to keep track of the time since when the eviction
+	// of Pods with EvictAfter or ForceDelete set has been delayed or blocked, so delays and timeouts are preserved across restarts.
+	// Note: The annotation value is a JSON object; it is set by the Machine controller and must not be modified by users.
+	MachineDrainPodStagesAnnotation = "cluster.x-k8s.io/drain-pod-stages"
+
 	// MachineSetSkipPreflightChecksAnnotation is the annotation used to provide a comma-separated list of
 	// preflight checks that should be skipped during the MachineSet reconciliation.
 	// Supported items are:
@@ -313,6 +313,10 @@ const (
 	// defined by a MachineHealthCheck object.
 	MachineHealthCheckUnhealthyMachineReason = "UnhealthyMachine"
 
+	// MachineHealthCheckUnhealthyProbeReason surfaces when the machine does not pass the probes run against the
+	// workload cluster defined by a MachineHealthCheck object.
+	MachineHealthCheckUnhealthyProbeReason = "UnhealthyProbe"
+
 	// MachineHealthCheckNodeStartupTimeoutReason surfaces when the node hosted on the machine does not appear within
 	// the timeout defined by a MachineHealthCheck object.
 	MachineHealthCheckNodeStartupTimeoutReason = "NodeStartupTimeout"
@@ -360,6 +364,23 @@ const (
 	MachineExternallyRemediatedRemediationRequestCreationFailedReason = "RemediationRequestCreationFailed"
 )
 
+// Machine's RemediationEscalation conditions and corresponding reasons.
+// Note: RemediationEscalation condition is set by the MachineHealthCheck controller.
+const (
+	// MachineRemediationEscalationCondition is only present if MHC instances targeting this machine
+	// are remediating the machine according to a remediation escalation policy; it surfaces the current
+	// step and attempt count of the remediation.
+	MachineRemediationEscalationCondition = "RemediationEscalation"
+
+	// MachineRemediationEscalationStepInProgressReason surfaces that a remediation step of the escalation
+	// is in progress for the machine.
+	MachineRemediationEscalationStepInProgressReason = "StepInProgress"
+
+	// MachineRemediationEscalationOwnerRemediationReason surfaces that all the remediation steps of the escalation
+	// have been tried and the machine is now being remediated by its owner controller.
+	MachineRemediationEscalationOwnerRemediationReason = "OwnerRemediation"
+)
+
 // Machine's Deleting condition and corresponding reasons.
 const (
 	// MachineDeletingCondition surfaces details about progress in the machine deletion workflow.
@@ -376,6 +397,10 @@ const (
 	// MachineDeletingInternalErrorReason surfaces unexpected failures when deleting a Machine.
 	MachineDeletingInternalErrorReason = InternalErrorReason
 
+	// MachineDeletingWaitingForBeforeMachineDeletionHookReason surfaces when the Machine deletion
+	// waits for the BeforeMachineDeletion Runtime SDK hook to allow the deletion to proceed.
+	MachineDeletingWaitingForBeforeMachineDeletionHookReason = "WaitingForBeforeMachineDeletionHook"
+
 	// MachineDeletingWaitingForPreDrainHookReason surfaces when the Machine deletion
 	// waits for pre-drain hooks to complete. I.e. it waits until there are no annotations
 	// with the `pre-drain.delete.hook.machine.cluster.x-k8s.io` prefix on the Machine anymore.
@@ -32,7 +32,8 @@ const (
 )
 
 // MachineDeploymentRolloutStrategyType defines the type of MachineDeployment rollout strategies.
-// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+// Note: Allowed values are validated on each field using this type, because the Staged strategy is supported only
+// by MachineDeployments, and not in Cluster topologies and ClusterClasses.
 type MachineDeploymentRolloutStrategyType string
 
 const (
@@ -43,6 +44,10 @@ const (
 	// OnDeleteMachineDeploymentStrategyType replaces old MachineSets when the deletion of the associated machines are completed.
 	OnDeleteMachineDeploymentStrategyType MachineDeploymentRolloutStrategyType = "OnDelete"
 
+	// StagedMachineDeploymentStrategyType replaces the old MachineSet by new one using rolling update, but
+	// the new MachineSet is scaled up in steps; after each step the rollout is paused until the step's gate passes.
+	StagedMachineDeploymentStrategyType MachineDeploymentRolloutStrategyType = "Staged"
+
 	// RevisionAnnotation is the revision annotation of a machine deployment's machine sets which records its rollout sequence.
 	RevisionAnnotation = "machinedeployment.clusters.x-k8s.io/revision"
 
@@ -156,6 +161,10 @@ const (
 	// MachineDeploymentNotRollingOutReason surfaces when all the machines are up-to-date.
 	MachineDeploymentNotRollingOutReason = NotRollingOutReason
 
+	// MachineDeploymentRollingOutWaitingForStepGateReason surfaces when a Staged rollout is paused
+	// until the gate of the current step passes.
+	MachineDeploymentRollingOutWaitingForStepGateReason = "WaitingForStepGate"
+
 	// MachineDeploymentRollingOutInternalErrorReason surfaces unexpected failures when listing machines.
 	MachineDeploymentRollingOutInternalErrorReason = InternalErrorReason
 )
@@ -315,15 +324,81 @@ type MachineDeploymentRolloutSpec struct {
 // with new ones.
 // +kubebuilder:validation:MinProperties=1
 type MachineDeploymentRolloutStrategy struct {
-	// type of rollout. Allowed values are RollingUpdate and OnDelete.
+	// type of rollout. Allowed values are RollingUpdate, OnDelete and Staged.
 	// Default is RollingUpdate.
 	// +required
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete;Staged
 	Type MachineDeploymentRolloutStrategyType `json:"type,omitempty"`
 
 	// rollingUpdate is the rolling update config params. Present only if
-	// type = RollingUpdate.
+	// type = RollingUpdate or type = Staged.
 	// +optional
 	RollingUpdate MachineDeploymentRolloutStrategyRollingUpdate `json:"rollingUpdate,omitempty,omitzero"`
+
+	// staged is the staged rollout config params. Required if type = Staged,
+	// and not allowed otherwise.
+	// +optional
+	Staged MachineDeploymentRolloutStrategyStaged `json:"staged,omitempty,omitzero"`
+}
+
+// MachineDeploymentRolloutStrategyStaged is used to control the desired behavior of a staged rollout.
+// Note: Steps apply only when replacing Machines of an existing MachineDeployment, i.e. when there are old MachineSets
+// with replicas; within each step, Machines are replaced respecting rollingUpdate.maxSurge and rollingUpdate.maxUnavailable.
+// +kubebuilder:validation:MinProperties=1
+type MachineDeploymentRolloutStrategyStaged struct {
+	// steps defines the steps of the staged rollout.
+	// Each step scales up the new MachineSet up to the step's replicas, then the rollout is paused
+	// until all the step's replicas are available and the step's gate passes.
+	// After the last step, the rollout continues like a RollingUpdate rollout.
+	// +required
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	Steps []MachineDeploymentRolloutStep `json:"steps,omitempty"`
+}
+
+// MachineDeploymentRolloutStep defines a step of a staged rollout.
+type MachineDeploymentRolloutStep struct {
+	// replicas is the number of replicas of the new MachineSet at the end of this step.
+	// Value can be an absolute number (ex: 5) or a percentage of desired
+	// machines (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up.
+	// Replicas must increase from one step to the next.
+	// +required
+	Replicas *intstr.IntOrString `json:"replicas,omitempty"`
+
+	// gate defines the checks that must pass before continuing with the next step.
+	// If not set, the rollout continues as soon as all the step's replicas are available.
+	// If more than one check is set, all of them must pass.
+	// +optional
+	Gate MachineDeploymentRolloutStepGate `json:"gate,omitempty,omitzero"`
+}
+
+// MachineDeploymentRolloutStepGate defines the checks that must pass before continuing a staged rollout.
+// +kubebuilder:validation:MinProperties=1
+type MachineDeploymentRolloutStepGate struct {
+	// soakSeconds is the time the step's replicas must be available before continuing with the next step.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SoakSeconds *int32 `json:"soakSeconds,omitempty"`
+
+	// conditionType is the type of a condition on the MachineDeployment which must be True before continuing with
+	// the next step, e.g. a condition set by an external analysis controller.
+	// Note: The condition must transition to True after all the step's replicas became available; a condition which
+	// is already True from a previous step does not pass the gate.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=316
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$`
+	ConditionType string `json:"conditionType,omitempty"`
+
+	// extensionName is the name of a Runtime Extension implementing the CanMachineDeploymentRolloutProceed hook,
+	// which must allow the rollout to proceed before continuing with the next step.
+	// Note: This requires the RuntimeSDK feature gate to be enabled.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	ExtensionName string `json:"extensionName,omitempty"`
 }
 
 // MachineDeploymentRolloutStrategyRollingUpdate is used to control the desired behavior of rolling update.
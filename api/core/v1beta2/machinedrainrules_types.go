@@ -27,8 +27,8 @@ const (
 	PodDrainLabel = "cluster.x-k8s.io/drain"
 )
 
-// MachineDrainRuleDrainBehavior defines the drain behavior. Can be either "Drain", "Skip", or "WaitCompleted".
-// +kubebuilder:validation:Enum=Drain;Skip;WaitCompleted
+// MachineDrainRuleDrainBehavior defines the drain behavior. Can be either "Drain", "Skip", "WaitCompleted", "EvictAfter" or "ForceDelete".
+// +kubebuilder:validation:Enum=Drain;Skip;WaitCompleted;EvictAfter;ForceDelete
 type MachineDrainRuleDrainBehavior string
 
 const (
@@ -41,6 +41,14 @@ const (
 	// MachineDrainRuleDrainBehaviorWaitCompleted means the Pod should not be evicted,
 	// but overall drain should wait until the Pod completes.
 	MachineDrainRuleDrainBehaviorWaitCompleted MachineDrainRuleDrainBehavior = "WaitCompleted"
+
+	// MachineDrainRuleDrainBehaviorEvictAfter means a Pod should be drained, but its eviction should be delayed
+	// until all the other Pods with the same order have been removed from the Node for a configurable time.
+	MachineDrainRuleDrainBehaviorEvictAfter MachineDrainRuleDrainBehavior = "EvictAfter"
+
+	// MachineDrainRuleDrainBehaviorForceDelete means a Pod should be drained, but it should be deleted
+	// if its eviction is blocked by a PodDisruptionBudget for longer than a configurable timeout.
+	MachineDrainRuleDrainBehaviorForceDelete MachineDrainRuleDrainBehavior = "ForceDelete"
 )
 
 // MachineDrainRuleSpec defines the spec of a MachineDrainRule.
@@ -118,7 +126,7 @@ type MachineDrainRuleSpec struct {
 // MachineDrainRuleDrainConfig configures if and how Pods are drained.
 type MachineDrainRuleDrainConfig struct {
 	// behavior defines the drain behavior.
-	// Can be either "Drain", "Skip", or "WaitCompleted".
+	// Can be either "Drain", "Skip", "WaitCompleted", "EvictAfter" or "ForceDelete".
 	// "Drain" means that the Pods to which this MachineDrainRule applies will be drained.
 	// If behavior is set to "Drain" the order in which Pods are drained can be configured
 	// with the order field. When draining Pods of a Node the Pods will be grouped by order
@@ -128,16 +136,57 @@ type MachineDrainRuleDrainConfig struct {
 	// "Skip" means that the Pods to which this MachineDrainRule applies will be skipped during drain.
 	// "WaitCompleted" means that the pods to which this MachineDrainRule applies will never be evicted
 	// and we wait for them to be completed, it is enforced that pods marked with this behavior always have Order=0.
+	// "EvictAfter" means that the Pods to which this MachineDrainRule applies will be drained, but only
+	// after all the other Pods with the same order have been removed from the Node for evictAfter.delaySeconds.
+	// "ForceDelete" means that the Pods to which this MachineDrainRule applies will be drained, but if their
+	// eviction is blocked by a PodDisruptionBudget for longer than forceDelete.timeoutSeconds they will be deleted.
 	// +required
 	Behavior MachineDrainRuleDrainBehavior `json:"behavior,omitempty"`
 
 	// order defines the order in which Pods are drained.
 	// Pods with higher order are drained after Pods with lower order.
-	// order can only be set if behavior is set to "Drain".
+	// order can only be set if behavior is set to "Drain", "EvictAfter" or "ForceDelete".
 	// If order is not set, 0 will be used.
 	// Valid values for order are from -2147483648 to 2147483647 (inclusive).
 	// +optional
 	Order *int32 `json:"order,omitempty"`
+
+	// evictAfter configures the "EvictAfter" behavior.
+	// evictAfter must be set if and only if behavior is set to "EvictAfter".
+	// +optional
+	EvictAfter MachineDrainRuleEvictAfterConfig `json:"evictAfter,omitempty,omitzero"`
+
+	// forceDelete configures the "ForceDelete" behavior.
+	// forceDelete must be set if and only if behavior is set to "ForceDelete".
+	// +optional
+	ForceDelete MachineDrainRuleForceDeleteConfig `json:"forceDelete,omitempty,omitzero"`
+}
+
+// MachineDrainRuleEvictAfterConfig configures the "EvictAfter" drain behavior.
+// +kubebuilder:validation:MinProperties=1
+type MachineDrainRuleEvictAfterConfig struct {
+	// delaySeconds is the number of seconds all the other Pods with the same order must have been removed
+	// from the Node before Pods with the "EvictAfter" behavior are evicted.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	DelaySeconds int32 `json:"delaySeconds,omitempty"`
+}
+
+// MachineDrainRuleForceDeleteConfig configures the "ForceDelete" drain behavior.
+// +kubebuilder:validation:MinProperties=1
+type MachineDrainRuleForceDeleteConfig struct {
+	// timeoutSeconds is the number of seconds the eviction of a Pod can be blocked by a PodDisruptionBudget
+	// before the Pod is deleted.
+	// Note: The timeout starts when Cluster API is first blocked by a PodDisruptionBudget while evicting the Pod.
+	// +required
+	// +kubebuilder:validation:Minimum=0
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// gracePeriodSeconds is the grace period used when deleting the Pod.
+	// If gracePeriodSeconds is not set, the terminationGracePeriodSeconds of the Pod is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	GracePeriodSeconds *int32 `json:"gracePeriodSeconds,omitempty"`
 }
 
 // MachineDrainRuleMachineSelector defines to which Machines this MachineDrainRule should be applied.
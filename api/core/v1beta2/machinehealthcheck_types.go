@@ -33,6 +33,11 @@ const (
 	// the MachineHealthCheck is blocked from making any further remediation.
 	MachineHealthCheckTooManyUnhealthyReason = "TooManyUnhealthy"
 
+	// MachineHealthCheckRemediationRateLimitedReason is the reason used when the number of remediations
+	// triggered within the time window defined in spec.remediation.rateLimit has been reached and
+	// the MachineHealthCheck is blocked from triggering further remediations until the time window frees up.
+	MachineHealthCheckRemediationRateLimitedReason = "RemediationRateLimited"
+
 	// MachineHealthCheckRemediationAllowedReason is the reason used when the number of unhealthy machine
 	// is within the limits defined by the MachineHealthCheck, and thus remediation is allowed.
 	MachineHealthCheckRemediationAllowedReason = "RemediationAllowed"
@@ -121,16 +126,36 @@ type MachineHealthCheckChecks struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=100
 	UnhealthyMachineConditions []UnhealthyMachineCondition `json:"unhealthyMachineConditions,omitempty"`
+
+	// probes contains a list of probes run against the workload cluster for the Node of each Machine
+	// to determine whether the machine is unhealthy, e.g. to detect broken CNI agents or CSI node plugins.
+	// The probes are combined in a logical OR, i.e. if any of the probes is failing for longer than
+	// its timeout, the machine is unhealthy.
+	// A probe which cannot be run, e.g. because requests to the workload cluster fail, is skipped until it can be run again.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	Probes []MachineHealthCheckProbe `json:"probes,omitempty"`
 }
 
 // MachineHealthCheckRemediation configures if and how remediations are triggered if a Machine is unhealthy.
 // +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:XValidation:rule="!has(self.templateRef) || !has(self.escalation)",message="templateRef and escalation are mutually exclusive"
 type MachineHealthCheckRemediation struct {
 	// triggerIf configures if remediations are triggered.
 	// If this field is not set, remediations are always triggered.
 	// +optional
 	TriggerIf MachineHealthCheckRemediationTriggerIf `json:"triggerIf,omitempty,omitzero"`
 
+	// rateLimit limits the number of remediations triggered within a sliding time window,
+	// e.g. to prevent a flapping network or a bad image from replacing a whole pool of Machines within minutes.
+	// If this field is not set, the number of remediations triggered over time is not limited.
+	// +optional
+	RateLimit MachineHealthCheckRemediationRateLimit `json:"rateLimit,omitempty,omitzero"`
+
 	// templateRef is a reference to a remediation template
 	// provided by an infrastructure provider.
 	//
@@ -139,6 +164,64 @@ type MachineHealthCheckRemediation struct {
 	// a controller that lives outside of Cluster API.
 	// +optional
 	TemplateRef MachineHealthCheckRemediationTemplateReference `json:"templateRef,omitempty,omitzero"`
+
+	// escalation defines an ordered list of remediation steps, e.g. a reboot, which are tried
+	// before falling back to remediation by the owner of the Machine, e.g. a replacement.
+	//
+	// This field is mutually exclusive with templateRef.
+	// +optional
+	Escalation MachineHealthCheckRemediationEscalation `json:"escalation,omitempty,omitzero"`
+}
+
+// MachineHealthCheckRemediationEscalation defines an ordered list of remediation steps.
+type MachineHealthCheckRemediationEscalation struct {
+	// steps is the ordered list of remediation steps to try for an unhealthy Machine.
+	// Each step hands off remediation of the Machine to a controller that lives outside of Cluster API;
+	// if the Machine is still unhealthy when the step times out, the next step is tried.
+	// If the Machine is still unhealthy after all the steps, it is remediated by its owner, e.g. it is replaced.
+	// +required
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	Steps []MachineHealthCheckRemediationEscalationStep `json:"steps,omitempty"`
+}
+
+// IsDefined returns true if the MachineHealthCheckRemediationEscalation is set.
+func (r *MachineHealthCheckRemediationEscalation) IsDefined() bool {
+	if r == nil {
+		return false
+	}
+	return len(r.Steps) > 0
+}
+
+// MachineHealthCheckRemediationEscalationStep is a remediation step of an escalation.
+type MachineHealthCheckRemediationEscalationStep struct {
+	// name of the remediation step.
+	// name must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name,omitempty"`
+
+	// templateRef is a reference to a remediation template provided by an infrastructure provider,
+	// e.g. a template for rebooting the Machine.
+	// +required
+	TemplateRef MachineHealthCheckRemediationTemplateReference `json:"templateRef,omitempty,omitzero"`
+
+	// timeoutSeconds is the time to wait for the Machine to become healthy after each attempt of this step
+	// before trying again or moving to the next step.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// maxAttempts is the number of times this step is attempted before moving to the next step.
+	// If this field is not set, the step is attempted once.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
 }
 
 // MachineHealthCheckRemediationTriggerIf configures if remediations are triggered.
@@ -165,6 +248,42 @@ type MachineHealthCheckRemediationTriggerIf struct {
 	UnhealthyInRange string `json:"unhealthyInRange,omitempty"`
 }
 
+// MachineHealthCheckRemediationRateLimit limits the number of remediations triggered within a sliding time window.
+// +kubebuilder:validation:XValidation:rule="has(self.maxRemediations) || has(self.maxClusterRemediations)",message="at least one of maxRemediations or maxClusterRemediations must be set"
+type MachineHealthCheckRemediationRateLimit struct {
+	// windowSeconds is the length of the sliding time window in which remediations are counted.
+	// For example, with a value of "600" and maxRemediations set to 2, at most 2 remediations
+	// are triggered in any 10 minutes period.
+	// +required
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:validation:Maximum=86400
+	WindowSeconds int32 `json:"windowSeconds,omitempty"`
+
+	// maxRemediations is the maximum number of remediations triggered by this MachineHealthCheck
+	// within the time window.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	MaxRemediations *int32 `json:"maxRemediations,omitempty"`
+
+	// maxClusterRemediations is the maximum number of remediations triggered by all the MachineHealthChecks
+	// of the Cluster, including this one, within the time window.
+	// NOTE: Only remediations triggered by this MachineHealthCheck are limited; other MachineHealthChecks
+	// of the Cluster are limited only by their own rateLimit.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	MaxClusterRemediations *int32 `json:"maxClusterRemediations,omitempty"`
+}
+
+// IsDefined returns true if the MachineHealthCheckRemediationRateLimit is set.
+func (r *MachineHealthCheckRemediationRateLimit) IsDefined() bool {
+	if r == nil {
+		return false
+	}
+	return r.WindowSeconds != 0 || r.MaxRemediations != nil || r.MaxClusterRemediations != nil
+}
+
 // MachineHealthCheckRemediationTemplateReference is a reference to a remediation template.
 type MachineHealthCheckRemediationTemplateReference struct {
 	// kind of the remediation template.
@@ -270,6 +389,84 @@ type UnhealthyMachineCondition struct {
 	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 }
 
+// MachineHealthCheckProbe is a probe run against the workload cluster for the Node of each Machine.
+// +kubebuilder:validation:XValidation:rule="has(self.daemonSetPod) != has(self.http)",message="exactly one of daemonSetPod or http must be set"
+type MachineHealthCheckProbe struct {
+	// name of the probe.
+	// name must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name,omitempty"`
+
+	// daemonSetPod checks that the Pods of a DaemonSet scheduled on the Node are ready,
+	// e.g. the Pods of a CNI agent or of a CSI node plugin.
+	// +optional
+	DaemonSetPod MachineHealthCheckDaemonSetPodProbe `json:"daemonSetPod,omitempty,omitzero"`
+
+	// http checks that an HTTP endpoint exposed via the Node responds successfully.
+	// +optional
+	HTTP MachineHealthCheckHTTPProbe `json:"http,omitempty,omitzero"`
+
+	// timeoutSeconds is the duration that a probe must be failing for,
+	// after which the machine is considered unhealthy.
+	// For example, with a value of "300", the probe must be failing
+	// for at least 5 minutes before the machine is considered unhealthy.
+	// +required
+	// +kubebuilder:validation:Minimum=0
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// MachineHealthCheckDaemonSetPodProbe checks that the Pods of a DaemonSet scheduled on a Node are ready.
+type MachineHealthCheckDaemonSetPodProbe struct {
+	// namespace of the Pods of the DaemonSet.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
+	// selector is a label selector to match the Pods of the DaemonSet, e.g. k8s-app=calico-node.
+	// The probe fails if there are no Pods matching the selector scheduled on a Node where a DaemonSet with a matching
+	// Pod template should run according to its nodeSelector, node affinity and tolerations, or if any of them is not ready.
+	// +required
+	Selector metav1.LabelSelector `json:"selector,omitempty,omitzero"`
+}
+
+// MachineHealthCheckHTTPProbe checks that an HTTP endpoint exposed via a Node responds successfully.
+// The endpoint is reached through the node proxy of the API server of the workload cluster,
+// and it is considered successful if it responds with a 2xx status code.
+type MachineHealthCheckHTTPProbe struct {
+	// scheme to use for connecting to the endpoint, one of HTTP, HTTPS.
+	// Defaults to HTTP.
+	// +optional
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	Scheme corev1.URIScheme `json:"scheme,omitempty"`
+
+	// port of the endpoint on the Node.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+
+	// path of the endpoint, e.g. /healthz.
+	// Defaults to /.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+
+	// requestTimeoutSeconds is the timeout for each request to the endpoint.
+	// Defaults to 5 seconds.
+	// Note: Requests are also subject to the timeout of the client used by Cluster API to communicate with
+	// the workload cluster, which is 10 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=60
+	RequestTimeoutSeconds *int32 `json:"requestTimeoutSeconds,omitempty"`
+}
+
 // MachineHealthCheckStatus defines the observed state of MachineHealthCheck.
 // +kubebuilder:validation:MinProperties=1
 type MachineHealthCheckStatus struct {
@@ -310,11 +507,33 @@ type MachineHealthCheckStatus struct {
 	// +kubebuilder:validation:items:MaxLength=253
 	Targets []string `json:"targets,omitempty"`
 
+	// remediations lists the remediations triggered by this MachineHealthCheck within the time window
+	// defined in spec.remediation.rateLimit; it is used to enforce the rate limit.
+	// Remediations are also tracked if another MachineHealthCheck of the same Cluster defines maxClusterRemediations,
+	// within the longest of the time windows, so they are counted by the cluster-wide rate limit.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=1000
+	Remediations []MachineHealthCheckRemediationRecord `json:"remediations,omitempty"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *MachineHealthCheckDeprecatedStatus `json:"deprecated,omitempty"`
 }
 
+// MachineHealthCheckRemediationRecord records a remediation triggered by a MachineHealthCheck.
+type MachineHealthCheckRemediationRecord struct {
+	// machineName is the name of the Machine being remediated.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	MachineName string `json:"machineName,omitempty"`
+
+	// time is the time when the remediation has been triggered.
+	// +required
+	Time metav1.Time `json:"time,omitempty,omitzero"`
+}
+
 // MachineHealthCheckDeprecatedStatus groups all the status fields that are deprecated and will be removed in a future version.
 // See https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20240916-improve-status-in-CAPI-resources.md for more context.
 type MachineHealthCheckDeprecatedStatus struct {
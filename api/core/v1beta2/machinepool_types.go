@@ -19,6 +19,7 @@ package v1beta2
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	capierrors "sigs.k8s.io/cluster-api/api/deprecated/errors"
 )
@@ -26,6 +27,11 @@ import (
 const (
 	// MachinePoolFinalizer is used to ensure deletion of dependencies (nodes, infra).
 	MachinePoolFinalizer = "machinepool.cluster.x-k8s.io"
+
+	// MachinePoolTemplateHashAnnotation is set on MachinePool Machines to the hash of the MachinePool bootstrap config
+	// and infrastructure reference, and of the infrastructure MachinePool spec.template, if any, at the time the
+	// Machine has been created; it is used to detect MachinePool Machines which are not up-to-date.
+	MachinePoolTemplateHashAnnotation = "machinepool.cluster.x-k8s.io/template-hash"
 )
 
 /*
@@ -97,6 +103,78 @@ type MachinePoolSpec struct {
 	// +kubebuilder:validation:items:MinLength=1
 	// +kubebuilder:validation:items:MaxLength=256
 	FailureDomains []string `json:"failureDomains,omitempty"`
+
+	// rollout allows you to configure the behaviour of rolling updates to the MachinePool Machines.
+	// It allows you to require that all Machines are replaced after a certain time,
+	// and allows you to define the strategy used during rolling replacements.
+	// Note: Rollout is enforced by Cluster API only for MachinePools whose infrastructure provider implements MachinePool Machines.
+	// +optional
+	Rollout MachinePoolRolloutSpec `json:"rollout,omitempty,omitzero"`
+}
+
+// MachinePoolRolloutStrategyType defines the type of MachinePool rollout strategies.
+// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+type MachinePoolRolloutStrategyType string
+
+const (
+	// RollingUpdateMachinePoolStrategyType replaces MachinePool Machines which are not up-to-date
+	// by gradually deleting them, while the infrastructure provider creates up-to-date replacements.
+	RollingUpdateMachinePoolStrategyType MachinePoolRolloutStrategyType = "RollingUpdate"
+
+	// OnDeleteMachinePoolStrategyType replaces MachinePool Machines which are not up-to-date
+	// only when they are deleted by the user.
+	OnDeleteMachinePoolStrategyType MachinePoolRolloutStrategyType = "OnDelete"
+)
+
+// MachinePoolRolloutSpec defines the rollout behavior.
+// +kubebuilder:validation:MinProperties=1
+type MachinePoolRolloutSpec struct {
+	// after is a field to indicate a rollout should be performed
+	// after the specified time even if no changes have been made to the
+	// MachinePool.
+	// Example: In the YAML the time can be specified in the RFC3339 format.
+	// To specify the rolloutAfter target as March 9, 2023, at 9 am UTC
+	// use "2023-03-09T09:00:00Z".
+	// +optional
+	After metav1.Time `json:"after,omitempty,omitzero"`
+
+	// strategy specifies how to roll out MachinePool Machines.
+	// If not set, Cluster API does not delete MachinePool Machines which are not up-to-date,
+	// and the rollout is left to the infrastructure provider.
+	// +optional
+	Strategy MachinePoolRolloutStrategy `json:"strategy,omitempty,omitzero"`
+}
+
+// MachinePoolRolloutStrategy describes how to replace existing MachinePool Machines
+// with new ones.
+// +kubebuilder:validation:MinProperties=1
+type MachinePoolRolloutStrategy struct {
+	// type of rollout. Allowed values are RollingUpdate and OnDelete.
+	// +required
+	Type MachinePoolRolloutStrategyType `json:"type,omitempty"`
+
+	// rollingUpdate is the rolling update config params. Present only if
+	// type = RollingUpdate.
+	// Note: There is no maxSurge, because Cluster API does not create MachinePool Machines; replicas, including
+	// surge replicas, are created by the infrastructure provider, and surge must be configured there if supported.
+	// +optional
+	RollingUpdate MachinePoolRolloutStrategyRollingUpdate `json:"rollingUpdate,omitempty,omitzero"`
+}
+
+// MachinePoolRolloutStrategyRollingUpdate is used to control the desired behavior of rolling update.
+// +kubebuilder:validation:MinProperties=1
+type MachinePoolRolloutStrategyRollingUpdate struct {
+	// maxUnavailable is the maximum number of machines that can be unavailable during the update.
+	// Value can be an absolute number (ex: 5) or a percentage of desired
+	// machines (ex: 10%).
+	// Absolute number is calculated from percentage by rounding down.
+	// Defaults to 1.
+	// Example: when this is set to 30%, Cluster API can delete Machines which are not up-to-date
+	// immediately when the rolling update starts, as long as at least 70% of desired machines are available.
+	// Note: When this is set to 0, Machines which are not up-to-date are deleted only when the infrastructure provider
+	// creates additional Machines above the desired number of machines.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 // MachinePoolStatus defines the observed state of MachinePool.
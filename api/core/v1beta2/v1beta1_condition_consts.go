@@ -162,6 +162,9 @@ const (
 	// When both machine and node issues are detected, this reason takes precedence over node-related reasons
 	// (NodeNotFoundV1Beta1Reason, NodeStartupTimeoutV1Beta1Reason, UnhealthyNodeConditionV1Beta1Reason).
 	UnhealthyMachineConditionV1Beta1Reason = "UnhealthyMachine"
+
+	// UnhealthyProbeV1Beta1Reason is the reason used when a machine does not pass one of the MachineHealthCheck's probes.
+	UnhealthyProbeV1Beta1Reason = "UnhealthyProbe"
 )
 
 const (
@@ -229,6 +232,10 @@ const (
 	// TooManyUnhealthyV1Beta1Reason is the reason used when too many Machines are unhealthy and the MachineHealthCheck is blocked
 	// from making any further remediations.
 	TooManyUnhealthyV1Beta1Reason = "TooManyUnhealthy"
+
+	// RemediationRateLimitedV1Beta1Reason is the reason used when the number of remediations triggered within the time window
+	// defined in the MachineHealthCheck has been reached and the MachineHealthCheck is blocked from making any further remediations.
+	RemediationRateLimitedV1Beta1Reason = "RemediationRateLimited"
 )
 
 // Conditions and condition Reasons for  MachineDeployments.
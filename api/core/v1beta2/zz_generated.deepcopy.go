@@ -558,6 +558,206 @@ func (in *ClusterInitializationStatus) DeepCopy() *ClusterInitializationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubeconfig) DeepCopyInto(out *ClusterKubeconfig) {
+	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]ClusterKubeconfigMirror, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubeconfig.
+func (in *ClusterKubeconfig) DeepCopy() *ClusterKubeconfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubeconfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubeconfigMirror) DeepCopyInto(out *ClusterKubeconfigMirror) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubeconfigMirror.
+func (in *ClusterKubeconfigMirror) DeepCopy() *ClusterKubeconfigMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubeconfigMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubeconfigRequest) DeepCopyInto(out *ClusterKubeconfigRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubeconfigRequest.
+func (in *ClusterKubeconfigRequest) DeepCopy() *ClusterKubeconfigRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubeconfigRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterKubeconfigRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubeconfigRequestList) DeepCopyInto(out *ClusterKubeconfigRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterKubeconfigRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubeconfigRequestList.
+func (in *ClusterKubeconfigRequestList) DeepCopy() *ClusterKubeconfigRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubeconfigRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterKubeconfigRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubeconfigRequestOIDCSpec) DeepCopyInto(out *ClusterKubeconfigRequestOIDCSpec) {
+	*out = *in
+	if in.ExtraScopes != nil {
+		in, out := &in.ExtraScopes, &out.ExtraScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubeconfigRequestOIDCSpec.
+func (in *ClusterKubeconfigRequestOIDCSpec) DeepCopy() *ClusterKubeconfigRequestOIDCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubeconfigRequestOIDCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubeconfigRequestSpec) DeepCopyInto(out *ClusterKubeconfigRequestSpec) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTLSeconds != nil {
+		in, out := &in.TTLSeconds, &out.TTLSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AutoRenew != nil {
+		in, out := &in.AutoRenew, &out.AutoRenew
+		*out = new(bool)
+		**out = **in
+	}
+	in.OIDC.DeepCopyInto(&out.OIDC)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubeconfigRequestSpec.
+func (in *ClusterKubeconfigRequestSpec) DeepCopy() *ClusterKubeconfigRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubeconfigRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubeconfigRequestStatus) DeepCopyInto(out *ClusterKubeconfigRequestStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.IssueTime.DeepCopyInto(&out.IssueTime)
+	in.ExpirationTime.DeepCopyInto(&out.ExpirationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubeconfigRequestStatus.
+func (in *ClusterKubeconfigRequestStatus) DeepCopy() *ClusterKubeconfigRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubeconfigRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubeconfigStatus) DeepCopyInto(out *ClusterKubeconfigStatus) {
+	*out = *in
+	in.CertificateExpirationTime.DeepCopyInto(&out.CertificateExpirationTime)
+	in.LastRotationTime.DeepCopyInto(&out.LastRotationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubeconfigStatus.
+func (in *ClusterKubeconfigStatus) DeepCopy() *ClusterKubeconfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubeconfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	*out = *in
@@ -625,6 +825,7 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = make([]ClusterAvailabilityGate, len(*in))
 		copy(*out, *in)
 	}
+	in.Kubeconfig.DeepCopyInto(&out.Kubeconfig)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -658,6 +859,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = new(WorkersStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Kubeconfig != nil {
+		in, out := &in.Kubeconfig, &out.Kubeconfig
+		*out = new(ClusterKubeconfigStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FailureDomains != nil {
 		in, out := &in.FailureDomains, &out.FailureDomains
 		*out = make([]FailureDomain, len(*in))
@@ -1933,10 +2139,52 @@ func (in *MachineDeploymentRolloutSpec) DeepCopy() *MachineDeploymentRolloutSpec
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentRolloutStep) DeepCopyInto(out *MachineDeploymentRolloutStep) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	in.Gate.DeepCopyInto(&out.Gate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentRolloutStep.
+func (in *MachineDeploymentRolloutStep) DeepCopy() *MachineDeploymentRolloutStep {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentRolloutStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentRolloutStepGate) DeepCopyInto(out *MachineDeploymentRolloutStepGate) {
+	*out = *in
+	if in.SoakSeconds != nil {
+		in, out := &in.SoakSeconds, &out.SoakSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentRolloutStepGate.
+func (in *MachineDeploymentRolloutStepGate) DeepCopy() *MachineDeploymentRolloutStepGate {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentRolloutStepGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineDeploymentRolloutStrategy) DeepCopyInto(out *MachineDeploymentRolloutStrategy) {
 	*out = *in
 	in.RollingUpdate.DeepCopyInto(&out.RollingUpdate)
+	in.Staged.DeepCopyInto(&out.Staged)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentRolloutStrategy.
@@ -1974,6 +2222,28 @@ func (in *MachineDeploymentRolloutStrategyRollingUpdate) DeepCopy() *MachineDepl
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentRolloutStrategyStaged) DeepCopyInto(out *MachineDeploymentRolloutStrategyStaged) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]MachineDeploymentRolloutStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentRolloutStrategyStaged.
+func (in *MachineDeploymentRolloutStrategyStaged) DeepCopy() *MachineDeploymentRolloutStrategyStaged {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentRolloutStrategyStaged)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineDeploymentSpec) DeepCopyInto(out *MachineDeploymentSpec) {
 	*out = *in
@@ -2376,6 +2646,8 @@ func (in *MachineDrainRuleDrainConfig) DeepCopyInto(out *MachineDrainRuleDrainCo
 		*out = new(int32)
 		**out = **in
 	}
+	out.EvictAfter = in.EvictAfter
+	in.ForceDelete.DeepCopyInto(&out.ForceDelete)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDrainRuleDrainConfig.
@@ -2388,6 +2660,46 @@ func (in *MachineDrainRuleDrainConfig) DeepCopy() *MachineDrainRuleDrainConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDrainRuleEvictAfterConfig) DeepCopyInto(out *MachineDrainRuleEvictAfterConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDrainRuleEvictAfterConfig.
+func (in *MachineDrainRuleEvictAfterConfig) DeepCopy() *MachineDrainRuleEvictAfterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDrainRuleEvictAfterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDrainRuleForceDeleteConfig) DeepCopyInto(out *MachineDrainRuleForceDeleteConfig) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDrainRuleForceDeleteConfig.
+func (in *MachineDrainRuleForceDeleteConfig) DeepCopy() *MachineDrainRuleForceDeleteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDrainRuleForceDeleteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineDrainRuleList) DeepCopyInto(out *MachineDrainRuleList) {
 	*out = *in
@@ -2549,6 +2861,13 @@ func (in *MachineHealthCheckChecks) DeepCopyInto(out *MachineHealthCheckChecks)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]MachineHealthCheckProbe, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineHealthCheckChecks.
@@ -2561,6 +2880,22 @@ func (in *MachineHealthCheckChecks) DeepCopy() *MachineHealthCheckChecks {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineHealthCheckDaemonSetPodProbe) DeepCopyInto(out *MachineHealthCheckDaemonSetPodProbe) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineHealthCheckDaemonSetPodProbe.
+func (in *MachineHealthCheckDaemonSetPodProbe) DeepCopy() *MachineHealthCheckDaemonSetPodProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineHealthCheckDaemonSetPodProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineHealthCheckDeprecatedStatus) DeepCopyInto(out *MachineHealthCheckDeprecatedStatus) {
 	*out = *in
@@ -2581,6 +2916,26 @@ func (in *MachineHealthCheckDeprecatedStatus) DeepCopy() *MachineHealthCheckDepr
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineHealthCheckHTTPProbe) DeepCopyInto(out *MachineHealthCheckHTTPProbe) {
+	*out = *in
+	if in.RequestTimeoutSeconds != nil {
+		in, out := &in.RequestTimeoutSeconds, &out.RequestTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineHealthCheckHTTPProbe.
+func (in *MachineHealthCheckHTTPProbe) DeepCopy() *MachineHealthCheckHTTPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineHealthCheckHTTPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineHealthCheckList) DeepCopyInto(out *MachineHealthCheckList) {
 	*out = *in
@@ -2613,11 +2968,35 @@ func (in *MachineHealthCheckList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineHealthCheckProbe) DeepCopyInto(out *MachineHealthCheckProbe) {
+	*out = *in
+	in.DaemonSetPod.DeepCopyInto(&out.DaemonSetPod)
+	in.HTTP.DeepCopyInto(&out.HTTP)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineHealthCheckProbe.
+func (in *MachineHealthCheckProbe) DeepCopy() *MachineHealthCheckProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineHealthCheckProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineHealthCheckRemediation) DeepCopyInto(out *MachineHealthCheckRemediation) {
 	*out = *in
 	in.TriggerIf.DeepCopyInto(&out.TriggerIf)
+	in.RateLimit.DeepCopyInto(&out.RateLimit)
 	out.TemplateRef = in.TemplateRef
+	in.Escalation.DeepCopyInto(&out.Escalation)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineHealthCheckRemediation.
@@ -2630,6 +3009,95 @@ func (in *MachineHealthCheckRemediation) DeepCopy() *MachineHealthCheckRemediati
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineHealthCheckRemediationEscalation) DeepCopyInto(out *MachineHealthCheckRemediationEscalation) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]MachineHealthCheckRemediationEscalationStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineHealthCheckRemediationEscalation.
+func (in *MachineHealthCheckRemediationEscalation) DeepCopy() *MachineHealthCheckRemediationEscalation {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineHealthCheckRemediationEscalation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineHealthCheckRemediationEscalationStep) DeepCopyInto(out *MachineHealthCheckRemediationEscalationStep) {
+	*out = *in
+	out.TemplateRef = in.TemplateRef
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineHealthCheckRemediationEscalationStep.
+func (in *MachineHealthCheckRemediationEscalationStep) DeepCopy() *MachineHealthCheckRemediationEscalationStep {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineHealthCheckRemediationEscalationStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineHealthCheckRemediationRateLimit) DeepCopyInto(out *MachineHealthCheckRemediationRateLimit) {
+	*out = *in
+	if in.MaxRemediations != nil {
+		in, out := &in.MaxRemediations, &out.MaxRemediations
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxClusterRemediations != nil {
+		in, out := &in.MaxClusterRemediations, &out.MaxClusterRemediations
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineHealthCheckRemediationRateLimit.
+func (in *MachineHealthCheckRemediationRateLimit) DeepCopy() *MachineHealthCheckRemediationRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineHealthCheckRemediationRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineHealthCheckRemediationRecord) DeepCopyInto(out *MachineHealthCheckRemediationRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineHealthCheckRemediationRecord.
+func (in *MachineHealthCheckRemediationRecord) DeepCopy() *MachineHealthCheckRemediationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineHealthCheckRemediationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineHealthCheckRemediationTemplateReference) DeepCopyInto(out *MachineHealthCheckRemediationTemplateReference) {
 	*out = *in
@@ -2713,6 +3181,13 @@ func (in *MachineHealthCheckStatus) DeepCopyInto(out *MachineHealthCheckStatus)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Remediations != nil {
+		in, out := &in.Remediations, &out.Remediations
+		*out = make([]MachineHealthCheckRemediationRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Deprecated != nil {
 		in, out := &in.Deprecated, &out.Deprecated
 		*out = new(MachineHealthCheckDeprecatedStatus)
@@ -3055,6 +3530,59 @@ func (in *MachinePoolList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolRolloutSpec) DeepCopyInto(out *MachinePoolRolloutSpec) {
+	*out = *in
+	in.After.DeepCopyInto(&out.After)
+	in.Strategy.DeepCopyInto(&out.Strategy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolRolloutSpec.
+func (in *MachinePoolRolloutSpec) DeepCopy() *MachinePoolRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolRolloutStrategy) DeepCopyInto(out *MachinePoolRolloutStrategy) {
+	*out = *in
+	in.RollingUpdate.DeepCopyInto(&out.RollingUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolRolloutStrategy.
+func (in *MachinePoolRolloutStrategy) DeepCopy() *MachinePoolRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolRolloutStrategyRollingUpdate) DeepCopyInto(out *MachinePoolRolloutStrategyRollingUpdate) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolRolloutStrategyRollingUpdate.
+func (in *MachinePoolRolloutStrategyRollingUpdate) DeepCopy() *MachinePoolRolloutStrategyRollingUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolRolloutStrategyRollingUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
 	*out = *in
@@ -3074,6 +3602,7 @@ func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.Rollout.DeepCopyInto(&out.Rollout)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolSpec.
@@ -78,6 +78,34 @@ type AfterControlPlaneInitializedResponse struct {
 func AfterControlPlaneInitialized(*AfterControlPlaneInitializedRequest, *AfterControlPlaneInitializedResponse) {
 }
 
+// AfterClusterCreateRequest is the request of the AfterClusterCreate hook.
+// +kubebuilder:object:root=true
+type AfterClusterCreateRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the lifecycle hook corresponds to.
+	// +required
+	Cluster clusterv1.Cluster `json:"cluster"`
+}
+
+var _ ResponseObject = &AfterClusterCreateResponse{}
+
+// AfterClusterCreateResponse is the response of the AfterClusterCreate hook.
+// +kubebuilder:object:root=true
+type AfterClusterCreateResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonResponse contains Status and Message fields common to all response types.
+	CommonResponse `json:",inline"`
+}
+
+// AfterClusterCreate is the hook that will be called after the topology of the Cluster is fully provisioned
+// for the first time, i.e. when the control plane and all the MachineDeployments and MachinePools are available.
+func AfterClusterCreate(*AfterClusterCreateRequest, *AfterClusterCreateResponse) {}
+
 // BeforeClusterUpgradeRequest is the request of the BeforeClusterUpgrade hook.
 // +kubebuilder:object:root=true
 type BeforeClusterUpgradeRequest struct {
@@ -358,6 +386,34 @@ type BeforeClusterDeleteResponse struct {
 // and before the cluster and its underlying objects are deleted.
 func BeforeClusterDelete(*BeforeClusterDeleteRequest, *BeforeClusterDeleteResponse) {}
 
+// AfterClusterDeleteRequest is the request of the AfterClusterDelete hook.
+// +kubebuilder:object:root=true
+type AfterClusterDeleteRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the lifecycle hook corresponds to.
+	// +required
+	Cluster clusterv1.Cluster `json:"cluster"`
+}
+
+var _ ResponseObject = &AfterClusterDeleteResponse{}
+
+// AfterClusterDeleteResponse is the response of the AfterClusterDelete hook.
+// +kubebuilder:object:root=true
+type AfterClusterDeleteResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonResponse contains Status and Message fields common to all response types.
+	CommonResponse `json:",inline"`
+}
+
+// AfterClusterDelete is the hook that is called after all the underlying objects of a cluster
+// have been deleted and immediately before the cluster itself is removed.
+func AfterClusterDelete(*AfterClusterDeleteRequest, *AfterClusterDeleteResponse) {}
+
 func init() {
 	catalogBuilder.RegisterHook(BeforeClusterCreate, &runtimecatalog.HookMeta{
 		Tags:    []string{"Lifecycle Hooks"},
@@ -382,6 +438,19 @@ func init() {
 			"- This is a non-blocking hook",
 	})
 
+	catalogBuilder.RegisterHook(AfterClusterCreate, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook after a Cluster is fully provisioned",
+		Description: "Cluster API Runtime will call this hook after the Cluster is fully provisioned for the first time, " +
+			"i.e. when the control plane and all the MachineDeployments and MachinePools of the Cluster's topology are available.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only for Clusters with a managed topology\n" +
+			"- This hook will be called after AfterControlPlaneInitialized\n" +
+			"- The call's request contains the Cluster object\n" +
+			"- This is a non-blocking hook",
+	})
+
 	catalogBuilder.RegisterHook(BeforeClusterUpgrade, &runtimecatalog.HookMeta{
 		Tags:    []string{"Lifecycle Hooks"},
 		Summary: "Cluster API Runtime will call this hook before the Cluster is upgraded",
@@ -473,4 +542,17 @@ func init() {
 			"- This is a blocking hook; Runtime Extension implementers can use this hook  to execute " +
 			"tasks before objects of the Cluster are deleted",
 	})
+
+	catalogBuilder.RegisterHook(AfterClusterDelete, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook after a Cluster is deleted",
+		Description: "Cluster API Runtime will call this hook after all the objects of the Cluster have been deleted, " +
+			"and immediately before the Cluster object itself is removed.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only for Clusters with a managed topology\n" +
+			"- This hook will be called only if at least one extension was registered for this hook when the Cluster deletion started\n" +
+			"- The call's request contains the Cluster object\n" +
+			"- This is a non-blocking hook; however, the Cluster object is not removed until the hook is successfully called",
+	})
 }
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/api/runtime/catalog"
+)
+
+// CanMachineDeploymentRolloutProceedRequest is the request of the CanMachineDeploymentRolloutProceed hook.
+// +kubebuilder:object:root=true
+type CanMachineDeploymentRolloutProceedRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// machineDeployment is the MachineDeployment being rolled out.
+	// +required
+	MachineDeployment clusterv1.MachineDeployment `json:"machineDeployment,omitempty,omitzero"`
+
+	// machineSet is the new MachineSet of the MachineDeployment, i.e. the MachineSet the rollout is scaling up.
+	// +required
+	MachineSet clusterv1.MachineSet `json:"machineSet,omitempty,omitzero"`
+
+	// step is the number of the step of the staged rollout which is completed, starting from 1.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	Step int32 `json:"step,omitempty"`
+}
+
+var _ RetryResponseObject = &CanMachineDeploymentRolloutProceedResponse{}
+
+// CanMachineDeploymentRolloutProceedResponse is the response of the CanMachineDeploymentRolloutProceed hook.
+// +kubebuilder:object:root=true
+type CanMachineDeploymentRolloutProceedResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// CanMachineDeploymentRolloutProceed is the hook that will be called before a staged MachineDeployment rollout
+// continues with the next step.
+func CanMachineDeploymentRolloutProceed(*CanMachineDeploymentRolloutProceedRequest, *CanMachineDeploymentRolloutProceedResponse) {
+}
+
+func init() {
+	catalogBuilder.RegisterHook(CanMachineDeploymentRolloutProceed, &runtimecatalog.HookMeta{
+		Tags:    []string{"Rollout Hooks"},
+		Summary: "Cluster API Runtime will call this hook before a staged MachineDeployment rollout continues with the next step",
+		Description: "Cluster API Runtime will call this hook when all the replicas of a step of a staged MachineDeployment rollout " +
+			"are available, and before the rollout continues with the next step.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook is called only for MachineDeployments using the Staged rollout strategy and only for the extension " +
+			"referenced in the step's gate\n" +
+			"- The call's request contains the MachineDeployment object, the new MachineSet object and the step number\n" +
+			"- This is a blocking hook; if the extension returns retryAfterSeconds > 0, the rollout stays paused " +
+			"until the hook is called again",
+	})
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/api/runtime/catalog"
+)
+
+// BeforeMachineDrainRequest is the request of the BeforeMachineDrain hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDrainRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the Machine belongs to.
+	// +required
+	Cluster clusterv1.Cluster `json:"cluster,omitempty,omitzero"`
+
+	// machine is the Machine object whose Node is going to be drained.
+	// +required
+	Machine clusterv1.Machine `json:"machine,omitempty,omitzero"`
+
+	// nodeName is the name of the Node that is going to be drained.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	NodeName string `json:"nodeName,omitempty"`
+}
+
+var _ RetryResponseObject = &BeforeMachineDrainResponse{}
+
+// BeforeMachineDrainResponse is the response of the BeforeMachineDrain hook.
+// The status of the operation is determined by the CommonRetryResponse fields:
+// - Status=Success + RetryAfterSeconds > 0: the extension is still working on the Node, drain is blocked
+// - Status=Success + RetryAfterSeconds = 0: the extension completed, drain can proceed
+// - Status=Failure: the extension failed, drain is blocked
+// +kubebuilder:object:root=true
+type BeforeMachineDrainResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+
+	// skipNodeDrain signals that the extension took over draining of the Node, and thus
+	// Cluster API should not evict Pods from the Node.
+	// Note: Cluster API is still going to cordon the Node and to wait for volumes to be detached.
+	// +optional
+	SkipNodeDrain *bool `json:"skipNodeDrain,omitempty"`
+}
+
+// BeforeMachineDrain is the hook that will be called before the Node of a Machine is drained.
+func BeforeMachineDrain(*BeforeMachineDrainRequest, *BeforeMachineDrainResponse) {}
+
+func init() {
+	catalogBuilder.RegisterHook(BeforeMachineDrain, &runtimecatalog.HookMeta{
+		Tags:    []string{"Machine Drain Hooks"},
+		Summary: "Cluster API Runtime will call this hook before the Node of a Machine is drained",
+		Description: "Cluster API Runtime will call this hook when a Machine is being deleted, after pre-drain hooks succeeded " +
+			"and immediately before the Node of the Machine is drained. Extensions can use this hook to augment or take over " +
+			"draining of the Node, e.g. to migrate VMs, checkpoint Pods or coordinate with a storage system.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only for Machines with a Node and only if drain is not skipped or timed out\n" +
+			"- The call's request contains the Cluster object, the Machine object and the name of the Node\n" +
+			"- This is a blocking hook; if any extension returns retryAfterSeconds > 0, drain is blocked until the hook is called again\n" +
+			"- This hook is called on every reconcile of the Machine while drain is in progress, so it must be idempotent\n" +
+			"- If any extension returns skipNodeDrain=true, Cluster API does not evict Pods from the Node, " +
+			"but it still cordons the Node and waits for volumes to be detached\n",
+	})
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/api/runtime/catalog"
+)
+
+// AfterMachineProvisionedRequest is the request of the AfterMachineProvisioned hook.
+// +kubebuilder:object:root=true
+type AfterMachineProvisionedRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the Machine belongs to.
+	// +required
+	Cluster clusterv1.Cluster `json:"cluster,omitempty,omitzero"`
+
+	// machine is the Machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1.Machine `json:"machine,omitempty,omitzero"`
+}
+
+var _ ResponseObject = &AfterMachineProvisionedResponse{}
+
+// AfterMachineProvisionedResponse is the response of the AfterMachineProvisioned hook.
+// +kubebuilder:object:root=true
+type AfterMachineProvisionedResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonResponse contains Status and Message fields common to all response types.
+	CommonResponse `json:",inline"`
+}
+
+// AfterMachineProvisioned is the hook that will be called after the infrastructure of a Machine is provisioned.
+func AfterMachineProvisioned(*AfterMachineProvisionedRequest, *AfterMachineProvisionedResponse) {}
+
+// AfterNodeReadyRequest is the request of the AfterNodeReady hook.
+// +kubebuilder:object:root=true
+type AfterNodeReadyRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the Machine belongs to.
+	// +required
+	Cluster clusterv1.Cluster `json:"cluster,omitempty,omitzero"`
+
+	// machine is the Machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1.Machine `json:"machine,omitempty,omitzero"`
+
+	// nodeName is the name of the Node hosted on the Machine.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	NodeName string `json:"nodeName,omitempty"`
+}
+
+var _ ResponseObject = &AfterNodeReadyResponse{}
+
+// AfterNodeReadyResponse is the response of the AfterNodeReady hook.
+// +kubebuilder:object:root=true
+type AfterNodeReadyResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonResponse contains Status and Message fields common to all response types.
+	CommonResponse `json:",inline"`
+}
+
+// AfterNodeReady is the hook that will be called after the Node hosted on a Machine becomes ready for the first time.
+func AfterNodeReady(*AfterNodeReadyRequest, *AfterNodeReadyResponse) {}
+
+// BeforeMachineDeletionRequest is the request of the BeforeMachineDeletion hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDeletionRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the cluster object the Machine belongs to.
+	// +required
+	Cluster clusterv1.Cluster `json:"cluster,omitempty,omitzero"`
+
+	// machine is the Machine object the lifecycle hook corresponds to.
+	// +required
+	Machine clusterv1.Machine `json:"machine,omitempty,omitzero"`
+}
+
+var _ RetryResponseObject = &BeforeMachineDeletionResponse{}
+
+// BeforeMachineDeletionResponse is the response of the BeforeMachineDeletion hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDeletionResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// BeforeMachineDeletion is the hook that will be called before a Machine is deleted.
+func BeforeMachineDeletion(*BeforeMachineDeletionRequest, *BeforeMachineDeletionResponse) {}
+
+func init() {
+	catalogBuilder.RegisterHook(AfterMachineProvisioned, &runtimecatalog.HookMeta{
+		Tags:    []string{"Machine Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook after the infrastructure of a Machine is provisioned",
+		Description: "Cluster API Runtime will call this hook after the infrastructure of a Machine is provisioned for the first time.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only for Machines created while at least one extension is registered for this hook\n" +
+			"- The call's request contains the Cluster object and the Machine object, including its status\n" +
+			"- This is a non-blocking hook",
+	})
+
+	catalogBuilder.RegisterHook(AfterNodeReady, &runtimecatalog.HookMeta{
+		Tags:    []string{"Machine Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook after the Node of a Machine becomes ready",
+		Description: "Cluster API Runtime will call this hook after the Node hosted on a Machine becomes ready for the first time.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only for Machines created while at least one extension is registered for this hook\n" +
+			"- The call's request contains the Cluster object, the Machine object, including its status, and the name of the Node\n" +
+			"- This is a non-blocking hook",
+	})
+
+	catalogBuilder.RegisterHook(BeforeMachineDeletion, &runtimecatalog.HookMeta{
+		Tags:    []string{"Machine Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook before a Machine is deleted",
+		Description: "Cluster API Runtime will call this hook after a Machine is marked for deletion and immediately before " +
+			"the Node hosted on the Machine is drained and the Machine is deleted.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- The call's request contains the Cluster object and the Machine object, including its status\n" +
+			"- This is a blocking hook; if any extension returns retryAfterSeconds > 0, the Machine deletion is blocked " +
+			"until the hook is called again",
+	})
+}
@@ -25,6 +25,108 @@ import (
 	"sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterClusterCreateRequest) DeepCopyInto(out *AfterClusterCreateRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Cluster.DeepCopyInto(&out.Cluster)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterClusterCreateRequest.
+func (in *AfterClusterCreateRequest) DeepCopy() *AfterClusterCreateRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterClusterCreateRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AfterClusterCreateRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterClusterCreateResponse) DeepCopyInto(out *AfterClusterCreateResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonResponse = in.CommonResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterClusterCreateResponse.
+func (in *AfterClusterCreateResponse) DeepCopy() *AfterClusterCreateResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterClusterCreateResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AfterClusterCreateResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterClusterDeleteRequest) DeepCopyInto(out *AfterClusterDeleteRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Cluster.DeepCopyInto(&out.Cluster)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterClusterDeleteRequest.
+func (in *AfterClusterDeleteRequest) DeepCopy() *AfterClusterDeleteRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterClusterDeleteRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AfterClusterDeleteRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterClusterDeleteResponse) DeepCopyInto(out *AfterClusterDeleteResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonResponse = in.CommonResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterClusterDeleteResponse.
+func (in *AfterClusterDeleteResponse) DeepCopy() *AfterClusterDeleteResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterClusterDeleteResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AfterClusterDeleteResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AfterClusterUpgradeRequest) DeepCopyInto(out *AfterClusterUpgradeRequest) {
 	*out = *in
@@ -188,6 +290,110 @@ func (in *AfterControlPlaneUpgradeResponse) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterMachineProvisionedRequest) DeepCopyInto(out *AfterMachineProvisionedRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	in.Machine.DeepCopyInto(&out.Machine)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterMachineProvisionedRequest.
+func (in *AfterMachineProvisionedRequest) DeepCopy() *AfterMachineProvisionedRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterMachineProvisionedRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AfterMachineProvisionedRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterMachineProvisionedResponse) DeepCopyInto(out *AfterMachineProvisionedResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonResponse = in.CommonResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterMachineProvisionedResponse.
+func (in *AfterMachineProvisionedResponse) DeepCopy() *AfterMachineProvisionedResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterMachineProvisionedResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AfterMachineProvisionedResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterNodeReadyRequest) DeepCopyInto(out *AfterNodeReadyRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	in.Machine.DeepCopyInto(&out.Machine)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterNodeReadyRequest.
+func (in *AfterNodeReadyRequest) DeepCopy() *AfterNodeReadyRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterNodeReadyRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AfterNodeReadyRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterNodeReadyResponse) DeepCopyInto(out *AfterNodeReadyResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonResponse = in.CommonResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterNodeReadyResponse.
+func (in *AfterNodeReadyResponse) DeepCopy() *AfterNodeReadyResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterNodeReadyResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AfterNodeReadyResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AfterWorkersUpgradeRequest) DeepCopyInto(out *AfterWorkersUpgradeRequest) {
 	*out = *in
@@ -473,6 +679,115 @@ func (in *BeforeControlPlaneUpgradeResponse) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineDeletionRequest) DeepCopyInto(out *BeforeMachineDeletionRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	in.Machine.DeepCopyInto(&out.Machine)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineDeletionRequest.
+func (in *BeforeMachineDeletionRequest) DeepCopy() *BeforeMachineDeletionRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineDeletionRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineDeletionRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineDeletionResponse) DeepCopyInto(out *BeforeMachineDeletionResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonRetryResponse = in.CommonRetryResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineDeletionResponse.
+func (in *BeforeMachineDeletionResponse) DeepCopy() *BeforeMachineDeletionResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineDeletionResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineDeletionResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineDrainRequest) DeepCopyInto(out *BeforeMachineDrainRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	in.Machine.DeepCopyInto(&out.Machine)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineDrainRequest.
+func (in *BeforeMachineDrainRequest) DeepCopy() *BeforeMachineDrainRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineDrainRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineDrainRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineDrainResponse) DeepCopyInto(out *BeforeMachineDrainResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonRetryResponse = in.CommonRetryResponse
+	if in.SkipNodeDrain != nil {
+		in, out := &in.SkipNodeDrain, &out.SkipNodeDrain
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineDrainResponse.
+func (in *BeforeMachineDrainResponse) DeepCopy() *BeforeMachineDrainResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineDrainResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineDrainResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BeforeWorkersUpgradeRequest) DeepCopyInto(out *BeforeWorkersUpgradeRequest) {
 	*out = *in
@@ -569,6 +884,58 @@ func (in *Builtins) DeepCopy() *Builtins {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanMachineDeploymentRolloutProceedRequest) DeepCopyInto(out *CanMachineDeploymentRolloutProceedRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.MachineDeployment.DeepCopyInto(&out.MachineDeployment)
+	in.MachineSet.DeepCopyInto(&out.MachineSet)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanMachineDeploymentRolloutProceedRequest.
+func (in *CanMachineDeploymentRolloutProceedRequest) DeepCopy() *CanMachineDeploymentRolloutProceedRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(CanMachineDeploymentRolloutProceedRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CanMachineDeploymentRolloutProceedRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanMachineDeploymentRolloutProceedResponse) DeepCopyInto(out *CanMachineDeploymentRolloutProceedResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonRetryResponse = in.CommonRetryResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanMachineDeploymentRolloutProceedResponse.
+func (in *CanMachineDeploymentRolloutProceedResponse) DeepCopy() *CanMachineDeploymentRolloutProceedResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(CanMachineDeploymentRolloutProceedResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CanMachineDeploymentRolloutProceedResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CanUpdateMachineRequest) DeepCopyInto(out *CanUpdateMachineRequest) {
 	*out = *in
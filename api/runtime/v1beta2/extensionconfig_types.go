@@ -316,7 +316,8 @@ const (
 	// the intent will be removed as soon as the hook call completes successfully.
 	PendingHooksAnnotation string = "runtime.cluster.x-k8s.io/pending-hooks"
 
-	// OkToDeleteAnnotation is the annotation used to indicate if a cluster is ready to be fully deleted.
-	// This annotation is added to the cluster after the BeforeClusterDelete hook has passed.
+	// OkToDeleteAnnotation is the annotation used to indicate if a cluster or a machine is ready to be fully deleted.
+	// This annotation is added to the cluster after the BeforeClusterDelete hook has passed, and to the machine
+	// after the BeforeMachineDeletion hook has passed.
 	OkToDeleteAnnotation string = "runtime.cluster.x-k8s.io/ok-to-delete"
 )
@@ -354,6 +354,12 @@ func (r *KubeadmConfigReconciler) reconcile(ctx context.Context, scope *Scope, c
 		return r.handleClusterNotInitialized(ctx, scope)
 	}
 
+	// A control plane Machine restoring etcd from a snapshot initializes a new etcd cluster, so it must use kubeadm init
+	// even if the control plane of the Cluster is already initialized.
+	if _, ok := config.Annotations[bootstrapv1.EtcdRestoreAnnotation]; ok && configOwner.IsControlPlaneMachine() {
+		return r.handleClusterNotInitialized(ctx, scope)
+	}
+
 	// Every other case it's a join scenario
 	// Nb. in this case ClusterConfiguration and InitConfiguration should not be defined by users, but in case of misconfigurations, CABPK simply ignore them
 
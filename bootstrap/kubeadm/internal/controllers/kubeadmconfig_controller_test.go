@@ -550,6 +550,56 @@ func TestKubeadmConfigReconciler_Reconcile_GenerateCloudConfigData(t *testing.T)
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+// If a control plane machine is restoring etcd from a snapshot, it must be initialized even if the control plane is already initialized.
+func TestKubeadmConfigReconciler_Reconcile_GenerateInitDataWhenRestoringEtcd(t *testing.T) {
+	g := NewWithT(t)
+
+	configName := "control-plane-restore-cfg"
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+	cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "validhost", Port: 6443}
+	cluster.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+	cluster.Status.Conditions = []metav1.Condition{{Type: clusterv1.ClusterControlPlaneInitializedCondition, Status: metav1.ConditionTrue}}
+
+	controlPlaneRestoreMachine := newControlPlaneMachine(cluster, "control-plane-restore-machine")
+	controlPlaneRestoreConfig := newControlPlaneInitKubeadmConfig(controlPlaneRestoreMachine.Namespace, configName)
+	controlPlaneRestoreConfig.Annotations = map[string]string{bootstrapv1.EtcdRestoreAnnotation: "snapshot"}
+
+	addKubeadmConfigToMachine(controlPlaneRestoreConfig, controlPlaneRestoreMachine)
+
+	objects := []client.Object{
+		cluster,
+		controlPlaneRestoreMachine,
+		controlPlaneRestoreConfig,
+	}
+	objects = append(objects, createSecrets(t, cluster, controlPlaneRestoreConfig)...)
+
+	myclient := fake.NewClientBuilder().WithObjects(objects...).WithStatusSubresource(&bootstrapv1.KubeadmConfig{}).Build()
+
+	initLocker := &myInitLocker{}
+	k := &KubeadmConfigReconciler{
+		Client:              myclient,
+		SecretCachingClient: myclient,
+		ClusterCache:        clustercache.NewFakeClusterCache(myclient, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}),
+		KubeadmInitLock:     initLocker,
+	}
+
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: metav1.NamespaceDefault,
+			Name:      configName,
+		},
+	}
+	result, err := k.Reconcile(ctx, request)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(time.Duration(0)))
+	// The init lock is acquired only when generating data for kubeadm init.
+	g.Expect(initLocker.locked).To(BeTrue())
+
+	s := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: configName}, s)).To(Succeed())
+	g.Expect(string(s.Data["value"])).To(ContainSubstring("kubeadm init"))
+}
+
 // If a control plane has no JoinConfiguration, then we will create a default and no error will occur.
 func TestKubeadmConfigReconciler_Reconcile_ErrorIfJoiningControlPlaneHasInvalidConfiguration(t *testing.T) {
 	g := NewWithT(t)
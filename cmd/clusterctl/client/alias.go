@@ -45,6 +45,10 @@ type UpgradePlan cluster.UpgradePlan
 // upgraded to a different version.
 type CertManagerUpgradePlan cluster.CertManagerUpgradePlan
 
+// TopologyPlanOutput defines the changes the topology controller would apply to the Clusters affected by
+// the input of TopologyPlan.
+type TopologyPlanOutput cluster.TopologyPlanOutput
+
 // Kubeconfig is a type that specifies inputs related to the actual kubeconfig.
 type Kubeconfig cluster.Kubeconfig
 
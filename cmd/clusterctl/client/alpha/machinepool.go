@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+// getMachinePool retrieves the MachinePool object corresponding to the name and namespace specified.
+func getMachinePool(ctx context.Context, proxy cluster.Proxy, name, namespace string) (*clusterv1.MachinePool, error) {
+	mpObj := &clusterv1.MachinePool{}
+	c, err := proxy.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mpObjKey := client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}
+	if err := c.Get(ctx, mpObjKey, mpObj); err != nil {
+		return nil, errors.Wrapf(err, "failed to get MachinePool %s/%s",
+			mpObjKey.Namespace, mpObjKey.Name)
+	}
+	return mpObj, nil
+}
+
+// setRolloutAfterOnMachinePool sets MachinePool.spec.rolloutAfter.
+func setRolloutAfterOnMachinePool(ctx context.Context, proxy cluster.Proxy, name, namespace string) error {
+	patch := client.RawPatch(types.MergePatchType, []byte(fmt.Sprintf(`{"spec":{"rollout":{"after":"%v"}}}`, time.Now().Format(time.RFC3339))))
+	return patchMachinePool(ctx, proxy, name, namespace, patch)
+}
+
+// patchMachinePool applies a patch to a MachinePool.
+func patchMachinePool(ctx context.Context, proxy cluster.Proxy, name, namespace string, patch client.Patch) error {
+	cFrom, err := proxy.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+	mpObj := &clusterv1.MachinePool{}
+	mpObjKey := client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}
+	if err := cFrom.Get(ctx, mpObjKey, mpObj); err != nil {
+		return errors.Wrapf(err, "failed to get MachinePool %s/%s", mpObj.GetNamespace(), mpObj.GetName())
+	}
+
+	if err := cFrom.Patch(ctx, mpObj, patch); err != nil {
+		return errors.Wrapf(err, "failed while patching MachinePool %s/%s", mpObj.GetNamespace(), mpObj.GetName())
+	}
+	return nil
+}
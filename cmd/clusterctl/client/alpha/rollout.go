@@ -29,11 +29,14 @@ const (
 	MachineDeployment = "machinedeployment"
 	// KubeadmControlPlane is a resource type.
 	KubeadmControlPlane = "kubeadmcontrolplane"
+	// MachinePool is a resource type.
+	MachinePool = "machinepool"
 )
 
 var validResourceTypes = []string{
 	MachineDeployment,
 	KubeadmControlPlane,
+	MachinePool,
 }
 
 // Rollout defines the behavior of a rollout implementation.
@@ -56,6 +56,17 @@ func (r *rollout) ObjectPauser(ctx context.Context, proxy cluster.Proxy, ref cor
 		if err := pauseKubeadmControlPlane(ctx, proxy, ref.Name, ref.Namespace); err != nil {
 			return err
 		}
+	case MachinePool:
+		mp, err := getMachinePool(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || mp == nil {
+			return errors.Wrapf(err, "failed to fetch %v/%v", ref.Kind, ref.Name)
+		}
+		if annotations.HasPaused(mp.GetObjectMeta()) {
+			return errors.Errorf("MachinePool is already paused: %v/%v\n", ref.Kind, ref.Name) //nolint:revive // MachinePool is intentionally capitalized.
+		}
+		if err := pauseMachinePool(ctx, proxy, ref.Name, ref.Namespace); err != nil {
+			return err
+		}
 	default:
 		return errors.Errorf("Invalid resource type %q, valid values are %v", ref.Kind, validResourceTypes)
 	}
@@ -73,3 +84,9 @@ func pauseKubeadmControlPlane(ctx context.Context, proxy cluster.Proxy, name, na
 	patch := client.RawPatch(types.MergePatchType, []byte(fmt.Sprintf("{\"metadata\":{\"annotations\":{%q: \"%t\"}}}", clusterv1.PausedAnnotation, true)))
 	return patchKubeadmControlPlane(ctx, proxy, name, namespace, patch)
 }
+
+// pauseMachinePool sets paused annotation to true.
+func pauseMachinePool(ctx context.Context, proxy cluster.Proxy, name, namespace string) error {
+	patch := client.RawPatch(types.MergePatchType, []byte(fmt.Sprintf("{\"metadata\":{\"annotations\":{%q: \"%t\"}}}", clusterv1.PausedAnnotation, true)))
+	return patchMachinePool(ctx, proxy, name, namespace, patch)
+}
@@ -141,6 +141,55 @@ func Test_ObjectPauser(t *testing.T) {
 			wantErr:    true,
 			wantPaused: false,
 		},
+		{
+			name: "machinepool should be paused",
+			fields: fields{
+				objs: []client.Object{
+					&clusterv1.MachinePool{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "MachinePool",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "mp",
+						},
+					},
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachinePool,
+					Name:      "mp",
+					Namespace: "default",
+				},
+			},
+			wantErr:    false,
+			wantPaused: true,
+		},
+		{
+			name: "re-pausing an already paused machinepool should return error",
+			fields: fields{
+				objs: []client.Object{
+					&clusterv1.MachinePool{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "MachinePool",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "mp",
+							Annotations: map[string]string{
+								clusterv1.PausedAnnotation: "true",
+							},
+						},
+					},
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachinePool,
+					Name:      "mp",
+					Namespace: "default",
+				},
+			},
+			wantErr:    true,
+			wantPaused: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -168,6 +217,11 @@ func Test_ObjectPauser(t *testing.T) {
 					err = cl.Get(context.TODO(), key, kcp)
 					g.Expect(err).ToNot(HaveOccurred())
 					g.Expect(annotations.HasPaused(kcp.GetObjectMeta())).To(Equal(tt.wantPaused))
+				case *clusterv1.MachinePool:
+					mp := &clusterv1.MachinePool{}
+					err = cl.Get(context.TODO(), key, mp)
+					g.Expect(err).ToNot(HaveOccurred())
+					g.Expect(annotations.HasPaused(mp.GetObjectMeta())).To(Equal(tt.wantPaused))
 				}
 			}
 		})
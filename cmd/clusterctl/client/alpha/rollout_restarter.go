@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/util/annotations"
 )
@@ -59,6 +60,23 @@ func (r *rollout) ObjectRestarter(ctx context.Context, proxy cluster.Proxy, ref
 		if err := setRolloutAfterOnKCP(ctx, proxy, ref.Name, ref.Namespace); err != nil {
 			return err
 		}
+	case MachinePool:
+		mp, err := getMachinePool(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || mp == nil {
+			return errors.Wrapf(err, "failed to fetch %v/%v", ref.Kind, ref.Name)
+		}
+		if annotations.HasPaused(mp.GetObjectMeta()) {
+			return errors.Errorf("can't restart paused MachinePool (run rollout resume first): %v/%v", ref.Kind, ref.Name)
+		}
+		if mp.Spec.Rollout.Strategy.Type != clusterv1.RollingUpdateMachinePoolStrategyType {
+			return errors.Errorf("can't restart MachinePool without the RollingUpdate rollout strategy (set 'spec.rollout.strategy.type' first): %v/%v", ref.Kind, ref.Name)
+		}
+		if !mp.Spec.Rollout.After.IsZero() && mp.Spec.Rollout.After.After(time.Now()) {
+			return errors.Errorf("can't update MachinePool (remove 'spec.rollout.after' first): %v/%v", ref.Kind, ref.Name)
+		}
+		if err := setRolloutAfterOnMachinePool(ctx, proxy, ref.Name, ref.Namespace); err != nil {
+			return err
+		}
 	default:
 		return errors.Errorf("Invalid resource type %v. Valid values: %v", ref.Kind, validResourceTypes)
 	}
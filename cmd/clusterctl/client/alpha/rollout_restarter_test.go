@@ -203,6 +203,120 @@ func Test_ObjectRestarter(t *testing.T) {
 			wantErr:     true,
 			wantRollout: false,
 		},
+		{
+			name: "machinepool should have rolloutAfter",
+			fields: fields{
+				objs: []client.Object{
+					&clusterv1.MachinePool{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "MachinePool",
+							APIVersion: clusterv1.GroupVersion.String(),
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "mp",
+						},
+						Spec: clusterv1.MachinePoolSpec{
+							Rollout: clusterv1.MachinePoolRolloutSpec{
+								Strategy: clusterv1.MachinePoolRolloutStrategy{
+									Type: clusterv1.RollingUpdateMachinePoolStrategyType,
+								},
+							},
+						},
+					},
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachinePool,
+					Name:      "mp",
+					Namespace: "default",
+				},
+			},
+			wantErr:     false,
+			wantRollout: true,
+		},
+		{
+			name: "machinepool without the RollingUpdate strategy should not have rolloutAfter",
+			fields: fields{
+				objs: []client.Object{
+					&clusterv1.MachinePool{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "MachinePool",
+							APIVersion: clusterv1.GroupVersion.String(),
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "mp",
+						},
+					},
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachinePool,
+					Name:      "mp",
+					Namespace: "default",
+				},
+			},
+			wantErr:     true,
+			wantRollout: false,
+		},
+		{
+			name: "paused machinepool should not have rolloutAfter",
+			fields: fields{
+				objs: []client.Object{
+					&clusterv1.MachinePool{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "MachinePool",
+							APIVersion: clusterv1.GroupVersion.String(),
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "mp",
+							Annotations: map[string]string{
+								clusterv1.PausedAnnotation: "true",
+							},
+						},
+					},
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachinePool,
+					Name:      "mp",
+					Namespace: "default",
+				},
+			},
+			wantErr:     true,
+			wantRollout: false,
+		},
+		{
+			name: "machinepool with spec.rolloutAfter should not be updatable",
+			fields: fields{
+				objs: []client.Object{
+					&clusterv1.MachinePool{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "MachinePool",
+							APIVersion: clusterv1.GroupVersion.String(),
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "mp",
+						},
+						Spec: clusterv1.MachinePoolSpec{
+							Rollout: clusterv1.MachinePoolRolloutSpec{
+								After: metav1.Time{Time: time.Now().Local().Add(time.Hour)},
+								Strategy: clusterv1.MachinePoolRolloutStrategy{
+									Type: clusterv1.RollingUpdateMachinePoolStrategyType,
+								},
+							},
+						},
+					},
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachinePool,
+					Name:      "mp",
+					Namespace: "default",
+				},
+			},
+			wantErr:     true,
+			wantRollout: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -238,6 +352,15 @@ func Test_ObjectRestarter(t *testing.T) {
 					} else {
 						g.Expect(kcp.Spec.Rollout.After.IsZero()).To(BeTrue())
 					}
+				case *clusterv1.MachinePool:
+					mp := &clusterv1.MachinePool{}
+					err = cl.Get(context.TODO(), key, mp)
+					g.Expect(err).ToNot(HaveOccurred())
+					if tt.wantRollout {
+						g.Expect(mp.Spec.Rollout.After.IsZero()).To(BeFalse())
+					} else {
+						g.Expect(mp.Spec.Rollout.After.IsZero()).To(BeTrue())
+					}
 				}
 			}
 		})
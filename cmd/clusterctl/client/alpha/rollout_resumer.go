@@ -57,6 +57,17 @@ func (r *rollout) ObjectResumer(ctx context.Context, proxy cluster.Proxy, ref co
 		if err := resumeKubeadmControlPlane(ctx, proxy, ref.Name, ref.Namespace); err != nil {
 			return err
 		}
+	case MachinePool:
+		mp, err := getMachinePool(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || mp == nil {
+			return errors.Wrapf(err, "failed to fetch %v/%v", ref.Kind, ref.Name)
+		}
+		if !annotations.HasPaused(mp.GetObjectMeta()) {
+			return errors.Errorf("MachinePool is not currently paused: %v/%v\n", ref.Kind, ref.Name) //nolint:revive // MachinePool is intentionally capitalized.
+		}
+		if err := resumeMachinePool(ctx, proxy, ref.Name, ref.Namespace); err != nil {
+			return err
+		}
 	default:
 		return errors.Errorf("invalid resource type %q, valid values are %v", ref.Kind, validResourceTypes)
 	}
@@ -78,3 +89,12 @@ func resumeKubeadmControlPlane(ctx context.Context, proxy cluster.Proxy, name, n
 
 	return patchKubeadmControlPlane(ctx, proxy, name, namespace, patch)
 }
+
+// resumeMachinePool removes paused annotation.
+func resumeMachinePool(ctx context.Context, proxy cluster.Proxy, name, namespace string) error {
+	// In the paused annotation we must replace slashes to ~1, see https://datatracker.ietf.org/doc/html/rfc6901#section-3.
+	pausedAnnotation := strings.ReplaceAll(clusterv1.PausedAnnotation, "/", "~1")
+	patch := client.RawPatch(types.JSONPatchType, []byte(fmt.Sprintf("[{\"op\": \"remove\", \"path\": \"/metadata/annotations/%s\"}]", pausedAnnotation)))
+
+	return patchMachinePool(ctx, proxy, name, namespace, patch)
+}
@@ -144,6 +144,55 @@ func Test_ObjectResumer(t *testing.T) {
 			wantErr:    true,
 			wantPaused: false,
 		},
+		{
+			name: "paused machinepool should be unpaused",
+			fields: fields{
+				objs: []client.Object{
+					&clusterv1.MachinePool{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "MachinePool",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "mp",
+							Annotations: map[string]string{
+								clusterv1.PausedAnnotation: "true",
+							},
+						},
+					},
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachinePool,
+					Name:      "mp",
+					Namespace: "default",
+				},
+			},
+			wantErr:    false,
+			wantPaused: false,
+		},
+		{
+			name: "unpausing an already unpaused machinepool should return error",
+			fields: fields{
+				objs: []client.Object{
+					&clusterv1.MachinePool{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "MachinePool",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "mp",
+						},
+					},
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachinePool,
+					Name:      "mp",
+					Namespace: "default",
+				},
+			},
+			wantErr:    true,
+			wantPaused: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -171,6 +220,11 @@ func Test_ObjectResumer(t *testing.T) {
 					err = cl.Get(context.TODO(), key, kcp)
 					g.Expect(err).ToNot(HaveOccurred())
 					g.Expect(annotations.HasPaused(kcp.GetObjectMeta())).To(Equal(tt.wantPaused))
+				case *clusterv1.MachinePool:
+					mp := &clusterv1.MachinePool{}
+					err = cl.Get(context.TODO(), key, mp)
+					g.Expect(err).ToNot(HaveOccurred())
+					g.Expect(annotations.HasPaused(mp.GetObjectMeta())).To(Equal(tt.wantPaused))
 				}
 			}
 		})
@@ -91,6 +91,9 @@ type AlphaClient interface {
 	RolloutPause(ctx context.Context, options RolloutPauseOptions) error
 	// RolloutResume provides rollout resume of paused cluster-api resources
 	RolloutResume(ctx context.Context, options RolloutResumeOptions) error
+	// TopologyPlan computes the changes the topology controller would apply to the Clusters affected by the
+	// given Cluster, ClusterClass and template objects.
+	TopologyPlan(ctx context.Context, options TopologyPlanOptions) (*TopologyPlanOutput, error)
 }
 
 // YamlPrinter exposes methods that prints the processed template and
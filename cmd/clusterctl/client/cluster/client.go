@@ -81,6 +81,9 @@ type Client interface {
 
 	// WorkloadCluster has methods for fetching kubeconfig of workload cluster from management cluster.
 	WorkloadCluster() WorkloadCluster
+
+	// Topology returns a TopologyClient that can be used for computing the changes to managed topologies.
+	Topology() TopologyClient
 }
 
 // PollImmediateWaiter tries a condition func until it returns true, an error, or the timeout is reached.
@@ -144,6 +147,10 @@ func (c *clusterClient) WorkloadCluster() WorkloadCluster {
 	return newWorkloadCluster(c.proxy)
 }
 
+func (c *clusterClient) Topology() TopologyClient {
+	return NewTopologyClient(c.proxy)
+}
+
 // Option is a configuration option supplied to New.
 type Option func(*clusterClient)
 
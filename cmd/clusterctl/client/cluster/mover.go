@@ -60,6 +60,14 @@ type ObjectMover interface {
 
 	// FromDirectory reads all the Cluster API objects existing in a configured directory to a target management cluster.
 	FromDirectory(ctx context.Context, toCluster Client, directory string) error
+
+	// ToArchive writes all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a
+	// compressed archive, including a manifest with the checksum of each object; if a passphrase is provided, Secrets are encrypted.
+	ToArchive(ctx context.Context, namespace string, archive string, options ArchiveOptions) error
+
+	// FromArchive verifies the integrity of an archive created by ToArchive and then reads all the Cluster API objects
+	// existing in it to a target management cluster.
+	FromArchive(ctx context.Context, toCluster Client, archive string, options ArchiveOptions) error
 }
 
 // objectMover implements the ObjectMover interface.
@@ -119,6 +127,16 @@ func (o *objectMover) FromDirectory(ctx context.Context, toCluster Client, direc
 	log := logf.Log
 	log.Info("Moving from directory...")
 
+	objs, err := o.filesToObjs(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to process object files")
+	}
+
+	return o.restoreObjs(ctx, toCluster, objs)
+}
+
+// restoreObjs restores objects read from a directory or from an archive to a target management cluster.
+func (o *objectMover) restoreObjs(ctx context.Context, toCluster Client, objs []unstructured.Unstructured) error {
 	// Build an empty object graph used for the fromDirectory sequence not tied to a specific namespace
 	objectGraph := newObjectGraph(o.fromProxy, o.fromProviderInventory)
 
@@ -128,11 +146,6 @@ func (o *objectMover) FromDirectory(ctx context.Context, toCluster Client, direc
 		return errors.Wrap(err, "failed to retrieve discovery types")
 	}
 
-	objs, err := o.filesToObjs(directory)
-	if err != nil {
-		return errors.Wrap(err, "failed to process object files")
-	}
-
 	for i := range objs {
 		if err = objectGraph.addRestoredObj(&objs[i]); err != nil {
 			return err
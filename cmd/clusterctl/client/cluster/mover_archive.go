@@ -0,0 +1,552 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+const (
+	// archiveManifestFile is the name of the file in the archive describing the archive content.
+	archiveManifestFile = "manifest.yaml"
+
+	// archiveObjectsDir is the folder in the archive containing the objects.
+	archiveObjectsDir = "objects"
+
+	// archiveManifestMACFile is the name of the file in the archive containing the HMAC-SHA256 of the manifest;
+	// it exists only in encrypted archives.
+	archiveManifestMACFile = "manifest.yaml.hmac"
+
+	// archiveManifestVersion is the version of the archive manifest.
+	archiveManifestVersion = "v1"
+
+	// archivePassphraseEncryption is the algorithm used to encrypt Secrets in the archive when using a passphrase;
+	// the archive key is derived from the passphrase using scrypt, and Secrets are encrypted with AES-256-GCM.
+	archivePassphraseEncryption = "scrypt-aes256-gcm"
+
+	// archiveAgeEncryption is the algorithm used to encrypt Secrets in the archive when using age recipients;
+	// the archive key is random and it is stored in the manifest encrypted with age, and Secrets are encrypted with AES-256-GCM.
+	archiveAgeEncryption = "age-aes256-gcm"
+
+	// archiveScryptN, archiveScryptR and archiveScryptP are the scrypt parameters used to derive the archive key
+	// from the passphrase. Note: Parameters are not read from the archive, so a crafted archive can't make
+	// key derivation arbitrarily expensive.
+	archiveScryptN = 1 << 15
+	archiveScryptR = 8
+	archiveScryptP = 1
+
+	// archiveKeySize is the size of the archive key; the first half is used to encrypt Secrets,
+	// the second half to authenticate the manifest.
+	archiveKeySize = 64
+
+	// archiveMaxFiles is the maximum number of files in the archive.
+	archiveMaxFiles = 100000
+
+	// archiveMaxFileSize is the maximum size of a file in the archive.
+	archiveMaxFileSize = 64 * 1024 * 1024
+
+	// archiveMaxSize is the maximum size of all the files in the archive.
+	archiveMaxSize = 1024 * 1024 * 1024
+)
+
+// ArchiveOptions carries the options for writing and reading a move archive.
+// Note: If both Passphrase and AgeRecipients are empty when writing the archive, Secrets are stored in plain text.
+type ArchiveOptions struct {
+	// Passphrase is used to encrypt Secrets when writing the archive, and to decrypt them when reading it.
+	Passphrase string
+
+	// AgeRecipients are the age public keys used to encrypt Secrets when writing the archive;
+	// AgeRecipients can't be used together with Passphrase.
+	AgeRecipients []string
+
+	// AgeIdentities is the content of an age identity file, used to decrypt Secrets when reading an archive
+	// written using AgeRecipients.
+	AgeIdentities string
+}
+
+// archiveManifest describes the content of a move archive.
+type archiveManifest struct {
+	// Version of the manifest.
+	Version string `json:"version"`
+
+	// Encryption contains the parameters used to encrypt Secrets, if any.
+	Encryption *archiveEncryption `json:"encryption,omitempty"`
+
+	// Objects is the list of objects in the archive.
+	Objects []archiveObject `json:"objects"`
+}
+
+// archiveEncryption contains the parameters used to encrypt Secrets in a move archive.
+type archiveEncryption struct {
+	// Algorithm used to encrypt Secrets.
+	Algorithm string `json:"algorithm"`
+
+	// Salt used to derive the archive key from the passphrase; it is set only when using a passphrase.
+	Salt []byte `json:"salt,omitempty"`
+
+	// EncryptedKey is the archive key encrypted with age for the recipients; it is set only when using age.
+	EncryptedKey []byte `json:"encryptedKey,omitempty"`
+}
+
+// archiveObject describes an object in a move archive.
+type archiveObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+
+	// File is the path of the file containing the object in the archive.
+	File string `json:"file"`
+
+	// SHA256 is the checksum of the file as stored in the archive.
+	SHA256 string `json:"sha256"`
+
+	// Encrypted is true if the file is encrypted.
+	Encrypted bool `json:"encrypted,omitempty"`
+}
+
+func (o *objectMover) ToArchive(ctx context.Context, namespace string, archive string, options ArchiveOptions) error {
+	log := logf.Log
+	log.Info("Moving to archive...")
+
+	objectGraph, err := o.getObjectGraph(ctx, namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to get object graph")
+	}
+
+	// Save objects to a temporary directory first, then pack them into the archive.
+	directory, err := os.MkdirTemp("", "clusterctl-move-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary directory")
+	}
+	defer os.RemoveAll(directory)
+
+	if err := o.toDirectory(ctx, objectGraph, directory); err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Writing archive %s", archive))
+	return writeArchive(directory, archive, options)
+}
+
+func (o *objectMover) FromArchive(ctx context.Context, toCluster Client, archive string, options ArchiveOptions) error {
+	log := logf.Log
+	log.Info("Moving from archive...")
+
+	// Read and verify the whole archive before restoring any object.
+	log.Info(fmt.Sprintf("Reading archive %s", archive))
+	objs, err := readArchive(archive, options)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read archive %s", archive)
+	}
+
+	return o.restoreObjs(ctx, toCluster, objs)
+}
+
+// writeArchive writes all the files in a directory into a gzip compressed tar archive, together with a manifest
+// containing the checksum of each file; if a passphrase or age recipients are provided, Secrets are encrypted
+// and the manifest is authenticated.
+func writeArchive(directory, archive string, options ArchiveOptions) error {
+	encryption, key, err := newArchiveKey(options)
+	if err != nil {
+		return err
+	}
+	manifest := &archiveManifest{
+		Version:    archiveManifestVersion,
+		Encryption: encryption,
+	}
+
+	files, err := os.ReadDir(directory)
+	if err != nil {
+		return err
+	}
+
+	contents := map[string][]byte{}
+	for i := range files {
+		content, err := os.ReadFile(filepath.Clean(filepath.Join(directory, files[i].Name())))
+		if err != nil {
+			return err
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(content); err != nil {
+			return errors.Wrapf(err, "failed to read object from %s", files[i].Name())
+		}
+
+		object := archiveObject{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+			File:       path.Join(archiveObjectsDir, files[i].Name()),
+		}
+
+		if key != nil && isSecret(obj) {
+			if content, err = encryptArchiveFile(archiveEncryptionKey(key), object.File, content); err != nil {
+				return errors.Wrapf(err, "failed to encrypt %s", object.File)
+			}
+			object.Encrypted = true
+		}
+
+		object.SHA256 = archiveChecksum(content)
+		manifest.Objects = append(manifest.Objects, object)
+		contents[object.File] = content
+	}
+
+	manifestContent, err := yaml.Marshal(manifest)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal archive manifest")
+	}
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	if err := writeArchiveFile(tarWriter, archiveManifestFile, manifestContent); err != nil {
+		return err
+	}
+	if key != nil {
+		if err := writeArchiveFile(tarWriter, archiveManifestMACFile, []byte(archiveManifestMAC(key, manifestContent))); err != nil {
+			return err
+		}
+	}
+	for _, object := range manifest.Objects {
+		if err := writeArchiveFile(tarWriter, object.File, contents[object.File]); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return errors.Wrap(err, "failed to write archive")
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return errors.Wrap(err, "failed to write archive")
+	}
+
+	return os.WriteFile(archive, buf.Bytes(), 0o600)
+}
+
+func writeArchiveFile(tarWriter *tar.Writer, name string, content []byte) error {
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0o600,
+		Size: int64(len(content)),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to write %s to archive", name)
+	}
+	if _, err := tarWriter.Write(content); err != nil {
+		return errors.Wrapf(err, "failed to write %s to archive", name)
+	}
+	return nil
+}
+
+// readArchive reads an archive written by writeArchive and returns the objects it contains.
+// Note: The checksum of every file is verified against the manifest, and files not listed in the manifest
+// are rejected; if the archive is encrypted, the manifest is authenticated before using it, and encrypted
+// Secrets are decrypted using the passphrase or the age identities.
+func readArchive(archive string, options ArchiveOptions) ([]unstructured.Unstructured, error) {
+	f, err := os.Open(filepath.Clean(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gzipReader, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read compressed archive")
+	}
+	defer gzipReader.Close()
+
+	contents := map[string][]byte{}
+	size := int64(0)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read archive")
+		}
+		if len(contents) >= archiveMaxFiles {
+			return nil, errors.Errorf("archive exceeds the maximum number of %d files", archiveMaxFiles)
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil, errors.Errorf("unexpected entry %s: only regular files are supported", header.Name)
+		}
+		if header.Size > archiveMaxFileSize {
+			return nil, errors.Errorf("file %s exceeds the maximum size of %d bytes", header.Name, archiveMaxFileSize)
+		}
+		if size += header.Size; size > archiveMaxSize {
+			return nil, errors.Errorf("archive exceeds the maximum size of %d bytes", archiveMaxSize)
+		}
+		if _, ok := contents[header.Name]; ok {
+			return nil, errors.Errorf("file %s is duplicated", header.Name)
+		}
+		content, err := io.ReadAll(io.LimitReader(tarReader, archiveMaxFileSize))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", header.Name)
+		}
+		contents[header.Name] = content
+	}
+
+	manifestContent, ok := contents[archiveManifestFile]
+	if !ok {
+		return nil, errors.Errorf("%s not found", archiveManifestFile)
+	}
+	manifest := &archiveManifest{}
+	if err := yaml.UnmarshalStrict(manifestContent, manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", archiveManifestFile)
+	}
+	if manifest.Version != archiveManifestVersion {
+		return nil, errors.Errorf("unsupported archive version %q", manifest.Version)
+	}
+
+	// Authenticate the manifest before trusting its content.
+	// Note: When a passphrase or age identities are provided the archive must be encrypted, otherwise
+	// it would be possible to replace an encrypted archive with a plain text one.
+	listedFiles := sets.New[string](archiveManifestFile)
+	var key []byte
+	if manifest.Encryption != nil {
+		if key, err = archiveKey(manifest.Encryption, options); err != nil {
+			return nil, err
+		}
+		mac, ok := contents[archiveManifestMACFile]
+		if !ok {
+			return nil, errors.Errorf("%s not found", archiveManifestMACFile)
+		}
+		if !hmac.Equal(mac, []byte(archiveManifestMAC(key, manifestContent))) {
+			return nil, errors.Errorf("failed to authenticate %s, the passphrase might be wrong or the manifest has been modified", archiveManifestFile)
+		}
+		listedFiles.Insert(archiveManifestMACFile)
+	} else if options.Passphrase != "" || options.AgeIdentities != "" {
+		return nil, errors.New("archive is not encrypted, but a passphrase or age identities have been provided")
+	}
+
+	// Verify the checksum of every file, and that there are no files which are not in the manifest.
+	for _, object := range manifest.Objects {
+		content, ok := contents[object.File]
+		if !ok {
+			return nil, errors.Errorf("file %s listed in the manifest not found", object.File)
+		}
+		if checksum := archiveChecksum(content); checksum != object.SHA256 {
+			return nil, errors.Errorf("checksum of file %s does not match the manifest: got %s, expected %s", object.File, checksum, object.SHA256)
+		}
+		if object.Encrypted && key == nil {
+			return nil, errors.Errorf("file %s is encrypted, but the archive has no encryption parameters", object.File)
+		}
+		listedFiles.Insert(object.File)
+	}
+	for name := range contents {
+		if !listedFiles.Has(name) {
+			return nil, errors.Errorf("file %s is not listed in the manifest", name)
+		}
+	}
+
+	rawYAMLs := make([][]byte, 0, len(manifest.Objects))
+	for _, object := range manifest.Objects {
+		content := contents[object.File]
+		if object.Encrypted {
+			if content, err = decryptArchiveFile(archiveEncryptionKey(key), object.File, content); err != nil {
+				return nil, errors.Wrapf(err, "failed to decrypt %s", object.File)
+			}
+		}
+
+		// Verify the object matches the manifest.
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(content); err != nil {
+			return nil, errors.Wrapf(err, "failed to read object from %s", object.File)
+		}
+		if obj.GetAPIVersion() != object.APIVersion || obj.GetKind() != object.Kind || obj.GetNamespace() != object.Namespace || obj.GetName() != object.Name {
+			return nil, errors.Errorf("object in file %s does not match the manifest", object.File)
+		}
+		if !object.Encrypted && isSecret(obj) && manifest.Encryption != nil {
+			return nil, errors.Errorf("Secret in file %s is not encrypted", object.File)
+		}
+
+		rawYAMLs = append(rawYAMLs, content)
+	}
+
+	return utilyaml.ToUnstructured(utilyaml.JoinYaml(rawYAMLs...))
+}
+
+func isSecret(obj *unstructured.Unstructured) bool {
+	return obj.GetAPIVersion() == "v1" && obj.GetKind() == "Secret"
+}
+
+func archiveChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// newArchiveKey returns the encryption parameters and the key for writing an archive, or nil if Secrets
+// should not be encrypted.
+func newArchiveKey(options ArchiveOptions) (*archiveEncryption, []byte, error) {
+	switch {
+	case options.Passphrase != "" && len(options.AgeRecipients) > 0:
+		return nil, nil, errors.New("can't use both a passphrase and age recipients to encrypt the archive")
+	case options.Passphrase != "":
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to generate salt")
+		}
+		encryption := &archiveEncryption{
+			Algorithm: archivePassphraseEncryption,
+			Salt:      salt,
+		}
+		key, err := archiveKey(encryption, options)
+		if err != nil {
+			return nil, nil, err
+		}
+		return encryption, key, nil
+	case len(options.AgeRecipients) > 0:
+		recipients, err := age.ParseRecipients(strings.NewReader(strings.Join(options.AgeRecipients, "\n")))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to parse age recipients")
+		}
+		key := make([]byte, archiveKeySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to generate archive key")
+		}
+		var encryptedKey bytes.Buffer
+		w, err := age.Encrypt(&encryptedKey, recipients...)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to encrypt archive key")
+		}
+		if _, err := w.Write(key); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to encrypt archive key")
+		}
+		if err := w.Close(); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to encrypt archive key")
+		}
+		return &archiveEncryption{
+			Algorithm:    archiveAgeEncryption,
+			EncryptedKey: encryptedKey.Bytes(),
+		}, key, nil
+	default:
+		return nil, nil, nil
+	}
+}
+
+// archiveKey returns the key of an encrypted archive, derived from the passphrase or decrypted using the age identities.
+func archiveKey(encryption *archiveEncryption, options ArchiveOptions) ([]byte, error) {
+	switch encryption.Algorithm {
+	case archivePassphraseEncryption:
+		if options.Passphrase == "" {
+			return nil, errors.New("archive contains encrypted Secrets, a passphrase is required")
+		}
+		key, err := scrypt.Key([]byte(options.Passphrase), encryption.Salt, archiveScryptN, archiveScryptR, archiveScryptP, archiveKeySize)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to derive archive key from passphrase")
+		}
+		return key, nil
+	case archiveAgeEncryption:
+		if options.AgeIdentities == "" {
+			return nil, errors.New("archive contains Secrets encrypted with age, age identities are required")
+		}
+		identities, err := age.ParseIdentities(strings.NewReader(options.AgeIdentities))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse age identities")
+		}
+		r, err := age.Decrypt(bytes.NewReader(encryption.EncryptedKey), identities...)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt archive key, the age identities might be wrong")
+		}
+		key, err := io.ReadAll(io.LimitReader(r, archiveKeySize+1))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt archive key")
+		}
+		if len(key) != archiveKeySize {
+			return nil, errors.Errorf("invalid archive key size %d, expected %d", len(key), archiveKeySize)
+		}
+		return key, nil
+	default:
+		return nil, errors.Errorf("unsupported archive encryption algorithm %q", encryption.Algorithm)
+	}
+}
+
+// archiveEncryptionKey returns the part of the archive key used to encrypt Secrets.
+func archiveEncryptionKey(key []byte) []byte {
+	return key[:archiveKeySize/2]
+}
+
+// archiveManifestMAC returns the hex encoded HMAC-SHA256 of the manifest, computed using the part
+// of the archive key used for authentication.
+func archiveManifestMAC(key, manifestContent []byte) string {
+	h := hmac.New(sha256.New, key[archiveKeySize/2:])
+	_, _ = h.Write(manifestContent)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// encryptArchiveFile encrypts a file using AES-256-GCM; the nonce is prepended to the ciphertext, and
+// the file name is used as additional data so encrypted files can't be swapped.
+func encryptArchiveFile(key []byte, name string, content []byte) ([]byte, error) {
+	gcm, err := newArchiveCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	return gcm.Seal(nonce, nonce, content, []byte(name)), nil
+}
+
+// decryptArchiveFile decrypts a file encrypted with encryptArchiveFile.
+func decryptArchiveFile(key []byte, name string, content []byte) ([]byte, error) {
+	gcm, err := newArchiveCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) < gcm.NonceSize() {
+		return nil, errors.New("encrypted content is too short")
+	}
+	return gcm.Open(nil, content[:gcm.NonceSize()], content[gcm.NonceSize():], []byte(name))
+}
+
+func newArchiveCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	return cipher.NewGCM(block)
+}
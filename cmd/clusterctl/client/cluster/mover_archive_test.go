@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	archiveTestCluster = `{"apiVersion":"cluster.x-k8s.io/v1beta2","kind":"Cluster","metadata":{"name":"foo","namespace":"ns1"}}`
+	archiveTestSecret  = `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"foo-kubeconfig","namespace":"ns1"},"data":{"value":"c2VjcmV0"}}`
+)
+
+func Test_writeAndReadArchive(t *testing.T) {
+	g := NewWithT(t)
+
+	identity, err := age.GenerateX25519Identity()
+	g.Expect(err).ToNot(HaveOccurred())
+	otherIdentity, err := age.GenerateX25519Identity()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	tests := []struct {
+		name         string
+		writeOptions ArchiveOptions
+		readOptions  ArchiveOptions
+		mutate       func(g *WithT, contents map[string][]byte)
+		wantErr      string
+	}{
+		{
+			name: "read an archive without encryption",
+		},
+		{
+			name:         "read an archive with Secrets encrypted with a passphrase",
+			writeOptions: ArchiveOptions{Passphrase: "passphrase"},
+			readOptions:  ArchiveOptions{Passphrase: "passphrase"},
+		},
+		{
+			name:         "read an archive with Secrets encrypted with age",
+			writeOptions: ArchiveOptions{AgeRecipients: []string{otherIdentity.Recipient().String(), identity.Recipient().String()}},
+			readOptions:  ArchiveOptions{AgeIdentities: identity.String()},
+		},
+		{
+			name:         "fails to read an archive with encrypted Secrets without passphrase",
+			writeOptions: ArchiveOptions{Passphrase: "passphrase"},
+			wantErr:      "a passphrase is required",
+		},
+		{
+			name:         "fails to read an archive with encrypted Secrets with the wrong passphrase",
+			writeOptions: ArchiveOptions{Passphrase: "passphrase"},
+			readOptions:  ArchiveOptions{Passphrase: "wrong"},
+			wantErr:      "the passphrase might be wrong",
+		},
+		{
+			name:         "fails to read an archive with Secrets encrypted with age without identities",
+			writeOptions: ArchiveOptions{AgeRecipients: []string{identity.Recipient().String()}},
+			wantErr:      "age identities are required",
+		},
+		{
+			name:         "fails to read an archive with Secrets encrypted with age with the wrong identities",
+			writeOptions: ArchiveOptions{AgeRecipients: []string{identity.Recipient().String()}},
+			readOptions:  ArchiveOptions{AgeIdentities: otherIdentity.String()},
+			wantErr:      "the age identities might be wrong",
+		},
+		{
+			name:        "fails to read an archive without encryption with a passphrase",
+			readOptions: ArchiveOptions{Passphrase: "passphrase"},
+			wantErr:     "archive is not encrypted",
+		},
+		{
+			name:         "fails to read an archive with encrypted Secrets with a modified manifest",
+			writeOptions: ArchiveOptions{Passphrase: "passphrase"},
+			readOptions:  ArchiveOptions{Passphrase: "passphrase"},
+			mutate: func(_ *WithT, contents map[string][]byte) {
+				contents[archiveManifestFile] = append(contents[archiveManifestFile], []byte("# modified\n")...)
+			},
+			wantErr: "failed to authenticate manifest.yaml",
+		},
+		{
+			name:         "fails to read an archive with encrypted Secrets without the manifest HMAC",
+			writeOptions: ArchiveOptions{AgeRecipients: []string{identity.Recipient().String()}},
+			readOptions:  ArchiveOptions{AgeIdentities: identity.String()},
+			mutate: func(_ *WithT, contents map[string][]byte) {
+				delete(contents, archiveManifestMACFile)
+			},
+			wantErr: "manifest.yaml.hmac not found",
+		},
+		{
+			name:         "fails to read an archive with scrypt parameters in the manifest",
+			writeOptions: ArchiveOptions{Passphrase: "passphrase"},
+			readOptions:  ArchiveOptions{Passphrase: "passphrase"},
+			mutate: func(_ *WithT, contents map[string][]byte) {
+				contents[archiveManifestFile] = bytes.Replace(contents[archiveManifestFile], []byte("  salt:"), []byte("  n: 1073741824\n  salt:"), 1)
+			},
+			wantErr: "unknown field",
+		},
+		{
+			name: "fails to read an archive with too many files",
+			mutate: func(_ *WithT, contents map[string][]byte) {
+				for i := range archiveMaxFiles {
+					contents[fmt.Sprintf("objects/file-%d.yaml", i)] = nil
+				}
+			},
+			wantErr: "archive exceeds the maximum number of 100000 files",
+		},
+		{
+			name: "fails to read an archive with a modified file",
+			mutate: func(_ *WithT, contents map[string][]byte) {
+				contents["objects/Cluster_ns1_foo.yaml"] = bytes.ReplaceAll(contents["objects/Cluster_ns1_foo.yaml"], []byte("foo"), []byte("bar"))
+			},
+			wantErr: "checksum of file objects/Cluster_ns1_foo.yaml does not match the manifest",
+		},
+		{
+			name: "fails to read an archive with a file not listed in the manifest",
+			mutate: func(_ *WithT, contents map[string][]byte) {
+				contents["objects/Secret_ns1_bar.yaml"] = []byte(archiveTestSecret)
+			},
+			wantErr: "file objects/Secret_ns1_bar.yaml is not listed in the manifest",
+		},
+		{
+			name: "fails to read an archive with a missing file",
+			mutate: func(_ *WithT, contents map[string][]byte) {
+				delete(contents, "objects/Secret_ns1_foo-kubeconfig.yaml")
+			},
+			wantErr: "file objects/Secret_ns1_foo-kubeconfig.yaml listed in the manifest not found",
+		},
+		{
+			name: "fails to read an archive without manifest",
+			mutate: func(_ *WithT, contents map[string][]byte) {
+				delete(contents, archiveManifestFile)
+			},
+			wantErr: "manifest.yaml not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			dir := t.TempDir()
+			g.Expect(os.WriteFile(filepath.Join(dir, "Cluster_ns1_foo.yaml"), []byte(archiveTestCluster), 0o600)).To(Succeed())
+			g.Expect(os.WriteFile(filepath.Join(dir, "Secret_ns1_foo-kubeconfig.yaml"), []byte(archiveTestSecret), 0o600)).To(Succeed())
+
+			archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+			g.Expect(writeArchive(dir, archive, tt.writeOptions)).To(Succeed())
+
+			contents := readArchiveFilesForTest(g, archive)
+			if tt.writeOptions.Passphrase != "" || len(tt.writeOptions.AgeRecipients) > 0 {
+				g.Expect(contents["objects/Secret_ns1_foo-kubeconfig.yaml"]).ToNot(ContainSubstring("c2VjcmV0"))
+				g.Expect(contents).To(HaveKey(archiveManifestMACFile))
+			} else {
+				g.Expect(contents["objects/Secret_ns1_foo-kubeconfig.yaml"]).To(ContainSubstring("c2VjcmV0"))
+				g.Expect(contents).ToNot(HaveKey(archiveManifestMACFile))
+			}
+			if tt.mutate != nil {
+				tt.mutate(g, contents)
+				writeArchiveFilesForTest(g, archive, contents)
+			}
+
+			objs, err := readArchive(archive, tt.readOptions)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(objs).To(HaveLen(2))
+			g.Expect(objs[0].GetKind()).To(Equal("Cluster"))
+			g.Expect(objs[1].GetKind()).To(Equal("Secret"))
+			g.Expect(objs[1].Object["data"]).To(HaveKeyWithValue("value", "c2VjcmV0"))
+		})
+	}
+}
+
+func Test_writeArchiveWithPassphraseAndAgeRecipients(t *testing.T) {
+	g := NewWithT(t)
+
+	identity, err := age.GenerateX25519Identity()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = writeArchive(t.TempDir(), filepath.Join(t.TempDir(), "backup.tar.gz"), ArchiveOptions{
+		Passphrase:    "passphrase",
+		AgeRecipients: []string{identity.Recipient().String()},
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("can't use both a passphrase and age recipients")))
+}
+
+func readArchiveFilesForTest(g *WithT, archive string) map[string][]byte {
+	f, err := os.Open(archive) //nolint:gosec // test file path.
+	g.Expect(err).ToNot(HaveOccurred())
+	defer f.Close()
+
+	gzipReader, err := gzip.NewReader(f)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	contents := map[string][]byte{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		g.Expect(err).ToNot(HaveOccurred())
+		content, err := io.ReadAll(tarReader)
+		g.Expect(err).ToNot(HaveOccurred())
+		contents[header.Name] = content
+	}
+	return contents
+}
+
+func writeArchiveFilesForTest(g *WithT, archive string, contents map[string][]byte) {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range contents {
+		g.Expect(writeArchiveFile(tarWriter, name, content)).To(Succeed())
+	}
+	g.Expect(tarWriter.Close()).To(Succeed())
+	g.Expect(gzipWriter.Close()).To(Succeed())
+	g.Expect(os.WriteFile(archive, buf.Bytes(), 0o600)).To(Succeed())
+}
@@ -0,0 +1,663 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/scheme"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/cluster-api/exp/topology/desiredstate"
+	"sigs.k8s.io/cluster-api/exp/topology/scope"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	topologycluster "sigs.k8s.io/cluster-api/internal/controllers/topology/cluster"
+	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/structuredmerge"
+	"sigs.k8s.io/cluster-api/util/cache"
+	utilcontract "sigs.k8s.io/cluster-api/util/contract"
+)
+
+// TopologyPlanAction is the action the topology controller would take on an object.
+type TopologyPlanAction string
+
+const (
+	// TopologyPlanActionCreate is used for objects that would be created.
+	TopologyPlanActionCreate TopologyPlanAction = "create"
+
+	// TopologyPlanActionUpdate is used for objects that would be updated in place.
+	TopologyPlanActionUpdate TopologyPlanAction = "update"
+
+	// TopologyPlanActionRotate is used for templates that would be rotated, i.e. replaced by a new template
+	// with a new name; this triggers a rollout of the machines using the template.
+	TopologyPlanActionRotate TopologyPlanAction = "rotate"
+
+	// TopologyPlanActionDelete is used for objects that would be deleted.
+	TopologyPlanActionDelete TopologyPlanAction = "delete"
+)
+
+// topologyPlanFieldManager is the field manager used to apply the input objects on top of the objects existing
+// in the management cluster.
+const topologyPlanFieldManager = "clusterctl-topology-plan"
+
+// TopologyPlanInput defines the input for the Plan function.
+type TopologyPlanInput struct {
+	// Objs are the Cluster, ClusterClass and template objects to compute the plan for.
+	// Objs are applied on top of the objects with the same kind, namespace and name existing in the management cluster.
+	Objs []*unstructured.Unstructured
+
+	// TargetClusterName is the name of the Cluster to compute the plan for. If empty, the plan is computed
+	// for all the Clusters affected by Objs.
+	TargetClusterName string
+
+	// TargetNamespace is the namespace used for Objs without a namespace, and for the target Cluster.
+	TargetNamespace string
+}
+
+// TopologyPlanChange is a change the topology controller would apply to an object.
+type TopologyPlanChange struct {
+	// Action is the action the topology controller would take on the object.
+	Action TopologyPlanAction
+
+	// Object is the desired object, or the current object if it would be deleted.
+	Object *unstructured.Unstructured
+
+	// Diff is the diff between the current and the desired object. It is only set for updated and rotated objects.
+	Diff string
+}
+
+// TopologyPlanCluster is the plan for a Cluster.
+type TopologyPlanCluster struct {
+	// Cluster is the Cluster the plan is computed for.
+	Cluster client.ObjectKey
+
+	// Changes are the changes the topology controller would apply to the objects of the Cluster topology.
+	Changes []TopologyPlanChange
+}
+
+// TopologyPlanOutput defines the output of the Plan function.
+type TopologyPlanOutput struct {
+	// Clusters are the plans for the Clusters affected by the input objects, sorted by namespace and name.
+	Clusters []TopologyPlanCluster
+}
+
+// TopologyClient has methods to work with ClusterClass and managed topologies.
+type TopologyClient interface {
+	// Plan computes the changes the topology controller would apply to the Clusters affected by the input objects,
+	// by running the same desired state generation as the topology controller, including inline patches.
+	// NOTE: external patches and variables discovered by Runtime Extensions are not supported.
+	Plan(ctx context.Context, in *TopologyPlanInput) (*TopologyPlanOutput, error)
+}
+
+// topologyClient implements TopologyClient.
+type topologyClient struct {
+	proxy Proxy
+}
+
+// ensure topologyClient implements TopologyClient.
+var _ TopologyClient = &topologyClient{}
+
+// NewTopologyClient returns a TopologyClient. If proxy is nil, the plan is computed offline,
+// only from the input objects.
+func NewTopologyClient(proxy Proxy) TopologyClient {
+	return &topologyClient{
+		proxy: proxy,
+	}
+}
+
+func (t *topologyClient) Plan(ctx context.Context, in *TopologyPlanInput) (*TopologyPlanOutput, error) {
+	log := logf.Log
+
+	objs, err := prepareTopologyPlanObjects(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var live client.Client
+	if t.proxy != nil {
+		live, err = t.proxy.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := newTopologyPlanClient(ctx, objs, live)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := topologyPlanClusters(ctx, c, objs, in.TargetNamespace, in.TargetClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	generator, err := desiredstate.NewGenerator(c, &topologyPlanClusterCache{}, nil,
+		cache.New[cache.HookEntry](ctx, cache.HookCacheDefaultTTL),
+		cache.New[desiredstate.GenerateUpgradePlanCacheEntry](ctx, 10*time.Minute),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create desired state generator")
+	}
+
+	out := &TopologyPlanOutput{}
+	for _, cluster := range clusters {
+		log.V(1).Info("Computing plan", "Cluster", klog.KObj(cluster))
+
+		clusterClass := &clusterv1.ClusterClass{}
+		if err := c.Get(ctx, cluster.GetClassKey(), clusterClass); err != nil {
+			return nil, errors.Wrapf(err, "failed to get ClusterClass %s for Cluster %s", cluster.GetClassKey(), klog.KObj(cluster))
+		}
+		if isTopologyPlanObject(objs, clusterClass) {
+			setClusterClassStatusVariables(clusterClass)
+		}
+
+		current := cluster.DeepCopy()
+		s, err := topologycluster.ComputeState(ctx, c, generator, cluster, clusterClass)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute the desired state of Cluster %s", klog.KObj(cluster))
+		}
+		// Compare the Cluster with the version before variables have been defaulted, so defaulted variables show up in the diff.
+		s.Current.Cluster = current
+
+		changes, err := topologyPlanChanges(ctx, s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute the changes for Cluster %s", klog.KObj(cluster))
+		}
+		out.Clusters = append(out.Clusters, TopologyPlanCluster{
+			Cluster: client.ObjectKeyFromObject(cluster),
+			Changes: changes,
+		})
+	}
+	return out, nil
+}
+
+// prepareTopologyPlanObjects defaults the namespace of the input objects and checks Cluster API core objects
+// are using the current API version.
+func prepareTopologyPlanObjects(in *TopologyPlanInput) ([]*unstructured.Unstructured, error) {
+	objs := make([]*unstructured.Unstructured, 0, len(in.Objs))
+	for _, o := range in.Objs {
+		obj := o.DeepCopy()
+		gvk := obj.GroupVersionKind()
+		if gvk.Group == clusterv1.GroupVersion.Group && gvk.Version != clusterv1.GroupVersion.Version {
+			return nil, errors.Errorf("%s %s must use apiVersion %s; use clusterctl convert to convert it", gvk.Kind, obj.GetName(), clusterv1.GroupVersion)
+		}
+		if obj.GetNamespace() == "" && gvk.GroupKind() != apiextensionsv1.Kind("CustomResourceDefinition") {
+			obj.SetNamespace(in.TargetNamespace)
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// isTopologyPlanObject returns true if obj is one of the input objects.
+func isTopologyPlanObject(objs []*unstructured.Unstructured, obj client.Object) bool {
+	gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+	if err != nil {
+		return false
+	}
+	for _, o := range objs {
+		if o.GroupVersionKind().GroupKind() == gvk.GroupKind() && o.GetNamespace() == obj.GetNamespace() && o.GetName() == obj.GetName() {
+			return true
+		}
+	}
+	return false
+}
+
+// newTopologyPlanClient returns a client serving the input objects, falling back to the management cluster, if any.
+// If there is no CustomResourceDefinition for a provider kind in the input objects, or for the kind of the objects
+// created from a template in the input objects, a CustomResourceDefinition declaring the current contract for the
+// versions in use is generated.
+func newTopologyPlanClient(ctx context.Context, objs []*unstructured.Unstructured, live client.Client) (client.Client, error) {
+	overlay := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	for _, obj := range objs {
+		obj = obj.DeepCopy()
+		// Input objects existing in the management cluster are applied on top of the existing object, so fields set
+		// by controllers, e.g. the Cluster's controlPlaneRef, are preserved.
+		if live != nil {
+			current := &unstructured.Unstructured{}
+			current.SetGroupVersionKind(obj.GroupVersionKind())
+			err := live.Get(ctx, client.ObjectKeyFromObject(obj), current)
+			switch {
+			case err == nil:
+				applied, err := topologyPlanApply(ctx, current, obj, topologyPlanFieldManager)
+				if err != nil {
+					return nil, err
+				}
+				applied.SetResourceVersion("")
+				obj = applied
+			case !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err):
+				return nil, errors.Wrapf(err, "failed to get %s %s", obj.GetKind(), klog.KObj(obj))
+			}
+		}
+		if err := overlay.Create(ctx, obj); err != nil {
+			return nil, errors.Wrapf(err, "failed to add %s %s to the plan", obj.GetKind(), klog.KObj(obj))
+		}
+	}
+	c := &topologyPlanClient{Client: overlay, live: live}
+
+	versions := map[schema.GroupKind]sets.Set[string]{}
+	for _, obj := range objs {
+		gvk := obj.GroupVersionKind()
+		if gvk.Group == clusterv1.GroupVersion.Group || clientgoscheme.Scheme.IsGroupRegistered(gvk.Group) || gvk.Group == apiextensionsv1.GroupName {
+			continue
+		}
+		// Objects created from templates are expected to use the same version as the template.
+		for _, gk := range []schema.GroupKind{gvk.GroupKind(), {Group: gvk.Group, Kind: strings.TrimSuffix(gvk.Kind, "Template")}} {
+			if _, ok := versions[gk]; !ok {
+				versions[gk] = sets.New[string]()
+			}
+			versions[gk].Insert(gvk.Version)
+		}
+	}
+	for gk, v := range versions {
+		_, err := contract.GetGKMetadata(ctx, c, gk)
+		if err == nil {
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		crd.SetName(utilcontract.CalculateCRDName(gk.Group, gk.Kind))
+		crd.SetLabels(map[string]string{
+			fmt.Sprintf("%s/%s", clusterv1.GroupVersion.Group, clusterv1.GroupVersion.Version): strings.Join(sets.List(v), "_"),
+		})
+		if err := overlay.Create(ctx, crd); err != nil {
+			return nil, errors.Wrapf(err, "failed to add CustomResourceDefinition for %s to the plan", gk)
+		}
+	}
+	return c, nil
+}
+
+// topologyPlanClient is a client.Client reading objects from the input objects, and falling back to the management
+// cluster, if any, for all the other objects.
+// NOTE: Writes are applied only to the input objects, but they are not expected when computing the desired state.
+type topologyPlanClient struct {
+	client.Client
+	live client.Reader
+}
+
+func (c *topologyPlanClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+	if c.live == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+	return c.live.Get(ctx, key, obj, opts...)
+}
+
+func (c *topologyPlanClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if c.live == nil {
+		return c.Client.List(ctx, list, opts...)
+	}
+
+	overlayList, ok := list.DeepCopyObject().(client.ObjectList)
+	if !ok {
+		return errors.Errorf("failed to copy %T", list)
+	}
+	if err := c.live.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	if err := c.Client.List(ctx, overlayList, opts...); err != nil {
+		return err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	overlayItems, err := meta.ExtractList(overlayList)
+	if err != nil {
+		return err
+	}
+	index := map[client.ObjectKey]int{}
+	for i, item := range items {
+		o, err := meta.Accessor(item)
+		if err != nil {
+			return err
+		}
+		index[client.ObjectKey{Namespace: o.GetNamespace(), Name: o.GetName()}] = i
+	}
+	for _, item := range overlayItems {
+		o, err := meta.Accessor(item)
+		if err != nil {
+			return err
+		}
+		if i, ok := index[client.ObjectKey{Namespace: o.GetNamespace(), Name: o.GetName()}]; ok {
+			items[i] = item
+			continue
+		}
+		items = append(items, item)
+	}
+	return meta.SetList(list, items)
+}
+
+// topologyPlanClusterCache is a ClusterCache not connecting to workload clusters.
+// NOTE: The desired state generator only uses the ClusterCache to check if MachinePools with Machines are upgrading.
+type topologyPlanClusterCache struct {
+	clustercache.ClusterCache
+}
+
+func (c *topologyPlanClusterCache) GetClient(_ context.Context, cluster client.ObjectKey) (client.Client, error) {
+	return nil, errors.Errorf("connecting to the workload cluster %s is not supported when computing a plan", cluster)
+}
+
+// topologyPlanClusters returns the Clusters affected by the input objects, sorted by namespace and name.
+// Clusters are affected if they are in the input objects, if their ClusterClass is in the input objects,
+// or if their ClusterClass references a template in the input objects.
+func topologyPlanClusters(ctx context.Context, c client.Client, objs []*unstructured.Unstructured, targetNamespace, targetClusterName string) ([]*clusterv1.Cluster, error) {
+	if targetClusterName != "" {
+		cluster := &clusterv1.Cluster{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: targetNamespace, Name: targetClusterName}, cluster); err != nil {
+			return nil, errors.Wrapf(err, "failed to get Cluster %s", klog.KRef(targetNamespace, targetClusterName))
+		}
+		if !cluster.Spec.Topology.IsDefined() {
+			return nil, errors.Errorf("Cluster %s does not use a managed topology", klog.KObj(cluster))
+		}
+		return []*clusterv1.Cluster{cluster}, nil
+	}
+
+	clusterClassList := &clusterv1.ClusterClassList{}
+	if err := c.List(ctx, clusterClassList); err != nil {
+		return nil, errors.Wrap(err, "failed to list ClusterClasses")
+	}
+	affectedClusterClasses := sets.New[client.ObjectKey]()
+	for i := range clusterClassList.Items {
+		clusterClass := &clusterClassList.Items[i]
+		if isTopologyPlanObject(objs, clusterClass) {
+			affectedClusterClasses.Insert(client.ObjectKeyFromObject(clusterClass))
+			continue
+		}
+		for _, ref := range clusterClassTemplateRefs(clusterClass) {
+			if isTopologyPlanTemplate(objs, clusterClass.Namespace, ref) {
+				affectedClusterClasses.Insert(client.ObjectKeyFromObject(clusterClass))
+				break
+			}
+		}
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := c.List(ctx, clusterList); err != nil {
+		return nil, errors.Wrap(err, "failed to list Clusters")
+	}
+	clusters := []*clusterv1.Cluster{}
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if !cluster.Spec.Topology.IsDefined() {
+			continue
+		}
+		if isTopologyPlanObject(objs, cluster) || affectedClusterClasses.Has(cluster.GetClassKey()) {
+			clusters = append(clusters, cluster)
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Namespace != clusters[j].Namespace {
+			return clusters[i].Namespace < clusters[j].Namespace
+		}
+		return clusters[i].Name < clusters[j].Name
+	})
+	return clusters, nil
+}
+
+// clusterClassTemplateRefs returns all the template references of a ClusterClass.
+func clusterClassTemplateRefs(clusterClass *clusterv1.ClusterClass) []clusterv1.ClusterClassTemplateReference {
+	refs := []clusterv1.ClusterClassTemplateReference{
+		clusterClass.Spec.Infrastructure.TemplateRef,
+		clusterClass.Spec.ControlPlane.TemplateRef,
+	}
+	if clusterClass.Spec.ControlPlane.MachineInfrastructure.TemplateRef.IsDefined() {
+		refs = append(refs, clusterClass.Spec.ControlPlane.MachineInfrastructure.TemplateRef)
+	}
+	for _, md := range clusterClass.Spec.Workers.MachineDeployments {
+		refs = append(refs, md.Bootstrap.TemplateRef, md.Infrastructure.TemplateRef)
+	}
+	for _, mp := range clusterClass.Spec.Workers.MachinePools {
+		refs = append(refs, mp.Bootstrap.TemplateRef, mp.Infrastructure.TemplateRef)
+	}
+	return refs
+}
+
+// isTopologyPlanTemplate returns true if the template referenced by ref is one of the input objects.
+func isTopologyPlanTemplate(objs []*unstructured.Unstructured, namespace string, ref clusterv1.ClusterClassTemplateReference) bool {
+	gk := ref.GroupVersionKind().GroupKind()
+	for _, o := range objs {
+		if o.GroupVersionKind().GroupKind() == gk && o.GetNamespace() == namespace && o.GetName() == ref.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// setClusterClassStatusVariables sets the inline variables of a ClusterClass in its status, like the ClusterClass
+// controller does. Variables discovered from external patches are preserved from the current status.
+func setClusterClassStatusVariables(clusterClass *clusterv1.ClusterClass) {
+	variables := map[string]clusterv1.ClusterClassStatusVariable{}
+	for _, v := range clusterClass.Status.Variables {
+		definitions := []clusterv1.ClusterClassStatusVariableDefinition{}
+		for _, d := range v.Definitions {
+			if d.From != clusterv1.VariableDefinitionFromInline {
+				definitions = append(definitions, d)
+			}
+		}
+		if len(definitions) > 0 {
+			v.Definitions = definitions
+			variables[v.Name] = v
+		}
+	}
+	for _, v := range clusterClass.Spec.Variables {
+		variables[v.Name] = clusterv1.ClusterClassStatusVariable{
+			Name:                v.Name,
+			DefinitionsConflict: ptr.To(false),
+			Definitions: []clusterv1.ClusterClassStatusVariableDefinition{
+				{
+					From:                      clusterv1.VariableDefinitionFromInline,
+					Required:                  v.Required,
+					DeprecatedV1Beta1Metadata: v.DeprecatedV1Beta1Metadata,
+					Schema:                    v.Schema,
+				},
+			},
+		}
+	}
+
+	clusterClass.Status.Variables = []clusterv1.ClusterClassStatusVariable{}
+	for _, name := range sets.List(sets.KeySet(variables)) {
+		clusterClass.Status.Variables = append(clusterClass.Status.Variables, variables[name])
+	}
+}
+
+// topologyPlanChanges returns the changes required to turn the current state of a Cluster topology into the desired state.
+func topologyPlanChanges(ctx context.Context, s *scope.Scope) ([]TopologyPlanChange, error) {
+	var changes []TopologyPlanChange
+	add := func(current, desired client.Object, isTemplate bool) error {
+		change, err := topologyPlanObjectChange(ctx, current, desired, isTemplate)
+		if err != nil {
+			return err
+		}
+		if change != nil {
+			changes = append(changes, *change)
+		}
+		return nil
+	}
+
+	if err := add(s.Current.InfrastructureCluster, s.Desired.InfrastructureCluster, false); err != nil {
+		return nil, err
+	}
+	if err := add(s.Current.ControlPlane.InfrastructureMachineTemplate, s.Desired.ControlPlane.InfrastructureMachineTemplate, true); err != nil {
+		return nil, err
+	}
+	if err := add(s.Current.ControlPlane.Object, s.Desired.ControlPlane.Object, false); err != nil {
+		return nil, err
+	}
+	if err := add(s.Current.ControlPlane.MachineHealthCheck, s.Desired.ControlPlane.MachineHealthCheck, false); err != nil {
+		return nil, err
+	}
+	if err := add(s.Current.Cluster, s.Desired.Cluster, false); err != nil {
+		return nil, err
+	}
+
+	for _, name := range sets.List(sets.KeySet(s.Current.MachineDeployments).Union(sets.KeySet(s.Desired.MachineDeployments))) {
+		current, desired := s.Current.MachineDeployments[name], s.Desired.MachineDeployments[name]
+		if current == nil {
+			current = &scope.MachineDeploymentState{}
+		}
+		if desired == nil {
+			// Templates of deleted MachineDeployments are garbage collected.
+			desired = &scope.MachineDeploymentState{BootstrapTemplate: current.BootstrapTemplate, InfrastructureMachineTemplate: current.InfrastructureMachineTemplate}
+		}
+		if err := add(current.BootstrapTemplate, desired.BootstrapTemplate, true); err != nil {
+			return nil, err
+		}
+		if err := add(current.InfrastructureMachineTemplate, desired.InfrastructureMachineTemplate, true); err != nil {
+			return nil, err
+		}
+		if err := add(current.Object, desired.Object, false); err != nil {
+			return nil, err
+		}
+		if err := add(current.MachineHealthCheck, desired.MachineHealthCheck, false); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range sets.List(sets.KeySet(s.Current.MachinePools).Union(sets.KeySet(s.Desired.MachinePools))) {
+		current, desired := s.Current.MachinePools[name], s.Desired.MachinePools[name]
+		if current == nil {
+			current = &scope.MachinePoolState{}
+		}
+		if desired == nil {
+			// Bootstrap and infrastructure objects of deleted MachinePools are garbage collected.
+			desired = &scope.MachinePoolState{BootstrapObject: current.BootstrapObject, InfrastructureMachinePoolObject: current.InfrastructureMachinePoolObject}
+		}
+		if err := add(current.BootstrapObject, desired.BootstrapObject, false); err != nil {
+			return nil, err
+		}
+		if err := add(current.InfrastructureMachinePoolObject, desired.InfrastructureMachinePoolObject, false); err != nil {
+			return nil, err
+		}
+		if err := add(current.Object, desired.Object, false); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// topologyPlanObjectChange returns the change required to turn the current object into the desired object, if any.
+// The desired object is server-side applied on top of the current object with the field manager of the topology
+// controller, so only the fields set in the desired object are changed, and fields the topology controller set
+// previously but are not set anymore are removed. Like the topology controller, templates with changes to the spec are rotated, while
+// templates with changes only to the metadata are updated in place.
+func topologyPlanObjectChange(ctx context.Context, current, desired client.Object, isTemplate bool) (*TopologyPlanChange, error) {
+	currentObj, err := topologyPlanUnstructured(current)
+	if err != nil {
+		return nil, err
+	}
+	desiredObj, err := topologyPlanUnstructured(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case currentObj == nil && desiredObj == nil:
+		return nil, nil
+	case currentObj == nil:
+		return &TopologyPlanChange{Action: TopologyPlanActionCreate, Object: desiredObj}, nil
+	case desiredObj == nil:
+		return &TopologyPlanChange{Action: TopologyPlanActionDelete, Object: currentObj}, nil
+	}
+
+	// The topology controller never sets the status.
+	unstructured.RemoveNestedField(desiredObj.Object, "status")
+	desiredObj.SetResourceVersion("")
+	desiredObj.SetManagedFields(nil)
+
+	mergedObj, err := topologyPlanApply(ctx, currentObj, desiredObj, structuredmerge.TopologyManagerName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fields set by the API server are not relevant for the diff.
+	for _, obj := range []*unstructured.Unstructured{currentObj, mergedObj} {
+		obj.SetResourceVersion("")
+		obj.SetGeneration(0)
+		obj.SetManagedFields(nil)
+	}
+	if reflect.DeepEqual(currentObj.Object, mergedObj.Object) {
+		return nil, nil
+	}
+
+	action := TopologyPlanActionUpdate
+	if isTemplate && !reflect.DeepEqual(currentObj.Object["spec"], mergedObj.Object["spec"]) {
+		action = TopologyPlanActionRotate
+	}
+	return &TopologyPlanChange{
+		Action: action,
+		Object: desiredObj,
+		Diff:   cmp.Diff(currentObj.Object, mergedObj.Object),
+	}, nil
+}
+
+// topologyPlanUnstructured converts obj to unstructured, setting its apiVersion and kind; nil objects are returned as nil.
+func topologyPlanUnstructured(obj client.Object) (*unstructured.Unstructured, error) {
+	if obj == nil || reflect.ValueOf(obj).IsNil() {
+		return nil, nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert %s %s to unstructured", gvk.Kind, klog.KObj(obj))
+	}
+	ret := &unstructured.Unstructured{Object: u}
+	ret.SetGroupVersionKind(gvk)
+	return ret, nil
+}
+
+// topologyPlanApply returns the result of server-side applying intent on top of current with the given field manager.
+// Server-side apply is run against an in-memory client seeded with current, using the structured merge of the
+// API server; as there are no OpenAPI schemas for Cluster API and provider types, lists are merged atomically.
+func topologyPlanApply(ctx context.Context, current, intent *unstructured.Unstructured, fieldManager string) (*unstructured.Unstructured, error) {
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithReturnManagedFields().WithObjects(current.DeepCopy()).Build()
+
+	applied := intent.DeepCopy()
+	applied.SetResourceVersion("")
+	applied.SetManagedFields(nil)
+	if err := c.Apply(ctx, client.ApplyConfigurationFromUnstructured(applied), client.FieldOwner(fieldManager), client.ForceOwnership); err != nil {
+		return nil, errors.Wrapf(err, "failed to apply %s %s", intent.GetKind(), klog.KObj(intent))
+	}
+	return applied, nil
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/structuredmerge"
+	"sigs.k8s.io/cluster-api/util/contract"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+var (
+	topologyPlanClusterClass = `
+apiVersion: cluster.x-k8s.io/v1beta2
+kind: ClusterClass
+metadata:
+  name: my-cluster-class
+spec:
+  infrastructure:
+    templateRef:
+      apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+      kind: TestInfrastructureClusterTemplate
+      name: my-infra-cluster-template
+  controlPlane:
+    templateRef:
+      apiVersion: controlplane.cluster.x-k8s.io/v1beta2
+      kind: TestControlPlaneTemplate
+      name: my-control-plane-template
+  workers:
+    machineDeployments:
+    - class: default-worker
+      bootstrap:
+        templateRef:
+          apiVersion: bootstrap.cluster.x-k8s.io/v1beta2
+          kind: TestBootstrapConfigTemplate
+          name: my-bootstrap-template
+      infrastructure:
+        templateRef:
+          apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+          kind: TestInfrastructureMachineTemplate
+          name: my-infra-machine-template
+  variables:
+  - name: image
+    required: true
+    schema:
+      openAPIV3Schema:
+        type: string
+  patches:
+  - name: image
+    definitions:
+    - selector:
+        apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+        kind: TestInfrastructureMachineTemplate
+        matchResources:
+          machineDeploymentClass:
+            names:
+            - default-worker
+      jsonPatches:
+      - op: add
+        path: /spec/template/spec/image
+        valueFrom:
+          variable: image
+`
+	topologyPlanClusterClassStatus = `status:
+  variables:
+  - name: image
+    definitionsConflict: false
+    definitions:
+    - from: inline
+      required: true
+      schema:
+        openAPIV3Schema:
+          type: string
+`
+	topologyPlanTemplates = `
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+kind: TestInfrastructureClusterTemplate
+metadata:
+  name: my-infra-cluster-template
+spec:
+  template:
+    spec: {}
+---
+apiVersion: controlplane.cluster.x-k8s.io/v1beta2
+kind: TestControlPlaneTemplate
+metadata:
+  name: my-control-plane-template
+spec:
+  template:
+    spec: {}
+---
+apiVersion: bootstrap.cluster.x-k8s.io/v1beta2
+kind: TestBootstrapConfigTemplate
+metadata:
+  name: my-bootstrap-template
+spec:
+  template:
+    spec: {}
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+kind: TestInfrastructureMachineTemplate
+metadata:
+  name: my-infra-machine-template
+spec:
+  template:
+    spec:
+      size: small
+`
+	topologyPlanCluster = `
+apiVersion: cluster.x-k8s.io/v1beta2
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  topology:
+    classRef:
+      name: my-cluster-class
+    version: v1.34.0
+    workers:
+      machineDeployments:
+      - class: default-worker
+        name: md-0
+    variables:
+    - name: image
+      value: my-image
+`
+)
+
+func topologyPlanObjs(g *WithT, yamls ...string) []*unstructured.Unstructured {
+	objs, err := utilyaml.ToUnstructured([]byte(strings.Join(yamls, "\n---\n")))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	ret := []*unstructured.Unstructured{}
+	for i := range objs {
+		ret = append(ret, &objs[i])
+	}
+	return ret
+}
+
+func topologyPlanCRD(group, kind string) *apiextensionsv1.CustomResourceDefinition {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	crd.SetName(contract.CalculateCRDName(group, kind))
+	crd.SetLabels(map[string]string{"cluster.x-k8s.io/v1beta2": "v1beta2"})
+	return crd
+}
+
+func topologyPlanChangesByKind(plan TopologyPlanCluster) map[string]TopologyPlanChange {
+	ret := map[string]TopologyPlanChange{}
+	for _, change := range plan.Changes {
+		ret[change.Object.GetKind()] = change
+	}
+	return ret
+}
+
+func Test_topologyClient_Plan(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("computes the objects to be created for a new Cluster offline", func(t *testing.T) {
+		g := NewWithT(t)
+
+		out, err := NewTopologyClient(nil).Plan(ctx, &TopologyPlanInput{
+			Objs:            topologyPlanObjs(g, topologyPlanClusterClass, topologyPlanTemplates, topologyPlanCluster),
+			TargetNamespace: "default",
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(out.Clusters).To(HaveLen(1))
+		g.Expect(out.Clusters[0].Cluster).To(Equal(client.ObjectKey{Namespace: "default", Name: "my-cluster"}))
+
+		changes := topologyPlanChangesByKind(out.Clusters[0])
+		for _, kind := range []string{"TestInfrastructureCluster", "TestControlPlane", "TestBootstrapConfigTemplate", "TestInfrastructureMachineTemplate", "MachineDeployment"} {
+			g.Expect(changes).To(HaveKey(kind))
+			g.Expect(changes[kind].Action).To(Equal(TopologyPlanActionCreate), kind)
+		}
+		g.Expect(changes["Cluster"].Action).To(Equal(TopologyPlanActionUpdate))
+
+		// Inline patches are applied.
+		image, _, err := unstructured.NestedString(changes["TestInfrastructureMachineTemplate"].Object.Object, "spec", "template", "spec", "image")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(image).To(Equal("my-image"))
+	})
+
+	t.Run("computes the changes to an existing Cluster when the ClusterClass changes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		// Create the current state of the Cluster from the plan of a new Cluster.
+		out, err := NewTopologyClient(nil).Plan(ctx, &TopologyPlanInput{
+			Objs:            topologyPlanObjs(g, topologyPlanClusterClass, topologyPlanTemplates, topologyPlanCluster),
+			TargetNamespace: "default",
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		proxy := test.NewFakeProxy().WithObjs(
+			topologyPlanCRD("infrastructure.cluster.x-k8s.io", "TestInfrastructureCluster"),
+			topologyPlanCRD("infrastructure.cluster.x-k8s.io", "TestInfrastructureClusterTemplate"),
+			topologyPlanCRD("infrastructure.cluster.x-k8s.io", "TestInfrastructureMachineTemplate"),
+			topologyPlanCRD("controlplane.cluster.x-k8s.io", "TestControlPlane"),
+			topologyPlanCRD("controlplane.cluster.x-k8s.io", "TestControlPlaneTemplate"),
+			topologyPlanCRD("bootstrap.cluster.x-k8s.io", "TestBootstrapConfigTemplate"),
+		)
+		for _, obj := range topologyPlanObjs(g, topologyPlanClusterClass+topologyPlanClusterClassStatus, topologyPlanTemplates) {
+			obj.SetNamespace("default")
+			proxy.WithObjs(obj)
+		}
+		for _, change := range out.Clusters[0].Changes {
+			proxy.WithObjs(change.Object)
+		}
+
+		// Nothing changes if the ClusterClass does not change.
+		out, err = NewTopologyClient(proxy).Plan(ctx, &TopologyPlanInput{
+			Objs:            topologyPlanObjs(g, topologyPlanClusterClass),
+			TargetNamespace: "default",
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(out.Clusters).To(HaveLen(1))
+		g.Expect(out.Clusters[0].Changes).To(BeEmpty())
+
+		// Changes to templates are rotated.
+		out, err = NewTopologyClient(proxy).Plan(ctx, &TopologyPlanInput{
+			Objs:            topologyPlanObjs(g, strings.ReplaceAll(topologyPlanTemplates, "size: small", "size: large")),
+			TargetNamespace: "default",
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(out.Clusters).To(HaveLen(1))
+		g.Expect(out.Clusters[0].Changes).To(HaveLen(1))
+		g.Expect(out.Clusters[0].Changes[0].Action).To(Equal(TopologyPlanActionRotate))
+		g.Expect(out.Clusters[0].Changes[0].Object.GetKind()).To(Equal("TestInfrastructureMachineTemplate"))
+		g.Expect(out.Clusters[0].Changes[0].Diff).To(ContainSubstring("large"))
+
+		// Changes to the Cluster are applied to the topology objects.
+		out, err = NewTopologyClient(proxy).Plan(ctx, &TopologyPlanInput{
+			Objs:              topologyPlanObjs(g, strings.ReplaceAll(topologyPlanCluster, "name: md-0", "name: md-0\n        replicas: 3")),
+			TargetNamespace:   "default",
+			TargetClusterName: "my-cluster",
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		// Fields of the existing Cluster set by the topology controller are preserved.
+		changes := topologyPlanChangesByKind(out.Clusters[0])
+		g.Expect(changes).To(HaveLen(1))
+		g.Expect(changes).To(HaveKey("MachineDeployment"))
+		g.Expect(changes["MachineDeployment"].Action).To(Equal(TopologyPlanActionUpdate))
+		g.Expect(changes["MachineDeployment"].Diff).To(ContainSubstring("replicas"))
+	})
+
+	t.Run("fails for Cluster API objects using an old API version", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := NewTopologyClient(nil).Plan(ctx, &TopologyPlanInput{
+			Objs:            topologyPlanObjs(g, strings.ReplaceAll(topologyPlanCluster, "v1beta2", "v1beta1")),
+			TargetNamespace: "default",
+		})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func Test_topologyPlanObjectChange(t *testing.T) {
+	template := func(labels map[string]string, size string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+		obj.SetKind("TestInfrastructureMachineTemplate")
+		obj.SetNamespace("default")
+		obj.SetName("my-infra-machine-template")
+		obj.SetLabels(labels)
+		obj.Object["spec"] = map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{"size": size}}}
+		return obj
+	}
+
+	tests := []struct {
+		name       string
+		current    *unstructured.Unstructured
+		desired    *unstructured.Unstructured
+		isTemplate bool
+		wantAction TopologyPlanAction
+	}{
+		{
+			name:    "no change",
+			current: template(map[string]string{"foo": "bar"}, "small"),
+			desired: template(map[string]string{"foo": "bar"}, "small"),
+		},
+		{
+			name:       "templates with changes to the spec are rotated",
+			current:    template(nil, "small"),
+			desired:    template(nil, "large"),
+			isTemplate: true,
+			wantAction: TopologyPlanActionRotate,
+		},
+		{
+			name:       "templates with changes only to the metadata are updated",
+			current:    template(nil, "small"),
+			desired:    template(map[string]string{"foo": "bar"}, "small"),
+			isTemplate: true,
+			wantAction: TopologyPlanActionUpdate,
+		},
+		{
+			name:       "other objects with changes to the spec are updated",
+			current:    template(nil, "small"),
+			desired:    template(nil, "large"),
+			wantAction: TopologyPlanActionUpdate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			change, err := topologyPlanObjectChange(context.Background(), tt.current, tt.desired, tt.isTemplate)
+			g.Expect(err).ToNot(HaveOccurred())
+			if tt.wantAction == "" {
+				g.Expect(change).To(BeNil())
+				return
+			}
+			g.Expect(change).ToNot(BeNil())
+			g.Expect(change.Action).To(Equal(tt.wantAction))
+		})
+	}
+
+	t.Run("fields not set anymore by the topology controller are removed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		// Create the current object by applying a template with a label as the topology controller, then
+		// apply another label with another field manager.
+		current, err := topologyPlanApply(context.Background(), template(nil, "small"), template(map[string]string{"foo": "bar"}, "small"), structuredmerge.TopologyManagerName)
+		g.Expect(err).ToNot(HaveOccurred())
+		current, err = topologyPlanApply(context.Background(), current, template(map[string]string{"other": "label"}, "small"), "other-manager")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(current.GetLabels()).To(Equal(map[string]string{"foo": "bar", "other": "label"}))
+
+		change, err := topologyPlanObjectChange(context.Background(), current, template(nil, "small"), true)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(change).ToNot(BeNil())
+		g.Expect(change.Action).To(Equal(TopologyPlanActionUpdate))
+		g.Expect(change.Diff).To(MatchRegexp(`-\s+"foo"`))
+		g.Expect(change.Diff).ToNot(MatchRegexp(`-\s+"other"`))
+	})
+}
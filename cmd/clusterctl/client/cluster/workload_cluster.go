@@ -18,17 +18,55 @@ package cluster
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	utilkubeconfig "sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/secret"
 )
 
+var (
+	userKubeconfigInterval = 2 * time.Second
+	userKubeconfigTimeout  = 2 * time.Minute
+)
+
+// UserKubeconfigOptions carries the options for issuing a kubeconfig for a user of a workload cluster.
+type UserKubeconfigOptions struct {
+	// WorkloadClusterName is the name of the workload cluster.
+	WorkloadClusterName string
+
+	// Namespace is the namespace of the workload cluster.
+	Namespace string
+
+	// User is the name of the user the kubeconfig is issued for.
+	User string
+
+	// Groups are the groups the user belongs to.
+	Groups []string
+
+	// TTL is the validity of the kubeconfig; if not set, the default of the ClusterKubeconfigRequest API applies.
+	TTL time.Duration
+}
+
 // WorkloadCluster has methods for fetching kubeconfig of workload cluster from management cluster.
 type WorkloadCluster interface {
 	// GetKubeconfig returns the kubeconfig of the workload cluster.
 	GetKubeconfig(ctx context.Context, workloadClusterName string, namespace string) (string, error)
+
+	// GetUserKubeconfig returns a kubeconfig of the workload cluster issued for a user via a ClusterKubeconfigRequest.
+	GetUserKubeconfig(ctx context.Context, options UserKubeconfigOptions) (string, error)
 }
 
 // workloadCluster implements WorkloadCluster.
@@ -59,3 +97,66 @@ func (p *workloadCluster) GetKubeconfig(ctx context.Context, workloadClusterName
 	}
 	return string(dataBytes), nil
 }
+
+func (p *workloadCluster) GetUserKubeconfig(ctx context.Context, options UserKubeconfigOptions) (string, error) {
+	cs, err := p.proxy.NewClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	request := &clusterv1.ClusterKubeconfigRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-", options.WorkloadClusterName),
+			Namespace:    options.Namespace,
+		},
+		Spec: clusterv1.ClusterKubeconfigRequestSpec{
+			ClusterName: options.WorkloadClusterName,
+			User:        options.User,
+			Groups:      options.Groups,
+		},
+	}
+	if options.TTL > 0 {
+		request.Spec.TTLSeconds = ptr.To(int32(options.TTL.Seconds()))
+	}
+	if err := cs.Create(ctx, request); err != nil {
+		return "", errors.Wrapf(err, "failed to create ClusterKubeconfigRequest for Cluster %s/%s", options.Namespace, options.WorkloadClusterName)
+	}
+	// The ClusterKubeconfigRequest is only required to get the kubeconfig, so it is deleted as soon as the kubeconfig
+	// has been read, or when it failed to be issued; the Secret of the request is garbage collected.
+	// Note: deleting the request does not invalidate the issued kubeconfig, which stays valid until the TTL expires.
+	defer func() {
+		if err := cs.Delete(ctx, request); err != nil && !apierrors.IsNotFound(err) {
+			logf.Log.Error(err, "Failed to delete ClusterKubeconfigRequest, it must be deleted manually", "ClusterKubeconfigRequest", klog.KObj(request))
+		}
+	}()
+
+	return waitForUserKubeconfig(ctx, cs, client.ObjectKeyFromObject(request))
+}
+
+// waitForUserKubeconfig waits for the kubeconfig of a ClusterKubeconfigRequest to be issued and returns it.
+func waitForUserKubeconfig(ctx context.Context, c client.Client, key client.ObjectKey) (string, error) {
+	request := &clusterv1.ClusterKubeconfigRequest{}
+	err := wait.PollUntilContextTimeout(ctx, userKubeconfigInterval, userKubeconfigTimeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.Get(ctx, key, request); err != nil {
+			return false, err
+		}
+		return conditions.IsTrue(request, clusterv1.ClusterKubeconfigRequestReadyCondition) && request.Status.SecretName != "", nil
+	})
+	if err != nil {
+		message := ""
+		if condition := conditions.Get(request, clusterv1.ClusterKubeconfigRequestReadyCondition); condition != nil {
+			message = fmt.Sprintf(": %s", condition.Message)
+		}
+		return "", errors.Wrapf(err, "failed waiting for ClusterKubeconfigRequest %s/%s to be ready%s", key.Namespace, key.Name, message)
+	}
+
+	kubeconfigSecret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: key.Namespace, Name: request.Status.SecretName}, kubeconfigSecret); err != nil {
+		return "", errors.Wrapf(err, "failed to get Secret %s/%s", key.Namespace, request.Status.SecretName)
+	}
+	data, ok := kubeconfigSecret.Data[secret.KubeconfigDataName]
+	if !ok {
+		return "", errors.Errorf("missing key %q in Secret %s/%s", secret.KubeconfigDataName, key.Namespace, request.Status.SecretName)
+	}
+	return string(data), nil
+}
@@ -19,10 +19,12 @@ package cluster
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
@@ -100,3 +102,100 @@ users:
 		})
 	}
 }
+
+func Test_WorkloadCluster_waitForUserKubeconfig(t *testing.T) {
+	readyRequest := &clusterv1.ClusterKubeconfigRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "test1-abcde", Namespace: "test"},
+		Spec:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test1", User: "alice"},
+		Status: clusterv1.ClusterKubeconfigRequestStatus{
+			SecretName: "test1-abcde-user-kubeconfig",
+			Conditions: []metav1.Condition{{
+				Type:   clusterv1.ClusterKubeconfigRequestReadyCondition,
+				Status: metav1.ConditionTrue,
+				Reason: clusterv1.ClusterKubeconfigRequestIssuedReason,
+			}},
+		},
+	}
+	waitingRequest := readyRequest.DeepCopy()
+	waitingRequest.Status = clusterv1.ClusterKubeconfigRequestStatus{
+		Conditions: []metav1.Condition{{
+			Type:    clusterv1.ClusterKubeconfigRequestReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.ClusterKubeconfigRequestWaitingForClusterReason,
+			Message: "Waiting for Cluster control plane endpoint",
+		}},
+	}
+	kubeconfigSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test1-abcde-user-kubeconfig", Namespace: "test"},
+		Data: map[string][]byte{
+			secret.KubeconfigDataName: []byte("kubeconfig"),
+		},
+	}
+
+	userKubeconfigInterval = 10 * time.Millisecond
+	userKubeconfigTimeout = 100 * time.Millisecond
+
+	tests := []struct {
+		name      string
+		objs      []client.Object
+		want      string
+		expectErr string
+	}{
+		{
+			name: "return the kubeconfig when the request is ready",
+			objs: []client.Object{readyRequest, kubeconfigSecret},
+			want: "kubeconfig",
+		},
+		{
+			name:      "return an error if the request is not ready",
+			objs:      []client.Object{waitingRequest},
+			expectErr: "Waiting for Cluster control plane endpoint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			c, err := test.NewFakeProxy().WithObjs(tt.objs...).NewClient(ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			data, err := waitForUserKubeconfig(ctx, c, client.ObjectKey{Namespace: "test", Name: "test1-abcde"})
+			if tt.expectErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.expectErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(data).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_WorkloadCluster_GetUserKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+
+	userKubeconfigInterval = 10 * time.Millisecond
+	userKubeconfigTimeout = 100 * time.Millisecond
+
+	ctx := context.Background()
+
+	proxy := test.NewFakeProxy()
+	wc := newWorkloadCluster(proxy)
+
+	// The fake client does not run the ClusterKubeconfigRequest controller, so the request is never ready.
+	_, err := wc.GetUserKubeconfig(ctx, UserKubeconfigOptions{
+		WorkloadClusterName: "test1",
+		Namespace:           "test",
+		User:                "alice",
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("failed waiting for ClusterKubeconfigRequest")))
+
+	// The ClusterKubeconfigRequest must be deleted.
+	c, err := proxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	requests := &clusterv1.ClusterKubeconfigRequestList{}
+	g.Expect(c.List(ctx, requests)).To(Succeed())
+	g.Expect(requests.Items).To(BeEmpty())
+}
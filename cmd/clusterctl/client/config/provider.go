@@ -48,16 +48,61 @@ type Provider interface {
 	Less(other Provider) bool
 }
 
+// VerifiableProvider is implemented by providers with a policy for verifying the integrity of the files
+// read from the provider repository.
+// Note: This interface is not part of Provider, so existing implementations of Provider are not affected;
+// use GetProviderVerification to get the verification policy of a Provider.
+type VerifiableProvider interface {
+	// Verification returns the policy for verifying the integrity of the provider files, if any.
+	Verification() *ProviderVerification
+}
+
+// GetProviderVerification returns the verification policy of a provider, if the provider implements
+// VerifiableProvider and a policy is defined.
+func GetProviderVerification(p Provider) *ProviderVerification {
+	if v, ok := p.(VerifiableProvider); ok {
+		return v.Verification()
+	}
+	return nil
+}
+
+// ProviderVerification defines how clusterctl verifies the integrity of the files read from a provider repository,
+// i.e. the components YAML, the metadata.yaml file, the cluster templates and the ClusterClass templates,
+// before using them.
+type ProviderVerification struct {
+	// Required makes clusterctl fail if a file cannot be verified, e.g. because the checksum file
+	// or the signature are not published in the provider repository.
+	// If not set, verification is performed only if the checksum file and the signature are published;
+	// please note that in both cases clusterctl always fails if a checksum or a signature does not match.
+	Required bool `json:"required,omitempty"`
+
+	// ChecksumFile is the name of a file published in the provider repository, in the format generated
+	// by sha256sum, containing the SHA256 checksums of the provider files, e.g. checksums.txt.
+	ChecksumFile string `json:"checksumFile,omitempty"`
+
+	// PublicKey is the PEM encoded public key, or the path to a file containing it, used to verify the detached
+	// signature of the checksum file if ChecksumFile is set, or of each provider file otherwise.
+	// The signature must be published next to the signed file with the .sig (base64 encoded signature)
+	// or the .bundle (cosign bundle) suffix, as generated by cosign sign-blob.
+	// Please note that only the signature in a cosign bundle is verified, while the certificate and
+	// the transparency log entries in the bundle, if any, are ignored.
+	PublicKey string `json:"publicKey,omitempty"`
+}
+
 // provider implements Provider.
 type provider struct {
 	name         string
 	url          string
 	providerType clusterctlv1.ProviderType
+	verification *ProviderVerification
 }
 
 // ensure provider implements provider.
 var _ Provider = &provider{}
 
+// ensure provider implements VerifiableProvider.
+var _ VerifiableProvider = &provider{}
+
 func (p *provider) Name() string {
 	return p.name
 }
@@ -70,6 +115,10 @@ func (p *provider) Type() clusterctlv1.ProviderType {
 	return p.providerType
 }
 
+func (p *provider) Verification() *ProviderVerification {
+	return p.verification
+}
+
 func (p *provider) SameAs(other Provider) bool {
 	return p.name == other.Name() && p.providerType == other.Type()
 }
@@ -491,9 +491,10 @@ func (p *providersClient) defaults() []Provider {
 
 // configProvider mirrors config.Provider interface and allows serialization of the corresponding info.
 type configProvider struct {
-	Name string                    `json:"name,omitempty"`
-	URL  string                    `json:"url,omitempty"`
-	Type clusterctlv1.ProviderType `json:"type,omitempty"`
+	Name         string                    `json:"name,omitempty"`
+	URL          string                    `json:"url,omitempty"`
+	Type         clusterctlv1.ProviderType `json:"type,omitempty"`
+	Verification *ProviderVerification     `json:"verification,omitempty"`
 }
 
 func (p *providersClient) List() ([]Provider, error) {
@@ -515,7 +516,19 @@ func (p *providersClient) List() ([]Provider, error) {
 			return nil, errors.Wrapf(err, "unable to evaluate url: %q", u.URL)
 		}
 
-		provider := NewProvider(u.Name, u.URL, u.Type)
+		if u.Verification != nil {
+			u.Verification.PublicKey, err = envsubst.Eval(u.Verification.PublicKey, os.Getenv)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to evaluate verification public key: %q", u.Verification.PublicKey)
+			}
+		}
+
+		provider := &provider{
+			name:         u.Name,
+			url:          u.URL,
+			providerType: u.Type,
+			verification: u.Verification,
+		}
 		if err := validateProvider(provider); err != nil {
 			return nil, errors.Wrapf(err, "error validating configuration for the %s with name %s. Please fix the providers value in clusterctl configuration file", provider.Type(), provider.Name())
 		}
@@ -581,6 +594,10 @@ func validateProvider(r Provider) error {
 		return errors.Wrap(err, "error parsing provider URL")
 	}
 
+	if v := GetProviderVerification(r); v != nil && v.Required && v.ChecksumFile == "" && v.PublicKey == "" {
+		return errors.New("verification is required but neither a checksum file nor a public key are set")
+	}
+
 	switch r.Type() {
 	case clusterctlv1.CoreProviderType,
 		clusterctlv1.BootstrapProviderType,
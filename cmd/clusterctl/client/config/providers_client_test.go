@@ -46,6 +46,18 @@ func Test_providers_List(t *testing.T) {
 		return defaultsAndZZZ[i].Less(defaultsAndZZZ[j])
 	})
 
+	defaultsAndZZZWithVerification := append([]Provider{}, defaultsAndZZZ...)
+	for i := range defaultsAndZZZWithVerification {
+		if defaultsAndZZZWithVerification[i].Name() == "zzz" {
+			defaultsAndZZZWithVerification[i] = &provider{
+				name:         "zzz",
+				url:          "https://zzz/infrastructure-components.yaml",
+				providerType: clusterctlv1.InfrastructureProviderType,
+				verification: &ProviderVerification{Required: true, ChecksumFile: "checksums.txt", PublicKey: "/keys/cosign.pub"},
+			}
+		}
+	}
+
 	defaultsWithOverride := append([]Provider{}, defaults...)
 	defaultsWithOverride[0] = NewProvider(defaults[0].Name(), "https://zzz/infrastructure-components.yaml", defaults[0].Type())
 
@@ -98,6 +110,27 @@ func Test_providers_List(t *testing.T) {
 			want:    defaultsAndZZZ,
 			wantErr: false,
 		},
+		{
+			name: "Returns user defined provider configurations with verification",
+			fields: fields{
+				configGetter: test.NewFakeReader().
+					WithVar(
+						ProvidersConfigKey,
+						"- name: \"zzz\"\n"+
+							"  url: \"https://zzz/infrastructure-components.yaml\"\n"+
+							"  type: \"InfrastructureProvider\"\n"+
+							"  verification:\n"+
+							"    required: true\n"+
+							"    checksumFile: \"checksums.txt\"\n"+
+							"    publicKey: \"${TEST_KEY_PATH}/cosign.pub\"\n",
+					),
+			},
+			envVars: map[string]string{
+				"TEST_KEY_PATH": "/keys",
+			},
+			want:    defaultsAndZZZWithVerification,
+			wantErr: false,
+		},
 		{
 			name: "User defined provider configurations override defaults",
 			fields: fields{
@@ -243,6 +276,20 @@ func Test_validateProvider(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Pass with required verification",
+			args: args{
+				r: &provider{name: "foo", url: "https://something.com", providerType: clusterctlv1.InfrastructureProviderType, verification: &ProviderVerification{Required: true, ChecksumFile: "checksums.txt"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Fails if verification is required without checksum file and public key",
+			args: args{
+				r: &provider{name: "foo", url: "https://something.com", providerType: clusterctlv1.InfrastructureProviderType, verification: &ProviderVerification{Required: true}},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
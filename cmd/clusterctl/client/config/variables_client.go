@@ -21,6 +21,12 @@ const (
 	GitHubTokenVariable = "github-token"
 	// GitLabAccessTokenVariable defines a variable hosting the GitLab access token. This can be used with Personal and Project access tokens.
 	GitLabAccessTokenVariable = "gitlab-access-token"
+	// OCIUsernameVariable defines a variable hosting the username used to authenticate to OCI registries.
+	OCIUsernameVariable = "oci-username"
+	// OCIPasswordVariable defines a variable hosting the password or the access token used to authenticate to OCI registries.
+	OCIPasswordVariable = "oci-password"
+	// HTTPRepositoryAllowInsecureVariable defines a variable which, if set to true, allows HTTP repositories to use plain http.
+	HTTPRepositoryAllowInsecureVariable = "http-repository-allow-insecure"
 )
 
 // VariablesClient has methods to work with environment variables and with variables defined in the clusterctl configuration file.
@@ -18,8 +18,11 @@ package client
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 )
 
 // GetKubeconfigOptions carries all the options supported by GetKubeconfig.
@@ -33,9 +36,23 @@ type GetKubeconfigOptions struct {
 
 	// WorkloadClusterName is the name of the workload cluster.
 	WorkloadClusterName string
+
+	// User is the name of the user to issue a kubeconfig for; if empty, the admin kubeconfig of the workload
+	// cluster is returned. Issuing kubeconfigs for users requires the ClusterKubeconfigRequest feature.
+	User string
+
+	// Groups are the groups the user belongs to.
+	Groups []string
+
+	// TTL is the validity of the kubeconfig issued for the user.
+	TTL time.Duration
 }
 
 func (c *clusterctlClient) GetKubeconfig(ctx context.Context, options GetKubeconfigOptions) (string, error) {
+	if options.User == "" && (len(options.Groups) > 0 || options.TTL > 0) {
+		return "", errors.New("groups and ttl can be set only when issuing a kubeconfig for a user")
+	}
+
 	// gets access to the management cluster
 	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
 	if err != nil {
@@ -58,5 +75,15 @@ func (c *clusterctlClient) GetKubeconfig(ctx context.Context, options GetKubecon
 		options.Namespace = currentNamespace
 	}
 
+	if options.User != "" {
+		return clusterClient.WorkloadCluster().GetUserKubeconfig(ctx, cluster.UserKubeconfigOptions{
+			WorkloadClusterName: options.WorkloadClusterName,
+			Namespace:           options.Namespace,
+			User:                options.User,
+			Groups:              options.Groups,
+			TTL:                 options.TTL,
+		})
+	}
+
 	return clusterClient.WorkloadCluster().GetKubeconfig(ctx, options.WorkloadClusterName, options.Namespace)
 }
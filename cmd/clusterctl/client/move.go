@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"os"
+	"path/filepath"
 
 	"github.com/pkg/errors"
 
@@ -49,6 +50,25 @@ type MoveOptions struct {
 	// ToDirectory save configuration to directory.
 	ToDirectory string
 
+	// FromArchive apply configuration from an archive created using ToArchive; the integrity of
+	// the archive is verified before applying any configuration.
+	FromArchive string
+
+	// ToArchive save configuration to a compressed archive, including a manifest with the checksum of each object.
+	ToArchive string
+
+	// ArchivePassphrase is used to encrypt Secrets when saving configuration to an archive, and to decrypt
+	// them when applying configuration from an archive. If both ArchivePassphrase and ArchiveAgeRecipients
+	// are empty, Secrets are saved in plain text.
+	ArchivePassphrase string
+
+	// ArchiveAgeRecipients are the age public keys used to encrypt Secrets when saving configuration to an archive.
+	ArchiveAgeRecipients []string
+
+	// ArchiveAgeIdentities is the content of an age identity file, used to decrypt Secrets when applying
+	// configuration from an archive saved using ArchiveAgeRecipients.
+	ArchiveAgeIdentities string
+
 	// DryRun means the move action is a dry run, no real action will be performed.
 	DryRun bool
 }
@@ -59,17 +79,33 @@ func (c *clusterctlClient) Move(ctx context.Context, options MoveOptions) error
 		return errors.Errorf("can't set both FromDirectory and ToDirectory")
 	}
 
+	// Only one between directory and archive can be used.
+	if (options.FromDirectory != "" || options.ToDirectory != "") && (options.FromArchive != "" || options.ToArchive != "") {
+		return errors.Errorf("can't set FromDirectory or ToDirectory together with FromArchive or ToArchive")
+	}
+
+	if options.FromArchive != "" && options.ToArchive != "" {
+		return errors.Errorf("can't set both FromArchive and ToArchive")
+	}
+
 	if !options.DryRun &&
 		options.FromDirectory == "" &&
 		options.ToDirectory == "" &&
+		options.FromArchive == "" &&
+		options.ToArchive == "" &&
 		options.ToKubeconfig == (Kubeconfig{}) {
-		return errors.Errorf("at least one of FromDirectory, ToDirectory and ToKubeconfig must be set")
+		return errors.Errorf("at least one of FromDirectory, ToDirectory, FromArchive, ToArchive and ToKubeconfig must be set")
 	}
 
-	if options.ToDirectory != "" {
+	switch {
+	case options.ToDirectory != "":
 		return c.toDirectory(ctx, options)
-	} else if options.FromDirectory != "" {
+	case options.FromDirectory != "":
 		return c.fromDirectory(ctx, options)
+	case options.ToArchive != "":
+		return c.toArchive(ctx, options)
+	case options.FromArchive != "":
+		return c.fromArchive(ctx, options)
 	}
 
 	return c.move(ctx, options)
@@ -137,6 +173,48 @@ func (c *clusterctlClient) toDirectory(ctx context.Context, options MoveOptions)
 	return fromCluster.ObjectMover().ToDirectory(ctx, options.Namespace, options.ToDirectory)
 }
 
+func (c *clusterctlClient) fromArchive(ctx context.Context, options MoveOptions) error {
+	toCluster, err := c.getClusterClient(ctx, options.ToKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(options.FromArchive); err != nil {
+		return err
+	}
+
+	return toCluster.ObjectMover().FromArchive(ctx, toCluster, options.FromArchive, cluster.ArchiveOptions{
+		Passphrase:    options.ArchivePassphrase,
+		AgeIdentities: options.ArchiveAgeIdentities,
+	})
+}
+
+func (c *clusterctlClient) toArchive(ctx context.Context, options MoveOptions) error {
+	fromCluster, err := c.getClusterClient(ctx, options.FromKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	// If the option specifying the Namespace is empty, try to detect it.
+	if options.Namespace == "" {
+		currentNamespace, err := fromCluster.Proxy().CurrentNamespace()
+		if err != nil {
+			return err
+		}
+		options.Namespace = currentNamespace
+	}
+
+	// Ensure the directory where the archive should be written exists.
+	if _, err := os.Stat(filepath.Dir(options.ToArchive)); err != nil {
+		return err
+	}
+
+	return fromCluster.ObjectMover().ToArchive(ctx, options.Namespace, options.ToArchive, cluster.ArchiveOptions{
+		Passphrase:    options.ArchivePassphrase,
+		AgeRecipients: options.ArchiveAgeRecipients,
+	})
+}
+
 func (c *clusterctlClient) getClusterClient(ctx context.Context, kubeconfig Kubeconfig) (cluster.Client, error) {
 	cluster, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: kubeconfig})
 	if err != nil {
@@ -18,6 +18,8 @@ package client
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -262,6 +264,127 @@ func Test_clusterctlClient_FromDirectory(t *testing.T) {
 	}
 }
 
+func Test_clusterctlClient_ToArchive(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		options MoveOptions
+		wantErr bool
+	}{
+		{
+			name: "does not return error if cluster client is found",
+			options: MoveOptions{
+				FromKubeconfig:    Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				ToArchive:         filepath.Join(dir, "backup.tar.gz"),
+				ArchivePassphrase: "passphrase",
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if from cluster client is not found",
+			options: MoveOptions{
+				FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "does-not-exist"},
+				ToArchive:      filepath.Join(dir, "backup.tar.gz"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if the archive directory does not exist",
+			options: MoveOptions{
+				FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				ToArchive:      filepath.Join(dir, "does-not-exist", "backup.tar.gz"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if both ToArchive and ToDirectory are set",
+			options: MoveOptions{
+				FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				ToArchive:      filepath.Join(dir, "backup.tar.gz"),
+				ToDirectory:    dir,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			err := fakeClientForMove().Move(ctx, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func Test_clusterctlClient_FromArchive(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	g := NewWithT(t)
+	g.Expect(os.WriteFile(archive, []byte{}, 0o600)).To(Succeed())
+
+	tests := []struct {
+		name    string
+		options MoveOptions
+		wantErr bool
+	}{
+		{
+			name: "does not return error if cluster client is found",
+			options: MoveOptions{
+				ToKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				FromArchive:  archive,
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if to cluster client is not found",
+			options: MoveOptions{
+				ToKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "does-not-exist"},
+				FromArchive:  archive,
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if the archive does not exist",
+			options: MoveOptions{
+				ToKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				FromArchive:  archive + ".does-not-exist",
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if both FromArchive and ToArchive are set",
+			options: MoveOptions{
+				ToKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				FromArchive:  archive,
+				ToArchive:    archive,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			err := fakeClientForMove().Move(ctx, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
 func fakeClientForMove() *fakeClient {
 	ctx := context.Background()
 
@@ -295,6 +418,8 @@ type fakeObjectMover struct {
 	moveErr          error
 	toDirectoryErr   error
 	fromDirectoryErr error
+	toArchiveErr     error
+	fromArchiveErr   error
 }
 
 func (f *fakeObjectMover) Move(_ context.Context, _ string, _ cluster.Client, _ bool, _ ...cluster.ResourceMutatorFunc) error {
@@ -316,3 +441,11 @@ func (f *fakeObjectMover) FromDirectory(_ context.Context, _ cluster.Client, _ s
 func (f *fakeObjectMover) Restore(_ context.Context, _ cluster.Client, _ string) error {
 	return f.fromDirectoryErr
 }
+
+func (f *fakeObjectMover) ToArchive(_ context.Context, _ string, _ string, _ cluster.ArchiveOptions) error {
+	return f.toArchiveErr
+}
+
+func (f *fakeObjectMover) FromArchive(_ context.Context, _ cluster.Client, _ string, _ cluster.ArchiveOptions) error {
+	return f.fromArchiveErr
+}
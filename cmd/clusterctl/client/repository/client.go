@@ -194,7 +194,31 @@ func repositoryFactory(ctx context.Context, providerConfig config.Provider, conf
 			return repo, err
 		}
 
-		return nil, errors.Errorf("invalid provider url. Only GitHub and GitLab are supported for %q schema", rURL.Scheme)
+		// otherwise the url is an HTTP repository driven by an index file
+		repo, err := NewHTTPRepository(ctx, providerConfig, configVariablesClient)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating the HTTP repository client")
+		}
+		return repo, err
+	}
+
+	// if the url is an HTTP repository driven by an index file using plain http; NewHTTPRepository
+	// rejects it unless insecure HTTP repositories are explicitly allowed.
+	if rURL.Scheme == httpScheme {
+		repo, err := NewHTTPRepository(ctx, providerConfig, configVariablesClient)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating the HTTP repository client")
+		}
+		return repo, err
+	}
+
+	// if the url is an OCI registry repository
+	if rURL.Scheme == ociScheme {
+		repo, err := NewOCIRepository(ctx, providerConfig, configVariablesClient)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating the OCI repository client")
+		}
+		return repo, err
 	}
 
 	// if the url is a local filesystem repository
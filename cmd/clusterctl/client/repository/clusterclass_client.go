@@ -86,6 +86,10 @@ func (cc *clusterClassClient) Get(ctx context.Context, name, targetNamespace str
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to read %q from provider's repository %q", filename, cc.provider.ManifestLabel())
 		}
+
+		if err := verifyFile(ctx, cc.provider, cc.repository, version, filename, rawArtifact); err != nil {
+			return nil, err
+		}
 	} else {
 		log.V(1).Info("Using", "override", filename, "provider", cc.provider.ManifestLabel(), "version", version)
 	}
@@ -96,6 +96,10 @@ func (f *componentsClient) getRawBytes(ctx context.Context, options *ComponentsO
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to read %q from provider's repository %q", path, f.provider.ManifestLabel())
 		}
+
+		if err := verifyFile(ctx, f.provider, f.repository, options.Version, path, file); err != nil {
+			return nil, err
+		}
 	} else {
 		log.Info("Using", "override", path, "provider", f.provider.ManifestLabel(), "version", options.Version)
 	}
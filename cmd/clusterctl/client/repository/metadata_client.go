@@ -80,6 +80,10 @@ func (f *metadataClient) Get(ctx context.Context) (*clusterctlv1.Metadata, error
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to read %q from the repository for provider %q", metadataFile, f.provider.ManifestLabel())
 		}
+
+		if err := verifyFile(ctx, f.provider, f.repository, version, metadataFile, file); err != nil {
+			return nil, err
+		}
 	} else {
 		log.V(1).Info("Using", "override", metadataFile, "provider", f.provider.ManifestLabel(), "version", version)
 	}
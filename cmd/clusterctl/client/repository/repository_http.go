@@ -0,0 +1,395 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+const (
+	httpScheme = "http"
+
+	// httpRepositoryIndexFile is the name of the index file of an HTTP repository.
+	httpRepositoryIndexFile = "index.yaml"
+
+	// httpRepositoryCacheFolder is the folder under $XDG_CACHE_HOME/cluster-api where HTTP repositories are cached.
+	httpRepositoryCacheFolder = "http-repositories"
+)
+
+// httpRepository provides support for providers hosted on a generic HTTP(S) server, e.g. an artifact server.
+//
+// The repository is driven by an index.yaml file, stored in the folder containing the version folders,
+// which lists the available versions and, for each version, the files with their URL and checksum.
+// The index is cached on disk and re-validated using its ETag, while files are cached on disk by checksum.
+type httpRepository struct {
+	providerConfig        config.Provider
+	configVariablesClient config.VariablesClient
+	httpClient            *http.Client
+	allowInsecure         bool
+	indexURL              *url.URL
+	defaultVersion        string
+	rootPath              string
+	componentsPath        string
+	cacheDir              string
+	index                 *httpRepositoryIndex
+}
+
+var _ Repository = &httpRepository{}
+
+// httpRepositoryIndex is the index file of an HTTP repository.
+type httpRepositoryIndex struct {
+	// Versions is the list of versions available in the repository.
+	Versions []httpRepositoryVersion `json:"versions"`
+}
+
+// httpRepositoryVersion is a version in the index of an HTTP repository.
+type httpRepositoryVersion struct {
+	// Version is the version, e.g. v1.0.0.
+	Version string `json:"version"`
+
+	// Files is the list of files of the version, e.g. the components YAML, metadata.yaml, cluster templates and ClusterClasses.
+	Files []httpRepositoryFile `json:"files"`
+}
+
+// httpRepositoryFile is a file in the index of an HTTP repository.
+type httpRepositoryFile struct {
+	// Name of the file, e.g. infrastructure-components.yaml.
+	Name string `json:"name"`
+
+	// URL of the file; it can be relative to the index URL. If not set, {version}/{name} is used.
+	URL string `json:"url,omitempty"`
+
+	// SHA256 is the checksum of the file.
+	SHA256 string `json:"sha256"`
+}
+
+type httpRepositoryOption func(*httpRepository)
+
+func injectHTTPRepositoryClient(c *http.Client) httpRepositoryOption {
+	return func(h *httpRepository) {
+		h.httpClient = c
+	}
+}
+
+func injectHTTPRepositoryCacheDir(dir string) httpRepositoryOption {
+	return func(h *httpRepository) {
+		h.cacheDir = dir
+	}
+}
+
+// NewHTTPRepository returns an httpRepository implementation.
+func NewHTTPRepository(ctx context.Context, providerConfig config.Provider, configVariablesClient config.VariablesClient, opts ...httpRepositoryOption) (Repository, error) {
+	if configVariablesClient == nil {
+		return nil, errors.New("invalid arguments: configVariablesClient can't be nil")
+	}
+
+	rURL, err := url.Parse(providerConfig.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	// Check if the path is in the expected format.
+	urlSplit := strings.Split(strings.TrimPrefix(rURL.Path, "/"), "/")
+	if (rURL.Scheme != httpsScheme && rURL.Scheme != httpScheme) || rURL.Host == "" || len(urlSplit) < 2 || urlSplit[len(urlSplit)-1] == "" {
+		return nil, errors.Errorf("invalid url: an HTTP repository url should be in the form https://{host}/{path}/{latest|version}/{componentsClient.yaml}, with the %s file in {path}", httpRepositoryIndexFile)
+	}
+
+	// Plain http is allowed only if the user explicitly opts in, because the index is not verified.
+	allowInsecure := false
+	if value, err := configVariablesClient.Get(config.HTTPRepositoryAllowInsecureVariable); err == nil {
+		if allowInsecure, err = strconv.ParseBool(value); err != nil {
+			return nil, errors.Wrapf(err, "invalid value %q for %s", value, config.HTTPRepositoryAllowInsecureVariable)
+		}
+	}
+	if rURL.Scheme == httpScheme && !allowInsecure {
+		return nil, errors.Errorf("invalid url: plain http is not allowed for HTTP repositories unless %s is set to true", config.HTTPRepositoryAllowInsecureVariable)
+	}
+
+	// Extract all the info from url split; the index is stored in the folder containing the version folders.
+	defaultVersion := urlSplit[len(urlSplit)-2]
+	path := urlSplit[len(urlSplit)-1]
+	indexURL := *rURL
+	indexURL.Path = "/" + strings.Join(append(urlSplit[:len(urlSplit)-2], httpRepositoryIndexFile), "/")
+	indexURL.RawPath = ""
+
+	repo := &httpRepository{
+		providerConfig:        providerConfig,
+		configVariablesClient: configVariablesClient,
+		httpClient:            http.DefaultClient,
+		allowInsecure:         allowInsecure,
+		indexURL:              &indexURL,
+		defaultVersion:        defaultVersion,
+		rootPath:              ".",
+		componentsPath:        path,
+		cacheDir:              filepath.Join(xdg.CacheHome, config.ConfigFolderXDG, httpRepositoryCacheFolder),
+	}
+
+	// Process httpRepositoryOptions.
+	for _, o := range opts {
+		o(repo)
+	}
+
+	if defaultVersion == latestVersionTag {
+		repo.defaultVersion, err = latestContractRelease(ctx, repo, clusterv1.GroupVersion.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get latest release")
+		}
+	}
+
+	return repo, nil
+}
+
+// DefaultVersion returns defaultVersion field of httpRepository struct.
+func (h *httpRepository) DefaultVersion() string {
+	return h.defaultVersion
+}
+
+// RootPath returns rootPath field of httpRepository struct.
+func (h *httpRepository) RootPath() string {
+	return h.rootPath
+}
+
+// ComponentsPath returns componentsPath field of httpRepository struct.
+func (h *httpRepository) ComponentsPath() string {
+	return h.componentsPath
+}
+
+// GetVersions returns the list of versions that are available in a provider repository.
+func (h *httpRepository) GetVersions(ctx context.Context) ([]string, error) {
+	index, err := h.getIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []string{}
+	for _, v := range index.Versions {
+		versions = append(versions, v.Version)
+	}
+	return versions, nil
+}
+
+// GetFile returns a file for a given provider version.
+func (h *httpRepository) GetFile(ctx context.Context, version, path string) ([]byte, error) {
+	log := logf.Log
+
+	index, err := h.getIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var file *httpRepositoryFile
+	for i := range index.Versions {
+		if index.Versions[i].Version != version {
+			continue
+		}
+		for j := range index.Versions[i].Files {
+			if index.Versions[i].Files[j].Name == path {
+				file = &index.Versions[i].Files[j]
+			}
+		}
+	}
+	if file == nil {
+		return nil, errors.Wrapf(errNotFound, "failed to get file %q with version %q from %q: file not found in the index", path, version, h.indexURL.Redacted())
+	}
+
+	fileURLString := file.URL
+	if fileURLString == "" {
+		fileURLString = fmt.Sprintf("%s/%s", url.PathEscape(version), url.PathEscape(path))
+	}
+	fileURL, err := h.indexURL.Parse(fileURLString)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q: invalid url %q", path, version, fileURLString)
+	}
+
+	if fileURL.Scheme != httpsScheme && (fileURL.Scheme != httpScheme || !h.allowInsecure) {
+		return nil, errors.Errorf("failed to get file %q with version %q: url %q must use https", path, version, fileURL.Redacted())
+	}
+
+	if content, ok := cacheFiles[fileURL.String()]; ok {
+		return content, nil
+	}
+
+	// Files are cached on disk by checksum, so there is no need to download them again.
+	blobPath := filepath.Join(h.cacheDir, "blobs", file.SHA256)
+	if content, err := os.ReadFile(blobPath); err == nil && httpRepositoryChecksum(content) == file.SHA256 { //nolint:gosec // The path is computed from the cache dir.
+		cacheFiles[fileURL.String()] = content
+		return content, nil
+	}
+
+	log.V(5).Info("Fetching", "file", path, "version", version, "url", fileURL.Redacted())
+	content, _, err := h.get(ctx, fileURL.String(), "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q", path, version)
+	}
+
+	if checksum := httpRepositoryChecksum(content); checksum != file.SHA256 {
+		return nil, errors.Errorf("failed to get file %q with version %q from %q: checksum %s does not match the index, expected %s", path, version, fileURL.Redacted(), checksum, file.SHA256)
+	}
+
+	if err := writeHTTPRepositoryCacheFile(blobPath, content); err != nil {
+		log.V(5).Info("Failed to cache file", "file", path, "version", version, "error", err)
+	}
+
+	cacheFiles[fileURL.String()] = content
+	return content, nil
+}
+
+// getIndex returns the repository index; the index is cached on disk and, if a cached index
+// exists, the index is downloaded again only if its ETag changed.
+func (h *httpRepository) getIndex(ctx context.Context) (*httpRepositoryIndex, error) {
+	log := logf.Log
+
+	if h.index != nil {
+		return h.index, nil
+	}
+
+	indexSum := sha256.Sum256([]byte(h.indexURL.String()))
+	indexCacheDir := filepath.Join(h.cacheDir, "indexes", hex.EncodeToString(indexSum[:]))
+	indexCachePath := filepath.Join(indexCacheDir, httpRepositoryIndexFile)
+	etagCachePath := filepath.Join(indexCacheDir, "etag")
+
+	etag := ""
+	cachedContent, err := os.ReadFile(indexCachePath) //nolint:gosec // The path is computed from the cache dir.
+	if err == nil {
+		if cachedETag, err := os.ReadFile(etagCachePath); err == nil { //nolint:gosec // The path is computed from the cache dir.
+			etag = strings.TrimSpace(string(cachedETag))
+		}
+	}
+
+	content, header, err := h.get(ctx, h.indexURL.String(), etag)
+	switch {
+	case errors.Is(err, errNotModified):
+		log.V(5).Info("Using cached index", "url", h.indexURL.Redacted())
+		content = cachedContent
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to get index from %q", h.indexURL.Redacted())
+	}
+
+	index := &httpRepositoryIndex{}
+	if err := yaml.Unmarshal(content, index); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse index from %q", h.indexURL.Redacted())
+	}
+	for _, v := range index.Versions {
+		for i := range v.Files {
+			f := &v.Files[i]
+			if f.Name == "" || f.SHA256 == "" {
+				return nil, errors.Errorf("invalid index from %q: name and sha256 must be set for all the files of version %s", h.indexURL.Redacted(), v.Version)
+			}
+			if _, err := hex.DecodeString(f.SHA256); err != nil || len(f.SHA256) != sha256.Size*2 {
+				return nil, errors.Errorf("invalid index from %q: sha256 of file %s of version %s must be 64 hex characters", h.indexURL.Redacted(), f.Name, v.Version)
+			}
+			// Checksums are compared with the lowercase output of httpRepositoryChecksum.
+			f.SHA256 = strings.ToLower(f.SHA256)
+		}
+	}
+
+	if header != nil {
+		if err := writeHTTPRepositoryCacheFile(indexCachePath, content); err != nil {
+			log.V(5).Info("Failed to cache index", "url", h.indexURL.Redacted(), "error", err)
+		}
+		if newETag := header.Get("ETag"); newETag != "" {
+			if err := writeHTTPRepositoryCacheFile(etagCachePath, []byte(newETag)); err != nil {
+				log.V(5).Info("Failed to cache index ETag", "url", h.indexURL.Redacted(), "error", err)
+			}
+		} else {
+			_ = os.Remove(etagCachePath)
+		}
+	}
+
+	h.index = index
+	return index, nil
+}
+
+// errNotModified is returned when a conditional request returns 304 Not Modified.
+var errNotModified = errors.New("304 Not Modified")
+
+// get performs a GET request; if etag is set, the request is a conditional request, and
+// errNotModified is returned if the content did not change.
+func (h *httpRepository) get(ctx context.Context, rawURL, etag string) ([]byte, http.Header, error) {
+	timeoutctx, cancel := context.WithTimeoutCause(ctx, 30*time.Second, errors.New("http request timeout expired"))
+	defer cancel()
+	request, err := http.NewRequestWithContext(timeoutctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create request for %q", rawURL)
+	}
+	if etag != "" {
+		request.Header.Set("If-None-Match", etag)
+	}
+
+	response, err := h.httpClient.Do(request)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get %q", rawURL)
+	}
+	defer response.Body.Close()
+
+	// Redirects must not downgrade requests to plain http.
+	if response.Request != nil && response.Request.URL.Scheme != httpsScheme && !h.allowInsecure {
+		return nil, nil, errors.Errorf("failed to get %q: redirected to %q, which does not use https", rawURL, response.Request.URL.Redacted())
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		if etag != "" {
+			return nil, nil, errNotModified
+		}
+		return nil, nil, errors.Errorf("failed to get %q, got %d", rawURL, response.StatusCode)
+	case http.StatusNotFound:
+		return nil, nil, errNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, nil, errors.Errorf("failed to get %q: unauthorized access", rawURL)
+	default:
+		return nil, nil, errors.Errorf("failed to get %q, got %d", rawURL, response.StatusCode)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read %q", rawURL)
+	}
+	return content, response.Header, nil
+}
+
+func httpRepositoryChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func writeHTTPRepositoryCacheFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_httpRepository_newHTTPRepository(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		variables      map[string]string
+		wantIndexURL   string
+		wantVersion    string
+		wantComponents string
+		wantErr        bool
+	}{
+		{
+			name:           "can create a new HTTP repo",
+			url:            "https://artifacts.example.com/capi/infrastructure-foo/v1.0.0/infrastructure-components.yaml",
+			wantIndexURL:   "https://artifacts.example.com/capi/infrastructure-foo/index.yaml",
+			wantVersion:    "v1.0.0",
+			wantComponents: "infrastructure-components.yaml",
+		},
+		{
+			name:           "can create a new HTTP repo with plain http if insecure is allowed",
+			url:            "http://artifacts.example.com:8080/v1.0.0/infrastructure-components.yaml",
+			variables:      map[string]string{config.HTTPRepositoryAllowInsecureVariable: "true"},
+			wantIndexURL:   "http://artifacts.example.com:8080/index.yaml",
+			wantVersion:    "v1.0.0",
+			wantComponents: "infrastructure-components.yaml",
+		},
+		{
+			name:    "provider url with plain http if insecure is not allowed",
+			url:     "http://artifacts.example.com:8080/v1.0.0/infrastructure-components.yaml",
+			wantErr: true,
+		},
+		{
+			name:      "provider url with plain http if insecure is explicitly not allowed",
+			url:       "http://artifacts.example.com:8080/v1.0.0/infrastructure-components.yaml",
+			variables: map[string]string{config.HTTPRepositoryAllowInsecureVariable: "false"},
+			wantErr:   true,
+		},
+		{
+			name:    "provider url without version",
+			url:     "https://artifacts.example.com/infrastructure-components.yaml",
+			wantErr: true,
+		},
+		{
+			name:    "provider url with wrong scheme",
+			url:     "ftp://artifacts.example.com/capi/v1.0.0/infrastructure-components.yaml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			configVariablesClient := test.NewFakeVariableClient()
+			for k, v := range tt.variables {
+				configVariablesClient.WithVar(k, v)
+			}
+
+			repo, err := NewHTTPRepository(context.Background(), config.NewProvider("test", tt.url, clusterctlv1.InfrastructureProviderType), configVariablesClient)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			httpRepo := repo.(*httpRepository)
+			g.Expect(httpRepo.indexURL.String()).To(Equal(tt.wantIndexURL))
+			g.Expect(httpRepo.DefaultVersion()).To(Equal(tt.wantVersion))
+			g.Expect(httpRepo.RootPath()).To(Equal("."))
+			g.Expect(httpRepo.ComponentsPath()).To(Equal(tt.wantComponents))
+		})
+	}
+}
+
+func Test_httpRepository(t *testing.T) {
+	components := map[string]string{
+		"/capi/infra/v1.0.0/infrastructure-components.yaml": "components-v1.0.0",
+		"/files/components-v1.1.0.yaml":                     "components-v1.1.0",
+		"/capi/infra/v1.0.0/metadata.yaml":                  "metadata-v1.0.0",
+		"/capi/infra/v1.1.0/metadata.yaml":                  "metadata-v1.1.0",
+	}
+	index := fmt.Sprintf(`versions:
+- version: v1.0.0
+  files:
+  - name: infrastructure-components.yaml
+    sha256: %s
+  - name: metadata.yaml
+    sha256: %s
+  - name: cluster-template.yaml
+    sha256: %s
+- version: v1.1.0
+  files:
+  - name: infrastructure-components.yaml
+    url: /files/components-v1.1.0.yaml
+    sha256: %s
+  - name: metadata.yaml
+    sha256: %s
+`,
+		httpRepositoryChecksum([]byte("components-v1.0.0")),
+		httpRepositoryChecksum([]byte("metadata-v1.0.0")),
+		httpRepositoryChecksum([]byte("template-v1.0.0")), // cluster-template.yaml is served with a different content.
+		httpRepositoryChecksum([]byte("components-v1.1.0")),
+		httpRepositoryChecksum([]byte("metadata-v1.1.0")),
+	)
+	components["/capi/infra/v1.0.0/cluster-template.yaml"] = "components-v1.0.0"
+
+	var indexRequests, indexDownloads, fileDownloads atomic.Int32
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/capi/infra/index.yaml" {
+			indexRequests.Add(1)
+			if r.Header.Get("If-None-Match") == `"index-etag"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			indexDownloads.Add(1)
+			w.Header().Set("ETag", `"index-etag"`)
+			fmt.Fprint(w, index)
+			return
+		}
+		if content, ok := components[r.URL.Path]; ok {
+			fileDownloads.Add(1)
+			fmt.Fprint(w, content)
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	cacheDir := t.TempDir()
+	newRepo := func(g *WithT, version string) Repository {
+		resetCaches()
+		providerURL := fmt.Sprintf("%s/capi/infra/%s/infrastructure-components.yaml", server.URL, version)
+		repo, err := NewHTTPRepository(context.Background(), config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType), test.NewFakeVariableClient(),
+			injectHTTPRepositoryClient(server.Client()), injectHTTPRepositoryCacheDir(cacheDir))
+		g.Expect(err).ToNot(HaveOccurred())
+		return repo
+	}
+
+	t.Run("gets versions and files", func(t *testing.T) {
+		g := NewWithT(t)
+
+		repo := newRepo(g, "v1.0.0")
+		versions, err := repo.GetVersions(context.Background())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(versions).To(Equal([]string{"v1.0.0", "v1.1.0"}))
+
+		content, err := repo.GetFile(context.Background(), "v1.0.0", "infrastructure-components.yaml")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(content)).To(Equal("components-v1.0.0"))
+
+		content, err = repo.GetFile(context.Background(), "v1.1.0", "infrastructure-components.yaml")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(content)).To(Equal("components-v1.1.0"))
+	})
+
+	t.Run("resolves latest", func(t *testing.T) {
+		g := NewWithT(t)
+
+		repo := newRepo(g, "latest")
+		g.Expect(repo.DefaultVersion()).To(Equal("v1.1.0"))
+	})
+
+	t.Run("uses the cached index and files if not modified", func(t *testing.T) {
+		g := NewWithT(t)
+
+		indexRequests.Store(0)
+		indexDownloads.Store(0)
+		fileDownloads.Store(0)
+
+		repo := newRepo(g, "v1.0.0")
+		content, err := repo.GetFile(context.Background(), "v1.0.0", "infrastructure-components.yaml")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(content)).To(Equal("components-v1.0.0"))
+
+		g.Expect(indexRequests.Load()).To(Equal(int32(1)))
+		g.Expect(indexDownloads.Load()).To(Equal(int32(0)))
+		g.Expect(fileDownloads.Load()).To(Equal(int32(0)))
+	})
+
+	t.Run("fails if a file does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		repo := newRepo(g, "v1.0.0")
+		_, err := repo.GetFile(context.Background(), "v1.1.0", "cluster-template.yaml")
+		g.Expect(err).To(MatchError(errNotFound))
+	})
+
+	t.Run("fails if the checksum does not match", func(t *testing.T) {
+		g := NewWithT(t)
+
+		repo := newRepo(g, "v1.0.0")
+		_, err := repo.GetFile(context.Background(), "v1.0.0", "cluster-template.yaml")
+		g.Expect(err).To(MatchError(ContainSubstring("does not match the index")))
+	})
+}
+
+func Test_httpRepository_indexValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		sha256  string
+		url     string
+		wantErr string
+	}{
+		{
+			name:   "accepts a lowercase checksum",
+			sha256: httpRepositoryChecksum([]byte("components")),
+		},
+		{
+			name:   "accepts an uppercase checksum",
+			sha256: strings.ToUpper(httpRepositoryChecksum([]byte("components"))),
+		},
+		{
+			name:    "rejects a short checksum",
+			sha256:  "9f86d081884c7d659a2feaa0c55ad015",
+			wantErr: "must be 64 hex characters",
+		},
+		{
+			name:    "rejects a checksum which is not hex",
+			sha256:  strings.Repeat("g", 64),
+			wantErr: "must be 64 hex characters",
+		},
+		{
+			name:    "rejects a checksum with a path",
+			sha256:  "../../" + httpRepositoryChecksum([]byte("components"))[6:],
+			wantErr: "must be 64 hex characters",
+		},
+		{
+			name:    "rejects a file url with plain http",
+			sha256:  httpRepositoryChecksum([]byte("components")),
+			url:     "http://artifacts.example.com/infrastructure-components.yaml",
+			wantErr: "must use https",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mux := http.NewServeMux()
+			server := httptest.NewTLSServer(mux)
+			defer server.Close()
+			mux.HandleFunc("/capi/infra/index.yaml", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprintf(w, "versions:\n- version: v1.0.0\n  files:\n  - name: infrastructure-components.yaml\n    url: %q\n    sha256: %s\n", tt.url, tt.sha256)
+			})
+			mux.HandleFunc("/capi/infra/v1.0.0/infrastructure-components.yaml", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, "components")
+			})
+
+			resetCaches()
+			providerURL := fmt.Sprintf("%s/capi/infra/v1.0.0/infrastructure-components.yaml", server.URL)
+			repo, err := NewHTTPRepository(context.Background(), config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType), test.NewFakeVariableClient(),
+				injectHTTPRepositoryClient(server.Client()), injectHTTPRepositoryCacheDir(t.TempDir()))
+			g.Expect(err).ToNot(HaveOccurred())
+
+			content, err := repo.GetFile(context.Background(), "v1.0.0", "infrastructure-components.yaml")
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(string(content)).To(Equal("components"))
+		})
+	}
+}
@@ -0,0 +1,424 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+const (
+	ociScheme = "oci"
+
+	// ociManifestMediaType is the media type of OCI image manifests.
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+
+	// ociTitleAnnotation is the annotation used to store the file name of a layer, as defined by the OCI image spec.
+	ociTitleAnnotation = "org.opencontainers.image.title"
+
+	// ociMaxTagPages is the maximum number of pages read when listing tags.
+	ociMaxTagPages = 100
+)
+
+// ociRepository provides support for providers hosted on an OCI registry.
+//
+// Each provider version is an OCI artifact tagged with the version, and each file of the provider, e.g. the
+// components YAML, metadata.yaml, cluster templates and ClusterClasses, is a layer of the artifact
+// annotated with org.opencontainers.image.title set to the file name; this is the layout used by
+// tools like oras, e.g. `oras push registry.example.com/capi/infrastructure-docker:v1.10.0 *.yaml`.
+// Repositories must use versioned tags, and "latest" is resolved to the latest semantic version tag.
+type ociRepository struct {
+	providerConfig        config.Provider
+	configVariablesClient config.VariablesClient
+	httpClient            *http.Client
+	registry              string
+	repository            string
+	defaultVersion        string
+	rootPath              string
+	componentsPath        string
+	username              string
+	password              string
+	token                 string
+}
+
+var _ Repository = &ociRepository{}
+
+type ociRepositoryOption func(*ociRepository)
+
+func injectOCIHTTPClient(c *http.Client) ociRepositoryOption {
+	return func(o *ociRepository) {
+		o.httpClient = c
+	}
+}
+
+// ociManifest is the subset of the OCI image manifest used by clusterctl.
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// ociDescriptor is the subset of the OCI content descriptor used by clusterctl.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// NewOCIRepository returns an ociRepository implementation.
+func NewOCIRepository(ctx context.Context, providerConfig config.Provider, configVariablesClient config.VariablesClient, opts ...ociRepositoryOption) (Repository, error) {
+	if configVariablesClient == nil {
+		return nil, errors.New("invalid arguments: configVariablesClient can't be nil")
+	}
+
+	rURL, err := url.Parse(providerConfig.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	// Check if the path is in the expected format.
+	urlSplit := strings.Split(strings.TrimPrefix(rURL.Path, "/"), "/")
+	if rURL.Scheme != ociScheme || rURL.Host == "" || len(urlSplit) < 3 || urlSplit[0] == "" {
+		return nil, errors.New("invalid url: an OCI repository url should be in the form oci://{registry}/{repository}/{latest|version-tag}/{componentsClient.yaml}")
+	}
+
+	// Extract all the info from url split.
+	repository := strings.Join(urlSplit[:len(urlSplit)-2], "/")
+	defaultVersion := urlSplit[len(urlSplit)-2]
+	path := urlSplit[len(urlSplit)-1]
+
+	repo := &ociRepository{
+		providerConfig:        providerConfig,
+		configVariablesClient: configVariablesClient,
+		httpClient:            http.DefaultClient,
+		registry:              rURL.Host,
+		repository:            repository,
+		defaultVersion:        defaultVersion,
+		rootPath:              ".",
+		componentsPath:        path,
+	}
+
+	// Process ociRepositoryOptions.
+	for _, o := range opts {
+		o(repo)
+	}
+
+	if username, err := configVariablesClient.Get(config.OCIUsernameVariable); err == nil {
+		repo.username = username
+	}
+	if password, err := configVariablesClient.Get(config.OCIPasswordVariable); err == nil {
+		repo.password = password
+	}
+
+	if defaultVersion == latestVersionTag {
+		repo.defaultVersion, err = latestContractRelease(ctx, repo, clusterv1.GroupVersion.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get latest release")
+		}
+	}
+
+	return repo, nil
+}
+
+// DefaultVersion returns defaultVersion field of ociRepository struct.
+func (o *ociRepository) DefaultVersion() string {
+	return o.defaultVersion
+}
+
+// RootPath returns rootPath field of ociRepository struct.
+func (o *ociRepository) RootPath() string {
+	return o.rootPath
+}
+
+// ComponentsPath returns componentsPath field of ociRepository struct.
+func (o *ociRepository) ComponentsPath() string {
+	return o.componentsPath
+}
+
+// GetVersions returns the list of versions that are available in a provider repository, i.e. the
+// tags of the repository which are valid semantic versions.
+func (o *ociRepository) GetVersions(ctx context.Context) ([]string, error) {
+	cacheID := fmt.Sprintf("%s://%s/%s", ociScheme, o.registry, o.repository)
+	if versions, ok := cacheVersions[cacheID]; ok {
+		return versions, nil
+	}
+
+	versions := []string{}
+	tagsURL := fmt.Sprintf("https://%s/v2/%s/tags/list", o.registry, o.repository)
+	for range ociMaxTagPages {
+		content, header, err := o.get(ctx, tagsURL, "application/json")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get tags for %s/%s", o.registry, o.repository)
+		}
+
+		tags := struct {
+			Tags []string `json:"tags"`
+		}{}
+		if err := json.Unmarshal(content, &tags); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse tags for %s/%s", o.registry, o.repository)
+		}
+		for _, tag := range tags.Tags {
+			if _, err := version.ParseSemantic(tag); err != nil {
+				continue
+			}
+			versions = append(versions, tag)
+		}
+
+		// Follow pagination, if any.
+		next := nextPageURL(header.Get("Link"))
+		if next == "" {
+			break
+		}
+		nextURL, err := url.Parse(tagsURL)
+		if err != nil {
+			return nil, err
+		}
+		if nextURL, err = nextURL.Parse(next); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse next page url %q", next)
+		}
+		tagsURL = nextURL.String()
+	}
+
+	cacheVersions[cacheID] = versions
+	return versions, nil
+}
+
+// GetFile returns a file for a given provider version.
+func (o *ociRepository) GetFile(ctx context.Context, version, path string) ([]byte, error) {
+	log := logf.Log
+
+	cacheID := fmt.Sprintf("%s://%s/%s:%s/%s", ociScheme, o.registry, o.repository, version, path)
+	if content, ok := cacheFiles[cacheID]; ok {
+		return content, nil
+	}
+
+	// Get the manifest of the artifact for the given version.
+	content, _, err := o.get(ctx, fmt.Sprintf("https://%s/v2/%s/manifests/%s", o.registry, o.repository, version), ociManifestMediaType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get manifest for %s/%s:%s", o.registry, o.repository, version)
+	}
+	manifest := &ociManifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse manifest for %s/%s:%s", o.registry, o.repository, version)
+	}
+
+	// Search for the layer containing the file.
+	var layer *ociDescriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].Annotations[ociTitleAnnotation] == filepath.Base(path) {
+			layer = &manifest.Layers[i]
+			break
+		}
+	}
+	if layer == nil {
+		return nil, errors.Wrapf(errNotFound, "failed to get file %q from %s/%s:%s: file not found in the artifact", path, o.registry, o.repository, version)
+	}
+
+	log.V(5).Info("Fetching", "file", path, "registry", o.registry, "repository", o.repository, "version", version, "digest", layer.Digest)
+	content, _, err = o.get(ctx, fmt.Sprintf("https://%s/v2/%s/blobs/%s", o.registry, o.repository, layer.Digest), "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q from %s/%s:%s", path, o.registry, o.repository, version)
+	}
+
+	// Verify the content matches the digest in the manifest.
+	algorithm, expected, ok := strings.Cut(layer.Digest, ":")
+	if !ok || algorithm != "sha256" {
+		return nil, errors.Errorf("failed to get file %q from %s/%s:%s: unsupported digest %q", path, o.registry, o.repository, version, layer.Digest)
+	}
+	if sum := sha256.Sum256(content); hex.EncodeToString(sum[:]) != expected {
+		return nil, errors.Errorf("failed to get file %q from %s/%s:%s: content does not match digest %s", path, o.registry, o.repository, version, layer.Digest)
+	}
+
+	cacheFiles[cacheID] = content
+	return content, nil
+}
+
+// get performs a GET request to the registry, taking care of authentication.
+// Note: If the registry requires a token, the token is requested using the credentials from the clusterctl config,
+// if any, and then reused for the following requests.
+func (o *ociRepository) get(ctx context.Context, rawURL, accept string) ([]byte, http.Header, error) {
+	response, err := o.do(ctx, rawURL, accept)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusUnauthorized {
+		if err := o.authenticate(ctx, response.Header.Get("WWW-Authenticate")); err != nil {
+			return nil, nil, err
+		}
+
+		// Retry the request after authentication.
+		response, err = o.do(ctx, rawURL, accept)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer response.Body.Close()
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil, errNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, nil, errors.Errorf("unauthorized access to %q, please check your credentials", rawURL)
+	default:
+		return nil, nil, errors.Errorf("failed to get %q, got %d", rawURL, response.StatusCode)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read %q", rawURL)
+	}
+	return content, response.Header, nil
+}
+
+func (o *ociRepository) do(ctx context.Context, rawURL, accept string) (*http.Response, error) {
+	timeoutctx, cancel := context.WithTimeoutCause(ctx, 30*time.Second, errors.New("http request timeout expired"))
+	request, err := http.NewRequestWithContext(timeoutctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "failed to create request for %q", rawURL)
+	}
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	switch {
+	case o.token != "":
+		request.Header.Set("Authorization", "Bearer "+o.token)
+	case o.username != "" || o.password != "":
+		request.SetBasicAuth(o.username, o.password)
+	}
+
+	response, err := o.httpClient.Do(request)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "failed to get %q", rawURL)
+	}
+	response.Body = &cancelOnCloseReader{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// authenticate gets a token from the registry's token service according to the challenge in the WWW-Authenticate header.
+func (o *ociRepository) authenticate(ctx context.Context, challenge string) error {
+	scheme, params := parseAuthChallenge(challenge)
+	if !strings.EqualFold(scheme, "Bearer") {
+		// For Basic authentication, credentials are already sent with every request.
+		return errors.Errorf("unauthorized access to %s/%s, please check your credentials", o.registry, o.repository)
+	}
+
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return errors.Errorf("invalid token realm %q", params["realm"])
+	}
+	query := tokenURL.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", o.repository)
+	}
+	query.Set("scope", scope)
+	tokenURL.RawQuery = query.Encode()
+
+	// Get the token using credentials, if any.
+	o.token = ""
+	response, err := o.do(ctx, tokenURL.String(), "application/json")
+	if err != nil {
+		return errors.Wrap(err, "failed to get registry token")
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to get registry token from %q, got %d: please check your credentials", tokenURL.Redacted(), response.StatusCode)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return errors.Wrap(err, "failed to parse registry token")
+	}
+	o.token = token.Token
+	if o.token == "" {
+		o.token = token.AccessToken
+	}
+	if o.token == "" {
+		return errors.New("failed to get registry token: token is empty")
+	}
+	return nil
+}
+
+// parseAuthChallenge parses a WWW-Authenticate header, e.g. Bearer realm="https://auth.example.com/token",service="registry.example.com".
+func parseAuthChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key = strings.TrimSpace(key); key != "" {
+			params[strings.ToLower(key)] = value
+		}
+	}
+	return scheme, params
+}
+
+// nextPageURL returns the url of the next page from a Link header, e.g. </v2/foo/tags/list?n=100&last=v1.0.0>; rel="next".
+func nextPageURL(link string) string {
+	if link == "" || !strings.Contains(link, `rel="next"`) {
+		return ""
+	}
+	start := strings.Index(link, "<")
+	end := strings.Index(link, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return link[start+1 : end]
+}
+
+// cancelOnCloseReader cancels the context of a request when the response body is closed.
+type cancelOnCloseReader struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelOnCloseReader) Close() error {
+	defer r.cancel()
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_ociRepository_newOCIRepository(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		wantRegistry   string
+		wantRepository string
+		wantVersion    string
+		wantComponents string
+		wantErr        bool
+	}{
+		{
+			name:           "can create a new OCI repo",
+			url:            "oci://registry.example.com/capi/infrastructure-docker/v1.0.0/infrastructure-components.yaml",
+			wantRegistry:   "registry.example.com",
+			wantRepository: "capi/infrastructure-docker",
+			wantVersion:    "v1.0.0",
+			wantComponents: "infrastructure-components.yaml",
+		},
+		{
+			name:           "can create a new OCI repo with a registry port",
+			url:            "oci://localhost:5000/infrastructure-docker/v1.0.0/infrastructure-components.yaml",
+			wantRegistry:   "localhost:5000",
+			wantRepository: "infrastructure-docker",
+			wantVersion:    "v1.0.0",
+			wantComponents: "infrastructure-components.yaml",
+		},
+		{
+			name:    "provider url without repository",
+			url:     "oci://registry.example.com/v1.0.0/infrastructure-components.yaml",
+			wantErr: true,
+		},
+		{
+			name:    "provider url with wrong scheme",
+			url:     "https://registry.example.com/capi/infrastructure-docker/v1.0.0/infrastructure-components.yaml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			repo, err := NewOCIRepository(context.Background(), config.NewProvider("test", tt.url, clusterctlv1.InfrastructureProviderType), test.NewFakeVariableClient())
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			ociRepo := repo.(*ociRepository)
+			g.Expect(ociRepo.registry).To(Equal(tt.wantRegistry))
+			g.Expect(ociRepo.repository).To(Equal(tt.wantRepository))
+			g.Expect(ociRepo.DefaultVersion()).To(Equal(tt.wantVersion))
+			g.Expect(ociRepo.RootPath()).To(Equal("."))
+			g.Expect(ociRepo.ComponentsPath()).To(Equal(tt.wantComponents))
+		})
+	}
+}
+
+func Test_ociRepository(t *testing.T) {
+	server := newFakeOCIRegistry(t, "capi/infrastructure-docker", map[string]map[string]string{
+		"v1.0.0": {
+			"infrastructure-components.yaml": "components-v1.0.0",
+			"metadata.yaml":                  "metadata-v1.0.0",
+		},
+		"v1.1.0": {
+			"infrastructure-components.yaml": "components-v1.1.0",
+			"metadata.yaml":                  "metadata-v1.1.0",
+		},
+	})
+	defer server.Close()
+
+	registry := strings.TrimPrefix(server.URL, "https://")
+
+	tests := []struct {
+		name           string
+		version        string
+		username       string
+		password       string
+		file           string
+		wantVersion    string
+		wantContent    string
+		wantErr        bool
+		wantNotFound   bool
+		wantAllVersion []string
+	}{
+		{
+			name:           "gets a file from a version",
+			version:        "v1.0.0",
+			username:       "user",
+			password:       "password",
+			file:           "infrastructure-components.yaml",
+			wantVersion:    "v1.0.0",
+			wantContent:    "components-v1.0.0",
+			wantAllVersion: []string{"v1.0.0", "v1.1.0"},
+		},
+		{
+			name:           "resolves latest",
+			version:        "latest",
+			username:       "user",
+			password:       "password",
+			file:           "infrastructure-components.yaml",
+			wantVersion:    "v1.1.0",
+			wantContent:    "components-v1.1.0",
+			wantAllVersion: []string{"v1.0.0", "v1.1.0"},
+		},
+		{
+			name:         "fails to get a file which does not exist",
+			version:      "v1.0.0",
+			username:     "user",
+			password:     "password",
+			file:         "cluster-template.yaml",
+			wantVersion:  "v1.0.0",
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:        "fails to get a file with wrong credentials",
+			version:     "v1.0.0",
+			username:    "user",
+			password:    "wrong",
+			file:        "infrastructure-components.yaml",
+			wantVersion: "v1.0.0",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			providerURL := fmt.Sprintf("oci://%s/capi/infrastructure-docker/%s/infrastructure-components.yaml", registry, tt.version)
+			variables := test.NewFakeVariableClient().
+				WithVar(config.OCIUsernameVariable, tt.username).
+				WithVar(config.OCIPasswordVariable, tt.password)
+
+			repo, err := NewOCIRepository(context.Background(), config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType), variables, injectOCIHTTPClient(server.Client()))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(repo.DefaultVersion()).To(Equal(tt.wantVersion))
+
+			got, err := repo.GetFile(context.Background(), repo.DefaultVersion(), tt.file)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				if tt.wantNotFound {
+					g.Expect(err).To(MatchError(errNotFound))
+				}
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(string(got)).To(Equal(tt.wantContent))
+
+			versions, err := repo.GetVersions(context.Background())
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(versions).To(ConsistOf(tt.wantAllVersion))
+		})
+	}
+}
+
+func Test_parseAuthChallenge(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, params := parseAuthChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:foo:pull"`)
+	g.Expect(scheme).To(Equal("Bearer"))
+	g.Expect(params).To(Equal(map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:foo:pull",
+	}))
+}
+
+// newFakeOCIRegistry returns a fake OCI registry serving a repository with an artifact for each version;
+// the registry requires a token, issued using the user/password credentials.
+func newFakeOCIRegistry(t *testing.T, repository string, artifacts map[string]map[string]string) *httptest.Server {
+	t.Helper()
+
+	blobs := map[string]string{}
+	manifests := map[string][]byte{}
+	tags := []string{"latest-build"}
+	for version, files := range artifacts {
+		manifest := ociManifest{MediaType: ociManifestMediaType}
+		for name, content := range files {
+			sum := sha256.Sum256([]byte(content))
+			digest := "sha256:" + hex.EncodeToString(sum[:])
+			blobs[digest] = content
+			manifest.Layers = append(manifest.Layers, ociDescriptor{
+				MediaType:   "application/vnd.oci.image.layer.v1.tar",
+				Digest:      digest,
+				Size:        int64(len(content)),
+				Annotations: map[string]string{ociTitleAnnotation: name},
+			})
+		}
+		manifestContent, err := json.Marshal(manifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifests[version] = manifestContent
+		tags = append(tags, version)
+	}
+
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"token":"test-token"}`)
+	})
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake-registry"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		prefix := "/v2/" + repository + "/"
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			http.NotFound(w, r)
+			return
+		}
+		switch path := strings.TrimPrefix(r.URL.Path, prefix); {
+		case path == "tags/list":
+			content, _ := json.Marshal(map[string]interface{}{"name": repository, "tags": tags})
+			_, _ = w.Write(content)
+		case strings.HasPrefix(path, "manifests/"):
+			manifest, ok := manifests[strings.TrimPrefix(path, "manifests/")]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", ociManifestMediaType)
+			_, _ = w.Write(manifest)
+		case strings.HasPrefix(path, "blobs/"):
+			blob, ok := blobs[strings.TrimPrefix(path, "blobs/")]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprint(w, blob)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	return server
+}
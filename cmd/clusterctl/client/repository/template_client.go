@@ -95,6 +95,10 @@ func (c *templateClient) Get(ctx context.Context, flavor, targetNamespace string
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to read %q from provider's repository %q", name, c.provider.ManifestLabel())
 		}
+
+		if err := verifyFile(ctx, c.provider, c.repository, version, name, rawArtifact); err != nil {
+			return nil, err
+		}
 	} else {
 		log.V(1).Info("Using", "override", name, "provider", c.provider.ManifestLabel(), "version", version)
 	}
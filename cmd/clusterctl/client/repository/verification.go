@@ -0,0 +1,252 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+const (
+	// signatureSuffix is the suffix of a file containing the base64 encoded signature of the file with the same name.
+	signatureSuffix = ".sig"
+
+	// bundleSuffix is the suffix of a file containing the cosign bundle of the file with the same name.
+	bundleSuffix = ".bundle"
+)
+
+// errSignatureNotFound is returned when neither the signature nor the cosign bundle of a file can be read.
+var errSignatureNotFound = errors.New("signature not found")
+
+// verifyFile verifies the integrity of a file read from a provider repository according to the verification
+// policy of the provider; it is used for all the files read from a provider repository, i.e. the components YAML,
+// the metadata.yaml file, the cluster templates and the ClusterClass templates.
+//
+// If a checksum file is configured, the file checksum is verified against it, and the signature, if a public key
+// is configured, is verified against the checksum file; otherwise the signature is verified against the file itself.
+// If verification is not required, it is skipped only when the checksum file or the signature can't be read, while
+// it fails if a signature is published but it can't be verified, e.g. a cosign bundle with a keyless signature.
+// Note: metadata.yaml files read only to pick the latest release of a provider are not verified,
+// while the metadata.yaml file of the release being used is.
+func verifyFile(ctx context.Context, provider config.Provider, repository Repository, version, path string, content []byte) error {
+	log := logf.Log
+
+	policy := config.GetProviderVerification(provider)
+	if policy == nil || (policy.ChecksumFile == "" && policy.PublicKey == "") {
+		return nil
+	}
+
+	signedFile, signedContent := path, content
+	if policy.ChecksumFile != "" {
+		checksums, err := repository.GetFile(ctx, version, policy.ChecksumFile)
+		switch {
+		case err != nil && !policy.Required:
+			log.Info("Skipping checksum verification, failed to read the checksum file", "file", path, "checksumFile", policy.ChecksumFile, "provider", provider.ManifestLabel(), "version", version, "error", err.Error())
+			return nil
+		case err != nil:
+			return errors.Wrapf(err, "failed to read checksum file %q from provider's repository %q", policy.ChecksumFile, provider.ManifestLabel())
+		}
+
+		if err := verifyChecksum(checksums, path, content); err != nil {
+			return errors.Wrapf(err, "failed to verify %q from provider's repository %q using checksum file %q", path, provider.ManifestLabel(), policy.ChecksumFile)
+		}
+		log.V(5).Info("Verified checksum", "file", path, "checksumFile", policy.ChecksumFile, "provider", provider.ManifestLabel(), "version", version)
+		signedFile, signedContent = policy.ChecksumFile, checksums
+	}
+
+	if policy.PublicKey == "" {
+		return nil
+	}
+
+	publicKey, err := parsePublicKey(policy.PublicKey)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the public key for provider %q", provider.ManifestLabel())
+	}
+
+	signature, err := getSignature(ctx, repository, version, signedFile)
+	switch {
+	case errors.Is(err, errSignatureNotFound) && !policy.Required:
+		log.Info("Skipping signature verification, failed to read the signature", "file", signedFile, "provider", provider.ManifestLabel(), "version", version, "error", err.Error())
+		return nil
+	case err != nil:
+		return errors.Wrapf(err, "failed to read the signature of %q from provider's repository %q", signedFile, provider.ManifestLabel())
+	}
+
+	if err := verifySignature(publicKey, signedContent, signature); err != nil {
+		return errors.Wrapf(err, "failed to verify the signature of %q from provider's repository %q", signedFile, provider.ManifestLabel())
+	}
+	log.V(5).Info("Verified signature", "file", signedFile, "provider", provider.ManifestLabel(), "version", version)
+	return nil
+}
+
+// verifyChecksum verifies the SHA256 checksum of a file against a checksum file in the format generated by sha256sum.
+func verifyChecksum(checksums []byte, path string, content []byte) error {
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		// sha256sum prefixes the file name with * when using binary mode.
+		if strings.TrimPrefix(fields[1], "*") != path {
+			continue
+		}
+		if !strings.EqualFold(fields[0], checksum) {
+			return errors.Errorf("checksum %s does not match the checksum file, expected %s", checksum, fields[0])
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "failed to read the checksum file")
+	}
+	return errors.Errorf("checksum of %q not found in the checksum file", path)
+}
+
+// getSignature returns the detached signature of a file, reading either the base64 encoded signature
+// or, if it does not exist, the cosign bundle published next to it.
+func getSignature(ctx context.Context, repository Repository, version, path string) ([]byte, error) {
+	content, sigErr := repository.GetFile(ctx, version, path+signatureSuffix)
+	if sigErr == nil {
+		signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode %q", path+signatureSuffix)
+		}
+		return signature, nil
+	}
+
+	content, err := repository.GetFile(ctx, version, path+bundleSuffix)
+	if err != nil {
+		return nil, errors.Wrapf(errSignatureNotFound, "neither %q nor %q can be read: %v; %v", path+signatureSuffix, path+bundleSuffix, sigErr, err)
+	}
+	signature, err := parseBundleSignature(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %q", path+bundleSuffix)
+	}
+	return signature, nil
+}
+
+// parseBundleSignature returns the signature from a cosign bundle; both the format generated by cosign sign-blob --bundle
+// and the sigstore bundle format are supported.
+// Note: Only bundles with a signature generated with a key are supported, and the signature is verified with the public
+// key configured for the provider; bundles with a keyless signature, i.e. with a certificate instead of a public key,
+// are rejected, because the certificate chain is not verified. Transparency log entries in the bundle, if any,
+// are not verified, so there is no guarantee that the signature has been recorded in a transparency log.
+func parseBundleSignature(content []byte) ([]byte, error) {
+	bundle := struct {
+		Base64Signature  string `json:"base64Signature"`
+		Cert             string `json:"cert"`
+		MessageSignature *struct {
+			Signature string `json:"signature"`
+		} `json:"messageSignature"`
+		VerificationMaterial *struct {
+			PublicKey *json.RawMessage `json:"publicKey"`
+		} `json:"verificationMaterial"`
+	}{}
+	if err := json.Unmarshal(content, &bundle); err != nil {
+		return nil, err
+	}
+
+	var encoded string
+	switch {
+	case bundle.Base64Signature != "":
+		// Note: cosign sign-blob stores in cert either the certificate of a keyless signature or the public key.
+		if bundle.Cert != "" {
+			cert, err := base64.StdEncoding.DecodeString(bundle.Cert)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to decode the certificate in the bundle")
+			}
+			if block, _ := pem.Decode(cert); block == nil || block.Type != "PUBLIC KEY" {
+				return nil, errors.New("bundle contains a keyless signature, only signatures generated with a key are supported")
+			}
+		}
+		encoded = bundle.Base64Signature
+	case bundle.MessageSignature != nil && bundle.MessageSignature.Signature != "":
+		if bundle.VerificationMaterial == nil || bundle.VerificationMaterial.PublicKey == nil {
+			return nil, errors.New("bundle contains a keyless signature, only signatures generated with a key are supported")
+		}
+		encoded = bundle.MessageSignature.Signature
+	default:
+		return nil, errors.New("signature not found in the bundle")
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+// parsePublicKey parses a PEM encoded public key, or reads it from a file if the value is not a PEM block.
+func parsePublicKey(value string) (crypto.PublicKey, error) {
+	content := []byte(value)
+	if !strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		var err error
+		content, err = os.ReadFile(value) //nolint:gosec // The path is provided by the user in the clusterctl config.
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("invalid public key: PEM block not found")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	return publicKey, nil
+}
+
+// verifySignature verifies a signature generated by cosign sign-blob with ECDSA, RSA (PKCS #1 v1.5) or Ed25519 keys.
+func verifySignature(publicKey crypto.PublicKey, content, signature []byte) error {
+	digest := sha256.Sum256(content)
+
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, content, signature) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return errors.Errorf("unsupported public key type %T", publicKey)
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	yaml "sigs.k8s.io/cluster-api/cmd/clusterctl/client/yamlprocessor"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+// verificationProvider is a config.Provider with a verification policy.
+type verificationProvider struct {
+	config.Provider
+	verification *config.ProviderVerification
+}
+
+func (p *verificationProvider) Verification() *config.ProviderVerification {
+	return p.verification
+}
+
+func Test_verifyFile(t *testing.T) {
+	g := NewWithT(t)
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ToNot(HaveOccurred())
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	g.Expect(err).ToNot(HaveOccurred())
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER}))
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	publicKeyFile := filepath.Join(t.TempDir(), "cosign.pub")
+	g.Expect(os.WriteFile(publicKeyFile, []byte(publicKey), 0o600)).To(Succeed())
+
+	sign := func(key *ecdsa.PrivateKey, content []byte) []byte {
+		digest := sha256.Sum256(content)
+		signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		g.Expect(err).ToNot(HaveOccurred())
+		return []byte(base64.StdEncoding.EncodeToString(signature))
+	}
+
+	components := []byte("components")
+	componentsSum := sha256.Sum256(components)
+	checksums := []byte(fmt.Sprintf("%s  metadata.yaml\n%s *components.yaml\n", hex.EncodeToString(componentsSum[:]), hex.EncodeToString(componentsSum[:])))
+	wrongChecksums := []byte(fmt.Sprintf("%s  components.yaml\n", hex.EncodeToString(make([]byte, 32))))
+
+	tests := []struct {
+		name         string
+		verification *config.ProviderVerification
+		files        map[string][]byte
+		wantErr      string
+	}{
+		{
+			name:  "pass without verification",
+			files: map[string][]byte{},
+		},
+		{
+			name:         "pass with a checksum file",
+			verification: &config.ProviderVerification{ChecksumFile: "checksums.txt"},
+			files: map[string][]byte{
+				"checksums.txt": checksums,
+			},
+		},
+		{
+			name:         "pass with a missing checksum file if verification is not required",
+			verification: &config.ProviderVerification{ChecksumFile: "checksums.txt"},
+			files:        map[string][]byte{},
+		},
+		{
+			name:         "fails with a missing checksum file if verification is required",
+			verification: &config.ProviderVerification{ChecksumFile: "checksums.txt", Required: true},
+			files:        map[string][]byte{},
+			wantErr:      "failed to read checksum file",
+		},
+		{
+			name:         "fails with a wrong checksum",
+			verification: &config.ProviderVerification{ChecksumFile: "checksums.txt"},
+			files: map[string][]byte{
+				"checksums.txt": wrongChecksums,
+			},
+			wantErr: "does not match the checksum file",
+		},
+		{
+			name:         "fails if the file is not listed in the checksum file",
+			verification: &config.ProviderVerification{ChecksumFile: "checksums.txt"},
+			files: map[string][]byte{
+				"checksums.txt": []byte("foo  bar.yaml\n"),
+			},
+			wantErr: "not found in the checksum file",
+		},
+		{
+			name:         "pass with a signature",
+			verification: &config.ProviderVerification{PublicKey: publicKey, Required: true},
+			files: map[string][]byte{
+				"components.yaml.sig": sign(privateKey, components),
+			},
+		},
+		{
+			name:         "pass with a public key file and a cosign bundle",
+			verification: &config.ProviderVerification{PublicKey: publicKeyFile, Required: true},
+			files: map[string][]byte{
+				"components.yaml.bundle": []byte(fmt.Sprintf(`{"base64Signature":%q}`, sign(privateKey, components))),
+			},
+		},
+		{
+			name:         "pass with a cosign bundle with a public key",
+			verification: &config.ProviderVerification{PublicKey: publicKey, Required: true},
+			files: map[string][]byte{
+				"components.yaml.bundle": []byte(fmt.Sprintf(`{"base64Signature":%q,"cert":%q}`, sign(privateKey, components), base64.StdEncoding.EncodeToString([]byte(publicKey)))),
+			},
+		},
+		{
+			name:         "pass with a sigstore bundle",
+			verification: &config.ProviderVerification{PublicKey: publicKey, Required: true},
+			files: map[string][]byte{
+				"components.yaml.bundle": []byte(fmt.Sprintf(`{"verificationMaterial":{"publicKey":{"hint":"foo"}},"messageSignature":{"signature":%q}}`, sign(privateKey, components))),
+			},
+		},
+		{
+			name:         "fails with a cosign bundle with a keyless signature",
+			verification: &config.ProviderVerification{PublicKey: publicKey},
+			files: map[string][]byte{
+				"components.yaml.bundle": []byte(fmt.Sprintf(`{"base64Signature":%q,"cert":%q}`, sign(privateKey, components), base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("foo")})))),
+			},
+			wantErr: "only signatures generated with a key are supported",
+		},
+		{
+			name:         "fails with a sigstore bundle with a keyless signature",
+			verification: &config.ProviderVerification{PublicKey: publicKey},
+			files: map[string][]byte{
+				"components.yaml.bundle": []byte(fmt.Sprintf(`{"verificationMaterial":{"certificate":{"rawBytes":"Zm9v"}},"messageSignature":{"signature":%q}}`, sign(privateKey, components))),
+			},
+			wantErr: "only signatures generated with a key are supported",
+		},
+		{
+			name:         "pass with a signed checksum file",
+			verification: &config.ProviderVerification{ChecksumFile: "checksums.txt", PublicKey: publicKey, Required: true},
+			files: map[string][]byte{
+				"checksums.txt":     checksums,
+				"checksums.txt.sig": sign(privateKey, checksums),
+			},
+		},
+		{
+			name:         "fails with a signature generated with another key",
+			verification: &config.ProviderVerification{PublicKey: publicKey},
+			files: map[string][]byte{
+				"components.yaml.sig": sign(otherKey, components),
+			},
+			wantErr: "invalid signature",
+		},
+		{
+			name:         "fails with a signature of another file",
+			verification: &config.ProviderVerification{ChecksumFile: "checksums.txt", PublicKey: publicKey},
+			files: map[string][]byte{
+				"checksums.txt":     checksums,
+				"checksums.txt.sig": sign(privateKey, components),
+			},
+			wantErr: "invalid signature",
+		},
+		{
+			name:         "pass with a missing signature if verification is not required",
+			verification: &config.ProviderVerification{PublicKey: publicKey},
+			files:        map[string][]byte{},
+		},
+		{
+			name:         "fails with a missing signature if verification is required",
+			verification: &config.ProviderVerification{PublicKey: publicKey, Required: true},
+			files:        map[string][]byte{},
+			wantErr:      "failed to read the signature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			repository := NewMemoryRepository().
+				WithDefaultVersion("v1.0.0").
+				WithFile("v1.0.0", "components.yaml", components)
+			for path, content := range tt.files {
+				repository.WithFile("v1.0.0", path, content)
+			}
+			provider := &verificationProvider{
+				Provider:     config.NewProvider("p1", "", clusterctlv1.InfrastructureProviderType),
+				verification: tt.verification,
+			}
+
+			err := verifyFile(context.Background(), provider, repository, "v1.0.0", "components.yaml", components)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func Test_verifyProviderFiles(t *testing.T) {
+	metadata := []byte("apiVersion: clusterctl.cluster.x-k8s.io/v1alpha3\nkind: Metadata\nreleaseSeries:\n- major: 1\n  minor: 0\n  contract: v1beta2\n")
+	template := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\n")
+
+	newChecksums := func(files map[string][]byte) []byte {
+		checksums := ""
+		for path, content := range files {
+			sum := sha256.Sum256(content)
+			checksums += fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), path)
+		}
+		return []byte(checksums)
+	}
+	validChecksums := newChecksums(map[string][]byte{
+		"metadata.yaml":         metadata,
+		"cluster-template.yaml": template,
+		"clusterclass-dev.yaml": template,
+	})
+	tamperedChecksums := newChecksums(map[string][]byte{
+		"metadata.yaml":         []byte("tampered"),
+		"cluster-template.yaml": []byte("tampered"),
+		"clusterclass-dev.yaml": []byte("tampered"),
+	})
+
+	getFiles := func(checksums []byte) map[string]error {
+		repository := NewMemoryRepository().
+			WithDefaultVersion("v1.0.0").
+			WithFile("v1.0.0", "metadata.yaml", metadata).
+			WithFile("v1.0.0", "cluster-template.yaml", template).
+			WithFile("v1.0.0", "clusterclass-dev.yaml", template).
+			WithFile("v1.0.0", "checksums.txt", checksums)
+		provider := &verificationProvider{
+			Provider:     config.NewProvider("p1", "", clusterctlv1.InfrastructureProviderType),
+			verification: &config.ProviderVerification{ChecksumFile: "checksums.txt", Required: true},
+		}
+		variables := test.NewFakeVariableClient()
+
+		errs := map[string]error{}
+		_, errs["metadata.yaml"] = newMetadataClient(provider, "v1.0.0", repository, variables).Get(context.Background())
+		_, errs["cluster-template.yaml"] = newTemplateClient(TemplateClientInput{
+			version:               "v1.0.0",
+			provider:              provider,
+			repository:            repository,
+			configVariablesClient: variables,
+			processor:             yaml.NewSimpleProcessor(),
+		}).Get(context.Background(), "", "ns1", true)
+		_, errs["clusterclass-dev.yaml"] = newClusterClassClient(ClusterClassClientInput{
+			version:               "v1.0.0",
+			provider:              provider,
+			repository:            repository,
+			configVariablesClient: variables,
+			processor:             yaml.NewSimpleProcessor(),
+		}).Get(context.Background(), "dev", "ns1", true)
+		return errs
+	}
+
+	t.Run("pass with valid checksums", func(t *testing.T) {
+		g := NewWithT(t)
+
+		for path, err := range getFiles(validChecksums) {
+			g.Expect(err).ToNot(HaveOccurred(), path)
+		}
+	})
+
+	t.Run("fails with wrong checksums", func(t *testing.T) {
+		g := NewWithT(t)
+
+		for path, err := range getFiles(tamperedChecksums) {
+			g.Expect(err).To(MatchError(ContainSubstring("does not match the checksum file")), path)
+		}
+	})
+}
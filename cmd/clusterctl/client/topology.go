@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+// TopologyPlanOptions carries the options supported by TopologyPlan.
+type TopologyPlanOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Offline computes the plan only from Objs, without accessing a management cluster.
+	Offline bool
+
+	// Objs are the Cluster, ClusterClass and template objects to compute the plan for.
+	Objs []*unstructured.Unstructured
+
+	// Cluster is the name of the Cluster to compute the plan for. If empty, the plan is computed
+	// for all the Clusters affected by Objs.
+	Cluster string
+
+	// Namespace used for Objs without a namespace and for the Cluster. If unspecified, the current namespace
+	// will be used, or the default namespace if Offline is set.
+	Namespace string
+}
+
+// TopologyPlan computes the changes the topology controller would apply to the Clusters affected by the
+// given Cluster, ClusterClass and template objects.
+func (c *clusterctlClient) TopologyPlan(ctx context.Context, options TopologyPlanOptions) (*TopologyPlanOutput, error) {
+	topologyClient := cluster.NewTopologyClient(nil)
+	if !options.Offline {
+		// gets access to the management cluster
+		clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+		if err != nil {
+			return nil, err
+		}
+
+		// Ensure this command only runs against management clusters with the current Cluster API contract.
+		if err := clusterClient.ProviderInventory().CheckCAPIContract(ctx); err != nil {
+			return nil, err
+		}
+
+		// If the option specifying the Namespace is empty, try to detect it.
+		if options.Namespace == "" {
+			currentNamespace, err := clusterClient.Proxy().CurrentNamespace()
+			if err != nil {
+				return nil, err
+			}
+			options.Namespace = currentNamespace
+		}
+		topologyClient = clusterClient.Topology()
+	}
+	if options.Namespace == "" {
+		options.Namespace = metav1.NamespaceDefault
+	}
+
+	out, err := topologyClient.Plan(ctx, &cluster.TopologyPlanInput{
+		Objs:              options.Objs,
+		TargetClusterName: options.Cluster,
+		TargetNamespace:   options.Namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return (*TopologyPlanOutput)(out), nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+func fakeClientForTopologyPlan() *fakeClient {
+	core := config.NewProvider("cluster-api", "https://somewhere.com", clusterctlv1.CoreProviderType)
+
+	ctx := context.Background()
+
+	config1 := newFakeConfig(ctx).
+		WithProvider(core)
+
+	cluster1 := newFakeCluster(cluster.Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}, config1).
+		WithProviderInventory(core.Name(), core.Type(), "v1.0.0", "capi-system").
+		WithObjs(fakeCAPISetupObjects()...)
+
+	return newFakeClient(ctx, config1).
+		WithCluster(cluster1)
+}
+
+func Test_clusterctlClient_TopologyPlan(t *testing.T) {
+	tests := []struct {
+		name         string
+		options      TopologyPlanOptions
+		wantClusters int
+		wantErr      bool
+	}{
+		{
+			name: "returns an empty plan if no Cluster is affected",
+			options: TopologyPlanOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+			},
+			wantClusters: 0,
+		},
+		{
+			name: "returns an empty plan if no Cluster is affected, offline",
+			options: TopologyPlanOptions{
+				Offline: true,
+			},
+			wantClusters: 0,
+		},
+		{
+			name: "fails if the Cluster does not exist",
+			options: TopologyPlanOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Cluster:    "my-cluster",
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if the Cluster does not exist, offline",
+			options: TopologyPlanOptions{
+				Offline: true,
+				Cluster: "my-cluster",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			out, err := fakeClientForTopologyPlan().TopologyPlan(ctx, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(out.Clusters).To(HaveLen(tt.wantClusters))
+		})
+	}
+}
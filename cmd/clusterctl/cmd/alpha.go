@@ -30,6 +30,7 @@ var alphaCmd = &cobra.Command{
 func init() {
 	// Alpha commands should be added here.
 	alphaCmd.AddCommand(rolloutCmd)
+	alphaCmd.AddCommand(topologyCmd)
 
 	RootCmd.AddCommand(alphaCmd)
 }
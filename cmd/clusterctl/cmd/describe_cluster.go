@@ -44,6 +44,7 @@ type describeClusterOptions struct {
 	grouping                bool
 	v1beta2                 bool
 	color                   bool
+	output                  string
 }
 
 var dc = &describeClusterOptions{}
@@ -72,7 +73,10 @@ var describeClusterClusterCmd = &cobra.Command{
 
 		# Describe the cluster named test-1 showing the MachineInfrastructure and BootstrapConfig objects
 		# also when their status is the same as the status of the corresponding machine object.
-		clusterctl describe cluster test-1 --echo`),
+		clusterctl describe cluster test-1 --echo
+
+		# Describe the cluster named test-1 in JSON format, e.g. for consumption by other tools.
+		clusterctl describe cluster test-1 -o json`),
 
 	Args: func(_ *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -111,6 +115,8 @@ func init() {
 	_ = describeClusterClusterCmd.Flags().MarkDeprecated("v1beta2",
 		"this field will be removed when v1beta1 will be dropped.")
 	describeClusterClusterCmd.Flags().BoolVarP(&dc.color, "color", "c", false, "Enable or disable color output; if not set color is enabled by default only if using tty. The flag is overridden by the NO_COLOR env variable if set.")
+	describeClusterClusterCmd.Flags().StringVarP(&dc.output, "output", "o", "",
+		"Output format; available options are 'yaml' and 'json'. If not set, the cluster is shown as a tree.")
 
 	// completions
 	describeClusterClusterCmd.ValidArgsFunction = resourceNameCompletionFunc(
@@ -125,6 +131,15 @@ func init() {
 }
 
 func runDescribeCluster(cmd *cobra.Command, name string) error {
+	switch dc.output {
+	case "", "yaml", "json":
+	default:
+		return errors.Errorf("invalid output format: %s", dc.output)
+	}
+	if dc.output != "" && !dc.v1beta2 {
+		return errors.New("--output can't be used with --v1beta2=false")
+	}
+
 	ctx := context.Background()
 
 	c, err := client.New(ctx, cfgFile)
@@ -153,8 +168,16 @@ func runDescribeCluster(cmd *cobra.Command, name string) error {
 		color.NoColor = !dc.color
 	}
 
-	switch dc.v1beta2 {
-	case true:
+	switch {
+	case dc.output == "json":
+		if err := cmdtree.PrintObjectTreeJSON(tree, os.Stdout); err != nil {
+			return errors.Wrap(err, "failed to print object tree")
+		}
+	case dc.output == "yaml":
+		if err := cmdtree.PrintObjectTreeYAML(tree, os.Stdout); err != nil {
+			return errors.Wrap(err, "failed to print object tree")
+		}
+	case dc.v1beta2:
 		if err := cmdtree.PrintObjectTree(tree, os.Stdout); err != nil {
 			return errors.Wrap(err, "failed to print object tree")
 		}
@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -32,6 +33,9 @@ type getKubeconfigOptions struct {
 	kubeconfig        string
 	kubeconfigContext string
 	namespace         string
+	user              string
+	groups            []string
+	ttl               time.Duration
 }
 
 var gk = &getKubeconfigOptions{}
@@ -40,14 +44,21 @@ var getKubeconfigCmd = &cobra.Command{
 	Use:   "kubeconfig NAME",
 	Short: "Gets the kubeconfig file for accessing a workload cluster",
 	Long: templates.LongDesc(`
-		Gets the kubeconfig file for accessing a workload cluster`),
+		Gets the kubeconfig file for accessing a workload cluster.
+
+		By default the admin kubeconfig of the workload cluster is returned; if --user is set, a short-lived
+		kubeconfig is issued for the user via a ClusterKubeconfigRequest.`),
 
 	Example: templates.Examples(`
 		# Get the workload cluster's kubeconfig.
 		clusterctl get kubeconfig <name of workload cluster>
 
 		# Get the workload cluster's kubeconfig in a particular namespace.
-		clusterctl get kubeconfig <name of workload cluster> --namespace foo`),
+		clusterctl get kubeconfig <name of workload cluster> --namespace foo
+
+		# Get a kubeconfig for the user alice, belonging to the developers group, valid for 8 hours.
+		# Note: This requires the ClusterKubeconfigRequest feature to be enabled.
+		clusterctl get kubeconfig <name of workload cluster> --user alice --group developers --ttl 8h`),
 
 	Args: func(_ *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -67,6 +78,12 @@ func init() {
 		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
 	getKubeconfigCmd.Flags().StringVar(&gk.kubeconfigContext, "kubeconfig-context", "",
 		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	getKubeconfigCmd.Flags().StringVar(&gk.user, "user", "",
+		"Name of the user to issue a kubeconfig for. If empty, the admin kubeconfig is returned.")
+	getKubeconfigCmd.Flags().StringArrayVar(&gk.groups, "group", nil,
+		"Group the user belongs to; can be repeated. Requires --user.")
+	getKubeconfigCmd.Flags().DurationVar(&gk.ttl, "ttl", 0,
+		"Validity of the kubeconfig issued for the user, e.g. 8h. If unspecified, defaults to 1h. Requires --user.")
 
 	// completions
 	getKubeconfigCmd.ValidArgsFunction = resourceNameCompletionFunc(
@@ -92,6 +109,9 @@ func runGetKubeconfig(workloadClusterName string) error {
 		Kubeconfig:          client.Kubeconfig{Path: gk.kubeconfig, Context: gk.kubeconfigContext},
 		WorkloadClusterName: workloadClusterName,
 		Namespace:           gk.namespace,
+		User:                gk.user,
+		Groups:              gk.groups,
+		TTL:                 gk.ttl,
 	}
 
 	out, err := c.GetKubeconfig(ctx, options)
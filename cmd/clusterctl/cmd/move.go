@@ -19,6 +19,8 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -40,6 +42,11 @@ type moveOptions struct {
 	namespace             string
 	fromDirectory         string
 	toDirectory           string
+	fromArchive           string
+	toArchive             string
+	archivePassphraseFile string
+	archiveAgeRecipients  []string
+	archiveAgeIdentity    string
 	dryRun                bool
 	hideAPIWarnings       string
 }
@@ -64,6 +71,21 @@ var moveCmd = &cobra.Command{
 
 		Read Cluster API objects and all dependencies from a directory into a management cluster.
 		clusterctl move --from-directory /tmp/backup-directory
+
+		Write Cluster API objects and all dependencies from a management cluster to a compressed archive, encrypting Secrets
+		with the passphrase stored in a file.
+		clusterctl move --to-archive /tmp/backup.tar.gz --archive-passphrase-file /tmp/passphrase
+
+		Verify the archive and read Cluster API objects and all dependencies from it into a management cluster.
+		clusterctl move --from-archive /tmp/backup.tar.gz --archive-passphrase-file /tmp/passphrase
+
+		Write Cluster API objects and all dependencies from a management cluster to a compressed archive, encrypting Secrets
+		with age for the given recipient.
+		clusterctl move --to-archive /tmp/backup.tar.gz --archive-age-recipient age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p
+
+		Verify the archive and read Cluster API objects and all dependencies from it into a management cluster, decrypting
+		Secrets with the age identity stored in a file.
+		clusterctl move --from-archive /tmp/backup.tar.gz --archive-age-identity-file /tmp/age-identity.txt
 	`),
 	Args: cobra.NoArgs,
 	RunE: func(*cobra.Command, []string) error {
@@ -88,12 +110,29 @@ func init() {
 		"Write Cluster API objects and all dependencies from a management cluster to directory.")
 	moveCmd.Flags().StringVar(&mo.fromDirectory, "from-directory", "",
 		"Read Cluster API objects and all dependencies from a directory into a management cluster.")
+	moveCmd.Flags().StringVar(&mo.toArchive, "to-archive", "",
+		"Write Cluster API objects and all dependencies from a management cluster to a compressed archive, including a manifest with the checksum of each object.")
+	moveCmd.Flags().StringVar(&mo.fromArchive, "from-archive", "",
+		"Verify the integrity of an archive created with --to-archive and read Cluster API objects and all dependencies from it into a management cluster.")
+	moveCmd.Flags().StringVar(&mo.archivePassphraseFile, "archive-passphrase-file", "",
+		"Path to a file containing the passphrase used to encrypt Secrets when using --to-archive, and to decrypt them when using --from-archive.")
+	moveCmd.Flags().StringSliceVar(&mo.archiveAgeRecipients, "archive-age-recipient", nil,
+		"The age public key used to encrypt Secrets when using --to-archive. The flag can be repeated to add multiple recipients.")
+	moveCmd.Flags().StringVar(&mo.archiveAgeIdentity, "archive-age-identity-file", "",
+		"Path to a file containing the age identities used to decrypt Secrets when using --from-archive.")
 	moveCmd.Flags().StringVar(&mo.hideAPIWarnings, "hide-api-warnings", "default",
 		"Set of API server warnings to hide. Valid sets are \"default\" (includes metadata.finalizer warnings), \"all\" , and \"none\".")
 
 	moveCmd.MarkFlagsMutuallyExclusive("to-directory", "to-kubeconfig")
 	moveCmd.MarkFlagsMutuallyExclusive("from-directory", "to-directory")
 	moveCmd.MarkFlagsMutuallyExclusive("from-directory", "kubeconfig")
+	moveCmd.MarkFlagsMutuallyExclusive("to-archive", "to-kubeconfig")
+	moveCmd.MarkFlagsMutuallyExclusive("from-archive", "to-archive")
+	moveCmd.MarkFlagsMutuallyExclusive("from-archive", "kubeconfig")
+	moveCmd.MarkFlagsMutuallyExclusive("to-archive", "to-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("from-archive", "from-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("archive-passphrase-file", "archive-age-recipient")
+	moveCmd.MarkFlagsMutuallyExclusive("archive-passphrase-file", "archive-age-identity-file")
 
 	RootCmd.AddCommand(moveCmd)
 }
@@ -103,9 +142,42 @@ func runMove() error {
 
 	if mo.toDirectory == "" &&
 		mo.fromDirectory == "" &&
+		mo.toArchive == "" &&
+		mo.fromArchive == "" &&
 		mo.toKubeconfig == "" &&
 		!mo.dryRun {
-		return errors.New("please specify a target cluster using the --to-kubeconfig flag when not using --dry-run, --to-directory or --from-directory")
+		return errors.New("please specify a target cluster using the --to-kubeconfig flag when not using --dry-run, --to-directory, --from-directory, --to-archive or --from-archive")
+	}
+
+	archivePassphrase := ""
+	if mo.archivePassphraseFile != "" {
+		if mo.toArchive == "" && mo.fromArchive == "" {
+			return errors.New("--archive-passphrase-file can only be used with --to-archive or --from-archive")
+		}
+		passphrase, err := os.ReadFile(mo.archivePassphraseFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to read archive passphrase file")
+		}
+		archivePassphrase = strings.TrimSpace(string(passphrase))
+		if archivePassphrase == "" {
+			return errors.New("archive passphrase file must not be empty")
+		}
+	}
+
+	if len(mo.archiveAgeRecipients) > 0 && mo.toArchive == "" {
+		return errors.New("--archive-age-recipient can only be used with --to-archive")
+	}
+
+	archiveAgeIdentities := ""
+	if mo.archiveAgeIdentity != "" {
+		if mo.fromArchive == "" {
+			return errors.New("--archive-age-identity-file can only be used with --from-archive")
+		}
+		identities, err := os.ReadFile(mo.archiveAgeIdentity)
+		if err != nil {
+			return errors.Wrap(err, "failed to read archive age identity file")
+		}
+		archiveAgeIdentities = string(identities)
 	}
 
 	configClient, err := config.New(ctx, cfgFile)
@@ -162,11 +234,16 @@ func runMove() error {
 	}
 
 	return c.Move(ctx, client.MoveOptions{
-		FromKubeconfig: client.Kubeconfig{Path: mo.fromKubeconfig, Context: mo.fromKubeconfigContext},
-		ToKubeconfig:   client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
-		FromDirectory:  mo.fromDirectory,
-		ToDirectory:    mo.toDirectory,
-		Namespace:      mo.namespace,
-		DryRun:         mo.dryRun,
+		FromKubeconfig:       client.Kubeconfig{Path: mo.fromKubeconfig, Context: mo.fromKubeconfigContext},
+		ToKubeconfig:         client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
+		FromDirectory:        mo.fromDirectory,
+		ToDirectory:          mo.toDirectory,
+		FromArchive:          mo.fromArchive,
+		ToArchive:            mo.toArchive,
+		ArchivePassphrase:    archivePassphrase,
+		ArchiveAgeRecipients: mo.archiveAgeRecipients,
+		ArchiveAgeIdentities: archiveAgeIdentities,
+		Namespace:            mo.namespace,
+		DryRun:               mo.dryRun,
 	})
 }
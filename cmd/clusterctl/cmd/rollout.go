@@ -30,20 +30,24 @@ var (
 
 		   * machinedeployment
 		   * kubeadmcontrolplane
+		   * machinepool
 		`)
 
 	rolloutExample = templates.Examples(`
-		# Force an immediate rollout of machinedeployment or kubeadmcontrolplane
+		# Force an immediate rollout of machinedeployment, kubeadmcontrolplane or machinepool
 		clusterctl alpha rollout restart machinedeployment/my-md-0
 		clusterctl alpha rollout restart kubeadmcontrolplane/my-kcp
+		clusterctl alpha rollout restart machinepool/my-mp-0
 
-		# Mark the machinedeployment or kubeadmcontrolplane as paused
+		# Mark the machinedeployment, kubeadmcontrolplane or machinepool as paused
 		clusterctl alpha rollout pause machinedeployment/my-md-0
 		clusterctl alpha rollout pause kubeadmcontrolplane/my-kcp
+		clusterctl alpha rollout pause machinepool/my-mp-0
 
-		# Resume an already paused machinedeployment or kubeadmcontrolplane
+		# Resume an already paused machinedeployment, kubeadmcontrolplane or machinepool
 		clusterctl alpha rollout resume machinedeployment/my-md-0
-		clusterctl alpha rollout resume kubeadmcontrolplane/my-kcp`)
+		clusterctl alpha rollout resume kubeadmcontrolplane/my-kcp
+		clusterctl alpha rollout resume machinepool/my-mp-0`)
 
 	rolloutCmd = &cobra.Command{
 		Use:     "rollout SUBCOMMAND",
@@ -46,7 +46,10 @@ var (
 		clusterctl alpha rollout restart machinedeployment/my-md-0
 
 		# Restart a kubeadmcontrolplane
-		clusterctl alpha rollout restart kubeadmcontrolplane/my-kcp`)
+		clusterctl alpha rollout restart kubeadmcontrolplane/my-kcp
+
+		# Restart a machinepool
+		clusterctl alpha rollout restart machinepool/my-mp-0`)
 )
 
 // NewCmdRolloutRestart returns a Command instance for 'rollout restart' sub command.
@@ -39,14 +39,17 @@ var (
 	resumeLong = templates.LongDesc(`
 		Resume a paused cluster-api resource
 
-	        Paused resources will not be reconciled by a controller. By resuming a resource, we allow it to be reconciled again. Currently only MachineDeployments and KubeadmControlPlanes support being resumed.`)
+	        Paused resources will not be reconciled by a controller. By resuming a resource, we allow it to be reconciled again. Currently only MachineDeployments, KubeadmControlPlanes and MachinePools support being resumed.`)
 
 	resumeExample = templates.Examples(`
 		# Resume an already paused machinedeployment
 		clusterctl alpha rollout resume machinedeployment/my-md-0
 
 		# Resume a kubeadmcontrolplane
-		clusterctl alpha rollout resume kubeadmcontrolplane/my-kcp`)
+		clusterctl alpha rollout resume kubeadmcontrolplane/my-kcp
+
+		# Resume a machinepool
+		clusterctl alpha rollout resume machinepool/my-mp-0`)
 )
 
 // NewCmdRolloutResume returns a Command instance for 'rollout resume' sub command.
@@ -0,0 +1,39 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+var (
+	topologyLong = templates.LongDesc(`
+		Commands for ClusterClass and managed topologies.`)
+
+	topologyCmd = &cobra.Command{
+		Use:   "topology SUBCOMMAND",
+		Short: "Commands for ClusterClass and managed topologies",
+		Long:  topologyLong,
+	}
+)
+
+func init() {
+	// subcommands
+	topologyCmd.AddCommand(topologyPlanCmd)
+}
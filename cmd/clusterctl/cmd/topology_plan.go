@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+type topologyPlanOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	files             []string
+	cluster           string
+	namespace         string
+	noDiff            bool
+}
+
+var tp = &topologyPlanOptions{}
+
+var (
+	topologyPlanLong = templates.LongDesc(`
+		Compute the changes the topology controller would apply to the Clusters affected by changes
+		to Cluster, ClusterClass or template objects, without applying them.
+
+		The plan is computed using the same desired state generation as the topology controller, including
+		inline patches; external patches and variables discovered by Runtime Extensions are not supported.
+
+		The plan reports the objects which would be created, updated in place, rotated (i.e. templates with
+		changes to the spec replaced by a new template, triggering a rollout of the machines using it) or
+		deleted, with a diff for updated and rotated objects. Changes are computed by server-side applying
+		the desired state locally, without running defaulting and validation webhooks.
+
+		If --kubeconfig or --kubeconfig-context are set, the input objects are server-side applied on top of
+		the objects existing in the management cluster, and the plan covers all the Clusters affected by the
+		input objects.
+		Otherwise, the plan is computed offline only from the input objects.`)
+
+	topologyPlanExample = templates.Examples(`
+		# Compute the objects that would be created for a new Cluster, offline.
+		clusterctl alpha topology plan -f clusterclass.yaml -f cluster.yaml
+
+		# Compute the changes to all the Clusters in the management cluster using a modified ClusterClass or template.
+		clusterctl alpha topology plan -f modified-clusterclass.yaml --kubeconfig ~/.kube/config
+
+		# Compute the changes to a Cluster only.
+		clusterctl alpha topology plan -f modified-clusterclass.yaml --kubeconfig ~/.kube/config --cluster my-cluster -n my-namespace`)
+
+	topologyPlanCmd = &cobra.Command{
+		Use:     "plan",
+		Short:   "Compute the changes to managed topologies caused by changes to Cluster, ClusterClass or template objects",
+		Long:    topologyPlanLong,
+		Example: topologyPlanExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTopologyPlan(cmd)
+		},
+	}
+)
+
+func init() {
+	topologyPlanCmd.Flags().StringVar(&tp.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If neither this flag nor --kubeconfig-context are set, the plan is computed offline.")
+	topologyPlanCmd.Flags().StringVar(&tp.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	topologyPlanCmd.Flags().StringArrayVarP(&tp.files, "file", "f", nil,
+		"Path to a file with the Cluster, ClusterClass and template objects to compute the plan for. Can be repeated.")
+	topologyPlanCmd.Flags().StringVarP(&tp.cluster, "cluster", "c", "",
+		"Name of the Cluster to compute the plan for. If empty, the plan is computed for all the Clusters affected by the input objects.")
+	topologyPlanCmd.Flags().StringVarP(&tp.namespace, "namespace", "n", "",
+		"Namespace used for the input objects without a namespace and for the Cluster. If unspecified, the current namespace will be used.")
+	topologyPlanCmd.Flags().BoolVar(&tp.noDiff, "no-diff", false,
+		"Only print the objects which would change, without a diff.")
+	_ = topologyPlanCmd.MarkFlagRequired("file")
+}
+
+func runTopologyPlan(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	objs := []*unstructured.Unstructured{}
+	for _, f := range tp.files {
+		// #nosec G304
+		// command accepts user-provided file path by design.
+		raw, err := os.ReadFile(f)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read input file %q", f)
+		}
+		fileObjs, err := utilyaml.ToUnstructured(raw)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse input file %q", f)
+		}
+		for i := range fileObjs {
+			objs = append(objs, &fileObjs[i])
+		}
+	}
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	out, err := c.TopologyPlan(ctx, client.TopologyPlanOptions{
+		Kubeconfig: client.Kubeconfig{Path: tp.kubeconfig, Context: tp.kubeconfigContext},
+		Offline:    !cmd.Flags().Changed("kubeconfig") && !cmd.Flags().Changed("kubeconfig-context"),
+		Objs:       objs,
+		Cluster:    tp.cluster,
+		Namespace:  tp.namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printTopologyPlanOutput(os.Stdout, out, !tp.noDiff)
+}
+
+// printTopologyPlanOutput prints the changes for each Cluster, followed by the diffs if showDiff is set.
+func printTopologyPlanOutput(writer io.Writer, out *client.TopologyPlanOutput, showDiff bool) error {
+	if len(out.Clusters) == 0 {
+		fmt.Fprintln(writer, "No Clusters are affected by the input objects.")
+		return nil
+	}
+
+	for _, plan := range out.Clusters {
+		fmt.Fprintf(writer, "Cluster %s/%s:\n", plan.Cluster.Namespace, plan.Cluster.Name)
+		if len(plan.Changes) == 0 {
+			fmt.Fprintf(writer, "  No changes.\n\n")
+			continue
+		}
+
+		w := tabwriter.NewWriter(writer, 10, 4, 3, ' ', 0)
+		fmt.Fprintln(w, "  ACTION\tKIND\tNAME")
+		for _, change := range plan.Changes {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", change.Action, change.Object.GetKind(), change.Object.GetName())
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(writer, "")
+
+		if !showDiff {
+			continue
+		}
+		for _, change := range plan.Changes {
+			if change.Diff == "" {
+				continue
+			}
+			fmt.Fprintf(writer, "Diff for %s %s (%s):\n%s\n", change.Object.GetKind(), change.Object.GetName(), change.Action, change.Diff)
+		}
+	}
+	return nil
+}
@@ -32,6 +32,7 @@ import (
 	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 	clustercontroller "sigs.k8s.io/cluster-api/internal/controllers/cluster"
 	clusterclasscontroller "sigs.k8s.io/cluster-api/internal/controllers/clusterclass"
+	clusterkubeconfigrequestcontroller "sigs.k8s.io/cluster-api/internal/controllers/clusterkubeconfigrequest"
 	"sigs.k8s.io/cluster-api/internal/controllers/clusterresourceset"
 	"sigs.k8s.io/cluster-api/internal/controllers/clusterresourcesetbinding"
 	extensionconfigcontroller "sigs.k8s.io/cluster-api/internal/controllers/extensionconfig"
@@ -50,9 +51,10 @@ import (
 
 // ClusterReconciler reconciles a Cluster object.
 type ClusterReconciler struct {
-	Client       client.Client
-	APIReader    client.Reader
-	ClusterCache clustercache.ClusterCache
+	Client              client.Client
+	APIReader           client.Reader
+	ClusterCache        clustercache.ClusterCache
+	SecretCachingClient client.Client
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
@@ -65,6 +67,7 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		Client:                      r.Client,
 		APIReader:                   r.APIReader,
 		ClusterCache:                r.ClusterCache,
+		SecretCachingClient:         r.SecretCachingClient,
 		WatchFilterValue:            r.WatchFilterValue,
 		RemoteConnectionGracePeriod: r.RemoteConnectionGracePeriod,
 	}).SetupWithManager(ctx, mgr, options)
@@ -157,6 +160,21 @@ func (r *MachineHealthCheckReconciler) SetupWithManager(ctx context.Context, mgr
 	}).SetupWithManager(ctx, mgr, options)
 }
 
+// ClusterKubeconfigRequestReconciler reconciles a ClusterKubeconfigRequest object.
+type ClusterKubeconfigRequestReconciler struct {
+	Client client.Client
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+}
+
+func (r *ClusterKubeconfigRequestReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return (&clusterkubeconfigrequestcontroller.Reconciler{
+		Client:           r.Client,
+		WatchFilterValue: r.WatchFilterValue,
+	}).SetupWithManager(ctx, mgr, options)
+}
+
 // ClusterTopologyReconciler reconciles a managed topology for a Cluster object.
 type ClusterTopologyReconciler struct {
 	Client       client.Client
@@ -420,6 +420,17 @@ func (ca *clusterAccessor) GetRESTConfig(ctx context.Context) (*rest.Config, err
 	return ca.lockedState.connection.restConfig, nil
 }
 
+func (ca *clusterAccessor) GetRESTClient(ctx context.Context) (RESTClient, error) {
+	ca.rLock(ctx)
+	defer ca.rUnlock(ctx)
+
+	if ca.lockedState.connection == nil {
+		return nil, errors.WithMessage(ErrClusterNotConnected, "error getting REST client")
+	}
+
+	return ca.lockedState.connection.restClient, nil
+}
+
 // Watch watches a workload cluster for events.
 // Each unique watch (by watcher.Name()) is only added once after a Connect (otherwise we return early).
 // During a disconnect existing watches (i.e. informers) are shutdown when stopping the cache.
@@ -150,6 +150,12 @@ func TestConnect(t *testing.T) {
 	g.Expect(r.List(ctx, nodeListUncached)).To(Succeed())
 	g.Expect(nodeListUncached.Items).To(BeEmpty())
 
+	// Get REST client and test a raw request
+	restClient, err := accessor.GetRESTClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = restClient.Get().AbsPath("/version").DoRaw(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
 	// Get client and test Get & List
 	c, err := accessor.GetClient(ctx)
 	g.Expect(err).ToNot(HaveOccurred())
@@ -160,6 +160,11 @@ type ClusterCache interface {
 	// If there is no connection to the workload cluster ErrClusterNotConnected will be returned.
 	GetRESTConfig(ctx context.Context, cluster client.ObjectKey) (*rest.Config, error)
 
+	// GetRESTClient returns a REST client for the given cluster, which can be used for requests
+	// that are not supported by the other clients, e.g. requests to the node proxy.
+	// If there is no connection to the workload cluster ErrClusterNotConnected will be returned.
+	GetRESTClient(ctx context.Context, cluster client.ObjectKey) (RESTClient, error)
+
 	// Watch watches a workload cluster for events.
 	// Each unique watch (by input.Name) is only added once after a Connect (otherwise we return early).
 	// During a disconnect existing watches (i.e. informers) are shutdown when stopping the cache.
@@ -431,6 +436,14 @@ func (cc *clusterCache) GetRESTConfig(ctx context.Context, cluster client.Object
 	return accessor.GetRESTConfig(ctx)
 }
 
+func (cc *clusterCache) GetRESTClient(ctx context.Context, cluster client.ObjectKey) (RESTClient, error) {
+	accessor := cc.getClusterAccessor(cluster)
+	if accessor == nil {
+		return nil, errors.WithMessage(ErrClusterNotConnected, "error getting REST client")
+	}
+	return accessor.GetRESTClient(ctx)
+}
+
 func (cc *clusterCache) Watch(ctx context.Context, cluster client.ObjectKey, watcher Watcher) error {
 	accessor := cc.getClusterAccessor(cluster)
 	if accessor == nil {
@@ -18,8 +18,10 @@ package internal
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -66,11 +68,14 @@ type ClientCertEntry struct {
 	ClusterUID          types.UID
 	ClientCert          *tls.Certificate
 	EncryptionAlgorithm bootstrapv1.EncryptionAlgorithmType
+	// CAHash is the hash of the etcd CA used to sign the client cert, so a new client cert is generated
+	// when the etcd CA is rotated.
+	CAHash string
 }
 
 // Key returns the cache key of a ClientCertEntry.
 func (r ClientCertEntry) Key() string {
-	return fmt.Sprintf("%s/%s/%s", r.Cluster.String(), r.ClusterUID, r.EncryptionAlgorithm)
+	return fmt.Sprintf("%s/%s/%s/%s", r.Cluster.String(), r.ClusterUID, r.EncryptionAlgorithm, r.CAHash)
 }
 
 // RemoteClusterConnectionError represents a failure to connect to a remote cluster.
@@ -152,8 +157,10 @@ func (m *Management) GetWorkloadCluster(ctx context.Context, cluster *clusterv1.
 	var clientCert tls.Certificate
 	if keyData != nil {
 		// Get client cert from cache if possible, otherwise generate it and add it to the cache.
-		// Note: The caching assumes that the etcd CA is not rotated during the lifetime of a Cluster.
-		if entry, ok := m.ClientCertCache.Has(ClientCertEntry{Cluster: clusterKey, ClusterUID: cluster.UID, EncryptionAlgorithm: keyEncryptionAlgorithm}.Key()); ok {
+		// Note: The cache key includes the hash of the etcd CA, so a new client cert is generated when the etcd CA is rotated.
+		caSum := sha256.Sum256(crtData)
+		caHash := hex.EncodeToString(caSum[:])
+		if entry, ok := m.ClientCertCache.Has(ClientCertEntry{Cluster: clusterKey, ClusterUID: cluster.UID, EncryptionAlgorithm: keyEncryptionAlgorithm, CAHash: caHash}.Key()); ok {
 			clientCert = *entry.ClientCert
 		} else {
 			// The client cert expires after 10 years, but that's okay as the cache has a TTL of 1 day.
@@ -161,7 +168,7 @@ func (m *Management) GetWorkloadCluster(ctx context.Context, cluster *clusterv1.
 			if err != nil {
 				return nil, err
 			}
-			m.ClientCertCache.Add(ClientCertEntry{Cluster: clusterKey, ClusterUID: cluster.UID, ClientCert: &clientCert, EncryptionAlgorithm: keyEncryptionAlgorithm})
+			m.ClientCertCache.Add(ClientCertEntry{Cluster: clusterKey, ClusterUID: cluster.UID, ClientCert: &clientCert, EncryptionAlgorithm: keyEncryptionAlgorithm, CAHash: caHash})
 		}
 	} else {
 		clientCert, err = m.getAPIServerEtcdClientCert(ctx, clusterKey)
@@ -378,6 +378,7 @@ func (c *ControlPlane) PatchMachines(ctx context.Context) error {
 				controlplanev1.KubeadmControlPlaneMachineSchedulerPodHealthyCondition,
 				controlplanev1.KubeadmControlPlaneMachineEtcdPodHealthyCondition,
 				controlplanev1.KubeadmControlPlaneMachineEtcdMemberHealthyCondition,
+				controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition,
 			}}); err != nil {
 				errList = append(errList, err)
 			}
@@ -0,0 +1,464 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+// certificateAuthorityRotationRequeueAfter is how long to wait before checking again the progress of a certificate authority rotation.
+const certificateAuthorityRotationRequeueAfter = 20 * time.Second
+
+// certificateAuthorityPurposes maps the certificate authorities which can be rotated to the corresponding Secret purpose.
+var certificateAuthorityPurposes = map[controlplanev1.KubeadmControlPlaneCertificateAuthority]secret.Purpose{
+	controlplanev1.ClusterCertificateAuthority:    secret.ClusterCA,
+	controlplanev1.EtcdCertificateAuthority:       secret.EtcdCA,
+	controlplanev1.FrontProxyCertificateAuthority: secret.FrontProxyCA,
+}
+
+// reconcileCertificateAuthorityRotation rotates the certificate authorities of the workload cluster as requested in
+// spec.certificateAuthorityRotation.
+// The rotation is performed in phases; at the beginning of each phase the CA Secrets are updated, and then all the
+// Machines created before the phase started are rolled out: control plane Machines are rolled out by KCP, while
+// worker Machines are rolled out by setting rollout.after on MachineDeployments and MachinePools.
+// - TrustNew: new certificate authorities are generated and added to the trusted ones; old certificate authorities are still used for signing.
+// - SignWithNew: new certificate authorities are used for signing; old certificate authorities are still trusted.
+// - RemoveOld: old certificate authorities are removed.
+// Note: This func is called when the control plane is stable, i.e. all the control plane Machines are up-to-date
+// and the number of replicas matches the desired number of replicas.
+func (r *KubeadmControlPlaneReconciler) reconcileCertificateAuthorityRotation(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	requestID := kcp.Spec.CertificateAuthorityRotation.RequestID
+	rotation := kcp.Status.CertificateAuthorityRotation
+
+	if rotation.Phase == "" || rotation.Phase == controlplanev1.RotationCompletedPhase {
+		switch requestID {
+		case "":
+			conditions.Delete(kcp, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)
+			return ctrl.Result{}, nil
+		case rotation.RequestID:
+			setCertificateAuthorityRotationCompletedCondition(kcp, rotation.RequestID)
+			return ctrl.Result{}, nil
+		}
+		return r.startCertificateAuthorityRotation(ctx, controlPlane)
+	}
+
+	// Wait for all the Machines and MachinePool Nodes created before the current phase started to be rolled out.
+	pendingMachines, pendingMachinePools, machinePoolsWithoutRollingUpdate, err := r.workersPendingCertificateAuthorityRotation(ctx, controlPlane)
+	if err != nil {
+		setCertificateAuthorityRotationInternalErrorCondition(kcp)
+		return ctrl.Result{}, err
+	}
+	if len(pendingMachines) > 0 || len(pendingMachinePools) > 0 {
+		waitingFor := []string{}
+		if len(pendingMachines) > 0 {
+			waitingFor = append(waitingFor, fmt.Sprintf("waiting for Machines to be rolled out: %s", summarizeMachineNames(pendingMachines)))
+		}
+		if len(machinePoolsWithoutRollingUpdate) > 0 {
+			waitingFor = append(waitingFor, fmt.Sprintf("Machines of MachinePools %s must be deleted manually, because MachinePools are not using the RollingUpdate rollout strategy", summarizeNames(machinePoolsWithoutRollingUpdate)))
+		}
+		if len(pendingMachinePools) > 0 {
+			waitingFor = append(waitingFor, fmt.Sprintf("waiting for MachinePools to be rolled out: %s", summarizeNames(pendingMachinePools)))
+		}
+		message := fmt.Sprintf("Rotation %s, phase %s: %s", rotation.RequestID, rotation.Phase, strings.Join(waitingFor, "; "))
+		if requestID != rotation.RequestID {
+			message += fmt.Sprintf("; rotation %s will start after rotation %s is completed", requestID, rotation.RequestID)
+		}
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingReason,
+			Message: message,
+		})
+		return ctrl.Result{RequeueAfter: certificateAuthorityRotationRequeueAfter}, nil
+	}
+
+	// All the Machines have been rolled out, move to the next phase.
+	var nextPhase controlplanev1.KubeadmControlPlaneRotationPhase
+	switch rotation.Phase {
+	case controlplanev1.RotationTrustNewPhase:
+		if err := r.updateCertificateAuthorities(ctx, controlPlane, rotation.CertificateAuthorities, secret.SwitchKeyPair); err != nil {
+			setCertificateAuthorityRotationInternalErrorCondition(kcp)
+			return ctrl.Result{}, err
+		}
+		nextPhase = controlplanev1.RotationSignWithNewPhase
+	case controlplanev1.RotationSignWithNewPhase:
+		if err := r.updateCertificateAuthorities(ctx, controlPlane, rotation.CertificateAuthorities, secret.CompleteKeyPairRotation); err != nil {
+			setCertificateAuthorityRotationInternalErrorCondition(kcp)
+			return ctrl.Result{}, err
+		}
+		nextPhase = controlplanev1.RotationRemoveOldPhase
+	case controlplanev1.RotationRemoveOldPhase:
+		kcp.Status.CertificateAuthorityRotation.Phase = controlplanev1.RotationCompletedPhase
+		setCertificateAuthorityRotationCompletedCondition(kcp, rotation.RequestID)
+		log.Info(fmt.Sprintf("Certificate authorities rotation %s completed", rotation.RequestID))
+		r.recorder.Eventf(kcp, corev1.EventTypeNormal, "CertificateAuthorityRotationCompleted", "Certificate authorities rotation %s completed", rotation.RequestID)
+		return ctrl.Result{}, nil
+	default:
+		setCertificateAuthorityRotationInternalErrorCondition(kcp)
+		return ctrl.Result{}, errors.Errorf("unknown certificate authorities rotation phase %q", rotation.Phase)
+	}
+
+	r.setCertificateAuthorityRotationPhase(ctx, kcp, nextPhase)
+	return ctrl.Result{RequeueAfter: certificateAuthorityRotationRequeueAfter}, nil
+}
+
+// startCertificateAuthorityRotation generates new certificate authorities and adds them to the trusted ones.
+func (r *KubeadmControlPlaneReconciler) startCertificateAuthorityRotation(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	kcp := controlPlane.KCP
+	requestID := kcp.Spec.CertificateAuthorityRotation.RequestID
+
+	certificateAuthorities := kcp.Spec.CertificateAuthorityRotation.CertificateAuthorities
+	if len(certificateAuthorities) == 0 {
+		certificateAuthorities = []controlplanev1.KubeadmControlPlaneCertificateAuthority{
+			controlplanev1.ClusterCertificateAuthority,
+			controlplanev1.EtcdCertificateAuthority,
+			controlplanev1.FrontProxyCertificateAuthority,
+		}
+	}
+	if !controlPlane.IsEtcdManaged() {
+		certificateAuthorities = slices.DeleteFunc(slices.Clone(certificateAuthorities), func(ca controlplanev1.KubeadmControlPlaneCertificateAuthority) bool {
+			return ca == controlplanev1.EtcdCertificateAuthority
+		})
+	}
+
+	// Only certificate authorities generated by Cluster API can be rotated.
+	for _, ca := range certificateAuthorities {
+		s, err := secret.GetFromNamespacedName(ctx, r.Client, util.ObjectKey(controlPlane.Cluster), certificateAuthorityPurposes[ca])
+		if err != nil {
+			setCertificateAuthorityRotationInternalErrorCondition(kcp)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to get %s certificate authority Secret", ca)
+		}
+		if s.Type != clusterv1.ClusterSecretType {
+			conditions.Set(kcp, metav1.Condition{
+				Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationBlockedReason,
+				Message: fmt.Sprintf("Rotation %s can't be started: %s certificate authority Secret %s is provided by the user", requestID, ca, s.Name),
+			})
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// Machines of MachinePools not using the RollingUpdate rollout strategy are not rolled out when setting rollout.after,
+	// so the rotation can't be started, otherwise it would wait for those Machines forever.
+	machinePoolsWithoutRollingUpdate, err := r.machinePoolsWithoutRollingUpdate(ctx, controlPlane.Cluster)
+	if err != nil {
+		setCertificateAuthorityRotationInternalErrorCondition(kcp)
+		return ctrl.Result{}, err
+	}
+	if len(machinePoolsWithoutRollingUpdate) > 0 {
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationBlockedReason,
+			Message: fmt.Sprintf("Rotation %s can't be started: MachinePools %s must use the RollingUpdate rollout strategy", requestID, summarizeNames(machinePoolsWithoutRollingUpdate)),
+		})
+		return ctrl.Result{}, nil
+	}
+
+	certificates := secret.NewCertificatesForInitialControlPlane(&kcp.Spec.KubeadmConfigSpec.ClusterConfiguration)
+	startRotation := func(s *corev1.Secret) (bool, error) {
+		_, purpose, err := secret.ParseSecretName(s.Name)
+		if err != nil {
+			return false, err
+		}
+		certificate := certificates.GetByPurpose(purpose)
+		if certificate == nil {
+			return false, errors.Errorf("unknown certificate authority %s", purpose)
+		}
+		next, err := secret.GenerateKeyPair(purpose, certificate.ValidityPeriodDays, certificate.KeyEncryptionAlgorithm)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to generate %s certificate authority", purpose)
+		}
+		return secret.StartKeyPairRotation(s, next)
+	}
+	if err := r.updateCertificateAuthorities(ctx, controlPlane, certificateAuthorities, startRotation); err != nil {
+		setCertificateAuthorityRotationInternalErrorCondition(kcp)
+		return ctrl.Result{}, err
+	}
+
+	kcp.Status.CertificateAuthorityRotation = controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationStatus{
+		RequestID:              requestID,
+		CertificateAuthorities: certificateAuthorities,
+	}
+	r.setCertificateAuthorityRotationPhase(ctx, kcp, controlplanev1.RotationTrustNewPhase)
+	return ctrl.Result{RequeueAfter: certificateAuthorityRotationRequeueAfter}, nil
+}
+
+// updateCertificateAuthorities applies a rotation step to the Secrets of the given certificate authorities, and then
+// propagates the cluster certificate authorities to the cluster-info ConfigMap in the workload cluster.
+// Note: rotation steps are idempotent, so this func can be safely called again in case of errors.
+func (r *KubeadmControlPlaneReconciler) updateCertificateAuthorities(ctx context.Context, controlPlane *internal.ControlPlane, certificateAuthorities []controlplanev1.KubeadmControlPlaneCertificateAuthority, step func(*corev1.Secret) (bool, error)) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	for _, ca := range certificateAuthorities {
+		s, err := secret.GetFromNamespacedName(ctx, r.Client, util.ObjectKey(controlPlane.Cluster), certificateAuthorityPurposes[ca])
+		if err != nil {
+			return errors.Wrapf(err, "failed to get %s certificate authority Secret", ca)
+		}
+
+		changed, err := step(s)
+		if err != nil {
+			return errors.Wrapf(err, "failed to rotate %s certificate authority", ca)
+		}
+		if changed {
+			if err := r.Client.Update(ctx, s); err != nil {
+				return errors.Wrapf(err, "failed to update %s certificate authority Secret %s", ca, klog.KObj(s))
+			}
+			log.Info(fmt.Sprintf("Updated %s certificate authority Secret %s", ca, klog.KObj(s)), "Secret", klog.KObj(s))
+		}
+
+		if ca != controlplanev1.ClusterCertificateAuthority {
+			continue
+		}
+		workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to create client to workload cluster")
+		}
+		if err := workloadCluster.UpdateClusterInfoCertificateAuthorities(ctx, s.Data[secret.TLSCrtDataName]); err != nil {
+			return errors.Wrap(err, "failed to update certificate authorities in the cluster-info ConfigMap")
+		}
+	}
+	return nil
+}
+
+// workersPendingCertificateAuthorityRotation returns the Machines of the Cluster which have been created before the
+// current phase of the certificate authority rotation started, or which are not yet provisioned, as well as the
+// MachinePools without MachinePool Machines which still have Nodes in the same situation; also, it triggers
+// the rollout of worker Machines by setting rollout.after on MachineDeployments and MachinePools.
+// Note: MachinePools with pending MachinePool Machines which are not using the RollingUpdate rollout strategy, e.g.
+// because the strategy has been changed after the rotation started, are returned separately, because
+// their Machines are not rolled out by setting rollout.after.
+func (r *KubeadmControlPlaneReconciler) workersPendingCertificateAuthorityRotation(ctx context.Context, controlPlane *internal.ControlPlane) (collections.Machines, []string, []string, error) {
+	phaseStartTime := controlPlane.KCP.Status.CertificateAuthorityRotation.PhaseStartTime
+
+	machinePools, err := r.rolloutWorkersForCertificateAuthorityRotation(ctx, controlPlane.Cluster, phaseStartTime)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	machines, err := r.managementCluster.GetMachinesForCluster(ctx, controlPlane.Cluster)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to get Machines")
+	}
+	isPending := func(machine *clusterv1.Machine) bool {
+		return machine.CreationTimestamp.Before(&phaseStartTime) || !machine.Status.NodeRef.IsDefined()
+	}
+	pendingMachines := machines.Filter(isPending)
+
+	// MachinePools whose infrastructure provider does not support MachinePool Machines are checked by looking at
+	// the Nodes in the workload cluster, because Machines do not exist and the MachinePool status does not
+	// surface if replicas have been created before the current phase started.
+	var pendingMachinePools, machinePoolsWithoutRollingUpdate []string
+	for i := range machinePools {
+		mp := &machinePools[i]
+		machinePoolMachines := machines.Filter(func(machine *clusterv1.Machine) bool {
+			return machine.Labels[clusterv1.MachinePoolNameLabel] == mp.Name
+		})
+		if len(machinePoolMachines) > 0 {
+			if mp.Spec.Rollout.Strategy.Type != clusterv1.RollingUpdateMachinePoolStrategyType && len(machinePoolMachines.Filter(isPending)) > 0 {
+				machinePoolsWithoutRollingUpdate = append(machinePoolsWithoutRollingUpdate, mp.Name)
+			}
+			continue
+		}
+		pending, err := r.machinePoolPendingCertificateAuthorityRotation(ctx, controlPlane.Cluster, mp, phaseStartTime)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if pending {
+			pendingMachinePools = append(pendingMachinePools, mp.Name)
+		}
+	}
+	return pendingMachines, pendingMachinePools, machinePoolsWithoutRollingUpdate, nil
+}
+
+// machinePoolsWithoutRollingUpdate returns the names of the MachinePools of the Cluster with MachinePool Machines which
+// are not using the RollingUpdate rollout strategy.
+func (r *KubeadmControlPlaneReconciler) machinePoolsWithoutRollingUpdate(ctx context.Context, cluster *clusterv1.Cluster) ([]string, error) {
+	if !feature.Gates.Enabled(feature.MachinePool) {
+		return nil, nil
+	}
+	machinePools, err := r.managementCluster.GetMachinePoolsForCluster(ctx, cluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list MachinePools")
+	}
+	machines, err := r.managementCluster.GetMachinesForCluster(ctx, cluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get Machines")
+	}
+
+	var names []string
+	for _, mp := range machinePools.Items {
+		if mp.Spec.Rollout.Strategy.Type == clusterv1.RollingUpdateMachinePoolStrategyType {
+			continue
+		}
+		machinePoolMachines := machines.Filter(func(machine *clusterv1.Machine) bool {
+			return machine.Labels[clusterv1.MachinePoolNameLabel] == mp.Name
+		})
+		if len(machinePoolMachines) > 0 {
+			names = append(names, mp.Name)
+		}
+	}
+	return names, nil
+}
+
+// machinePoolPendingCertificateAuthorityRotation returns true if a MachinePool has Nodes which have been created before the
+// current phase of the certificate authority rotation started, or if not all its replicas have a Node yet.
+func (r *KubeadmControlPlaneReconciler) machinePoolPendingCertificateAuthorityRotation(ctx context.Context, cluster *clusterv1.Cluster, mp *clusterv1.MachinePool, phaseStartTime metav1.Time) (bool, error) {
+	if mp.Spec.Replicas != nil && int32(len(mp.Status.NodeRefs)) < *mp.Spec.Replicas {
+		return true, nil
+	}
+	if len(mp.Status.NodeRefs) == 0 {
+		return false, nil
+	}
+
+	remoteClient, err := r.ClusterCache.GetClient(ctx, client.ObjectKeyFromObject(cluster))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get cluster client while checking Nodes of MachinePool %s", klog.KObj(mp))
+	}
+	for _, nodeRef := range mp.Status.NodeRefs {
+		node := &corev1.Node{}
+		if err := remoteClient.Get(ctx, client.ObjectKey{Name: nodeRef.Name}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				// The Node has been deleted, but the MachinePool status has not been updated yet.
+				return true, nil
+			}
+			return false, errors.Wrapf(err, "failed to get Node %s of MachinePool %s", nodeRef.Name, klog.KObj(mp))
+		}
+		if node.CreationTimestamp.Before(&phaseStartTime) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// rolloutWorkersForCertificateAuthorityRotation sets rollout.after on MachineDeployments and MachinePools of the Cluster,
+// so all the worker Machines created before the current phase of the certificate authority rotation are rolled out;
+// it returns the MachinePools of the Cluster.
+func (r *KubeadmControlPlaneReconciler) rolloutWorkersForCertificateAuthorityRotation(ctx context.Context, cluster *clusterv1.Cluster, phaseStartTime metav1.Time) ([]clusterv1.MachinePool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := r.Client.List(ctx, machineDeployments, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return nil, errors.Wrap(err, "failed to list MachineDeployments")
+	}
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+		if !md.Spec.Rollout.After.Before(&phaseStartTime) {
+			continue
+		}
+		original := md.DeepCopy()
+		md.Spec.Rollout.After = phaseStartTime
+		if err := r.Client.Patch(ctx, md, client.MergeFrom(original)); err != nil {
+			return nil, errors.Wrapf(err, "failed to set rollout.after on MachineDeployment %s", klog.KObj(md))
+		}
+		log.Info(fmt.Sprintf("Rolling out MachineDeployment %s (certificate authorities rotation)", klog.KObj(md)), "MachineDeployment", klog.KObj(md))
+	}
+
+	if !feature.Gates.Enabled(feature.MachinePool) {
+		return nil, nil
+	}
+	machinePools, err := r.managementCluster.GetMachinePoolsForCluster(ctx, cluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list MachinePools")
+	}
+	for i := range machinePools.Items {
+		mp := &machinePools.Items[i]
+		if !mp.Spec.Rollout.After.Before(&phaseStartTime) {
+			continue
+		}
+		original := mp.DeepCopy()
+		mp.Spec.Rollout.After = phaseStartTime
+		if err := r.Client.Patch(ctx, mp, client.MergeFrom(original)); err != nil {
+			return nil, errors.Wrapf(err, "failed to set rollout.after on MachinePool %s", klog.KObj(mp))
+		}
+		log.Info(fmt.Sprintf("Rolling out MachinePool %s (certificate authorities rotation)", klog.KObj(mp)), "MachinePool", klog.KObj(mp))
+	}
+	return machinePools.Items, nil
+}
+
+func (r *KubeadmControlPlaneReconciler) setCertificateAuthorityRotationPhase(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, phase controlplanev1.KubeadmControlPlaneRotationPhase) {
+	log := ctrl.LoggerFrom(ctx)
+	requestID := kcp.Status.CertificateAuthorityRotation.RequestID
+
+	kcp.Status.CertificateAuthorityRotation.Phase = phase
+	kcp.Status.CertificateAuthorityRotation.PhaseStartTime = metav1.Now()
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingReason,
+		Message: fmt.Sprintf("Rotation %s, phase %s: rolling out Machines", requestID, phase),
+	})
+	log.Info(fmt.Sprintf("Certificate authorities rotation %s moved to phase %s", requestID, phase))
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "CertificateAuthorityRotation", "Certificate authorities rotation %s moved to phase %s", requestID, phase)
+}
+
+// summarizeMachineNames returns a sorted list of Machine names, truncated if there are too many Machines.
+func summarizeMachineNames(machines collections.Machines) string {
+	return summarizeNames(machines.Names())
+}
+
+// summarizeNames returns a sorted list of names, truncated if there are too many names.
+func summarizeNames(names []string) string {
+	names = slices.Clone(names)
+	slices.Sort(names)
+	if len(names) > 3 {
+		return fmt.Sprintf("%s, ... (%d more)", strings.Join(names[:3], ", "), len(names)-3)
+	}
+	return strings.Join(names, ", ")
+}
+
+func setCertificateAuthorityRotationCompletedCondition(kcp *controlplanev1.KubeadmControlPlane, requestID string) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationCompletedReason,
+		Message: fmt.Sprintf("Rotation %s completed", requestID),
+	})
+}
+
+func setCertificateAuthorityRotationInternalErrorCondition(kcp *controlplanev1.KubeadmControlPlane) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+		Status:  metav1.ConditionUnknown,
+		Reason:  controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingInternalErrorReason,
+		Message: "Please check controller logs for errors",
+	})
+}
@@ -0,0 +1,412 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	certutil "k8s.io/client-go/util/cert"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestReconcileCertificateAuthorityRotation(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	newCASecret := func(g *WithT, purpose secret.Purpose, secretType corev1.SecretType) *corev1.Secret {
+		keyPair, err := secret.GenerateKeyPair(purpose, 0, "")
+		g.Expect(err).ToNot(HaveOccurred())
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secret.Name(cluster.Name, purpose),
+				Namespace: cluster.Namespace,
+			},
+			Type: secretType,
+			Data: map[string][]byte{
+				secret.TLSCrtDataName: keyPair.Cert,
+				secret.TLSKeyDataName: keyPair.Key,
+			},
+		}
+	}
+
+	newControlPlane := func(requestID string, workloadCluster *fakeWorkloadCluster, machines ...*clusterv1.Machine) *internal.ControlPlane {
+		kcp := &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kcp",
+				Namespace: metav1.NamespaceDefault,
+				UID:       "uid",
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				CertificateAuthorityRotation: controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationSpec{
+					RequestID: requestID,
+				},
+			},
+		}
+		controlPlane := &internal.ControlPlane{
+			KCP:            kcp,
+			Cluster:        cluster,
+			Machines:       collections.FromMachines(machines...),
+			KubeadmConfigs: map[string]*bootstrapv1.KubeadmConfig{},
+		}
+		controlPlane.InjectTestManagementCluster(&fakeManagementCluster{
+			Machines:     collections.FromMachines(machines...),
+			MachinePools: &clusterv1.MachinePoolList{},
+			Workload:     workloadCluster,
+		})
+		return controlPlane
+	}
+
+	t.Run("does nothing if a rotation is not requested", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane("", &fakeWorkloadCluster{})
+		result, err := r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(conditions.Get(controlPlane.KCP, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(BeNil())
+	})
+
+	t.Run("blocks the rotation if a certificate authority is provided by the user", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clusterCA := newCASecret(g, secret.ClusterCA, clusterv1.ClusterSecretType)
+		userProvidedCA := newCASecret(g, secret.FrontProxyCA, corev1.SecretTypeOpaque)
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(clusterCA, userProvidedCA),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane("rotation-1", &fakeWorkloadCluster{})
+		controlPlane.KCP.Spec.CertificateAuthorityRotation.CertificateAuthorities = []controlplanev1.KubeadmControlPlaneCertificateAuthority{
+			controlplanev1.ClusterCertificateAuthority,
+			controlplanev1.FrontProxyCertificateAuthority,
+		}
+		result, err := r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationBlockedReason))
+		g.Expect(controlPlane.KCP.Status.CertificateAuthorityRotation.Phase).To(BeEmpty())
+
+		// The Secrets must not be changed.
+		got := &corev1.Secret{}
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(clusterCA), got)).To(Succeed())
+		g.Expect(got.Data).To(Equal(clusterCA.Data))
+	})
+
+	t.Run("rotates certificate authorities", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clusterCA := newCASecret(g, secret.ClusterCA, clusterv1.ClusterSecretType)
+		md := &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "md",
+				Namespace: cluster.Namespace,
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+			},
+		}
+		oldMachine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "old-machine",
+				Namespace:         cluster.Namespace,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Status: clusterv1.MachineStatus{NodeRef: clusterv1.MachineNodeReference{Name: "old-node"}},
+		}
+		workloadCluster := &fakeWorkloadCluster{}
+		controlPlane := newControlPlane("rotation-1", workloadCluster, oldMachine)
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(clusterCA, md),
+			recorder: record.NewFakeRecorder(32),
+			managementCluster: &fakeManagementCluster{
+				Machines:     collections.FromMachines(oldMachine),
+				MachinePools: &clusterv1.MachinePoolList{},
+				Workload:     workloadCluster,
+			},
+		}
+		controlPlane.KCP.Spec.CertificateAuthorityRotation.CertificateAuthorities = []controlplanev1.KubeadmControlPlaneCertificateAuthority{
+			controlplanev1.ClusterCertificateAuthority,
+		}
+
+		// TrustNew: the new certificate authority is added to the trusted ones.
+		result, err := r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(certificateAuthorityRotationRequeueAfter))
+		g.Expect(controlPlane.KCP.Status.CertificateAuthorityRotation.RequestID).To(Equal("rotation-1"))
+		g.Expect(controlPlane.KCP.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.RotationTrustNewPhase))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingReason))
+
+		got := &corev1.Secret{}
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(clusterCA), got)).To(Succeed())
+		g.Expect(got.Data).To(HaveKey(secret.NextTLSCrtDataName))
+		g.Expect(got.Data[secret.TLSKeyDataName]).To(Equal(clusterCA.Data[secret.TLSKeyDataName]))
+		trusted, err := certutil.ParseCertsPEM(got.Data[secret.TLSCrtDataName])
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(trusted).To(HaveLen(2))
+		g.Expect(workloadCluster.clusterInfoCertificateAuthorities).To(Equal(got.Data[secret.TLSCrtDataName]))
+		nextKey := got.Data[secret.NextTLSKeyDataName]
+
+		// Wait for Machines created before the phase started to be rolled out.
+		result, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(certificateAuthorityRotationRequeueAfter))
+		g.Expect(controlPlane.KCP.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.RotationTrustNewPhase))
+		g.Expect(conditions.GetMessage(controlPlane.KCP, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(ContainSubstring("old-machine"))
+
+		gotMD := &clusterv1.MachineDeployment{}
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(md), gotMD)).To(Succeed())
+		g.Expect(gotMD.Spec.Rollout.After.IsZero()).To(BeFalse())
+
+		// SignWithNew: the new certificate authority is used for signing once all the Machines are rolled out.
+		rolloutMachines := func() {
+			newMachine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "new-machine",
+					Namespace:         cluster.Namespace,
+					CreationTimestamp: metav1.NewTime(controlPlane.KCP.Status.CertificateAuthorityRotation.PhaseStartTime.Add(time.Second)),
+				},
+				Status: clusterv1.MachineStatus{NodeRef: clusterv1.MachineNodeReference{Name: "new-node"}},
+			}
+			managementCluster := &fakeManagementCluster{
+				Machines:     collections.FromMachines(newMachine),
+				MachinePools: &clusterv1.MachinePoolList{},
+				Workload:     workloadCluster,
+			}
+			controlPlane.InjectTestManagementCluster(managementCluster)
+			r.managementCluster = managementCluster
+		}
+		rolloutMachines()
+		result, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(certificateAuthorityRotationRequeueAfter))
+		g.Expect(controlPlane.KCP.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.RotationSignWithNewPhase))
+
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(clusterCA), got)).To(Succeed())
+		g.Expect(got.Data).ToNot(HaveKey(secret.NextTLSCrtDataName))
+		g.Expect(got.Data[secret.TLSKeyDataName]).To(Equal(nextKey))
+
+		// RemoveOld: the old certificate authority is removed.
+		rolloutMachines()
+		result, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(certificateAuthorityRotationRequeueAfter))
+		g.Expect(controlPlane.KCP.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.RotationRemoveOldPhase))
+
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(clusterCA), got)).To(Succeed())
+		trusted, err = certutil.ParseCertsPEM(got.Data[secret.TLSCrtDataName])
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(trusted).To(HaveLen(1))
+		g.Expect(workloadCluster.clusterInfoCertificateAuthorities).To(Equal(got.Data[secret.TLSCrtDataName]))
+
+		// Completed.
+		rolloutMachines()
+		result, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(controlPlane.KCP.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.RotationCompletedPhase))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationCompletedReason))
+
+		result, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+	})
+	t.Run("waits for MachinePools without MachinePool Machines to be rolled out", func(t *testing.T) {
+		utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePool, true)
+		g := NewWithT(t)
+
+		phaseStartTime := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+		newMachinePool := func(name string, nodeNames ...string) *clusterv1.MachinePool {
+			mp := &clusterv1.MachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: cluster.Namespace,
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+				},
+				Spec: clusterv1.MachinePoolSpec{
+					Replicas: ptr.To[int32](1),
+					Rollout:  clusterv1.MachinePoolRolloutSpec{After: phaseStartTime},
+				},
+			}
+			for _, nodeName := range nodeNames {
+				mp.Status.NodeRefs = append(mp.Status.NodeRefs, corev1.ObjectReference{Kind: "Node", Name: nodeName})
+			}
+			return mp
+		}
+		newNode := func(name string, creationTimestamp time.Time) *corev1.Node {
+			return &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					CreationTimestamp: metav1.NewTime(creationTimestamp),
+				},
+			}
+		}
+
+		// mp-old has a Node created before the phase started, mp-provisioning does not have a Node yet, mp-new
+		// has a Node created after the phase started, while mp-machines has MachinePool Machines which are checked
+		// like any other Machine.
+		oldPool := newMachinePool("mp-old", "old-node")
+		provisioningPool := newMachinePool("mp-provisioning")
+		newPool := newMachinePool("mp-new", "new-node")
+		machinesPool := newMachinePool("mp-machines", "machine-pool-node")
+		machinePoolMachine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "machine-pool-machine",
+				Namespace:         cluster.Namespace,
+				Labels:            map[string]string{clusterv1.MachinePoolNameLabel: machinesPool.Name},
+				CreationTimestamp: metav1.NewTime(phaseStartTime.Add(time.Second)),
+			},
+			Status: clusterv1.MachineStatus{NodeRef: clusterv1.MachineNodeReference{Name: "machine-pool-node"}},
+		}
+
+		remoteClient := fake.NewClientBuilder().WithObjects(
+			newNode("old-node", phaseStartTime.Add(-time.Hour)),
+			newNode("new-node", phaseStartTime.Add(time.Second)),
+		).Build()
+		r := &KubeadmControlPlaneReconciler{
+			Client:       newFakeClient(oldPool, provisioningPool, newPool, machinesPool),
+			ClusterCache: clustercache.NewFakeClusterCache(remoteClient, client.ObjectKeyFromObject(cluster)),
+			recorder:     record.NewFakeRecorder(32),
+			managementCluster: &fakeManagementCluster{
+				Machines:     collections.FromMachines(machinePoolMachine),
+				MachinePools: &clusterv1.MachinePoolList{Items: []clusterv1.MachinePool{*oldPool, *provisioningPool, *newPool, *machinesPool}},
+				Workload:     &fakeWorkloadCluster{},
+			},
+		}
+
+		controlPlane := newControlPlane("rotation-1", &fakeWorkloadCluster{})
+		controlPlane.KCP.Status.CertificateAuthorityRotation = controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationStatus{
+			RequestID:      "rotation-1",
+			Phase:          controlplanev1.RotationTrustNewPhase,
+			PhaseStartTime: phaseStartTime,
+		}
+
+		pendingMachines, pendingMachinePools, _, err := r.workersPendingCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(pendingMachines).To(BeEmpty())
+		g.Expect(pendingMachinePools).To(ConsistOf("mp-old", "mp-provisioning"))
+
+		result, err := r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(certificateAuthorityRotationRequeueAfter))
+		g.Expect(controlPlane.KCP.Status.CertificateAuthorityRotation.Phase).To(Equal(controlplanev1.RotationTrustNewPhase))
+		g.Expect(conditions.GetMessage(controlPlane.KCP, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(Equal(
+			"Rotation rotation-1, phase TrustNew: waiting for MachinePools to be rolled out: mp-old, mp-provisioning"))
+	})
+
+	t.Run("blocks the rotation if MachinePools with MachinePool Machines are not using the RollingUpdate rollout strategy", func(t *testing.T) {
+		utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePool, true)
+		g := NewWithT(t)
+
+		clusterCA := newCASecret(g, secret.ClusterCA, clusterv1.ClusterSecretType)
+		newMachinePool := func(name string, strategy clusterv1.MachinePoolRolloutStrategyType) *clusterv1.MachinePool {
+			return &clusterv1.MachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: cluster.Namespace,
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+				},
+				Spec: clusterv1.MachinePoolSpec{
+					Rollout: clusterv1.MachinePoolRolloutSpec{
+						Strategy: clusterv1.MachinePoolRolloutStrategy{Type: strategy},
+					},
+				},
+			}
+		}
+		newMachinePoolMachine := func(name string, mp *clusterv1.MachinePool) *clusterv1.Machine {
+			return &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Namespace:         cluster.Namespace,
+					Labels:            map[string]string{clusterv1.MachinePoolNameLabel: mp.Name},
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+				Status: clusterv1.MachineStatus{NodeRef: clusterv1.MachineNodeReference{Name: name}},
+			}
+		}
+
+		rollingUpdatePool := newMachinePool("mp-rolling-update", clusterv1.RollingUpdateMachinePoolStrategyType)
+		onDeletePool := newMachinePool("mp-on-delete", clusterv1.OnDeleteMachinePoolStrategyType)
+		defaultPool := newMachinePool("mp-default", "")
+		withoutMachinesPool := newMachinePool("mp-without-machines", "")
+		machines := collections.FromMachines(
+			newMachinePoolMachine("rolling-update-machine", rollingUpdatePool),
+			newMachinePoolMachine("on-delete-machine", onDeletePool),
+			newMachinePoolMachine("default-machine", defaultPool),
+		)
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(clusterCA, rollingUpdatePool, onDeletePool, defaultPool, withoutMachinesPool),
+			recorder: record.NewFakeRecorder(32),
+			managementCluster: &fakeManagementCluster{
+				Machines:     machines,
+				MachinePools: &clusterv1.MachinePoolList{Items: []clusterv1.MachinePool{*rollingUpdatePool, *onDeletePool, *defaultPool, *withoutMachinesPool}},
+				Workload:     &fakeWorkloadCluster{},
+			},
+		}
+
+		controlPlane := newControlPlane("rotation-1", &fakeWorkloadCluster{})
+		controlPlane.KCP.Spec.CertificateAuthorityRotation.CertificateAuthorities = []controlplanev1.KubeadmControlPlaneCertificateAuthority{
+			controlplanev1.ClusterCertificateAuthority,
+		}
+		result, err := r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(controlPlane.KCP.Status.CertificateAuthorityRotation.Phase).To(BeEmpty())
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationBlockedReason))
+		g.Expect(conditions.GetMessage(controlPlane.KCP, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(Equal(
+			"Rotation rotation-1 can't be started: MachinePools mp-default, mp-on-delete must use the RollingUpdate rollout strategy"))
+
+		// If the rollout strategy is changed after the rotation started, the rotation surfaces that Machines must be deleted manually.
+		controlPlane.KCP.Status.CertificateAuthorityRotation = controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationStatus{
+			RequestID:      "rotation-1",
+			Phase:          controlplanev1.RotationTrustNewPhase,
+			PhaseStartTime: metav1.Now(),
+		}
+		result, err = r.reconcileCertificateAuthorityRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(certificateAuthorityRotationRequeueAfter))
+		g.Expect(conditions.GetMessage(controlPlane.KCP, controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition)).To(Equal(
+			"Rotation rotation-1, phase TrustNew: waiting for Machines to be rolled out: default-machine, on-delete-machine, rolling-update-machine; " +
+				"Machines of MachinePools mp-default, mp-on-delete must be deleted manually, because MachinePools are not using the RollingUpdate rollout strategy"))
+	})
+}
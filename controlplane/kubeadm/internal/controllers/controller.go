@@ -87,7 +87,7 @@ var (
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments;machinepools,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
 // KubeadmControlPlaneReconciler reconciles a KubeadmControlPlane object.
@@ -385,6 +385,11 @@ func patchKubeadmControlPlane(ctx context.Context, patchHelper *patch.Helper, kc
 			controlplanev1.KubeadmControlPlaneScalingUpCondition,
 			controlplanev1.KubeadmControlPlaneScalingDownCondition,
 			controlplanev1.KubeadmControlPlaneRemediatingCondition,
+			controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition,
+			controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
+			controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+			controlplanev1.KubeadmControlPlaneCertificateAuthorityRotatingCondition,
+			controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingCondition,
 			controlplanev1.KubeadmControlPlaneDeletingCondition,
 		}},
 	)
@@ -483,6 +488,16 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 		return ctrl.Result{}, err
 	}
 
+	// Note: The EtcdSnapshotSucceeded condition is set when taking etcd snapshots, and it is dropped when etcd snapshots are disabled.
+	if !controlPlane.IsEtcdManaged() || !ptr.Deref(controlPlane.KCP.Spec.Etcd.Snapshot.Enabled, false) {
+		conditions.Delete(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition)
+	}
+
+	// Restore etcd from a snapshot, if requested; while the restore is in progress other operations are not performed.
+	if result, err := r.reconcileEtcdRestore(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	// Ensures the number of etcd members is in sync with the number of machines/nodes.
 	if result, err := r.reconcileEtcdMembers(ctx, controlPlane); err != nil || !result.IsZero() {
 		return result, err
@@ -600,7 +615,25 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 	if err := r.reconcileCertificateExpiries(ctx, controlPlane); err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+
+	// Defragment etcd members and handle NOSPACE alarms, if enabled.
+	// Note: This is performed only when the control plane is stable, so it doesn't interfere with other operations.
+	// Note: This is performed before rotations, because rotations return early while waiting e.g. for worker
+	// Machines to be rolled out, and handling NOSPACE alarms must not be delayed until rotations complete.
+	if result, err := r.reconcileEtcdDefragmentation(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	// Rotate certificate authorities, if requested.
+	// Note: This is performed only when the control plane is stable; the rollout of control plane Machines
+	// required by the rotation is then driven by the regular rollout logic.
+	if result, err := r.reconcileCertificateAuthorityRotation(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	// Rotate the service account signing key, if requested.
+	// Note: This is performed only when the control plane is stable, same as certificate authorities rotation.
+	return r.reconcileServiceAccountKeyRotation(ctx, controlPlane)
 }
 
 // reconcileClusterCertificates ensures that all the cluster certificates exists and
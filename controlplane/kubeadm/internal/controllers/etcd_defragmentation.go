@@ -0,0 +1,331 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// etcdDefragmentationTimeout is the maximum time allowed for defragmenting an etcd member.
+	etcdDefragmentationTimeout = 2 * time.Minute
+
+	// etcdDefragmentationRequeueAfter is how long to wait before defragmenting the next etcd member.
+	etcdDefragmentationRequeueAfter = 30 * time.Second
+
+	mebibyte = 1024 * 1024
+)
+
+// etcdMemberDBStatus is the status of the database of the etcd member hosted on a control plane Machine.
+type etcdMemberDBStatus struct {
+	machine *clusterv1.Machine
+	status  *etcd.DBStatus
+}
+
+// fragmentationPercent returns the percentage of the database of the etcd member not in use.
+func (s etcdMemberDBStatus) fragmentationPercent() int32 {
+	if s.status.DBSize <= 0 || s.status.DBSizeInUse >= s.status.DBSize {
+		return 0
+	}
+	return int32((s.status.DBSize - s.status.DBSizeInUse) * 100 / s.status.DBSize)
+}
+
+// reconcileEtcdDefragmentation defragments etcd members exceeding the thresholds defined in spec.etcd.defragmentation,
+// and handles NOSPACE alarms by compacting etcd, defragmenting members and then disarming the alarms.
+// Members are defragmented one at a time, followers first and the leader last; this func defragments at most one
+// member and requeues if other members must be defragmented.
+// Note: This func should be called only when the control plane is stable, e.g. not during a rollout or a scale operation.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdDefragmentation(ctx context.Context, controlPlane *internal.ControlPlane) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	spec := kcp.Spec.Etcd.Defragmentation
+
+	if !controlPlane.IsEtcdManaged() || !ptr.Deref(spec.Enabled, false) {
+		conditions.Delete(kcp, controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition)
+		delete(kcp.Annotations, controlplanev1.EtcdCompactedRevisionAnnotation)
+		machinesWithCondition := false
+		for _, machine := range controlPlane.Machines {
+			if conditions.Has(machine, controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition) {
+				conditions.Delete(machine, controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition)
+				machinesWithCondition = true
+			}
+		}
+		if machinesWithCondition {
+			return ctrl.Result{}, controlPlane.PatchMachines(ctx)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Always attempt to patch the Machine conditions.
+	defer func() {
+		if err := controlPlane.PatchMachines(ctx); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		setEtcdDefragmentingInternalErrorCondition(kcp)
+		return ctrl.Result{}, errors.Wrap(err, "failed to get workload cluster")
+	}
+
+	minDBSize := int64(controlplanev1.DefaultEtcdDefragmentationMinDBSizeMebibytes) * mebibyte
+	if spec.MinDBSizeMebibytes != 0 {
+		minDBSize = int64(spec.MinDBSizeMebibytes) * mebibyte
+	}
+	minFragmentationPercent := controlplanev1.DefaultEtcdDefragmentationMinFragmentationPercent
+	if spec.MinFragmentationPercent != 0 {
+		minFragmentationPercent = spec.MinFragmentationPercent
+	}
+
+	var noSpaceAlarms []etcd.MemberAlarm
+	if ptr.Deref(spec.DisarmNoSpaceAlarm, true) {
+		for _, alarm := range controlPlane.EtcdMembersAlarms {
+			if alarm.Type == etcd.AlarmNoSpace {
+				noSpaceAlarms = append(noSpaceAlarms, alarm)
+			}
+		}
+	}
+
+	// When handling a NOSPACE alarm, compact etcd before inspecting the database of the etcd members, so superseded
+	// keys are discarded and the space they release is accounted for when identifying members to be defragmented.
+	// Note: The compacted revision is tracked on the KubeadmControlPlane, so etcd is compacted again only if there
+	// are new revisions, e.g. due to deletions, and not on every reconcile while the alarm is present.
+	if len(noSpaceAlarms) > 0 {
+		if ok, message := etcdMembersCanBeDefragmented(controlPlane, noSpaceAlarms); !ok {
+			conditions.Set(kcp, metav1.Condition{
+				Type:    controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  controlplanev1.KubeadmControlPlaneEtcdDefragmentingReason,
+				Message: fmt.Sprintf("Waiting for %s before compacting etcd to handle NOSPACE alarms", message),
+			})
+			return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+		}
+
+		// Note: If the compacted revision is not set or it is not valid, etcd is compacted.
+		compactedRevision, err := strconv.ParseInt(kcp.Annotations[controlplanev1.EtcdCompactedRevisionAnnotation], 10, 64)
+		if err != nil {
+			compactedRevision = 0
+		}
+		revision, err := workloadCluster.CompactEtcd(ctx, controlPlane.Nodes, compactedRevision)
+		if err != nil {
+			setEtcdDefragmentingInternalErrorCondition(kcp)
+			r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdCompaction", "Failed to compact etcd: %v", err)
+			return ctrl.Result{}, errors.Wrap(err, "failed to compact etcd")
+		}
+		if revision != compactedRevision {
+			log.Info(fmt.Sprintf("Etcd compacted to revision %d", revision))
+			annotations.AddAnnotations(kcp, map[string]string{controlplanev1.EtcdCompactedRevisionAnnotation: strconv.FormatInt(revision, 10)})
+		}
+	} else {
+		delete(kcp.Annotations, controlplanev1.EtcdCompactedRevisionAnnotation)
+	}
+
+	// Inspect the database of all the etcd members, and identify members to be defragmented.
+	var inspectionFailed []string
+	var pending []etcdMemberDBStatus
+	for _, machine := range controlPlane.Machines {
+		if !machine.Status.NodeRef.IsDefined() {
+			continue
+		}
+
+		status, err := workloadCluster.GetEtcdMemberDBStatus(ctx, machine.Status.NodeRef.Name)
+		if err != nil {
+			log.Error(err, "Failed to get etcd member database status", "Machine", klog.KObj(machine))
+			inspectionFailed = append(inspectionFailed, machine.Name)
+			conditions.Set(machine, metav1.Condition{
+				Type:    controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingInspectionFailedReason,
+				Message: "Failed to get etcd member database status",
+			})
+			continue
+		}
+
+		memberStatus := etcdMemberDBStatus{machine: machine, status: status}
+		mustBeDefragmented := memberStatus.fragmentationPercent() >= minFragmentationPercent && status.DBSize >= minDBSize
+		// Note: When handling a NOSPACE alarm, all the members with space not in use after compaction are defragmented,
+		// no matter of the thresholds; this ensures space is released before disarming the alarms, otherwise the
+		// alarms would fire again immediately.
+		if len(noSpaceAlarms) > 0 {
+			mustBeDefragmented = memberStatus.fragmentationPercent() > 0
+		}
+		if mustBeDefragmented {
+			pending = append(pending, memberStatus)
+			conditions.Set(machine, metav1.Condition{
+				Type:    controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentationPendingReason,
+				Message: fmt.Sprintf("Etcd member database size is %d MiB, %d%% not in use", status.DBSize/mebibyte, memberStatus.fragmentationPercent()),
+			})
+			continue
+		}
+		conditions.Set(machine, metav1.Condition{
+			Type:   controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition,
+			Status: metav1.ConditionFalse,
+			Reason: controlplanev1.KubeadmControlPlaneMachineEtcdMemberNotDefragmentingReason,
+		})
+	}
+
+	if len(inspectionFailed) > 0 {
+		sort.Strings(inspectionFailed)
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdDefragmentingInspectionFailedReason,
+			Message: fmt.Sprintf("Failed to get etcd member database status for Machines %s", strings.Join(inspectionFailed, ", ")),
+		})
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+	}
+
+	// If there are no more members to defragment, disarm NOSPACE alarms, if any.
+	if len(pending) == 0 {
+		if len(noSpaceAlarms) > 0 {
+			if err := workloadCluster.DisarmEtcdAlarms(ctx, noSpaceAlarms, controlPlane.Nodes); err != nil {
+				setEtcdDefragmentingInternalErrorCondition(kcp)
+				r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdAlarmDisarm", "Failed to disarm etcd NOSPACE alarms: %v", err)
+				return ctrl.Result{}, errors.Wrap(err, "failed to disarm etcd NOSPACE alarms")
+			}
+			log.Info("Etcd NOSPACE alarms disarmed")
+			delete(kcp.Annotations, controlplanev1.EtcdCompactedRevisionAnnotation)
+			r.recorder.Event(kcp, corev1.EventTypeNormal, "EtcdAlarmDisarmed", "Etcd NOSPACE alarms disarmed")
+		}
+
+		conditions.Set(kcp, metav1.Condition{
+			Type:   controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+			Status: metav1.ConditionFalse,
+			Reason: controlplanev1.KubeadmControlPlaneEtcdNotDefragmentingReason,
+		})
+		return ctrl.Result{}, nil
+	}
+
+	// Defragment followers first, and the leader last.
+	sort.SliceStable(pending, func(i, j int) bool {
+		iIsLeader := pending[i].status.MemberID == pending[i].status.LeaderID
+		jIsLeader := pending[j].status.MemberID == pending[j].status.LeaderID
+		if iIsLeader != jIsLeader {
+			return !iIsLeader
+		}
+		return pending[i].machine.Name < pending[j].machine.Name
+	})
+	pendingNames := make([]string, 0, len(pending))
+	for _, p := range pending {
+		pendingNames = append(pendingNames, p.machine.Name)
+	}
+
+	// Defragment only when the etcd cluster is healthy, so defragmenting a member does not lead to quorum loss.
+	// Note: Members with a NOSPACE alarm are not healthy, but they can be defragmented.
+	if ok, message := etcdMembersCanBeDefragmented(controlPlane, noSpaceAlarms); !ok {
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdDefragmentingReason,
+			Message: fmt.Sprintf("Waiting for %s before defragmenting etcd members of Machines %s", message, strings.Join(pendingNames, ", ")),
+		})
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+	}
+
+	next := pending[0]
+	defragmentationCtx, cancel := context.WithTimeout(ctx, etcdDefragmentationTimeout)
+	defer cancel()
+	if err := workloadCluster.DefragmentEtcdMember(defragmentationCtx, next.machine.Status.NodeRef.Name); err != nil {
+		setEtcdDefragmentingInternalErrorCondition(kcp)
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdDefragmentation",
+			"Failed to defragment etcd member of Machine %s: %v", next.machine.Name, err)
+		return ctrl.Result{}, errors.Wrapf(err, "failed to defragment etcd member of Machine %s", klog.KObj(next.machine))
+	}
+
+	conditions.Set(next.machine, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.KubeadmControlPlaneMachineEtcdMemberNotDefragmentingReason,
+		Message: fmt.Sprintf("Etcd member defragmented, database size was %d MiB", next.status.DBSize/mebibyte),
+	})
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdDefragmentingReason,
+		Message: fmt.Sprintf("Defragmenting etcd members of Machines %s", strings.Join(pendingNames, ", ")),
+	})
+	log.Info(fmt.Sprintf("Etcd member of Machine %s defragmented", klog.KObj(next.machine)), "Machine", klog.KObj(next.machine))
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "EtcdMemberDefragmented", "Etcd member of Machine %s defragmented", next.machine.Name)
+
+	// Note: Requeue to check the database status again, and to defragment the next member if any.
+	return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+}
+
+// etcdMembersCanBeDefragmented checks if etcd members can be defragmented one at a time without risks for the etcd cluster.
+// Note: This check leverage the information collected in reconcileControlPlaneAndMachinesConditions at the beginning of reconcile.
+func etcdMembersCanBeDefragmented(controlPlane *internal.ControlPlane, noSpaceAlarms []etcd.MemberAlarm) (bool, string) {
+	if !controlPlane.EtcdMembersAndMachinesAreMatching {
+		return false, "etcd members and Machines to match"
+	}
+
+	membersWithNoSpaceAlarm := map[string]bool{}
+	for _, member := range controlPlane.EtcdMembers {
+		for _, alarm := range noSpaceAlarms {
+			if alarm.MemberID == member.ID {
+				membersWithNoSpaceAlarm[member.Name] = true
+			}
+		}
+	}
+
+	var notHealthy []string
+	for _, machine := range controlPlane.Machines {
+		if conditions.IsTrue(machine, controlplanev1.KubeadmControlPlaneMachineEtcdMemberHealthyCondition) {
+			continue
+		}
+		if machine.Status.NodeRef.IsDefined() && membersWithNoSpaceAlarm[machine.Status.NodeRef.Name] {
+			continue
+		}
+		notHealthy = append(notHealthy, machine.Name)
+	}
+	if len(notHealthy) > 0 {
+		sort.Strings(notHealthy)
+		return false, fmt.Sprintf("etcd members of Machines %s to be healthy", strings.Join(notHealthy, ", "))
+	}
+	return true, ""
+}
+
+func setEtcdDefragmentingInternalErrorCondition(kcp *controlplanev1.KubeadmControlPlane) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition,
+		Status:  metav1.ConditionUnknown,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdDefragmentingInternalErrorReason,
+		Message: "Please check controller logs for errors",
+	})
+}
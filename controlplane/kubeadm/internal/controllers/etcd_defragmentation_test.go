@@ -0,0 +1,271 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+func TestReconcileEtcdDefragmentation(t *testing.T) {
+	newMachine := func(name string, etcdHealthy bool) *clusterv1.Machine {
+		status := metav1.ConditionFalse
+		if etcdHealthy {
+			status = metav1.ConditionTrue
+		}
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+			},
+			Status: clusterv1.MachineStatus{
+				NodeRef: clusterv1.MachineNodeReference{Name: name},
+				Conditions: []metav1.Condition{{
+					Type:   controlplanev1.KubeadmControlPlaneMachineEtcdMemberHealthyCondition,
+					Status: status,
+					Reason: "Reason",
+				}},
+			},
+		}
+	}
+	newControlPlane := func(g *WithT, workloadCluster *fakeWorkloadCluster, enabled bool, machines ...*clusterv1.Machine) *internal.ControlPlane {
+		controlPlane := &internal.ControlPlane{
+			KCP: &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kcp",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Etcd: controlplanev1.KubeadmControlPlaneEtcdSpec{
+						Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{
+							Enabled: ptr.To(enabled),
+						},
+					},
+				},
+			},
+			Cluster:                           &clusterv1.Cluster{},
+			Machines:                          collections.FromMachines(machines...),
+			EtcdMembersAndMachinesAreMatching: true,
+		}
+		for i, machine := range machines {
+			controlPlane.EtcdMembers = append(controlPlane.EtcdMembers, &etcd.Member{ID: uint64(i + 1), Name: machine.Status.NodeRef.Name})
+		}
+		controlPlane.InjectTestManagementCluster(&fakeManagementCluster{Workload: workloadCluster})
+
+		objs := []client.Object{}
+		for _, machine := range machines {
+			objs = append(objs, machine)
+		}
+		fakeClient := fake.NewClientBuilder().WithObjects(objs...).WithStatusSubresource(&clusterv1.Machine{}).Build()
+		patchHelpers := map[string]*patch.Helper{}
+		for _, machine := range machines {
+			helper, err := patch.NewHelper(machine, fakeClient)
+			g.Expect(err).ToNot(HaveOccurred())
+			patchHelpers[machine.Name] = helper
+		}
+		controlPlane.SetPatchHelpers(patchHelpers)
+		return controlPlane
+	}
+
+	t.Run("does nothing if defragmentation is not enabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		workloadCluster := &fakeWorkloadCluster{}
+		machine := newMachine("m1", true)
+		conditions.Set(machine, metav1.Condition{
+			Type:   controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition,
+			Status: metav1.ConditionFalse,
+			Reason: controlplanev1.KubeadmControlPlaneMachineEtcdMemberNotDefragmentingReason,
+		})
+		controlPlane := newControlPlane(g, workloadCluster, false, machine)
+		r := &KubeadmControlPlaneReconciler{recorder: record.NewFakeRecorder(32)}
+
+		result, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(conditions.Has(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition)).To(BeFalse())
+		g.Expect(conditions.Has(machine, controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition)).To(BeFalse())
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(BeEmpty())
+	})
+
+	t.Run("defragments followers first", func(t *testing.T) {
+		g := NewWithT(t)
+
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdMemberDBStatuses: map[string]*etcd.DBStatus{
+				// m1 is the leader and it must be defragmented.
+				"m1": {MemberID: 1, LeaderID: 1, DBSize: 400 * mebibyte, DBSizeInUse: 100 * mebibyte},
+				// m2 is a follower and it must be defragmented.
+				"m2": {MemberID: 2, LeaderID: 1, DBSize: 400 * mebibyte, DBSizeInUse: 100 * mebibyte},
+				// m3 is a follower and it is not fragmented.
+				"m3": {MemberID: 3, LeaderID: 1, DBSize: 400 * mebibyte, DBSizeInUse: 300 * mebibyte},
+			},
+		}
+		m1, m2, m3 := newMachine("m1", true), newMachine("m2", true), newMachine("m3", true)
+		controlPlane := newControlPlane(g, workloadCluster, true, m1, m2, m3)
+		r := &KubeadmControlPlaneReconciler{recorder: record.NewFakeRecorder(32)}
+
+		result, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(Equal([]string{"m2"}))
+		g.Expect(workloadCluster.compactEtcdCalled).To(Equal(0))
+
+		g.Expect(conditions.IsTrue(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(m1, controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentationPendingReason))
+		g.Expect(conditions.GetReason(m2, controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneMachineEtcdMemberNotDefragmentingReason))
+		g.Expect(conditions.GetReason(m3, controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneMachineEtcdMemberNotDefragmentingReason))
+	})
+
+	t.Run("does not defragment members below thresholds", func(t *testing.T) {
+		g := NewWithT(t)
+
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdMemberDBStatuses: map[string]*etcd.DBStatus{
+				// m1 is fragmented, but its database is small.
+				"m1": {MemberID: 1, LeaderID: 1, DBSize: 40 * mebibyte, DBSizeInUse: 10 * mebibyte},
+			},
+		}
+		m1 := newMachine("m1", true)
+		controlPlane := newControlPlane(g, workloadCluster, true, m1)
+		r := &KubeadmControlPlaneReconciler{recorder: record.NewFakeRecorder(32)}
+
+		result, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(BeEmpty())
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdNotDefragmentingReason))
+	})
+
+	t.Run("waits for etcd members to be healthy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdMemberDBStatuses: map[string]*etcd.DBStatus{
+				"m1": {MemberID: 1, LeaderID: 1, DBSize: 400 * mebibyte, DBSizeInUse: 100 * mebibyte},
+				"m2": {MemberID: 2, LeaderID: 1, DBSize: 400 * mebibyte, DBSizeInUse: 100 * mebibyte},
+			},
+		}
+		m1, m2 := newMachine("m1", true), newMachine("m2", false)
+		controlPlane := newControlPlane(g, workloadCluster, true, m1, m2)
+		r := &KubeadmControlPlaneReconciler{recorder: record.NewFakeRecorder(32)}
+
+		result, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(BeEmpty())
+		g.Expect(conditions.GetMessage(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition)).To(ContainSubstring("etcd members of Machines m2 to be healthy"))
+	})
+
+	t.Run("reports inspection failures", func(t *testing.T) {
+		g := NewWithT(t)
+
+		workloadCluster := &fakeWorkloadCluster{}
+		m1 := newMachine("m1", true)
+		controlPlane := newControlPlane(g, workloadCluster, true, m1)
+		r := &KubeadmControlPlaneReconciler{recorder: record.NewFakeRecorder(32)}
+
+		result, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdDefragmentingInspectionFailedReason))
+		g.Expect(conditions.GetReason(m1, controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneMachineEtcdMemberDefragmentingInspectionFailedReason))
+	})
+
+	t.Run("handles NOSPACE alarms", func(t *testing.T) {
+		g := NewWithT(t)
+
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdMemberDBStatuses: map[string]*etcd.DBStatus{
+				// Note: members with a NOSPACE alarm are defragmented no matter of the size of their database.
+				"m1": {MemberID: 1, LeaderID: 1, DBSize: 40 * mebibyte, DBSizeInUse: 10 * mebibyte},
+			},
+			EtcdRevision: 10,
+		}
+		m1 := newMachine("m1", false)
+		controlPlane := newControlPlane(g, workloadCluster, true, m1)
+		controlPlane.EtcdMembersAlarms = []etcd.MemberAlarm{{MemberID: 1, Type: etcd.AlarmNoSpace}}
+		r := &KubeadmControlPlaneReconciler{recorder: record.NewFakeRecorder(32)}
+
+		result, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+		g.Expect(workloadCluster.compactEtcdCalled).To(Equal(1))
+		g.Expect(controlPlane.KCP.Annotations).To(HaveKeyWithValue(controlplanev1.EtcdCompactedRevisionAnnotation, "10"))
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(Equal([]string{"m1"}))
+		g.Expect(workloadCluster.disarmedEtcdAlarms).To(BeEmpty())
+
+		// Disarm the alarm once there are no more members to be defragmented.
+		// Note: etcd is not compacted again, because there are no new revisions.
+		workloadCluster.EtcdMemberDBStatuses["m1"] = &etcd.DBStatus{MemberID: 1, LeaderID: 1, DBSize: 10 * mebibyte, DBSizeInUse: 10 * mebibyte}
+		result, err = r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(workloadCluster.compactEtcdCalled).To(Equal(1))
+		g.Expect(workloadCluster.disarmedEtcdAlarms).To(Equal(controlPlane.EtcdMembersAlarms))
+		g.Expect(controlPlane.KCP.Annotations).ToNot(HaveKey(controlplanev1.EtcdCompactedRevisionAnnotation))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdDefragmentingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdNotDefragmentingReason))
+	})
+
+	t.Run("handles NOSPACE alarms with members below thresholds", func(t *testing.T) {
+		g := NewWithT(t)
+
+		workloadCluster := &fakeWorkloadCluster{
+			EtcdMemberDBStatuses: map[string]*etcd.DBStatus{
+				// Note: members with a NOSPACE alarm are defragmented no matter of the thresholds.
+				"m1": {MemberID: 1, LeaderID: 1, DBSize: 40 * mebibyte, DBSizeInUse: 38 * mebibyte},
+			},
+			EtcdRevision: 10,
+		}
+		m1 := newMachine("m1", false)
+		controlPlane := newControlPlane(g, workloadCluster, true, m1)
+		controlPlane.EtcdMembersAlarms = []etcd.MemberAlarm{{MemberID: 1, Type: etcd.AlarmNoSpace}}
+		r := &KubeadmControlPlaneReconciler{recorder: record.NewFakeRecorder(32)}
+
+		result, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+		g.Expect(workloadCluster.compactEtcdCalled).To(Equal(1))
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(Equal([]string{"m1"}))
+		g.Expect(workloadCluster.disarmedEtcdAlarms).To(BeEmpty())
+
+		// Disarm the alarm only after compacting new revisions and checking there is no more space to be released.
+		workloadCluster.EtcdRevision = 12
+		workloadCluster.EtcdMemberDBStatuses["m1"] = &etcd.DBStatus{MemberID: 1, LeaderID: 1, DBSize: 38 * mebibyte, DBSizeInUse: 38 * mebibyte}
+		result, err = r.reconcileEtcdDefragmentation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(workloadCluster.compactEtcdCalled).To(Equal(2))
+		g.Expect(workloadCluster.defragmentedEtcdMembers).To(Equal([]string{"m1"}))
+		g.Expect(workloadCluster.disarmedEtcdAlarms).To(Equal(controlPlane.EtcdMembersAlarms))
+	})
+}
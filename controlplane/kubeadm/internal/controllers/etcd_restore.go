@@ -0,0 +1,253 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/desiredstate"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	capicontrollerutil "sigs.k8s.io/cluster-api/util/controller"
+)
+
+// etcdRestoreRequeueAfter is how long to wait before checking again the progress of an etcd restore.
+const etcdRestoreRequeueAfter = 20 * time.Second
+
+// reconcileEtcdRestore restores etcd from the snapshot defined in spec.etcd.restore, if any.
+// The restore is performed by deleting all the control plane Machines, and then by creating a new control plane Machine
+// initializing a single member etcd cluster from the snapshot; as soon as the etcd cluster is healthy again, the restore
+// is considered completed, and the control plane is scaled up by the regular reconcile.
+// This func returns a non-zero result while the restore is in progress, so the other KCP operations are not performed.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdRestore(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	snapshotName := kcp.Spec.Etcd.Restore.SnapshotName
+
+	if snapshotName == "" || !controlPlane.IsEtcdManaged() {
+		conditions.Delete(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)
+		return ctrl.Result{}, nil
+	}
+
+	if kcp.Status.Etcd.LastRestore.SnapshotName == snapshotName {
+		setEtcdRestoreCompletedCondition(kcp, snapshotName)
+		return ctrl.Result{}, nil
+	}
+
+	// If the Machine restoring etcd already exists, wait for the etcd cluster to become healthy.
+	restoreMachines := controlPlane.Machines.Filter(func(machine *clusterv1.Machine) bool {
+		kubeadmConfig, ok := controlPlane.KubeadmConfigs[machine.Name]
+		return ok && kubeadmConfig.Annotations[bootstrapv1.EtcdRestoreAnnotation] == snapshotName
+	}, collections.Not(collections.HasDeletionTimestamp))
+	if restoreMachine := restoreMachines.Newest(); restoreMachine != nil {
+		if restoreMachine.Status.NodeRef.IsDefined() && conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition) {
+			kcp.Status.Etcd.LastRestore = controlplanev1.KubeadmControlPlaneEtcdRestoreStatus{
+				SnapshotName: snapshotName,
+				Time:         metav1.Now(),
+			}
+			setEtcdRestoreCompletedCondition(kcp, snapshotName)
+			log.Info(fmt.Sprintf("Etcd restored from snapshot %s", snapshotName), "Machine", klog.KObj(restoreMachine))
+			r.recorder.Eventf(kcp, corev1.EventTypeNormal, "EtcdRestoreCompleted", "Etcd restored from snapshot %s", snapshotName)
+			return ctrl.Result{}, nil
+		}
+
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdRestoringReason,
+			Message: fmt.Sprintf("Waiting for Machine %s to restore etcd from snapshot %s", restoreMachine.Name, snapshotName),
+		})
+		return ctrl.Result{RequeueAfter: etcdRestoreRequeueAfter}, nil
+	}
+
+	// Check preconditions before starting the restore.
+	// Note: The restore deletes all the control plane Machines, so it starts only if KCP observed that the etcd cluster
+	// lost quorum; most notably, the restore is blocked when the health of the etcd cluster is unknown, e.g. because
+	// KCP can't reach the workload cluster.
+	// Note: If the restore is already in progress, preconditions are not checked again, because deleting Machines
+	// might change the health of the etcd cluster.
+	if conditions.GetReason(kcp, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition) != controlplanev1.KubeadmControlPlaneEtcdRestoringReason {
+		if !conditions.IsFalse(kcp, controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition) ||
+			conditions.GetReason(kcp, controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition) != controlplanev1.KubeadmControlPlaneEtcdClusterQuorumLostReason {
+			conditions.Set(kcp, metav1.Condition{
+				Type:    controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  controlplanev1.KubeadmControlPlaneEtcdRestoreBlockedReason,
+				Message: fmt.Sprintf("Etcd can't be restored from snapshot %s until the etcd cluster is reported as having lost quorum", snapshotName),
+			})
+			return ctrl.Result{RequeueAfter: etcdRestoreRequeueAfter}, nil
+		}
+	}
+
+	store, err := r.getEtcdSnapshotStore(ctx, kcp)
+	if err != nil {
+		setEtcdRestoreInternalErrorCondition(kcp)
+		return ctrl.Result{}, err
+	}
+	snapshot, err := store.Get(ctx, kcp, snapshotName)
+	if err != nil {
+		setEtcdRestoreInternalErrorCondition(kcp)
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get etcd snapshot %s", snapshotName)
+	}
+	if snapshot == nil {
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdRestoreBlockedReason,
+			Message: fmt.Sprintf("Etcd snapshot %s does not exist", snapshotName),
+		})
+		return ctrl.Result{RequeueAfter: etcdRestoreRequeueAfter}, nil
+	}
+
+	// Snapshots stored in Secrets can't be restored by KCP, because the Machine restoring etcd would have to read them
+	// from its bootstrap data, which is usually subject to size limits way lower than the size of a snapshot.
+	if kcp.Spec.Etcd.Snapshot.Storage.Type != controlplanev1.EtcdSnapshotS3StorageType {
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdRestoreBlockedReason,
+			Message: fmt.Sprintf("Etcd snapshot %s is stored in a Secret and it can't be restored by KCP, only snapshots stored in an object store can be restored", snapshotName),
+		})
+		return ctrl.Result{RequeueAfter: etcdRestoreRequeueAfter}, nil
+	}
+
+	// Delete all the existing control plane Machines.
+	// Note: the etcd cluster has lost quorum, so the pre-terminate hook removing the etcd member cannot be executed;
+	// also drain and wait for volume detach are skipped, because they require a working API server.
+	if len(controlPlane.Machines) > 0 {
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdRestoringReason,
+			Message: fmt.Sprintf("Deleting control plane Machines before restoring etcd from snapshot %s", snapshotName),
+		})
+
+		var errs []error
+		for _, machine := range controlPlane.Machines {
+			if err := r.deleteMachineForEtcdRestore(ctx, controlPlane, machine); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 {
+			return ctrl.Result{}, kerrors.NewAggregate(errs)
+		}
+		return ctrl.Result{RequeueAfter: deleteRequeueAfter}, nil
+	}
+
+	// Create the Machine restoring etcd from the snapshot.
+	restoreSnapshot, err := store.RestoreSnapshot(ctx, kcp, *snapshot)
+	if err != nil {
+		setEtcdRestoreInternalErrorCondition(kcp)
+		return ctrl.Result{}, err
+	}
+	fd, err := controlPlane.NextFailureDomainForScaleUp(ctx)
+	if err != nil {
+		setEtcdRestoreInternalErrorCondition(kcp)
+		return ctrl.Result{}, err
+	}
+	newMachine, err := r.cloneConfigsAndGenerateMachine(ctx, controlPlane.Cluster, kcp, false, fd, func(kubeadmConfig *bootstrapv1.KubeadmConfig) error {
+		return desiredstate.ApplyEtcdRestore(kubeadmConfig, restoreSnapshot)
+	})
+	if err != nil {
+		setEtcdRestoreInternalErrorCondition(kcp)
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdRestore", "Failed to create control plane Machine to restore etcd from snapshot %s: %v", snapshotName, err)
+		return ctrl.Result{}, errors.Wrapf(err, "failed to create control plane Machine to restore etcd from snapshot %s", snapshotName)
+	}
+
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdRestoringReason,
+		Message: fmt.Sprintf("Waiting for Machine %s to restore etcd from snapshot %s", newMachine.Name, snapshotName),
+	})
+	log.Info(fmt.Sprintf("Machine %s created (etcd restore)", klog.KObj(newMachine)), "Machine", klog.KObj(newMachine), "snapshot", snapshotName)
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "EtcdRestoreStarted", "Restoring etcd from snapshot %s on Machine %s", snapshotName, newMachine.Name)
+	return ctrl.Result{RequeueAfter: etcdRestoreRequeueAfter}, nil
+}
+
+// deleteMachineForEtcdRestore deletes a control plane Machine before restoring etcd, skipping all the operations
+// that require a working etcd cluster.
+func (r *KubeadmControlPlaneReconciler) deleteMachineForEtcdRestore(ctx context.Context, controlPlane *internal.ControlPlane, machine *clusterv1.Machine) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	_, hasPreTerminateHook := machine.Annotations[controlplanev1.PreTerminateHookCleanupAnnotation]
+	_, hasExcludeNodeDraining := machine.Annotations[clusterv1.ExcludeNodeDrainingAnnotation]
+	_, hasExcludeWaitForNodeVolumeDetach := machine.Annotations[clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation]
+	if hasPreTerminateHook || !hasExcludeNodeDraining || !hasExcludeWaitForNodeVolumeDetach {
+		machineOriginal := machine.DeepCopy()
+		if machine.Annotations == nil {
+			machine.Annotations = map[string]string{}
+		}
+		delete(machine.Annotations, controlplanev1.PreTerminateHookCleanupAnnotation)
+		machine.Annotations[clusterv1.ExcludeNodeDrainingAnnotation] = ""
+		machine.Annotations[clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation] = ""
+		if err := r.Client.Patch(ctx, machine, client.MergeFrom(machineOriginal)); err != nil {
+			return errors.Wrapf(err, "failed to patch control plane Machine %s before restoring etcd", klog.KObj(machine))
+		}
+	}
+
+	if !machine.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	if deletedMachine, err := r.machineClientWithDeleteResponse.Delete(ctx, machine); err != nil {
+		if !apierrors.IsNotFound(err) {
+			r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, "FailedEtcdRestore",
+				"Failed to delete control plane Machine %s before restoring etcd: %v", machine.Name, err)
+			return errors.Wrapf(err, "failed to delete control plane Machine %s before restoring etcd", klog.KObj(machine))
+		}
+	} else if deletedMachine != nil {
+		r.controller.DeferNextReconcileUntilCacheUpToDate(controlPlane.KCP, capicontrollerutil.StructuredObject(clusterv1.GroupVersion, "Machine"), deletedMachine.GetResourceVersion())
+	}
+
+	// Note: We intentionally log after Delete because we want this log line to show up only after DeletionTimestamp has been set.
+	log.Info(fmt.Sprintf("Machine %s deleting (etcd restore)", klog.KObj(machine)), "Machine", klog.KObj(machine))
+	return nil
+}
+
+func setEtcdRestoreCompletedCondition(kcp *controlplanev1.KubeadmControlPlane, snapshotName string) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdRestoreCompletedReason,
+		Message: fmt.Sprintf("Etcd restored from snapshot %s", snapshotName),
+	})
+}
+
+func setEtcdRestoreInternalErrorCondition(kcp *controlplanev1.KubeadmControlPlane) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdRestoringCondition,
+		Status:  metav1.ConditionUnknown,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdRestoringInternalErrorReason,
+		Message: "Please check controller logs for errors",
+	})
+}
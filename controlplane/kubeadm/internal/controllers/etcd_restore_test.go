@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileEtcdRestore(t *testing.T) {
+	newControlPlane := func(snapshotName string, etcdHealthy bool) *internal.ControlPlane {
+		kcp := &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kcp",
+				Namespace: metav1.NamespaceDefault,
+				UID:       "uid",
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Etcd: controlplanev1.KubeadmControlPlaneEtcdSpec{
+					Restore: controlplanev1.KubeadmControlPlaneEtcdRestoreSpec{
+						SnapshotName: snapshotName,
+					},
+				},
+			},
+		}
+		status, reason := metav1.ConditionFalse, controlplanev1.KubeadmControlPlaneEtcdClusterQuorumLostReason
+		if etcdHealthy {
+			status, reason = metav1.ConditionTrue, controlplanev1.KubeadmControlPlaneEtcdClusterHealthyReason
+		}
+		conditions.Set(kcp, metav1.Condition{
+			Type:   controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition,
+			Status: status,
+			Reason: reason,
+		})
+		return &internal.ControlPlane{
+			KCP: kcp,
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster",
+					Namespace: metav1.NamespaceDefault,
+				},
+			},
+			Machines:       collections.New(),
+			KubeadmConfigs: map[string]*bootstrapv1.KubeadmConfig{},
+		}
+	}
+
+	t.Run("does nothing if a restore is not requested", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane("", false)
+		result, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(conditions.Get(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(BeNil())
+	})
+
+	t.Run("does nothing if the restore is already completed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane("snapshot", true)
+		controlPlane.KCP.Status.Etcd.LastRestore.SnapshotName = "snapshot"
+		result, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdRestoreCompletedReason))
+	})
+
+	t.Run("blocks the restore if the etcd cluster is healthy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane("snapshot", true)
+		result, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(etcdRestoreRequeueAfter))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdRestoreBlockedReason))
+	})
+
+	t.Run("blocks the restore if the etcd cluster did not lose quorum", func(t *testing.T) {
+		for _, etcdClusterHealthy := range []metav1.Condition{
+			{Status: metav1.ConditionUnknown, Reason: controlplanev1.KubeadmControlPlaneEtcdClusterConnectionDownReason},
+			{Status: metav1.ConditionUnknown, Reason: controlplanev1.KubeadmControlPlaneEtcdClusterInspectionFailedReason},
+			{Status: metav1.ConditionFalse, Reason: controlplanev1.KubeadmControlPlaneEtcdClusterNotHealthyReason},
+		} {
+			g := NewWithT(t)
+
+			r := &KubeadmControlPlaneReconciler{
+				Client:   newFakeClient(),
+				recorder: record.NewFakeRecorder(32),
+			}
+
+			controlPlane := newControlPlane("snapshot", false)
+			controlPlane.Machines = collections.FromMachines(&clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "m1", Namespace: metav1.NamespaceDefault}})
+			etcdClusterHealthy.Type = controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition
+			conditions.Set(controlPlane.KCP, etcdClusterHealthy)
+
+			result, err := r.reconcileEtcdRestore(ctx, controlPlane)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result.RequeueAfter).To(Equal(etcdRestoreRequeueAfter))
+			g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdRestoreBlockedReason), "unexpected reason with EtcdClusterHealthy %s", etcdClusterHealthy.Reason)
+			g.Expect(conditions.GetMessage(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(ContainSubstring("lost quorum"))
+		}
+	})
+
+	t.Run("blocks the restore if the snapshot does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane("snapshot", false)
+		result, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(etcdRestoreRequeueAfter))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdRestoreBlockedReason))
+		g.Expect(conditions.GetMessage(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(ContainSubstring("does not exist"))
+	})
+
+	t.Run("blocks the restore if the snapshot is stored in a Secret", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane("snapshot", false)
+		store := &secretEtcdSnapshotStore{client: r.Client}
+		g.Expect(store.Save(ctx, controlPlane.KCP, etcdSnapshot{Name: "snapshot"}, []byte("snapshot"))).To(Succeed())
+
+		result, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(etcdRestoreRequeueAfter))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdRestoreBlockedReason))
+		g.Expect(conditions.GetMessage(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(ContainSubstring("stored in a Secret"))
+	})
+
+	t.Run("waits for the Machine restoring etcd", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane("snapshot", false)
+		machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: metav1.NamespaceDefault}}
+		controlPlane.Machines.Insert(machine)
+		controlPlane.KubeadmConfigs[machine.Name] = &bootstrapv1.KubeadmConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        machine.Name,
+				Namespace:   metav1.NamespaceDefault,
+				Annotations: map[string]string{bootstrapv1.EtcdRestoreAnnotation: "snapshot"},
+			},
+		}
+
+		result, err := r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(etcdRestoreRequeueAfter))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdRestoringReason))
+
+		// Complete the restore as soon as the Machine has a Node and etcd is healthy.
+		machine.Status.NodeRef = clusterv1.MachineNodeReference{Name: "node-1"}
+		conditions.Set(controlPlane.KCP, metav1.Condition{
+			Type:   controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition,
+			Status: metav1.ConditionTrue,
+			Reason: "Reason",
+		})
+		result, err = r.reconcileEtcdRestore(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(controlPlane.KCP.Status.Etcd.LastRestore.SnapshotName).To(Equal("snapshot"))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdRestoringCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdRestoreCompletedReason))
+	})
+}
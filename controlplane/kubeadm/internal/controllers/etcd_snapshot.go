@@ -0,0 +1,510 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/desiredstate"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/objectstore"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// etcdSnapshotTimeout is the maximum time allowed for taking an etcd snapshot.
+	etcdSnapshotTimeout = 2 * time.Minute
+
+	// etcdSnapshotFailureTimeout is the maximum time operations removing etcd members are blocked by failing etcd snapshots;
+	// after this timeout, etcd members are removed without taking a snapshot until a snapshot succeeds again.
+	etcdSnapshotFailureTimeout = 15 * time.Minute
+
+	// etcdSnapshotSecretKey is the key of the Secret data storing an etcd snapshot.
+	etcdSnapshotSecretKey = "snapshot"
+
+	// etcdSnapshotSecretMaxSize is the max size of an etcd snapshot stored in a Secret, after compression and encoding.
+	// Note: The limit is slightly lower than the max size of a Secret (1MiB), so there is room for metadata.
+	etcdSnapshotSecretMaxSize = 1000 * 1024
+
+	// etcdSnapshotDownloadURLSecretKey is the key of the Secret data storing the URL to download an etcd snapshot
+	// from an object store.
+	etcdSnapshotDownloadURLSecretKey = "downloadURL"
+
+	// etcdSnapshotDownloadURLExpiry is how long the URL to download an etcd snapshot from an object store is valid;
+	// the Machine restoring etcd must download the snapshot within this time.
+	etcdSnapshotDownloadURLExpiry = 24 * time.Hour
+
+	// etcdSnapshotS3AccessKeyIDSecretKey and etcdSnapshotS3SecretAccessKeySecretKey are the keys of the Secret data
+	// storing the credentials for an S3 compatible object store.
+	etcdSnapshotS3AccessKeyIDSecretKey     = "accessKeyID"
+	etcdSnapshotS3SecretAccessKeySecretKey = "secretAccessKey"
+)
+
+// etcdSnapshot is an etcd snapshot taken by KCP.
+type etcdSnapshot struct {
+	Name              string
+	ClusterName       string
+	MachineName       string
+	EtcdVersion       string
+	SHA256            string
+	CreationTimestamp metav1.Time
+}
+
+// etcdSnapshotStore stores etcd snapshots taken by KCP.
+type etcdSnapshotStore interface {
+	// Save stores an etcd snapshot.
+	Save(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, snapshot etcdSnapshot, data []byte) error
+
+	// Get returns an etcd snapshot, or nil if the snapshot does not exist.
+	Get(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) (*etcdSnapshot, error)
+
+	// List returns all the etcd snapshots taken by a KubeadmControlPlane.
+	List(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) ([]etcdSnapshot, error)
+
+	// Delete deletes an etcd snapshot.
+	Delete(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) error
+
+	// RestoreSnapshot returns how the Machine restoring etcd reads an etcd snapshot.
+	RestoreSnapshot(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, snapshot etcdSnapshot) (desiredstate.EtcdRestoreSnapshot, error)
+}
+
+// getEtcdSnapshotStore returns the etcdSnapshotStore configured for a KubeadmControlPlane.
+func (r *KubeadmControlPlaneReconciler) getEtcdSnapshotStore(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) (etcdSnapshotStore, error) {
+	storage := kcp.Spec.Etcd.Snapshot.Storage
+	switch storage.Type {
+	case "", controlplanev1.EtcdSnapshotSecretStorageType:
+		return &secretEtcdSnapshotStore{client: r.Client}, nil
+	case controlplanev1.EtcdSnapshotS3StorageType:
+		credentials := &corev1.Secret{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: storage.S3.CredentialsSecretName}, credentials); err != nil {
+			return nil, errors.Wrapf(err, "failed to get credentials for the etcd snapshot object store from Secret %s", storage.S3.CredentialsSecretName)
+		}
+		objectStore, err := objectstore.NewS3Client(storage.S3.Endpoint, storage.S3.Region, storage.S3.Bucket,
+			string(credentials.Data[etcdSnapshotS3AccessKeyIDSecretKey]), string(credentials.Data[etcdSnapshotS3SecretAccessKeySecretKey]))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create etcd snapshot object store client")
+		}
+		return &objectEtcdSnapshotStore{client: r.Client, objectStore: objectStore, prefix: storage.S3.Prefix}, nil
+	default:
+		return nil, errors.Errorf("etcd snapshot storage type %q is not supported", kcp.Spec.Etcd.Snapshot.Storage.Type)
+	}
+}
+
+// reconcileEtcdSnapshot takes an etcd snapshot before the etcd member hosted on machineToDelete is removed, if enabled.
+// If the snapshot cannot be taken, an error is returned and the operation removing the etcd member should not proceed;
+// if etcd snapshots kept failing for longer than etcdSnapshotFailureTimeout, the failure is reported via the
+// EtcdSnapshotSucceeded condition and an event, and the operation removing the etcd member is not blocked anymore.
+// Note: A snapshot is taken only once for each Machine, so this func is safe to be called again if the
+// operation removing the etcd member fails after the snapshot has been taken.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdSnapshot(ctx context.Context, controlPlane *internal.ControlPlane, workloadCluster internal.WorkloadCluster, machineToDelete *clusterv1.Machine, reason string) error {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	if !controlPlane.IsEtcdManaged() || !ptr.Deref(kcp.Spec.Etcd.Snapshot.Enabled, false) {
+		return nil
+	}
+
+	name := etcdSnapshotName(kcp, machineToDelete)
+	if err := r.takeEtcdSnapshot(ctx, controlPlane, workloadCluster, machineToDelete, name, reason); err != nil {
+		// Note: The condition is false since the first of consecutive failures, and setting it again with the same status
+		// preserves lastTransitionTime; this allows to compute for how long etcd snapshots have been failing.
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdSnapshotFailedReason,
+			Message: fmt.Sprintf("Failed to take etcd snapshot %s before deleting Machine %s", name, machineToDelete.Name),
+		})
+		failingSince := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition).LastTransitionTime
+		if time.Since(failingSince.Time) < etcdSnapshotFailureTimeout {
+			return err
+		}
+
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdSnapshotSkippedReason,
+			Message: fmt.Sprintf("Etcd snapshots failing for more than %s, Machine %s deleted without taking a snapshot", etcdSnapshotFailureTimeout, machineToDelete.Name),
+		})
+		log.Error(err, fmt.Sprintf("Etcd snapshots failing for more than %s, deleting Machine %s (%s) without taking a snapshot", etcdSnapshotFailureTimeout, klog.KObj(machineToDelete), reason), "Machine", klog.KObj(machineToDelete))
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "EtcdSnapshotSkipped",
+			"Etcd snapshots failing for more than %s, deleting Machine %s (%s) without taking a snapshot", etcdSnapshotFailureTimeout, machineToDelete.Name, reason)
+		return nil
+	}
+
+	conditions.Set(kcp, metav1.Condition{
+		Type:   controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition,
+		Status: metav1.ConditionTrue,
+		Reason: controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededReason,
+	})
+	return nil
+}
+
+// takeEtcdSnapshot takes an etcd snapshot and stores it, unless a snapshot with the same name already exists.
+func (r *KubeadmControlPlaneReconciler) takeEtcdSnapshot(ctx context.Context, controlPlane *internal.ControlPlane, workloadCluster internal.WorkloadCluster, machineToDelete *clusterv1.Machine, name, reason string) error {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	store, err := r.getEtcdSnapshotStore(ctx, kcp)
+	if err != nil {
+		return errors.Wrapf(err, "failed to take etcd snapshot %s", name)
+	}
+
+	existingSnapshot, err := store.Get(ctx, kcp, name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to take etcd snapshot %s", name)
+	}
+	if existingSnapshot != nil {
+		return nil
+	}
+
+	// We cannot perform any etcd operation without a list of nodes.
+	if controlPlane.NodeListError != nil {
+		return errors.Wrapf(controlPlane.NodeListError, "failed to take etcd snapshot %s", name)
+	}
+
+	snapshotCtx, cancel := context.WithTimeout(ctx, etcdSnapshotTimeout)
+	defer cancel()
+
+	data := &bytes.Buffer{}
+	etcdVersion, err := workloadCluster.TakeEtcdSnapshot(snapshotCtx, data, controlPlane.Nodes)
+	if err != nil {
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdSnapshot",
+			"Failed to take etcd snapshot before deleting Machine %s (%s): %v", machineToDelete.Name, reason, err)
+		return errors.Wrapf(err, "failed to take etcd snapshot %s", name)
+	}
+
+	checksum := sha256.Sum256(data.Bytes())
+	snapshot := etcdSnapshot{
+		Name:              name,
+		ClusterName:       controlPlane.Cluster.Name,
+		MachineName:       machineToDelete.Name,
+		EtcdVersion:       etcdVersion,
+		SHA256:            hex.EncodeToString(checksum[:]),
+		CreationTimestamp: metav1.Now(),
+	}
+	if err := store.Save(ctx, kcp, snapshot, data.Bytes()); err != nil {
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdSnapshot",
+			"Failed to store etcd snapshot before deleting Machine %s (%s): %v", machineToDelete.Name, reason, err)
+		return errors.Wrapf(err, "failed to store etcd snapshot %s", name)
+	}
+
+	kcp.Status.Etcd.LastSnapshot = controlplanev1.KubeadmControlPlaneEtcdSnapshotStatus{
+		Name: name,
+		Time: snapshot.CreationTimestamp,
+	}
+	log.Info(fmt.Sprintf("Etcd snapshot %s taken before deleting Machine %s (%s)", name, klog.KObj(machineToDelete), reason), "Machine", klog.KObj(machineToDelete))
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "EtcdSnapshotTaken",
+		"Etcd snapshot %s taken before deleting Machine %s (%s)", name, machineToDelete.Name, reason)
+
+	// Delete the oldest snapshots exceeding retention.
+	// Note: Failures in deleting old snapshots do not block the operation removing the etcd member.
+	if err := pruneEtcdSnapshots(ctx, store, kcp); err != nil {
+		log.Error(err, "Failed to delete old etcd snapshots")
+	}
+	return nil
+}
+
+// pruneEtcdSnapshots deletes the oldest etcd snapshots exceeding retention.
+func pruneEtcdSnapshots(ctx context.Context, store etcdSnapshotStore, kcp *controlplanev1.KubeadmControlPlane) error {
+	retention := kcp.Spec.Etcd.Snapshot.Retention
+	if retention == 0 {
+		retention = controlplanev1.DefaultEtcdSnapshotRetention
+	}
+
+	snapshots, err := store.List(ctx, kcp)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) <= int(retention) {
+		return nil
+	}
+
+	// Sort snapshots from the newest to the oldest.
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if snapshots[i].CreationTimestamp.Equal(&snapshots[j].CreationTimestamp) {
+			return snapshots[i].Name > snapshots[j].Name
+		}
+		return snapshots[j].CreationTimestamp.Before(&snapshots[i].CreationTimestamp)
+	})
+	for _, snapshot := range snapshots[retention:] {
+		// Never delete the snapshot that is going to be restored.
+		if snapshot.Name == kcp.Spec.Etcd.Restore.SnapshotName {
+			continue
+		}
+		if err := store.Delete(ctx, kcp, snapshot.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// etcdSnapshotName returns the name of the etcd snapshot taken before deleting a Machine.
+func etcdSnapshotName(kcp *controlplanev1.KubeadmControlPlane, machine *clusterv1.Machine) string {
+	return fmt.Sprintf("%s-etcd-snapshot-%s", kcp.Name, machine.Name)
+}
+
+// secretEtcdSnapshotStore stores etcd snapshots in Secrets.
+// Note: Snapshots are compressed using gzip and base64 encoded, so they can be restored manually using e.g.
+// kubectl get secret <snapshot-name> -o jsonpath='{.data.snapshot}' | base64 -d | base64 -d | gunzip > snapshot.db.
+type secretEtcdSnapshotStore struct {
+	client client.Client
+}
+
+var _ etcdSnapshotStore = &secretEtcdSnapshotStore{}
+
+func (s *secretEtcdSnapshotStore) Save(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, snapshot etcdSnapshot, data []byte) error {
+	compressed := &bytes.Buffer{}
+	w := gzip.NewWriter(compressed)
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrap(err, "failed to compress etcd snapshot")
+	}
+	if err := w.Close(); err != nil {
+		return errors.Wrap(err, "failed to compress etcd snapshot")
+	}
+	encoded := base64.StdEncoding.EncodeToString(compressed.Bytes())
+	if len(encoded) > etcdSnapshotSecretMaxSize {
+		return errors.Errorf("etcd snapshot is too big to be stored in a Secret: %d bytes after compression, max %d bytes", len(encoded), etcdSnapshotSecretMaxSize)
+	}
+
+	secret := newEtcdSnapshotSecret(kcp, snapshot)
+	secret.Data = map[string][]byte{
+		etcdSnapshotSecretKey: []byte(encoded),
+	}
+	if err := s.client.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *secretEtcdSnapshotStore) Get(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) (*etcdSnapshot, error) {
+	secret, err := getEtcdSnapshotSecret(ctx, s.client, kcp, name)
+	if err != nil || secret == nil {
+		return nil, err
+	}
+	snapshot := secretToEtcdSnapshot(secret)
+	return &snapshot, nil
+}
+
+func (s *secretEtcdSnapshotStore) List(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) ([]etcdSnapshot, error) {
+	return listEtcdSnapshotSecrets(ctx, s.client, kcp)
+}
+
+func (s *secretEtcdSnapshotStore) Delete(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) error {
+	return deleteEtcdSnapshotSecret(ctx, s.client, kcp, name)
+}
+
+// RestoreSnapshot returns an error, because etcd snapshots stored in Secrets can't be restored by KCP.
+// Note: The Machine restoring etcd would have to read the snapshot from its bootstrap data, which is usually subject
+// to size limits way lower than the size of a snapshot; those snapshots can be restored manually.
+func (s *secretEtcdSnapshotStore) RestoreSnapshot(_ context.Context, _ *controlplanev1.KubeadmControlPlane, snapshot etcdSnapshot) (desiredstate.EtcdRestoreSnapshot, error) {
+	return desiredstate.EtcdRestoreSnapshot{}, errors.Errorf("etcd snapshot %s is stored in a Secret and it can't be restored by KCP", snapshot.Name)
+}
+
+// etcdSnapshotObjectStore is an object store etcd snapshots can be stored in.
+type etcdSnapshotObjectStore interface {
+	// PutObject stores an object.
+	PutObject(ctx context.Context, key string, data []byte) error
+
+	// DeleteObject deletes an object; deleting an object that does not exist is not an error.
+	DeleteObject(ctx context.Context, key string) error
+
+	// PresignGetObject returns a URL that can be used to download an object without credentials until it expires.
+	PresignGetObject(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+var _ etcdSnapshotObjectStore = &objectstore.S3Client{}
+
+// objectEtcdSnapshotStore stores etcd snapshots in an object store.
+// Note: Each snapshot is tracked by a Secret without the snapshot data, so snapshots can be listed and garbage
+// collected together with the KubeadmControlPlane; the Secret is also used to pass the URL to download the
+// snapshot to the Machine restoring etcd.
+type objectEtcdSnapshotStore struct {
+	client      client.Client
+	objectStore etcdSnapshotObjectStore
+	prefix      string
+}
+
+var _ etcdSnapshotStore = &objectEtcdSnapshotStore{}
+
+func (s *objectEtcdSnapshotStore) Save(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, snapshot etcdSnapshot, data []byte) error {
+	if err := s.objectStore.PutObject(ctx, s.objectKey(kcp, snapshot.Name), data); err != nil {
+		return err
+	}
+	if err := s.client.Create(ctx, newEtcdSnapshotSecret(kcp, snapshot)); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *objectEtcdSnapshotStore) Get(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) (*etcdSnapshot, error) {
+	secret, err := getEtcdSnapshotSecret(ctx, s.client, kcp, name)
+	if err != nil || secret == nil {
+		return nil, err
+	}
+	snapshot := secretToEtcdSnapshot(secret)
+	return &snapshot, nil
+}
+
+func (s *objectEtcdSnapshotStore) List(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) ([]etcdSnapshot, error) {
+	return listEtcdSnapshotSecrets(ctx, s.client, kcp)
+}
+
+func (s *objectEtcdSnapshotStore) Delete(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) error {
+	if err := s.objectStore.DeleteObject(ctx, s.objectKey(kcp, name)); err != nil {
+		return err
+	}
+	return deleteEtcdSnapshotSecret(ctx, s.client, kcp, name)
+}
+
+// RestoreSnapshot stores a pre-signed URL to download an etcd snapshot in the Secret tracking the snapshot, and
+// returns the Secret as a source for the URL, so the Machine restoring etcd downloads the snapshot from the object store.
+func (s *objectEtcdSnapshotStore) RestoreSnapshot(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, snapshot etcdSnapshot) (desiredstate.EtcdRestoreSnapshot, error) {
+	downloadURL, err := s.objectStore.PresignGetObject(ctx, s.objectKey(kcp, snapshot.Name), etcdSnapshotDownloadURLExpiry)
+	if err != nil {
+		return desiredstate.EtcdRestoreSnapshot{}, errors.Wrapf(err, "failed to generate URL to download etcd snapshot %s", snapshot.Name)
+	}
+
+	secret, err := getEtcdSnapshotSecret(ctx, s.client, kcp, snapshot.Name)
+	if err != nil {
+		return desiredstate.EtcdRestoreSnapshot{}, err
+	}
+	if secret == nil {
+		return desiredstate.EtcdRestoreSnapshot{}, errors.Errorf("etcd snapshot %s does not exist", snapshot.Name)
+	}
+	secretOriginal := secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[etcdSnapshotDownloadURLSecretKey] = []byte(downloadURL)
+	if err := s.client.Patch(ctx, secret, client.MergeFrom(secretOriginal)); err != nil {
+		return desiredstate.EtcdRestoreSnapshot{}, errors.Wrapf(err, "failed to store URL to download etcd snapshot %s", snapshot.Name)
+	}
+
+	return desiredstate.EtcdRestoreSnapshot{
+		Name:        snapshot.Name,
+		EtcdVersion: snapshot.EtcdVersion,
+		DownloadURLFrom: bootstrapv1.FileSource{
+			Secret: bootstrapv1.SecretFileSource{
+				Name: snapshot.Name,
+				Key:  etcdSnapshotDownloadURLSecretKey,
+			},
+		},
+		SHA256: snapshot.SHA256,
+	}, nil
+}
+
+// objectKey returns the key of the object storing an etcd snapshot.
+func (s *objectEtcdSnapshotStore) objectKey(kcp *controlplanev1.KubeadmControlPlane, name string) string {
+	return path.Join(s.prefix, kcp.Namespace, name)
+}
+
+// newEtcdSnapshotSecret returns the Secret tracking an etcd snapshot.
+func newEtcdSnapshotSecret(kcp *controlplanev1.KubeadmControlPlane, snapshot etcdSnapshot) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      snapshot.Name,
+			Namespace: kcp.Namespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:       snapshot.ClusterName,
+				controlplanev1.EtcdSnapshotLabel: kcp.Name,
+			},
+			Annotations: map[string]string{
+				controlplanev1.EtcdSnapshotMachineAnnotation: snapshot.MachineName,
+				controlplanev1.EtcdSnapshotVersionAnnotation: snapshot.EtcdVersion,
+				controlplanev1.EtcdSnapshotSHA256Annotation:  snapshot.SHA256,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind)),
+			},
+		},
+		Type: clusterv1.ClusterSecretType,
+	}
+}
+
+// getEtcdSnapshotSecret returns the Secret tracking an etcd snapshot, or nil if the Secret does not exist.
+func getEtcdSnapshotSecret(ctx context.Context, c client.Client, kcp *controlplanev1.KubeadmControlPlane, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if secret.Labels[controlplanev1.EtcdSnapshotLabel] != kcp.Name {
+		return nil, errors.Errorf("Secret %s is not an etcd snapshot taken by KubeadmControlPlane %s", klog.KObj(secret), klog.KObj(kcp))
+	}
+	return secret, nil
+}
+
+// listEtcdSnapshotSecrets returns all the etcd snapshots tracked by Secrets for a KubeadmControlPlane.
+func listEtcdSnapshotSecrets(ctx context.Context, c client.Client, kcp *controlplanev1.KubeadmControlPlane) ([]etcdSnapshot, error) {
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(kcp.Namespace), client.MatchingLabels{controlplanev1.EtcdSnapshotLabel: kcp.Name}); err != nil {
+		return nil, err
+	}
+	snapshots := make([]etcdSnapshot, 0, len(secrets.Items))
+	for i := range secrets.Items {
+		snapshots = append(snapshots, secretToEtcdSnapshot(&secrets.Items[i]))
+	}
+	return snapshots, nil
+}
+
+// deleteEtcdSnapshotSecret deletes the Secret tracking an etcd snapshot.
+func deleteEtcdSnapshotSecret(ctx context.Context, c client.Client, kcp *controlplanev1.KubeadmControlPlane, name string) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: kcp.Namespace,
+		},
+	}
+	if err := c.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func secretToEtcdSnapshot(secret *corev1.Secret) etcdSnapshot {
+	return etcdSnapshot{
+		Name:              secret.Name,
+		ClusterName:       secret.Labels[clusterv1.ClusterNameLabel],
+		MachineName:       secret.Annotations[controlplanev1.EtcdSnapshotMachineAnnotation],
+		EtcdVersion:       secret.Annotations[controlplanev1.EtcdSnapshotVersionAnnotation],
+		SHA256:            secret.Annotations[controlplanev1.EtcdSnapshotSHA256Annotation],
+		CreationTimestamp: secret.CreationTimestamp,
+	}
+}
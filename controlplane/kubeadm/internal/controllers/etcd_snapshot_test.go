@@ -0,0 +1,348 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/desiredstate"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileEtcdSnapshot(t *testing.T) {
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-1",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	newControlPlane := func(enabled bool) *internal.ControlPlane {
+		return &internal.ControlPlane{
+			KCP: &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kcp",
+					Namespace: metav1.NamespaceDefault,
+					UID:       "uid",
+				},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Etcd: controlplanev1.KubeadmControlPlaneEtcdSpec{
+						Snapshot: controlplanev1.KubeadmControlPlaneEtcdSnapshotSpec{
+							Enabled: ptr.To(enabled),
+						},
+					},
+				},
+			},
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster",
+					Namespace: metav1.NamespaceDefault,
+				},
+			},
+		}
+	}
+
+	t.Run("does nothing if etcd snapshots are not enabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeClient := newFakeClient()
+		workloadCluster := &fakeWorkloadCluster{}
+		r := &KubeadmControlPlaneReconciler{
+			Client:   fakeClient,
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane(false)
+		g.Expect(r.reconcileEtcdSnapshot(ctx, controlPlane, workloadCluster, machine, "scale down")).To(Succeed())
+		g.Expect(workloadCluster.takeEtcdSnapshotCalled).To(Equal(0))
+		g.Expect(controlPlane.KCP.Status.Etcd.LastSnapshot.Name).To(BeEmpty())
+	})
+
+	t.Run("takes an etcd snapshot only once", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeClient := newFakeClient()
+		workloadCluster := &fakeWorkloadCluster{EtcdSnapshotData: []byte("snapshot")}
+		r := &KubeadmControlPlaneReconciler{
+			Client:   fakeClient,
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane(true)
+		g.Expect(r.reconcileEtcdSnapshot(ctx, controlPlane, workloadCluster, machine, "scale down")).To(Succeed())
+		g.Expect(workloadCluster.takeEtcdSnapshotCalled).To(Equal(1))
+		g.Expect(controlPlane.KCP.Status.Etcd.LastSnapshot.Name).To(Equal("kcp-etcd-snapshot-machine-1"))
+
+		secret := &corev1.Secret{}
+		g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "kcp-etcd-snapshot-machine-1"}, secret)).To(Succeed())
+		g.Expect(secret.Type).To(Equal(clusterv1.ClusterSecretType))
+		g.Expect(secret.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "cluster"))
+		g.Expect(secret.Labels).To(HaveKeyWithValue(controlplanev1.EtcdSnapshotLabel, "kcp"))
+		g.Expect(secret.Annotations).To(HaveKeyWithValue(controlplanev1.EtcdSnapshotMachineAnnotation, "machine-1"))
+		g.Expect(secret.Annotations).To(HaveKeyWithValue(controlplanev1.EtcdSnapshotVersionAnnotation, "3.6.4"))
+		g.Expect(secret.Annotations).To(HaveKeyWithValue(controlplanev1.EtcdSnapshotSHA256Annotation, "16a0eeb0791b6c92451fd284dd9f599e0a7dbe7f6ebea6e2d2d06c7f74aec112"))
+		g.Expect(decodeEtcdSnapshot(g, secret.Data[etcdSnapshotSecretKey])).To(Equal([]byte("snapshot")))
+		g.Expect(conditions.IsTrue(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition)).To(BeTrue())
+
+		g.Expect(r.reconcileEtcdSnapshot(ctx, controlPlane, workloadCluster, machine, "scale down")).To(Succeed())
+		g.Expect(workloadCluster.takeEtcdSnapshotCalled).To(Equal(1))
+	})
+
+	t.Run("returns an error if the etcd snapshot fails", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeClient := newFakeClient()
+		workloadCluster := &fakeWorkloadCluster{EtcdSnapshotErr: errors.New("failed to take snapshot")}
+		r := &KubeadmControlPlaneReconciler{
+			Client:   fakeClient,
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane(true)
+		g.Expect(r.reconcileEtcdSnapshot(ctx, controlPlane, workloadCluster, machine, "remediation")).ToNot(Succeed())
+		g.Expect(controlPlane.KCP.Status.Etcd.LastSnapshot.Name).To(BeEmpty())
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdSnapshotFailedReason))
+
+		secrets := &corev1.SecretList{}
+		g.Expect(fakeClient.List(ctx, secrets)).To(Succeed())
+		g.Expect(secrets.Items).To(BeEmpty())
+
+		// Snapshots failing since a short time keep blocking the operation removing the etcd member.
+		g.Expect(r.reconcileEtcdSnapshot(ctx, controlPlane, workloadCluster, machine, "remediation")).ToNot(Succeed())
+	})
+
+	t.Run("does not block removing the etcd member if etcd snapshots keep failing", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeClient := newFakeClient()
+		workloadCluster := &fakeWorkloadCluster{EtcdSnapshotErr: errors.New("failed to take snapshot")}
+		r := &KubeadmControlPlaneReconciler{
+			Client:   fakeClient,
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane(true)
+		conditions.Set(controlPlane.KCP, metav1.Condition{
+			Type:               controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             controlplanev1.KubeadmControlPlaneEtcdSnapshotFailedReason,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-etcdSnapshotFailureTimeout - time.Minute)),
+		})
+		g.Expect(r.reconcileEtcdSnapshot(ctx, controlPlane, workloadCluster, machine, "remediation")).To(Succeed())
+		g.Expect(workloadCluster.takeEtcdSnapshotCalled).To(Equal(1))
+		g.Expect(controlPlane.KCP.Status.Etcd.LastSnapshot.Name).To(BeEmpty())
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition)).To(Equal(controlplanev1.KubeadmControlPlaneEtcdSnapshotSkippedReason))
+
+		// A successful snapshot resets the failure timeout.
+		workloadCluster.EtcdSnapshotErr = nil
+		workloadCluster.EtcdSnapshotData = []byte("snapshot")
+		g.Expect(r.reconcileEtcdSnapshot(ctx, controlPlane, workloadCluster, machine, "remediation")).To(Succeed())
+		g.Expect(controlPlane.KCP.Status.Etcd.LastSnapshot.Name).To(Equal("kcp-etcd-snapshot-machine-1"))
+		g.Expect(conditions.IsTrue(controlPlane.KCP, controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition)).To(BeTrue())
+	})
+}
+
+func TestPruneEtcdSnapshots(t *testing.T) {
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kcp",
+			Namespace: metav1.NamespaceDefault,
+			UID:       "uid",
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Etcd: controlplanev1.KubeadmControlPlaneEtcdSpec{
+				Snapshot: controlplanev1.KubeadmControlPlaneEtcdSnapshotSpec{
+					Enabled:   ptr.To(true),
+					Retention: 2,
+				},
+				Restore: controlplanev1.KubeadmControlPlaneEtcdRestoreSpec{
+					SnapshotName: "snapshot-0",
+				},
+			},
+		},
+	}
+
+	g := NewWithT(t)
+
+	store := &secretEtcdSnapshotStore{client: newFakeClient()}
+	// Note: the fake client sets increasing creation timestamps, so snapshot-4 is the newest snapshot.
+	for i := range 5 {
+		g.Expect(store.Save(ctx, kcp, etcdSnapshot{Name: fmt.Sprintf("snapshot-%d", i)}, []byte("snapshot"))).To(Succeed())
+	}
+
+	g.Expect(pruneEtcdSnapshots(ctx, store, kcp)).To(Succeed())
+
+	snapshots, err := store.List(ctx, kcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	names := []string{}
+	for _, s := range snapshots {
+		names = append(names, s.Name)
+	}
+	// The snapshot to be restored is never deleted.
+	g.Expect(names).To(ConsistOf("snapshot-0", "snapshot-3", "snapshot-4"))
+}
+
+func TestSecretEtcdSnapshotStore(t *testing.T) {
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kcp",
+			Namespace: metav1.NamespaceDefault,
+			UID:       "uid",
+		},
+	}
+
+	t.Run("returns nil if the snapshot does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		store := &secretEtcdSnapshotStore{client: newFakeClient()}
+		snapshot, err := store.Get(ctx, kcp, "snapshot")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(snapshot).To(BeNil())
+	})
+
+	t.Run("returns an error if the Secret is not an etcd snapshot", func(t *testing.T) {
+		g := NewWithT(t)
+
+		store := &secretEtcdSnapshotStore{client: newFakeClient(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "snapshot",
+				Namespace: metav1.NamespaceDefault,
+			},
+		})}
+		_, err := store.Get(ctx, kcp, "snapshot")
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("returns an error if the snapshot is too big", func(t *testing.T) {
+		g := NewWithT(t)
+
+		// Note: random data cannot be compressed, so the snapshot exceeds the max size of a Secret.
+		data := make([]byte, etcdSnapshotSecretMaxSize)
+		_, err := rand.Read(data)
+		g.Expect(err).ToNot(HaveOccurred())
+		store := &secretEtcdSnapshotStore{client: newFakeClient()}
+		g.Expect(store.Save(ctx, kcp, etcdSnapshot{Name: "snapshot"}, data)).ToNot(Succeed())
+	})
+
+	t.Run("returns an error when restoring a snapshot", func(t *testing.T) {
+		g := NewWithT(t)
+
+		store := &secretEtcdSnapshotStore{client: newFakeClient()}
+		_, err := store.RestoreSnapshot(ctx, kcp, etcdSnapshot{Name: "snapshot", EtcdVersion: "3.6.4", SHA256: "sha"})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestObjectEtcdSnapshotStore(t *testing.T) {
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kcp",
+			Namespace: metav1.NamespaceDefault,
+			UID:       "uid",
+		},
+	}
+
+	g := NewWithT(t)
+
+	fakeClient := newFakeClient()
+	objectStore := &fakeEtcdSnapshotObjectStore{objects: map[string][]byte{}}
+	store := &objectEtcdSnapshotStore{client: fakeClient, objectStore: objectStore, prefix: "snapshots"}
+
+	snapshot := etcdSnapshot{Name: "snapshot", ClusterName: "cluster", EtcdVersion: "3.6.4", SHA256: "sha"}
+	g.Expect(store.Save(ctx, kcp, snapshot, []byte("snapshot"))).To(Succeed())
+	g.Expect(objectStore.objects).To(Equal(map[string][]byte{"snapshots/default/snapshot": []byte("snapshot")}))
+
+	// The snapshot data is stored only in the object store.
+	secret := &corev1.Secret{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "snapshot"}, secret)).To(Succeed())
+	g.Expect(secret.Data).To(BeEmpty())
+
+	got, err := store.Get(ctx, kcp, "snapshot")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got.EtcdVersion).To(Equal("3.6.4"))
+	g.Expect(got.SHA256).To(Equal("sha"))
+
+	// The Machine restoring etcd downloads the snapshot using the URL stored in the Secret.
+	restoreSnapshot, err := store.RestoreSnapshot(ctx, kcp, *got)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(restoreSnapshot).To(Equal(desiredstate.EtcdRestoreSnapshot{
+		Name:        "snapshot",
+		EtcdVersion: "3.6.4",
+		DownloadURLFrom: bootstrapv1.FileSource{
+			Secret: bootstrapv1.SecretFileSource{
+				Name: "snapshot",
+				Key:  etcdSnapshotDownloadURLSecretKey,
+			},
+		},
+		SHA256: "sha",
+	}))
+	g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "snapshot"}, secret)).To(Succeed())
+	g.Expect(string(secret.Data[etcdSnapshotDownloadURLSecretKey])).To(Equal("https://objectstore.example.com/snapshots/default/snapshot?expires=86400"))
+
+	g.Expect(store.Delete(ctx, kcp, "snapshot")).To(Succeed())
+	g.Expect(objectStore.objects).To(BeEmpty())
+	got, err = store.Get(ctx, kcp, "snapshot")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(BeNil())
+}
+
+type fakeEtcdSnapshotObjectStore struct {
+	objects map[string][]byte
+}
+
+func (f *fakeEtcdSnapshotObjectStore) PutObject(_ context.Context, key string, data []byte) error {
+	f.objects[key] = data
+	return nil
+}
+
+func (f *fakeEtcdSnapshotObjectStore) DeleteObject(_ context.Context, key string) error {
+	delete(f.objects, key)
+	return nil
+}
+
+func (f *fakeEtcdSnapshotObjectStore) PresignGetObject(_ context.Context, key string, expires time.Duration) (string, error) {
+	return fmt.Sprintf("https://objectstore.example.com/%s?expires=%d", key, int(expires.Seconds())), nil
+}
+
+func decodeEtcdSnapshot(g *WithT, data []byte) []byte {
+	compressed, err := base64.StdEncoding.DecodeString(string(data))
+	g.Expect(err).ToNot(HaveOccurred())
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	g.Expect(err).ToNot(HaveOccurred())
+	decoded, err := io.ReadAll(r)
+	g.Expect(err).ToNot(HaveOccurred())
+	return decoded
+}
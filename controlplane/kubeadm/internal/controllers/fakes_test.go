@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/blang/semver/v4"
@@ -78,9 +79,19 @@ type fakeWorkloadCluster struct {
 	*internal.Workload
 	KubeadmConfigExist         bool
 	APIServerCertificateExpiry *time.Time
+	EtcdSnapshotData           []byte
+	EtcdSnapshotErr            error
+	EtcdMemberDBStatuses       map[string]*etcd.DBStatus
+	EtcdRevision               int64
 
 	forwardEtcdLeadershipCalled int
 	removeEtcdMemberCalled      int
+	takeEtcdSnapshotCalled      int
+	compactEtcdCalled           int
+	defragmentedEtcdMembers     []string
+	disarmedEtcdAlarms          []etcd.MemberAlarm
+
+	clusterInfoCertificateAuthorities []byte
 }
 
 func (f *fakeWorkloadCluster) ForwardEtcdLeadership(_ context.Context, _ *clusterv1.Machine, leaderCandidate *clusterv1.Machine, _ []*internal.Node) error {
@@ -112,10 +123,52 @@ func (f *fakeWorkloadCluster) RemoveEtcdMember(_ context.Context, _ *etcd.Member
 	return nil
 }
 
+func (f *fakeWorkloadCluster) TakeEtcdSnapshot(_ context.Context, out io.Writer, _ []*internal.Node) (string, error) {
+	f.takeEtcdSnapshotCalled++
+	if f.EtcdSnapshotErr != nil {
+		return "", f.EtcdSnapshotErr
+	}
+	if _, err := out.Write(f.EtcdSnapshotData); err != nil {
+		return "", err
+	}
+	return "3.6.4", nil
+}
+
+func (f *fakeWorkloadCluster) GetEtcdMemberDBStatus(_ context.Context, nodeName string) (*etcd.DBStatus, error) {
+	status, ok := f.EtcdMemberDBStatuses[nodeName]
+	if !ok {
+		return nil, errors.Errorf("etcd member %s not found", nodeName)
+	}
+	return status, nil
+}
+
+func (f *fakeWorkloadCluster) DefragmentEtcdMember(_ context.Context, nodeName string) error {
+	f.defragmentedEtcdMembers = append(f.defragmentedEtcdMembers, nodeName)
+	return nil
+}
+
+func (f *fakeWorkloadCluster) CompactEtcd(_ context.Context, _ []*internal.Node, compactedRevision int64) (int64, error) {
+	if f.EtcdRevision <= compactedRevision {
+		return compactedRevision, nil
+	}
+	f.compactEtcdCalled++
+	return f.EtcdRevision, nil
+}
+
+func (f *fakeWorkloadCluster) DisarmEtcdAlarms(_ context.Context, alarms []etcd.MemberAlarm, _ []*internal.Node) error {
+	f.disarmedEtcdAlarms = append(f.disarmedEtcdAlarms, alarms...)
+	return nil
+}
+
 func (f *fakeWorkloadCluster) UpdateClusterConfiguration(context.Context, semver.Version, ...func(*bootstrapv1.ClusterConfiguration)) error {
 	return nil
 }
 
+func (f *fakeWorkloadCluster) UpdateClusterInfoCertificateAuthorities(_ context.Context, caData []byte) error {
+	f.clusterInfoCertificateAuthorities = caData
+	return nil
+}
+
 type fakeMigrator struct {
 	migrateCalled    bool
 	migrateErr       error
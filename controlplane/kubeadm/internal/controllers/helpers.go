@@ -88,11 +88,25 @@ func (r *KubeadmControlPlaneReconciler) reconcileKubeconfig(ctx context.Context,
 		return ctrl.Result{}, err
 	}
 
+	// Also rotate the kubeconfig if the certificate authorities trusted by the kubeconfig are not up to date,
+	// e.g. when certificate authorities are being rotated.
+	if !needsRotation {
+		caSecret, err := secret.GetFromNamespacedName(ctx, r.SecretCachingClient, clusterName, secret.ClusterCA)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to retrieve cluster CA Secret")
+		}
+		needsRotation, err = kubeconfig.NeedsCertificateAuthorityUpdate(configSecret, caSecret)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	if needsRotation {
 		log.Info("Rotating kubeconfig secret")
 		if err := kubeconfig.RegenerateSecret(ctx, r.Client, configSecret, kubeconfig.KeyEncryptionAlgorithm(controlPlane.GetKeyEncryptionAlgorithm())); err != nil {
 			return ctrl.Result{}, errors.Wrap(err, "failed to regenerate kubeconfig")
 		}
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeNormal, "KubeconfigRotated", "Kubeconfig Secret %s has been rotated", configSecret.Name)
 	}
 
 	return ctrl.Result{}, nil
@@ -157,7 +171,7 @@ func (r *KubeadmControlPlaneReconciler) reconcileExternalReference(ctx context.C
 	return r.Client.Patch(ctx, obj, client.MergeFrom(original))
 }
 
-func (r *KubeadmControlPlaneReconciler) cloneConfigsAndGenerateMachine(ctx context.Context, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane, isJoin bool, failureDomain string) (*clusterv1.Machine, error) {
+func (r *KubeadmControlPlaneReconciler) cloneConfigsAndGenerateMachine(ctx context.Context, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane, isJoin bool, failureDomain string, kubeadmConfigMutators ...func(*bootstrapv1.KubeadmConfig) error) (*clusterv1.Machine, error) {
 	var errs []error
 
 	machine, err := desiredstate.ComputeDesiredMachine(kcp, cluster, failureDomain, nil)
@@ -175,7 +189,7 @@ func (r *KubeadmControlPlaneReconciler) cloneConfigsAndGenerateMachine(ctx conte
 	machine.Spec.InfrastructureRef = infraRef
 
 	// Clone the bootstrap configuration
-	bootstrapConfig, bootstrapRef, err := r.createKubeadmConfig(ctx, kcp, cluster, isJoin, machine.Name)
+	bootstrapConfig, bootstrapRef, err := r.createKubeadmConfig(ctx, kcp, cluster, isJoin, machine.Name, kubeadmConfigMutators...)
 	if err != nil {
 		v1beta1conditions.MarkFalse(kcp, controlplanev1.MachinesCreatedV1Beta1Condition, controlplanev1.BootstrapTemplateCloningFailedV1Beta1Reason,
 			clusterv1.ConditionSeverityError, "%s", err.Error())
@@ -249,11 +263,16 @@ func (r *KubeadmControlPlaneReconciler) createInfraMachine(ctx context.Context,
 	}, nil
 }
 
-func (r *KubeadmControlPlaneReconciler) createKubeadmConfig(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, cluster *clusterv1.Cluster, isJoin bool, name string) (*bootstrapv1.KubeadmConfig, clusterv1.ContractVersionedObjectReference, error) {
+func (r *KubeadmControlPlaneReconciler) createKubeadmConfig(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, cluster *clusterv1.Cluster, isJoin bool, name string, mutators ...func(*bootstrapv1.KubeadmConfig) error) (*bootstrapv1.KubeadmConfig, clusterv1.ContractVersionedObjectReference, error) {
 	kubeadmConfig, err := desiredstate.ComputeDesiredKubeadmConfig(kcp, cluster, isJoin, name, nil)
 	if err != nil {
 		return nil, clusterv1.ContractVersionedObjectReference{}, errors.Wrapf(err, "failed to create KubeadmConfig")
 	}
+	for _, mutate := range mutators {
+		if err := mutate(kubeadmConfig); err != nil {
+			return nil, clusterv1.ContractVersionedObjectReference{}, errors.Wrapf(err, "failed to create KubeadmConfig")
+		}
+	}
 
 	// Create the full object with capi-kubeadmcontrolplane.
 	// Below ssa.RemoveManagedFieldsForLabelsAndAnnotations will drop ownership for labels and annotations
@@ -271,6 +271,73 @@ func TestKubeadmControlPlaneReconciler_reconcileKubeconfig(t *testing.T) {
 	g.Expect(kubeconfigSecret.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, cluster.Name))
 }
 
+func TestKubeadmControlPlaneReconciler_reconcileKubeconfigCertificateAuthorityRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "test.local", Port: 8443},
+		},
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: "v1.16.6",
+		},
+	}
+
+	clusterCerts := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
+	g.Expect(clusterCerts.Generate()).To(Succeed())
+	caCertSecret := clusterCerts.GetByPurpose(secret.ClusterCA).AsSecret(
+		client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "foo"},
+		*metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind("KubeadmControlPlane")),
+	)
+
+	fakeClient := newFakeClient(kcp.DeepCopy(), caCertSecret.DeepCopy())
+	r := &KubeadmControlPlaneReconciler{
+		Client:              fakeClient,
+		SecretCachingClient: fakeClient,
+		recorder:            record.NewFakeRecorder(32),
+	}
+
+	controlPlane := &internal.ControlPlane{
+		KCP:     kcp,
+		Cluster: cluster,
+	}
+
+	_, err := r.reconcileKubeconfig(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Start rotating the cluster CA.
+	next, err := secret.GenerateKeyPair(secret.ClusterCA, 0, "")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(caCertSecret), caCertSecret)).To(Succeed())
+	_, err = secret.StartKeyPairRotation(caCertSecret, next)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Client.Update(ctx, caCertSecret)).To(Succeed())
+
+	_, err = r.reconcileKubeconfig(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	kubeconfigSecret := &corev1.Secret{}
+	secretName := client.ObjectKey{
+		Namespace: metav1.NamespaceDefault,
+		Name:      secret.Name(cluster.Name, secret.Kubeconfig),
+	}
+	g.Expect(r.Client.Get(ctx, secretName, kubeconfigSecret)).To(Succeed())
+	needsUpdate, err := kubeconfig.NeedsCertificateAuthorityUpdate(kubeconfigSecret, caCertSecret)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(needsUpdate).To(BeFalse())
+}
+
 func TestCloneConfigsAndGenerateMachineAndSyncMachines(t *testing.T) {
 	setup := func(t *testing.T, g *WithT) *corev1.Namespace {
 		t.Helper()
@@ -306,6 +306,7 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 		// A new machine will come up completing the operation as part of the regular reconcile.
 
 		// If the control plane is initialized, before deleting the machine:
+		// - take an etcd snapshot, if enabled.
 		// - if the machine hosts the etcd leader, forward etcd leadership to another machine.
 		// - delete the etcd member hosted on the machine being deleted.
 		// - remove the etcd member from the kubeadm config map (only for kubernetes version older than v1.22.0)
@@ -331,6 +332,18 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 				})
 				return ctrl.Result{}, nil
 			}
+			if err := r.reconcileEtcdSnapshot(ctx, controlPlane, workloadCluster, machineToBeRemediated, "remediation"); err != nil {
+				log.Error(err, "Failed to take etcd snapshot before remediation")
+				v1beta1conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedV1Beta1Condition, clusterv1.RemediationFailedV1Beta1Reason, clusterv1.ConditionSeverityError, "%s", err.Error())
+
+				conditions.Set(machineToBeRemediated, metav1.Condition{
+					Type:    clusterv1.MachineOwnerRemediatedCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  controlplanev1.KubeadmControlPlaneMachineRemediationInternalErrorReason,
+					Message: "Please check controller logs for errors",
+				})
+				return ctrl.Result{}, err
+			}
 			if err := workloadCluster.ForwardEtcdLeadership(ctx, machineToBeRemediated, etcdLeaderCandidate, controlPlane.Nodes); err != nil {
 				log.Error(err, "Failed to move etcd leadership to candidate machine", "candidate", klog.KObj(etcdLeaderCandidate))
 				v1beta1conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedV1Beta1Condition, clusterv1.RemediationFailedV1Beta1Reason, clusterv1.ConditionSeverityError, "%s", err.Error())
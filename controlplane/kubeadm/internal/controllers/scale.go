@@ -145,6 +145,12 @@ func (r *KubeadmControlPlaneReconciler) scaleDownControlPlane(
 			return ctrl.Result{}, errors.Wrap(controlPlane.NodeListError, "unable to forward etcd leadership")
 		}
 
+		// Take an etcd snapshot before removing the etcd member, if enabled.
+		if err := r.reconcileEtcdSnapshot(ctx, controlPlane, workloadCluster, machineToDelete, "scale down"); err != nil {
+			log.Error(err, "Failed to take etcd snapshot before scale down")
+			return ctrl.Result{}, err
+		}
+
 		etcdLeaderCandidate := controlPlane.Machines.Newest()
 		if err := workloadCluster.ForwardEtcdLeadership(ctx, machineToDelete, etcdLeaderCandidate, controlPlane.Nodes); err != nil {
 			log.Error(err, "Failed to move leadership to candidate machine", "candidate", etcdLeaderCandidate.Name)
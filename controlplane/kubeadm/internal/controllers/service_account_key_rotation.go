@@ -0,0 +1,233 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+const (
+	// serviceAccountKeyRotationRequeueAfter is how long to wait before checking again the progress of a service account signing key rotation.
+	serviceAccountKeyRotationRequeueAfter = 20 * time.Second
+
+	// defaultServiceAccountKeyRotationOverlapPeriod is how long the old public key is still accepted after the new key
+	// is used for signing, if not specified in spec.serviceAccountKeyRotation.overlapPeriodSeconds.
+	defaultServiceAccountKeyRotationOverlapPeriod = time.Hour
+)
+
+// reconcileServiceAccountKeyRotation rotates the key used to sign service account tokens in the workload cluster
+// as requested in spec.serviceAccountKeyRotation.
+// The rotation is performed in phases; at the beginning of each phase the service account Secret is updated, and then
+// all the control plane Machines created before the phase started are rolled out by the regular rollout logic.
+// - TrustNew: a new key is generated and its public key is accepted by kube-apiserver; the old key is still used for signing.
+// - SignWithNew: the new key is used for signing; the old public key is still accepted for the overlap period.
+// - RemoveOld: the old public key is removed.
+// Note: This func is called when the control plane is stable, i.e. all the control plane Machines are up-to-date
+// and the number of replicas matches the desired number of replicas.
+func (r *KubeadmControlPlaneReconciler) reconcileServiceAccountKeyRotation(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	requestID := kcp.Spec.ServiceAccountKeyRotation.RequestID
+	rotation := kcp.Status.ServiceAccountKeyRotation
+
+	if rotation.Phase == "" || rotation.Phase == controlplanev1.RotationCompletedPhase {
+		switch requestID {
+		case "":
+			conditions.Delete(kcp, controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingCondition)
+			return ctrl.Result{}, nil
+		case rotation.RequestID:
+			setServiceAccountKeyRotationCompletedCondition(kcp, rotation.RequestID)
+			return ctrl.Result{}, nil
+		}
+		return r.startServiceAccountKeyRotation(ctx, controlPlane)
+	}
+
+	// Wait for all the control plane Machines created before the current phase started to be rolled out.
+	pending := controlPlane.Machines.Filter(func(machine *clusterv1.Machine) bool {
+		return machine.CreationTimestamp.Before(&rotation.PhaseStartTime) || !machine.Status.NodeRef.IsDefined()
+	})
+	if len(pending) > 0 {
+		setServiceAccountKeyRotatingCondition(kcp, fmt.Sprintf("Rotation %s, phase %s: waiting for Machines to be rolled out: %s", rotation.RequestID, rotation.Phase, summarizeMachineNames(pending)))
+		return ctrl.Result{RequeueAfter: serviceAccountKeyRotationRequeueAfter}, nil
+	}
+
+	// All the control plane Machines have been rolled out, move to the next phase.
+	var nextPhase controlplanev1.KubeadmControlPlaneRotationPhase
+	switch rotation.Phase {
+	case controlplanev1.RotationTrustNewPhase:
+		if err := r.updateServiceAccountKey(ctx, controlPlane.Cluster, secret.SwitchKeyPair); err != nil {
+			setServiceAccountKeyRotationInternalErrorCondition(kcp)
+			return ctrl.Result{}, err
+		}
+		nextPhase = controlplanev1.RotationSignWithNewPhase
+	case controlplanev1.RotationSignWithNewPhase:
+		// Wait for the overlap period, so tokens signed with the old key can be refreshed before the old public key is removed.
+		// Note: The overlap period starts when the last control plane Machine signing with the new key is created,
+		// i.e. when the last control plane Machine signing with the old key starts being deleted.
+		overlapPeriod := defaultServiceAccountKeyRotationOverlapPeriod
+		if kcp.Spec.ServiceAccountKeyRotation.OverlapPeriodSeconds != nil {
+			overlapPeriod = time.Duration(*kcp.Spec.ServiceAccountKeyRotation.OverlapPeriodSeconds) * time.Second
+		}
+		overlapStartTime := rotation.PhaseStartTime.Time
+		for _, machine := range controlPlane.Machines {
+			if machine.CreationTimestamp.After(overlapStartTime) {
+				overlapStartTime = machine.CreationTimestamp.Time
+			}
+		}
+		if remaining := time.Until(overlapStartTime.Add(overlapPeriod)); remaining > 0 {
+			setServiceAccountKeyRotatingCondition(kcp, fmt.Sprintf("Rotation %s, phase %s: waiting %s for tokens signed with the old key to be refreshed", rotation.RequestID, rotation.Phase, remaining.Round(time.Second)))
+			return ctrl.Result{RequeueAfter: min(remaining, serviceAccountKeyRotationRequeueAfter)}, nil
+		}
+
+		if err := r.updateServiceAccountKey(ctx, controlPlane.Cluster, secret.CompleteKeyPairRotation); err != nil {
+			setServiceAccountKeyRotationInternalErrorCondition(kcp)
+			return ctrl.Result{}, err
+		}
+		nextPhase = controlplanev1.RotationRemoveOldPhase
+	case controlplanev1.RotationRemoveOldPhase:
+		kcp.Status.ServiceAccountKeyRotation.Phase = controlplanev1.RotationCompletedPhase
+		setServiceAccountKeyRotationCompletedCondition(kcp, rotation.RequestID)
+		log.Info(fmt.Sprintf("Service account signing key rotation %s completed", rotation.RequestID))
+		r.recorder.Eventf(kcp, corev1.EventTypeNormal, "ServiceAccountKeyRotationCompleted", "Service account signing key rotation %s completed", rotation.RequestID)
+		return ctrl.Result{}, nil
+	default:
+		setServiceAccountKeyRotationInternalErrorCondition(kcp)
+		return ctrl.Result{}, errors.Errorf("unknown service account signing key rotation phase %q", rotation.Phase)
+	}
+
+	r.setServiceAccountKeyRotationPhase(ctx, kcp, nextPhase)
+	return ctrl.Result{RequeueAfter: serviceAccountKeyRotationRequeueAfter}, nil
+}
+
+// startServiceAccountKeyRotation generates a new service account signing key and adds its public key to the accepted ones.
+func (r *KubeadmControlPlaneReconciler) startServiceAccountKeyRotation(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	kcp := controlPlane.KCP
+	requestID := kcp.Spec.ServiceAccountKeyRotation.RequestID
+
+	// Only service account keys generated by Cluster API can be rotated.
+	s, err := secret.GetFromNamespacedName(ctx, r.Client, util.ObjectKey(controlPlane.Cluster), secret.ServiceAccount)
+	if err != nil {
+		setServiceAccountKeyRotationInternalErrorCondition(kcp)
+		return ctrl.Result{}, errors.Wrap(err, "failed to get service account Secret")
+	}
+	if s.Type != clusterv1.ClusterSecretType {
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneServiceAccountKeyRotationBlockedReason,
+			Message: fmt.Sprintf("Rotation %s can't be started: service account Secret %s is provided by the user", requestID, s.Name),
+		})
+		return ctrl.Result{}, nil
+	}
+
+	certificate := secret.NewCertificatesForInitialControlPlane(&kcp.Spec.KubeadmConfigSpec.ClusterConfiguration).GetByPurpose(secret.ServiceAccount)
+	next, err := secret.GenerateKeyPair(secret.ServiceAccount, certificate.ValidityPeriodDays, certificate.KeyEncryptionAlgorithm)
+	if err != nil {
+		setServiceAccountKeyRotationInternalErrorCondition(kcp)
+		return ctrl.Result{}, errors.Wrap(err, "failed to generate service account key")
+	}
+	startRotation := func(s *corev1.Secret) (bool, error) {
+		return secret.StartKeyPairRotation(s, next)
+	}
+	if err := r.updateServiceAccountKey(ctx, controlPlane.Cluster, startRotation); err != nil {
+		setServiceAccountKeyRotationInternalErrorCondition(kcp)
+		return ctrl.Result{}, err
+	}
+
+	kcp.Status.ServiceAccountKeyRotation = controlplanev1.KubeadmControlPlaneServiceAccountKeyRotationStatus{
+		RequestID: requestID,
+	}
+	r.setServiceAccountKeyRotationPhase(ctx, kcp, controlplanev1.RotationTrustNewPhase)
+	return ctrl.Result{RequeueAfter: serviceAccountKeyRotationRequeueAfter}, nil
+}
+
+// updateServiceAccountKey applies a rotation step to the service account Secret.
+// Note: rotation steps are idempotent, so this func can be safely called again in case of errors.
+func (r *KubeadmControlPlaneReconciler) updateServiceAccountKey(ctx context.Context, cluster *clusterv1.Cluster, step func(*corev1.Secret) (bool, error)) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	s, err := secret.GetFromNamespacedName(ctx, r.Client, util.ObjectKey(cluster), secret.ServiceAccount)
+	if err != nil {
+		return errors.Wrap(err, "failed to get service account Secret")
+	}
+
+	changed, err := step(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to rotate service account key")
+	}
+	if !changed {
+		return nil
+	}
+	if err := r.Client.Update(ctx, s); err != nil {
+		return errors.Wrapf(err, "failed to update service account Secret %s", klog.KObj(s))
+	}
+	log.Info(fmt.Sprintf("Updated service account Secret %s", klog.KObj(s)), "Secret", klog.KObj(s))
+	return nil
+}
+
+func (r *KubeadmControlPlaneReconciler) setServiceAccountKeyRotationPhase(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, phase controlplanev1.KubeadmControlPlaneRotationPhase) {
+	log := ctrl.LoggerFrom(ctx)
+	requestID := kcp.Status.ServiceAccountKeyRotation.RequestID
+
+	kcp.Status.ServiceAccountKeyRotation.Phase = phase
+	kcp.Status.ServiceAccountKeyRotation.PhaseStartTime = metav1.Now()
+	setServiceAccountKeyRotatingCondition(kcp, fmt.Sprintf("Rotation %s, phase %s: rolling out Machines", requestID, phase))
+	log.Info(fmt.Sprintf("Service account signing key rotation %s moved to phase %s", requestID, phase))
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "ServiceAccountKeyRotation", "Service account signing key rotation %s moved to phase %s", requestID, phase)
+}
+
+func setServiceAccountKeyRotatingCondition(kcp *controlplanev1.KubeadmControlPlane, message string) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingReason,
+		Message: message,
+	})
+}
+
+func setServiceAccountKeyRotationCompletedCondition(kcp *controlplanev1.KubeadmControlPlane, requestID string) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.KubeadmControlPlaneServiceAccountKeyRotationCompletedReason,
+		Message: fmt.Sprintf("Rotation %s completed", requestID),
+	})
+}
+
+func setServiceAccountKeyRotationInternalErrorCondition(kcp *controlplanev1.KubeadmControlPlane) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingCondition,
+		Status:  metav1.ConditionUnknown,
+		Reason:  controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingInternalErrorReason,
+		Message: "Please check controller logs for errors",
+	})
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestReconcileServiceAccountKeyRotation(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	newServiceAccountSecret := func(g *WithT, secretType corev1.SecretType) *corev1.Secret {
+		keyPair, err := secret.GenerateKeyPair(secret.ServiceAccount, 0, "")
+		g.Expect(err).ToNot(HaveOccurred())
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secret.Name(cluster.Name, secret.ServiceAccount),
+				Namespace: cluster.Namespace,
+			},
+			Type: secretType,
+			Data: map[string][]byte{
+				secret.TLSCrtDataName: keyPair.Cert,
+				secret.TLSKeyDataName: keyPair.Key,
+			},
+		}
+	}
+
+	newControlPlane := func(requestID string, machines ...*clusterv1.Machine) *internal.ControlPlane {
+		return &internal.ControlPlane{
+			KCP: &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kcp",
+					Namespace: metav1.NamespaceDefault,
+					UID:       "uid",
+				},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					ServiceAccountKeyRotation: controlplanev1.KubeadmControlPlaneServiceAccountKeyRotationSpec{
+						RequestID: requestID,
+					},
+				},
+			},
+			Cluster:        cluster,
+			Machines:       collections.FromMachines(machines...),
+			KubeadmConfigs: map[string]*bootstrapv1.KubeadmConfig{},
+		}
+	}
+
+	newMachine := func(name string, creationTimestamp time.Time) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         cluster.Namespace,
+				CreationTimestamp: metav1.NewTime(creationTimestamp),
+			},
+			Status: clusterv1.MachineStatus{NodeRef: clusterv1.MachineNodeReference{Name: name}},
+		}
+	}
+
+	publicKeys := func(g *WithT, s *corev1.Secret) []interface{} {
+		keys, err := keyutil.ParsePublicKeysPEM(s.Data[secret.TLSCrtDataName])
+		g.Expect(err).ToNot(HaveOccurred())
+		return keys
+	}
+
+	t.Run("does nothing if a rotation is not requested", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane("")
+		result, err := r.reconcileServiceAccountKeyRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(conditions.Get(controlPlane.KCP, controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingCondition)).To(BeNil())
+	})
+
+	t.Run("blocks the rotation if the service account key is provided by the user", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(newServiceAccountSecret(g, corev1.SecretTypeOpaque)),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane("rotation-1")
+		result, err := r.reconcileServiceAccountKeyRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneServiceAccountKeyRotationBlockedReason))
+		g.Expect(controlPlane.KCP.Status.ServiceAccountKeyRotation.Phase).To(BeEmpty())
+	})
+
+	t.Run("rotates the service account key", func(t *testing.T) {
+		g := NewWithT(t)
+
+		serviceAccount := newServiceAccountSecret(g, clusterv1.ClusterSecretType)
+		r := &KubeadmControlPlaneReconciler{
+			Client:   newFakeClient(serviceAccount),
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		controlPlane := newControlPlane("rotation-1", newMachine("old-machine", time.Now().Add(-time.Hour)))
+		controlPlane.KCP.Spec.ServiceAccountKeyRotation.OverlapPeriodSeconds = ptr.To[int32](600)
+		rolloutMachines := func() {
+			controlPlane.Machines = collections.FromMachines(newMachine("new-machine", controlPlane.KCP.Status.ServiceAccountKeyRotation.PhaseStartTime.Add(time.Second)))
+		}
+
+		// TrustNew: the new public key is accepted in addition to the old one.
+		result, err := r.reconcileServiceAccountKeyRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(serviceAccountKeyRotationRequeueAfter))
+		g.Expect(controlPlane.KCP.Status.ServiceAccountKeyRotation.RequestID).To(Equal("rotation-1"))
+		g.Expect(controlPlane.KCP.Status.ServiceAccountKeyRotation.Phase).To(Equal(controlplanev1.RotationTrustNewPhase))
+
+		got := &corev1.Secret{}
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(serviceAccount), got)).To(Succeed())
+		g.Expect(publicKeys(g, got)).To(HaveLen(2))
+		g.Expect(got.Data[secret.TLSKeyDataName]).To(Equal(serviceAccount.Data[secret.TLSKeyDataName]))
+		nextKey := got.Data[secret.NextTLSKeyDataName]
+		g.Expect(nextKey).ToNot(BeEmpty())
+
+		// Wait for control plane Machines created before the phase started to be rolled out.
+		result, err = r.reconcileServiceAccountKeyRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(serviceAccountKeyRotationRequeueAfter))
+		g.Expect(controlPlane.KCP.Status.ServiceAccountKeyRotation.Phase).To(Equal(controlplanev1.RotationTrustNewPhase))
+		g.Expect(conditions.GetMessage(controlPlane.KCP, controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingCondition)).To(ContainSubstring("old-machine"))
+
+		// SignWithNew: the new key is used for signing once all the control plane Machines are rolled out.
+		rolloutMachines()
+		result, err = r.reconcileServiceAccountKeyRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(serviceAccountKeyRotationRequeueAfter))
+		g.Expect(controlPlane.KCP.Status.ServiceAccountKeyRotation.Phase).To(Equal(controlplanev1.RotationSignWithNewPhase))
+
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(serviceAccount), got)).To(Succeed())
+		g.Expect(publicKeys(g, got)).To(HaveLen(2))
+		g.Expect(got.Data[secret.TLSKeyDataName]).To(Equal(nextKey))
+
+		// Wait for the overlap period before removing the old public key.
+		rolloutMachines()
+		result, err = r.reconcileServiceAccountKeyRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		g.Expect(controlPlane.KCP.Status.ServiceAccountKeyRotation.Phase).To(Equal(controlplanev1.RotationSignWithNewPhase))
+		g.Expect(conditions.GetMessage(controlPlane.KCP, controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingCondition)).To(ContainSubstring("for tokens signed with the old key to be refreshed"))
+
+		// RemoveOld: the old public key is removed after the overlap period.
+		controlPlane.KCP.Spec.ServiceAccountKeyRotation.OverlapPeriodSeconds = ptr.To[int32](0)
+		result, err = r.reconcileServiceAccountKeyRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(serviceAccountKeyRotationRequeueAfter))
+		g.Expect(controlPlane.KCP.Status.ServiceAccountKeyRotation.Phase).To(Equal(controlplanev1.RotationRemoveOldPhase))
+
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(serviceAccount), got)).To(Succeed())
+		g.Expect(publicKeys(g, got)).To(HaveLen(1))
+		g.Expect(got.Data[secret.TLSKeyDataName]).To(Equal(nextKey))
+
+		// Completed.
+		rolloutMachines()
+		result, err = r.reconcileServiceAccountKeyRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(controlPlane.KCP.Status.ServiceAccountKeyRotation.Phase).To(Equal(controlplanev1.RotationCompletedPhase))
+		g.Expect(conditions.GetReason(controlPlane.KCP, controlplanev1.KubeadmControlPlaneServiceAccountKeyRotatingCondition)).To(Equal(controlplanev1.KubeadmControlPlaneServiceAccountKeyRotationCompletedReason))
+	})
+}
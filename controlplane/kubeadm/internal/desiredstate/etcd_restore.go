@@ -0,0 +1,235 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package desiredstate
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+)
+
+const (
+	// etcdRestoreSnapshotPath is the path where the etcd snapshot to restore is downloaded on the Machine restoring etcd.
+	etcdRestoreSnapshotPath = "/var/lib/etcd-restore/snapshot.db"
+
+	// etcdRestoreDownloadURLPath is the path where the URL to download the etcd snapshot to restore is written on the Machine restoring etcd.
+	etcdRestoreDownloadURLPath = "/var/lib/etcd-restore/snapshot.url"
+
+	// etcdRestoreScriptPath is the path where the script restoring etcd is written on the Machine restoring etcd.
+	etcdRestoreScriptPath = "/run/kubeadm/etcd-restore.sh"
+
+	// kubeadmInitConfigPath is the path where the kubeadm bootstrap provider writes the config for kubeadm init.
+	kubeadmInitConfigPath = "/run/kubeadm/kubeadm.yaml"
+
+	// etcdManifestPath is the path of the etcd static Pod manifest generated by kubeadm.
+	etcdManifestPath = "/etc/kubernetes/manifests/etcd.yaml"
+
+	// defaultEtcdDataDir is the default etcd data dir used by kubeadm.
+	defaultEtcdDataDir = "/var/lib/etcd"
+
+	// defaultEtcdImageRepository is the default image repository used by kubeadm.
+	defaultEtcdImageRepository = "registry.k8s.io"
+)
+
+var etcdRestoreScriptTemplate = template.Must(template.New("etcd-restore").Parse(`#!/bin/bash
+# Restores the etcd data dir from a snapshot before running kubeadm init.
+# This file is generated by KubeadmControlPlane.
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SNAPSHOT={{ .SnapshotPath }}
+DATA_DIR={{ .DataDir }}
+IMAGE={{ .Image }}
+KUBEADM_CONFIG={{ .KubeadmConfigPath }}
+ETCD_MANIFEST={{ .EtcdManifestPath }}
+
+if [ -d "${DATA_DIR}/member" ]; then
+  echo "etcd data dir ${DATA_DIR} already restored"
+  exit 0
+fi
+
+# Read the etcd member name and peer URL from the etcd static Pod manifest generated by kubeadm, so they match
+# the ones used by kubeadm init (e.g. when nodeRegistration.name or localAPIEndpoint.advertiseAddress are set).
+# The manifest is then removed, so it doesn't fail kubeadm init preflight checks.
+kubeadm init phase etcd local --config "${KUBEADM_CONFIG}"
+NODE_NAME="$(sed -n 's/^ *- --name=//p' "${ETCD_MANIFEST}")"
+PEER_URL="$(sed -n 's/^ *- --initial-advertise-peer-urls=//p' "${ETCD_MANIFEST}")"
+rm -f "${ETCD_MANIFEST}"
+if [ -z "${NODE_NAME}" ] || [ -z "${PEER_URL}" ]; then
+  echo "failed to read the etcd member name and peer URL from ${ETCD_MANIFEST}"
+  exit 1
+fi
+
+mkdir -p "$(dirname "${DATA_DIR}")"
+curl --fail --silent --show-error --retry 5 --retry-connrefused --output "${SNAPSHOT}" "$(cat {{ .DownloadURLPath }})"
+rm -f {{ .DownloadURLPath }}
+{{- if .SHA256 }}
+if ! echo "{{ .SHA256 }}  ${SNAPSHOT}" | sha256sum --check --status; then
+  echo "etcd snapshot ${SNAPSHOT} does not match the expected checksum"
+  exit 1
+fi
+{{- end }}
+ctr -n k8s.io images pull "${IMAGE}"
+ctr -n k8s.io run --rm --net-host \
+  --mount "type=bind,src=$(dirname "${SNAPSHOT}"),dst=$(dirname "${SNAPSHOT}"),options=rbind:ro" \
+  --mount "type=bind,src=$(dirname "${DATA_DIR}"),dst=$(dirname "${DATA_DIR}"),options=rbind:rw" \
+  "${IMAGE}" etcd-restore \
+  etcdutl snapshot restore "${SNAPSHOT}" \
+  --data-dir "${DATA_DIR}" \
+  --name "${NODE_NAME}" \
+  --initial-cluster "${NODE_NAME}=${PEER_URL}" \
+  --initial-advertise-peer-urls "${PEER_URL}"
+rm -f "${SNAPSHOT}"
+`))
+
+// EtcdRestoreSnapshot defines the etcd snapshot to be restored by a Machine.
+type EtcdRestoreSnapshot struct {
+	// Name of the etcd snapshot.
+	Name string
+
+	// EtcdVersion is the version of the etcd member the snapshot has been taken from.
+	EtcdVersion string
+
+	// DownloadURLFrom is the source to read the URL to download the snapshot from; the Machine restoring etcd
+	// downloads the snapshot, so the snapshot is not included in the bootstrap data of the Machine, which is usually
+	// subject to size limits of the infrastructure provider.
+	DownloadURLFrom bootstrapv1.FileSource
+
+	// SHA256 is the checksum of the snapshot; when set, the Machine verifies the snapshot before restoring it.
+	SHA256 string
+}
+
+// ApplyEtcdRestore modifies a KubeadmConfig for kubeadm init, so the corresponding Machine restores the etcd data dir
+// from a snapshot before running kubeadm init.
+// Note: changes applied by this func are ignored when checking if the KubeadmConfig is up-to-date.
+func ApplyEtcdRestore(kubeadmConfig *bootstrapv1.KubeadmConfig, snapshot EtcdRestoreSnapshot) error {
+	if snapshot.DownloadURLFrom.Secret.Name == "" {
+		return errors.Errorf("failed to restore snapshot %s: the source of the URL to download the snapshot is not set", snapshot.Name)
+	}
+
+	clusterConfiguration := kubeadmConfig.Spec.ClusterConfiguration
+
+	dataDir := clusterConfiguration.Etcd.Local.DataDir
+	if dataDir == "" {
+		dataDir = defaultEtcdDataDir
+	}
+
+	imageRepository := clusterConfiguration.Etcd.Local.ImageRepository
+	if imageRepository == "" {
+		imageRepository = clusterConfiguration.ImageRepository
+	}
+	if imageRepository == "" {
+		imageRepository = defaultEtcdImageRepository
+	}
+	imageTag := clusterConfiguration.Etcd.Local.ImageTag
+	if imageTag == "" {
+		if snapshot.EtcdVersion == "" {
+			return errors.Errorf("failed to compute the etcd image to restore snapshot %s: etcd version is unknown", snapshot.Name)
+		}
+		// Note: kubeadm uses etcd images tagged with the etcd version and a -<revision> suffix.
+		imageTag = fmt.Sprintf("%s-0", strings.TrimPrefix(snapshot.EtcdVersion, "v"))
+	}
+
+	script := &bytes.Buffer{}
+	if err := etcdRestoreScriptTemplate.Execute(script, map[string]string{
+		"SnapshotPath":      etcdRestoreSnapshotPath,
+		"DownloadURLPath":   etcdRestoreDownloadURLPath,
+		"SHA256":            snapshot.SHA256,
+		"DataDir":           dataDir,
+		"Image":             fmt.Sprintf("%s/etcd:%s", imageRepository, imageTag),
+		"KubeadmConfigPath": kubeadmInitConfigPath,
+		"EtcdManifestPath":  etcdManifestPath,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to generate the script to restore snapshot %s", snapshot.Name)
+	}
+
+	if kubeadmConfig.Annotations == nil {
+		kubeadmConfig.Annotations = map[string]string{}
+	}
+	kubeadmConfig.Annotations[bootstrapv1.EtcdRestoreAnnotation] = snapshot.Name
+
+	kubeadmConfig.Spec.Files = append(kubeadmConfig.Spec.Files,
+		bootstrapv1.File{
+			Path:        etcdRestoreDownloadURLPath,
+			Owner:       "root:root",
+			Permissions: "0600",
+			ContentFrom: snapshot.DownloadURLFrom,
+		},
+		bootstrapv1.File{
+			Path:        etcdRestoreScriptPath,
+			Owner:       "root:root",
+			Permissions: "0700",
+			Content:     script.String(),
+		},
+	)
+	kubeadmConfig.Spec.PreKubeadmCommands = append(kubeadmConfig.Spec.PreKubeadmCommands, etcdRestoreScriptPath)
+
+	// The etcd data dir is populated before running kubeadm init, so the corresponding preflight check must be ignored.
+	preflightError := etcdDataDirPreflightError(dataDir)
+	if !slices.Contains(kubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors, preflightError) {
+		kubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors = append(kubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors, preflightError)
+	}
+	return nil
+}
+
+// RemoveEtcdRestore drops from a KubeadmConfig the changes applied by ApplyEtcdRestore.
+// Note: desiredKubeadmConfig is used to preserve values that are also part of the desired state.
+func RemoveEtcdRestore(desiredKubeadmConfig, kubeadmConfig *bootstrapv1.KubeadmConfig) {
+	if _, ok := kubeadmConfig.Annotations[bootstrapv1.EtcdRestoreAnnotation]; !ok {
+		return
+	}
+
+	kubeadmConfig.Spec.Files = slices.DeleteFunc(kubeadmConfig.Spec.Files, func(f bootstrapv1.File) bool {
+		return f.Path == etcdRestoreDownloadURLPath || f.Path == etcdRestoreScriptPath
+	})
+	if len(kubeadmConfig.Spec.Files) == 0 {
+		kubeadmConfig.Spec.Files = nil
+	}
+	kubeadmConfig.Spec.PreKubeadmCommands = slices.DeleteFunc(kubeadmConfig.Spec.PreKubeadmCommands, func(c string) bool {
+		return c == etcdRestoreScriptPath
+	})
+	if len(kubeadmConfig.Spec.PreKubeadmCommands) == 0 {
+		kubeadmConfig.Spec.PreKubeadmCommands = nil
+	}
+
+	dataDir := kubeadmConfig.Spec.ClusterConfiguration.Etcd.Local.DataDir
+	if dataDir == "" {
+		dataDir = defaultEtcdDataDir
+	}
+	preflightError := etcdDataDirPreflightError(dataDir)
+	if !slices.Contains(desiredKubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors, preflightError) &&
+		!slices.Contains(desiredKubeadmConfig.Spec.JoinConfiguration.NodeRegistration.IgnorePreflightErrors, preflightError) {
+		kubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors = slices.DeleteFunc(kubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors, func(e string) bool {
+			return e == preflightError
+		})
+		if len(kubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors) == 0 {
+			kubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors = nil
+		}
+	}
+}
+
+// etcdDataDirPreflightError returns the name of the kubeadm preflight check validating the etcd data dir is empty.
+func etcdDataDirPreflightError(dataDir string) string {
+	return "DirAvailable-" + strings.ReplaceAll(dataDir, "/", "-")
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package desiredstate
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+)
+
+func Test_ApplyEtcdRestore(t *testing.T) {
+	downloadURLFrom := bootstrapv1.FileSource{
+		Secret: bootstrapv1.SecretFileSource{
+			Name: "snapshot",
+			Key:  "downloadURL",
+		},
+	}
+	snapshot := EtcdRestoreSnapshot{
+		Name:            "snapshot",
+		EtcdVersion:     "3.6.4",
+		DownloadURLFrom: downloadURLFrom,
+	}
+	downloadURLFile := bootstrapv1.File{
+		Path:        etcdRestoreDownloadURLPath,
+		Owner:       "root:root",
+		Permissions: "0600",
+		ContentFrom: downloadURLFrom,
+	}
+
+	tests := []struct {
+		name                 string
+		clusterConfiguration bootstrapv1.ClusterConfiguration
+		snapshot             EtcdRestoreSnapshot
+		wantImage            string
+		wantDownloadURLFile  bootstrapv1.File
+		wantScript           []string
+		wantPreflightError   string
+		wantErr              bool
+	}{
+		{
+			name:                "uses defaults",
+			snapshot:            snapshot,
+			wantImage:           "registry.k8s.io/etcd:3.6.4-0",
+			wantDownloadURLFile: downloadURLFile,
+			wantScript: []string{
+				`--output "${SNAPSHOT}" "$(cat /var/lib/etcd-restore/snapshot.url)"`,
+				`KUBEADM_CONFIG=/run/kubeadm/kubeadm.yaml`,
+				`kubeadm init phase etcd local --config "${KUBEADM_CONFIG}"`,
+				`--initial-cluster "${NODE_NAME}=${PEER_URL}"`,
+			},
+			wantPreflightError: "DirAvailable--var-lib-etcd",
+		},
+		{
+			name: "downloads and verifies the snapshot",
+			snapshot: EtcdRestoreSnapshot{
+				Name:            "snapshot",
+				EtcdVersion:     "3.6.4",
+				DownloadURLFrom: downloadURLFrom,
+				SHA256:          "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			},
+			wantImage:           "registry.k8s.io/etcd:3.6.4-0",
+			wantDownloadURLFile: downloadURLFile,
+			wantScript: []string{
+				`--output "${SNAPSHOT}" "$(cat /var/lib/etcd-restore/snapshot.url)"`,
+				`echo "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  ${SNAPSHOT}" | sha256sum --check --status`,
+			},
+			wantPreflightError: "DirAvailable--var-lib-etcd",
+		},
+		{
+			name: "uses image repository and data dir from ClusterConfiguration",
+			clusterConfiguration: bootstrapv1.ClusterConfiguration{
+				ImageRepository: "example.com/k8s",
+				Etcd: bootstrapv1.Etcd{
+					Local: bootstrapv1.LocalEtcd{
+						DataDir: "/data/etcd",
+					},
+				},
+			},
+			snapshot:            snapshot,
+			wantImage:           "example.com/k8s/etcd:3.6.4-0",
+			wantDownloadURLFile: downloadURLFile,
+			wantPreflightError:  "DirAvailable--data-etcd",
+		},
+		{
+			name: "uses etcd image from ClusterConfiguration",
+			clusterConfiguration: bootstrapv1.ClusterConfiguration{
+				ImageRepository: "example.com/k8s",
+				Etcd: bootstrapv1.Etcd{
+					Local: bootstrapv1.LocalEtcd{
+						ImageRepository: "example.com/etcd",
+						ImageTag:        "3.6.5-1",
+					},
+				},
+			},
+			snapshot:            EtcdRestoreSnapshot{Name: "snapshot", DownloadURLFrom: downloadURLFrom},
+			wantImage:           "example.com/etcd/etcd:3.6.5-1",
+			wantDownloadURLFile: downloadURLFile,
+			wantPreflightError:  "DirAvailable--var-lib-etcd",
+		},
+		{
+			name:     "fails if the etcd version is unknown",
+			snapshot: EtcdRestoreSnapshot{Name: "snapshot", DownloadURLFrom: downloadURLFrom},
+			wantErr:  true,
+		},
+		{
+			name:     "fails if the snapshot cannot be downloaded",
+			snapshot: EtcdRestoreSnapshot{Name: "snapshot", EtcdVersion: "3.6.4"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kubeadmConfig := &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: tt.clusterConfiguration,
+					Files:                []bootstrapv1.File{{Path: "/etc/foo", Content: "foo"}},
+					PreKubeadmCommands:   []string{"echo foo"},
+				},
+			}
+			desiredKubeadmConfig := kubeadmConfig.DeepCopy()
+
+			err := ApplyEtcdRestore(kubeadmConfig, tt.snapshot)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(kubeadmConfig).To(Equal(desiredKubeadmConfig))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			g.Expect(kubeadmConfig.Annotations).To(HaveKeyWithValue(bootstrapv1.EtcdRestoreAnnotation, "snapshot"))
+			g.Expect(kubeadmConfig.Spec.Files).To(HaveLen(3))
+			g.Expect(kubeadmConfig.Spec.Files[1]).To(Equal(tt.wantDownloadURLFile))
+			g.Expect(kubeadmConfig.Spec.Files[2].Path).To(Equal(etcdRestoreScriptPath))
+			g.Expect(kubeadmConfig.Spec.Files[2].Content).To(ContainSubstring("IMAGE=" + tt.wantImage))
+			for _, s := range tt.wantScript {
+				g.Expect(kubeadmConfig.Spec.Files[2].Content).To(ContainSubstring(s))
+			}
+			if tt.snapshot.SHA256 == "" {
+				g.Expect(kubeadmConfig.Spec.Files[2].Content).ToNot(ContainSubstring("sha256sum"))
+			}
+			g.Expect(kubeadmConfig.Spec.PreKubeadmCommands).To(Equal([]string{"echo foo", etcdRestoreScriptPath}))
+			g.Expect(kubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors).To(ConsistOf(tt.wantPreflightError))
+
+			// Changes applied by ApplyEtcdRestore are dropped by RemoveEtcdRestore.
+			RemoveEtcdRestore(desiredKubeadmConfig, kubeadmConfig)
+			delete(kubeadmConfig.Annotations, bootstrapv1.EtcdRestoreAnnotation)
+			g.Expect(kubeadmConfig.Spec).To(Equal(desiredKubeadmConfig.Spec))
+		})
+	}
+}
+
+func Test_RemoveEtcdRestore(t *testing.T) {
+	t.Run("does nothing if the KubeadmConfig is not restoring etcd", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kubeadmConfig := &bootstrapv1.KubeadmConfig{
+			Spec: bootstrapv1.KubeadmConfigSpec{
+				Files:              []bootstrapv1.File{{Path: etcdRestoreScriptPath}},
+				PreKubeadmCommands: []string{etcdRestoreScriptPath},
+			},
+		}
+		original := kubeadmConfig.DeepCopy()
+
+		RemoveEtcdRestore(&bootstrapv1.KubeadmConfig{}, kubeadmConfig)
+		g.Expect(kubeadmConfig).To(Equal(original))
+	})
+
+	t.Run("preserves preflight errors from the desired KubeadmConfig", func(t *testing.T) {
+		g := NewWithT(t)
+
+		desiredKubeadmConfig := &bootstrapv1.KubeadmConfig{
+			Spec: bootstrapv1.KubeadmConfigSpec{
+				InitConfiguration: bootstrapv1.InitConfiguration{
+					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+						IgnorePreflightErrors: []string{"DirAvailable--var-lib-etcd"},
+					},
+				},
+			},
+		}
+		kubeadmConfig := desiredKubeadmConfig.DeepCopy()
+		g.Expect(ApplyEtcdRestore(kubeadmConfig, EtcdRestoreSnapshot{
+			Name:        "snapshot",
+			EtcdVersion: "3.6.4",
+			DownloadURLFrom: bootstrapv1.FileSource{
+				Secret: bootstrapv1.SecretFileSource{Name: "snapshot", Key: "downloadURL"},
+			},
+		})).To(Succeed())
+
+		RemoveEtcdRestore(desiredKubeadmConfig, kubeadmConfig)
+		g.Expect(kubeadmConfig.Spec).To(Equal(desiredKubeadmConfig.Spec))
+	})
+}
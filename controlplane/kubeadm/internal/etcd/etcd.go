@@ -19,11 +19,13 @@ package etcd
 import (
 	"context"
 	"crypto/tls"
+	"io"
 	"net"
 	"time"
 
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -38,12 +40,16 @@ type GRPCDial func(ctx context.Context, addr string) (net.Conn, error)
 // etcd wraps the etcd client from etcd's clientv3 package.
 // This interface is implemented by both the clientv3 package and the backoff adapter that adds retries to the client.
 type etcd interface {
+	AlarmDisarm(ctx context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error)
 	AlarmList(ctx context.Context) (*clientv3.AlarmResponse, error)
 	Close() error
+	Compact(ctx context.Context, rev int64, opts ...clientv3.CompactOption) (*clientv3.CompactResponse, error)
+	Defragment(ctx context.Context, endpoint string) (*clientv3.DefragmentResponse, error)
 	Endpoints() []string
 	MemberList(ctx context.Context, opts ...clientv3.OpOption) (*clientv3.MemberListResponse, error)
 	MemberRemove(ctx context.Context, id uint64) (*clientv3.MemberRemoveResponse, error)
 	MoveLeader(ctx context.Context, id uint64) (*clientv3.MoveLeaderResponse, error)
+	Snapshot(ctx context.Context) (io.ReadCloser, error)
 	Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
 }
 
@@ -52,6 +58,7 @@ type Client struct {
 	EtcdClient  etcd
 	Endpoint    string
 	LeaderID    uint64
+	Version     string
 	Errors      []string
 	CallTimeout time.Duration
 }
@@ -79,6 +86,24 @@ const (
 	AlarmCorrupt
 )
 
+// DBStatus represents the status of the backend database of an etcd member.
+type DBStatus struct {
+	// MemberID is the ID of the member.
+	MemberID uint64
+
+	// LeaderID is the ID of the current leader, as known by the member.
+	LeaderID uint64
+
+	// Revision is the current revision of the key-value store.
+	Revision int64
+
+	// DBSize is the size of the backend database physically allocated, in bytes.
+	DBSize int64
+
+	// DBSizeInUse is the size of the backend database logically in use, in bytes.
+	DBSizeInUse int64
+}
+
 // DefaultCallTimeout represents the duration that the etcd client waits at most
 // for read and write operations to etcd.
 const DefaultCallTimeout = 15 * time.Second
@@ -193,6 +218,7 @@ func newEtcdClient(ctx context.Context, etcdClient etcd, callTimeout time.Durati
 		Endpoint:    endpoints[0],
 		EtcdClient:  etcdClient,
 		LeaderID:    status.Leader,
+		Version:     status.Version,
 		Errors:      status.Errors,
 		CallTimeout: callTimeout,
 	}, nil
@@ -262,3 +288,78 @@ func (c *Client) Alarms(ctx context.Context) ([]MemberAlarm, error) {
 
 	return memberAlarms, nil
 }
+
+// Snapshot streams a snapshot of the backend database of the etcd member the client is connected to into w,
+// and returns the number of bytes written.
+// Note: the call timeout is not applied to this operation, because its duration depends on the size of the database;
+// callers are responsible for setting a deadline on ctx.
+func (c *Client) Snapshot(ctx context.Context, w io.Writer) (int64, error) {
+	rc, err := c.EtcdClient.Snapshot(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get etcd snapshot")
+	}
+	defer rc.Close()
+
+	n, err := io.Copy(w, rc)
+	if err != nil {
+		return n, errors.Wrap(err, "failed to read etcd snapshot")
+	}
+	return n, nil
+}
+
+// DBStatus retrieves the status of the backend database of the etcd member the client is connected to.
+func (c *Client) DBStatus(ctx context.Context) (*DBStatus, error) {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	status, err := c.EtcdClient.Status(ctx, c.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get etcd status")
+	}
+
+	return &DBStatus{
+		MemberID:    status.Header.GetMemberId(),
+		LeaderID:    status.Leader,
+		Revision:    status.Header.GetRevision(),
+		DBSize:      status.DbSize,
+		DBSizeInUse: status.DbSizeInUse,
+	}, nil
+}
+
+// Compact compacts the etcd key-value store up to the given revision, discarding all the superseded keys.
+// Compacting to a revision that has already been compacted is not considered an error.
+func (c *Client) Compact(ctx context.Context, revision int64) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	if _, err := c.EtcdClient.Compact(ctx, revision, clientv3.WithCompactPhysical()); err != nil && !errors.Is(err, rpctypes.ErrCompacted) {
+		return errors.Wrapf(err, "failed to compact etcd to revision %d", revision)
+	}
+	return nil
+}
+
+// Defragment defragments the backend database of the etcd member the client is connected to, releasing
+// free space to the file system.
+// Note: the call timeout is not applied to this operation, because its duration depends on the size of the database;
+// callers are responsible for setting a deadline on ctx. While defragmentation is in progress, the member
+// does not serve requests.
+func (c *Client) Defragment(ctx context.Context) error {
+	if _, err := c.EtcdClient.Defragment(ctx, c.Endpoint); err != nil {
+		return errors.Wrap(err, "failed to defragment etcd member")
+	}
+	return nil
+}
+
+// DisarmAlarm disarms an alarm raised by an etcd member.
+func (c *Client) DisarmAlarm(ctx context.Context, alarm MemberAlarm) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	if _, err := c.EtcdClient.AlarmDisarm(ctx, &clientv3.AlarmMember{
+		MemberID: alarm.MemberID,
+		Alarm:    etcdserverpb.AlarmType(alarm.Type),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to disarm etcd alarm %s for member %d", AlarmTypeName[alarm.Type], alarm.MemberID)
+	}
+	return nil
+}
@@ -17,11 +17,13 @@ limitations under the License.
 package etcd
 
 import (
+	"bytes"
 	"testing"
 
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -95,3 +97,121 @@ func TestEtcdMembers_WithSuccess(t *testing.T) {
 	err = client.RemoveMember(ctx, 1234)
 	g.Expect(err).ToNot(HaveOccurred())
 }
+
+func TestEtcdSnapshot(t *testing.T) {
+	t.Run("streams the snapshot of the etcd member", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &etcdfake.FakeEtcdClient{
+			EtcdEndpoints:  []string{"https://etcd-instance:2379"},
+			SnapshotData:   []byte("snapshot-data"),
+			StatusResponse: &clientv3.StatusResponse{Version: "3.6.4"},
+		}
+
+		client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(client.Version).To(Equal("3.6.4"))
+
+		buf := &bytes.Buffer{}
+		n, err := client.Snapshot(ctx, buf)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(n).To(Equal(int64(len("snapshot-data"))))
+		g.Expect(buf.String()).To(Equal("snapshot-data"))
+	})
+	t.Run("fails if the snapshot cannot be retrieved", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &etcdfake.FakeEtcdClient{
+			EtcdEndpoints:  []string{"https://etcd-instance:2379"},
+			SnapshotError:  errors.New("something went wrong"),
+			StatusResponse: &clientv3.StatusResponse{},
+		}
+
+		client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.Snapshot(ctx, &bytes.Buffer{})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestEtcdMaintenance(t *testing.T) {
+	t.Run("returns the status of the database of the etcd member", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &etcdfake.FakeEtcdClient{
+			EtcdEndpoints: []string{"https://etcd-instance:2379"},
+			StatusResponse: &clientv3.StatusResponse{
+				Header:      &etcdserverpb.ResponseHeader{MemberId: 1234, Revision: 42},
+				Leader:      5678,
+				DbSize:      100,
+				DbSizeInUse: 40,
+			},
+		}
+
+		client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		status, err := client.DBStatus(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(*status).To(Equal(DBStatus{
+			MemberID:    1234,
+			LeaderID:    5678,
+			Revision:    42,
+			DBSize:      100,
+			DBSizeInUse: 40,
+		}))
+	})
+	t.Run("compacts, defragments and disarms alarms", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &etcdfake.FakeEtcdClient{
+			EtcdEndpoints:  []string{"https://etcd-instance:2379"},
+			StatusResponse: &clientv3.StatusResponse{},
+		}
+
+		client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(client.Compact(ctx, 42)).To(Succeed())
+		g.Expect(fakeEtcdClient.CompactedRevision).To(Equal(int64(42)))
+
+		g.Expect(client.Defragment(ctx)).To(Succeed())
+		g.Expect(fakeEtcdClient.DefragmentedMember).To(Equal("https://etcd-instance:2379"))
+
+		g.Expect(client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})).To(Succeed())
+		g.Expect(fakeEtcdClient.DisarmedAlarms).To(ConsistOf(&clientv3.AlarmMember{MemberID: 1234, Alarm: etcdserverpb.AlarmType_NOSPACE}))
+	})
+	t.Run("ignores revisions already compacted", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &etcdfake.FakeEtcdClient{
+			EtcdEndpoints:  []string{"https://etcd-instance:2379"},
+			StatusResponse: &clientv3.StatusResponse{},
+			CompactError:   rpctypes.ErrCompacted,
+		}
+
+		client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(client.Compact(ctx, 42)).To(Succeed())
+	})
+	t.Run("fails if maintenance operations fail", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &etcdfake.FakeEtcdClient{
+			EtcdEndpoints:    []string{"https://etcd-instance:2379"},
+			StatusResponse:   &clientv3.StatusResponse{},
+			CompactError:     errors.New("something went wrong"),
+			DefragmentError:  errors.New("something went wrong"),
+			AlarmDisarmError: errors.New("something went wrong"),
+		}
+
+		client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(client.Compact(ctx, 42)).ToNot(Succeed())
+		g.Expect(client.Defragment(ctx)).ToNot(Succeed())
+		g.Expect(client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})).ToNot(Succeed())
+	})
+}
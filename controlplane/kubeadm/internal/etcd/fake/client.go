@@ -18,7 +18,9 @@ limitations under the License.
 package fake
 
 import (
+	"bytes"
 	"context"
+	"io"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
@@ -29,6 +31,15 @@ type FakeEtcdClient struct { //nolint:revive
 	AlarmResponse *clientv3.AlarmResponse
 	AlarmError    error
 
+	AlarmDisarmResponse *clientv3.AlarmResponse
+	AlarmDisarmError    error
+
+	CompactResponse *clientv3.CompactResponse
+	CompactError    error
+
+	DefragmentResponse *clientv3.DefragmentResponse
+	DefragmentError    error
+
 	MemberListResponse *clientv3.MemberListResponse
 	MemberListError    error
 
@@ -38,11 +49,17 @@ type FakeEtcdClient struct { //nolint:revive
 	MoveLeaderResponse *clientv3.MoveLeaderResponse
 	MoveLeaderError    error
 
+	SnapshotData  []byte
+	SnapshotError error
+
 	StatusResponse *clientv3.StatusResponse
 	StatusError    error
 
-	MovedLeader   uint64
-	RemovedMember uint64
+	MovedLeader        uint64
+	RemovedMember      uint64
+	CompactedRevision  int64
+	DefragmentedMember string
+	DisarmedAlarms     []*clientv3.AlarmMember
 }
 
 func (c *FakeEtcdClient) Endpoints() []string {
@@ -58,10 +75,25 @@ func (c *FakeEtcdClient) Close() error {
 	return nil
 }
 
+func (c *FakeEtcdClient) AlarmDisarm(_ context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error) {
+	c.DisarmedAlarms = append(c.DisarmedAlarms, m)
+	return c.AlarmDisarmResponse, c.AlarmDisarmError
+}
+
 func (c *FakeEtcdClient) AlarmList(_ context.Context) (*clientv3.AlarmResponse, error) {
 	return c.AlarmResponse, c.AlarmError
 }
 
+func (c *FakeEtcdClient) Compact(_ context.Context, rev int64, _ ...clientv3.CompactOption) (*clientv3.CompactResponse, error) {
+	c.CompactedRevision = rev
+	return c.CompactResponse, c.CompactError
+}
+
+func (c *FakeEtcdClient) Defragment(_ context.Context, endpoint string) (*clientv3.DefragmentResponse, error) {
+	c.DefragmentedMember = endpoint
+	return c.DefragmentResponse, c.DefragmentError
+}
+
 func (c *FakeEtcdClient) MemberList(_ context.Context, _ ...clientv3.OpOption) (*clientv3.MemberListResponse, error) {
 	return c.MemberListResponse, c.MemberListError
 }
@@ -69,6 +101,12 @@ func (c *FakeEtcdClient) MemberRemove(_ context.Context, i uint64) (*clientv3.Me
 	c.RemovedMember = i
 	return c.MemberRemoveResponse, c.MemberRemoveError
 }
+func (c *FakeEtcdClient) Snapshot(_ context.Context) (io.ReadCloser, error) {
+	if c.SnapshotError != nil {
+		return nil, c.SnapshotError
+	}
+	return io.NopCloser(bytes.NewReader(c.SnapshotData)), nil
+}
 func (c *FakeEtcdClient) Status(_ context.Context, _ string) (*clientv3.StatusResponse, error) {
 	return c.StatusResponse, c.StatusError
 }
@@ -90,6 +90,22 @@ func UpToDate(
 		res.EligibleForInPlaceUpdate = false
 	}
 
+	// Machines created before the current phase of a certificate authority rotation started.
+	if rotation := kcp.Status.CertificateAuthorityRotation; rotation.Phase != "" && rotation.Phase != controlplanev1.RotationCompletedPhase &&
+		collections.ShouldRolloutAfter(reconciliationTime, rotation.PhaseStartTime)(machine) {
+		res.LogMessages = append(res.LogMessages, fmt.Sprintf("certificate authorities are being rotated, phase %s", rotation.Phase))
+		res.ConditionMessages = append(res.ConditionMessages, "Certificate authorities are being rotated")
+		res.EligibleForInPlaceUpdate = false
+	}
+
+	// Machines created before the current phase of a service account signing key rotation started.
+	if rotation := kcp.Status.ServiceAccountKeyRotation; rotation.Phase != "" && rotation.Phase != controlplanev1.RotationCompletedPhase &&
+		collections.ShouldRolloutAfter(reconciliationTime, rotation.PhaseStartTime)(machine) {
+		res.LogMessages = append(res.LogMessages, fmt.Sprintf("service account signing key is being rotated, phase %s", rotation.Phase))
+		res.ConditionMessages = append(res.ConditionMessages, "Service account signing key is being rotated")
+		res.EligibleForInPlaceUpdate = false
+	}
+
 	// Machines that do not match with KCP config.
 	// Note: matchesMachineSpec will update res with desired and current objects if necessary.
 	matches, specLogMessages, specConditionMessages, err := matchesMachineSpec(ctx, c, infraMachines, kubeadmConfigs, kcp, cluster, machine, res)
@@ -306,6 +322,10 @@ func PrepareKubeadmConfigsForDiff(desiredKubeadmConfig, currentKubeadmConfig *bo
 	desiredKubeadmConfig = desiredKubeadmConfig.DeepCopy()
 	currentKubeadmConfig = currentKubeadmConfig.DeepCopy()
 
+	// Ignore changes added to the KubeadmConfig of a Machine restoring etcd from a snapshot.
+	// Note: Those changes are relevant only for the bootstrap of the Machine, so no reason to trigger a rollout because of that.
+	desiredstate.RemoveEtcdRestore(desiredKubeadmConfig, currentKubeadmConfig)
+
 	if convertCurrentInitConfigurationToJoinConfiguration && isKubeadmConfigForInit(currentKubeadmConfig) {
 		// Convert InitConfiguration to JoinConfiguration
 		currentKubeadmConfig.Spec.JoinConfiguration.Timeouts = currentKubeadmConfig.Spec.InitConfiguration.Timeouts
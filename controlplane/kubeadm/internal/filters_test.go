@@ -1675,6 +1675,63 @@ func TestUpToDate(t *testing.T) {
 			expectLogMessages:              []string{"rolloutAfter expired"},
 			expectConditionMessages:        []string{"KubeadmControlPlane spec.rolloutAfter expired"},
 		},
+		{
+			name: "certificate authorities are being rotated",
+			kcp: func() *controlplanev1.KubeadmControlPlane {
+				kcp := defaultKcp.DeepCopy()
+				kcp.Status.CertificateAuthorityRotation = controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationStatus{
+					RequestID:      "rotation-1",
+					Phase:          controlplanev1.RotationTrustNewPhase,
+					PhaseStartTime: metav1.Time{Time: reconciliationTime.Add(-1 * 24 * time.Hour)}, // one day ago
+				}
+				return kcp
+			}(),
+			machine:                        defaultMachine, // created two days ago
+			infraConfigs:                   defaultInfraConfigs,
+			machineConfigs:                 defaultMachineConfigs,
+			expectUptoDate:                 false,
+			expectEligibleForInPlaceUpdate: false,
+			expectLogMessages:              []string{"certificate authorities are being rotated, phase TrustNew"},
+			expectConditionMessages:        []string{"Certificate authorities are being rotated"},
+		},
+		{
+			name: "service account signing key is being rotated",
+			kcp: func() *controlplanev1.KubeadmControlPlane {
+				kcp := defaultKcp.DeepCopy()
+				kcp.Status.ServiceAccountKeyRotation = controlplanev1.KubeadmControlPlaneServiceAccountKeyRotationStatus{
+					RequestID:      "rotation-1",
+					Phase:          controlplanev1.RotationSignWithNewPhase,
+					PhaseStartTime: metav1.Time{Time: reconciliationTime.Add(-1 * 24 * time.Hour)}, // one day ago
+				}
+				return kcp
+			}(),
+			machine:                        defaultMachine, // created two days ago
+			infraConfigs:                   defaultInfraConfigs,
+			machineConfigs:                 defaultMachineConfigs,
+			expectUptoDate:                 false,
+			expectEligibleForInPlaceUpdate: false,
+			expectLogMessages:              []string{"service account signing key is being rotated, phase SignWithNew"},
+			expectConditionMessages:        []string{"Service account signing key is being rotated"},
+		},
+		{
+			name: "certificate authorities rotation completed",
+			kcp: func() *controlplanev1.KubeadmControlPlane {
+				kcp := defaultKcp.DeepCopy()
+				kcp.Status.CertificateAuthorityRotation = controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationStatus{
+					RequestID:      "rotation-1",
+					Phase:          controlplanev1.RotationCompletedPhase,
+					PhaseStartTime: metav1.Time{Time: reconciliationTime.Add(-1 * 24 * time.Hour)}, // one day ago
+				}
+				return kcp
+			}(),
+			machine:                        defaultMachine, // created two days ago
+			infraConfigs:                   defaultInfraConfigs,
+			machineConfigs:                 defaultMachineConfigs,
+			expectUptoDate:                 true,
+			expectEligibleForInPlaceUpdate: false,
+			expectLogMessages:              nil,
+			expectConditionMessages:        nil,
+		},
 		{
 			name: "kubernetes version does not match",
 			kcp: func() *controlplanev1.KubeadmControlPlane {
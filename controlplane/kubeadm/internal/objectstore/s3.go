@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package objectstore implements clients for the object stores used by KCP to store etcd snapshots.
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/pkg/errors"
+)
+
+const (
+	// s3RequestTimeout is the timeout for requests to the object store.
+	s3RequestTimeout = 5 * time.Minute
+
+	// s3NoSuchKeyErrorCode is the error code returned by S3 compatible object stores for objects that do not exist.
+	s3NoSuchKeyErrorCode = "NoSuchKey"
+)
+
+// S3Client is a client for S3 compatible object stores, supporting the operations required to store etcd snapshots.
+// Objects are addressed using path-style URLs, e.g. <endpoint>/<bucket>/<key>.
+type S3Client struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3Client returns a new S3Client.
+// Note: Only https endpoints are supported, because requests carry etcd snapshots with all the data of a cluster.
+func NewS3Client(endpoint, region, bucket, accessKeyID, secretAccessKey string) (*S3Client, error) {
+	return newS3Client(endpoint, region, bucket, accessKeyID, secretAccessKey, nil)
+}
+
+// newS3Client returns a new S3Client using the given transport; if transport is nil, the default transport is used.
+func newS3Client(endpoint, region, bucket, accessKeyID, secretAccessKey string, transport http.RoundTripper) (*S3Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid object store endpoint %q", endpoint)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return nil, errors.Errorf("invalid object store endpoint %q: must be an https URL", endpoint)
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return nil, errors.Errorf("invalid object store endpoint %q: must not have a path, a query or a fragment", endpoint)
+	}
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, errors.New("object store credentials must not be empty")
+	}
+	c, err := minio.New(u.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
+		Secure:       true,
+		Transport:    transport,
+		Region:       region,
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create client for object store endpoint %q", endpoint)
+	}
+	// Always use the configured endpoint, also for AWS S3, because it must be reachable from control plane Machines.
+	c.SetS3EnableDualstack(false)
+	return &S3Client{
+		client: c,
+		bucket: bucket,
+	}, nil
+}
+
+// PutObject stores an object.
+func (c *S3Client) PutObject(ctx context.Context, key string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, s3RequestTimeout)
+	defer cancel()
+
+	if _, err := c.client.PutObject(ctx, c.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	}); err != nil {
+		return errors.Wrapf(err, "failed to put object %s", key)
+	}
+	return nil
+}
+
+// DeleteObject deletes an object; deleting an object that does not exist is not an error.
+func (c *S3Client) DeleteObject(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, s3RequestTimeout)
+	defer cancel()
+
+	if err := c.client.RemoveObject(ctx, c.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		if minio.ToErrorResponse(err).Code == s3NoSuchKeyErrorCode {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete object %s", key)
+	}
+	return nil
+}
+
+// PresignGetObject returns a pre-signed URL that can be used to download an object without credentials until it expires.
+func (c *S3Client) PresignGetObject(ctx context.Context, key string, expires time.Duration) (string, error) {
+	u, err := c.client.PresignedGetObject(ctx, c.bucket, key, expires, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to generate pre-signed URL for object %s", key)
+	}
+	return u.String(), nil
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/blang/semver/v4"
@@ -195,6 +197,12 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldK, newK
 		{spec, "remediation", "*"},
 		{spec, "machineNaming"},
 		{spec, "machineNaming", "*"},
+		{spec, "etcd"},
+		{spec, "etcd", "*"},
+		{spec, "certificateAuthorityRotation"},
+		{spec, "certificateAuthorityRotation", "*"},
+		{spec, "serviceAccountKeyRotation"},
+		{spec, "serviceAccountKeyRotation", "*"},
 		{spec, "rollout"},
 		{spec, "rollout", "*"},
 	}
@@ -284,6 +292,39 @@ func validateKubeadmControlPlaneSpec(s controlplanev1.KubeadmControlPlaneSpec, p
 		}
 	}
 
+	if externalEtcd && !reflect.DeepEqual(s.Etcd, controlplanev1.KubeadmControlPlaneEtcdSpec{}) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("etcd"),
+				"cannot be set when using an external etcd",
+			),
+		)
+	}
+
+	allErrs = append(allErrs, validateEtcdSnapshotStorage(s.Etcd.Snapshot.Storage, pathPrefix.Child("etcd", "snapshot", "storage"))...)
+
+	// Note: Snapshots stored in Secrets can't be restored by KCP, because they don't fit the bootstrap data of the Machine restoring etcd.
+	if s.Etcd.Restore.SnapshotName != "" && s.Etcd.Snapshot.Storage.Type != controlplanev1.EtcdSnapshotS3StorageType {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("etcd", "restore", "snapshotName"),
+				fmt.Sprintf("can only be set when etcd snapshots are stored in an object store, i.e. when etcd.snapshot.storage.type is %s", controlplanev1.EtcdSnapshotS3StorageType),
+			),
+		)
+	}
+
+	if externalEtcd && slices.Contains(s.CertificateAuthorityRotation.CertificateAuthorities, controlplanev1.EtcdCertificateAuthority) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("certificateAuthorityRotation", "certificateAuthorities"),
+				"cannot contain Etcd when using an external etcd",
+			),
+		)
+	}
+
 	if s.MachineTemplate.Spec.InfrastructureRef.APIGroup == "" {
 		allErrs = append(
 			allErrs,
@@ -406,6 +447,41 @@ func validateRolloutAndCertValidityFields(rolloutSpec controlplanev1.KubeadmCont
 	return allErrs
 }
 
+func validateEtcdSnapshotStorage(storage controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage, pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if storage.Type != controlplanev1.EtcdSnapshotS3StorageType {
+		if !reflect.DeepEqual(storage.S3, controlplanev1.KubeadmControlPlaneEtcdSnapshotS3Storage{}) {
+			allErrs = append(allErrs,
+				field.Forbidden(
+					pathPrefix.Child("s3"),
+					fmt.Sprintf("can only be set when type is %s", controlplanev1.EtcdSnapshotS3StorageType),
+				))
+		}
+		return allErrs
+	}
+
+	if reflect.DeepEqual(storage.S3, controlplanev1.KubeadmControlPlaneEtcdSnapshotS3Storage{}) {
+		allErrs = append(allErrs,
+			field.Required(
+				pathPrefix.Child("s3"),
+				fmt.Sprintf("must be set when type is %s", controlplanev1.EtcdSnapshotS3StorageType),
+			))
+		return allErrs
+	}
+
+	if u, err := url.Parse(storage.S3.Endpoint); err != nil || u.Scheme != "https" || u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		allErrs = append(allErrs,
+			field.Invalid(
+				pathPrefix.Child("s3", "endpoint"),
+				storage.S3.Endpoint,
+				"must be an https URL without path, query and fragment",
+			))
+	}
+
+	return allErrs
+}
+
 func validateNaming(machineNaming controlplanev1.MachineNamingSpec, pathPrefix *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -137,6 +137,53 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 		},
 	}
 
+	etcdSnapshotExternalEtcd := evenReplicasExternalEtcd.DeepCopy()
+	etcdSnapshotExternalEtcd.Spec.Etcd.Snapshot.Enabled = ptr.To(true)
+
+	etcdSnapshotS3Storage := valid.DeepCopy()
+	etcdSnapshotS3Storage.Spec.Etcd.Snapshot = controlplanev1.KubeadmControlPlaneEtcdSnapshotSpec{
+		Enabled: ptr.To(true),
+		Storage: controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{
+			Type: controlplanev1.EtcdSnapshotS3StorageType,
+			S3: controlplanev1.KubeadmControlPlaneEtcdSnapshotS3Storage{
+				Endpoint:              "https://s3.us-east-1.amazonaws.com",
+				Region:                "us-east-1",
+				Bucket:                "snapshots",
+				CredentialsSecretName: "credentials",
+			},
+		},
+	}
+
+	etcdSnapshotS3StorageHTTPEndpoint := etcdSnapshotS3Storage.DeepCopy()
+	etcdSnapshotS3StorageHTTPEndpoint.Spec.Etcd.Snapshot.Storage.S3.Endpoint = "http://s3.us-east-1.amazonaws.com"
+
+	etcdSnapshotS3StorageEndpointWithPath := etcdSnapshotS3Storage.DeepCopy()
+	etcdSnapshotS3StorageEndpointWithPath.Spec.Etcd.Snapshot.Storage.S3.Endpoint = "https://storage.example.com/s3"
+
+	etcdSnapshotS3StorageMissing := etcdSnapshotS3Storage.DeepCopy()
+	etcdSnapshotS3StorageMissing.Spec.Etcd.Snapshot.Storage.S3 = controlplanev1.KubeadmControlPlaneEtcdSnapshotS3Storage{}
+
+	etcdRestoreS3Storage := etcdSnapshotS3Storage.DeepCopy()
+	etcdRestoreS3Storage.Spec.Etcd.Restore.SnapshotName = "snapshot"
+
+	etcdRestoreSecretStorage := valid.DeepCopy()
+	etcdRestoreSecretStorage.Spec.Etcd.Snapshot.Enabled = ptr.To(true)
+	etcdRestoreSecretStorage.Spec.Etcd.Restore.SnapshotName = "snapshot"
+
+	etcdSnapshotSecretStorageWithS3 := etcdSnapshotS3Storage.DeepCopy()
+	etcdSnapshotSecretStorageWithS3.Spec.Etcd.Snapshot.Storage.Type = controlplanev1.EtcdSnapshotSecretStorageType
+
+	etcdCARotationExternalEtcd := evenReplicasExternalEtcd.DeepCopy()
+	etcdCARotationExternalEtcd.Spec.CertificateAuthorityRotation = controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationSpec{
+		RequestID:              "rotation-1",
+		CertificateAuthorities: []controlplanev1.KubeadmControlPlaneCertificateAuthority{controlplanev1.EtcdCertificateAuthority},
+	}
+
+	caRotationExternalEtcd := evenReplicasExternalEtcd.DeepCopy()
+	caRotationExternalEtcd.Spec.CertificateAuthorityRotation = controlplanev1.KubeadmControlPlaneCertificateAuthorityRotationSpec{
+		RequestID: "rotation-1",
+	}
+
 	validVersion := valid.DeepCopy()
 	validVersion.Spec.Version = "v1.16.6"
 
@@ -220,6 +267,56 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: false,
 			kcp:       evenReplicasExternalEtcd,
 		},
+		{
+			name:      "should return error when etcd snapshots are enabled when using external etcd",
+			expectErr: true,
+			kcp:       etcdSnapshotExternalEtcd,
+		},
+		{
+			name:      "should succeed when storing etcd snapshots in an S3 object store",
+			expectErr: false,
+			kcp:       etcdSnapshotS3Storage,
+		},
+		{
+			name:      "should return error when the S3 object store endpoint is not https",
+			expectErr: true,
+			kcp:       etcdSnapshotS3StorageHTTPEndpoint,
+		},
+		{
+			name:      "should return error when the S3 object store endpoint has a path",
+			expectErr: true,
+			kcp:       etcdSnapshotS3StorageEndpointWithPath,
+		},
+		{
+			name:      "should succeed when restoring etcd from a snapshot stored in an S3 object store",
+			expectErr: false,
+			kcp:       etcdRestoreS3Storage,
+		},
+		{
+			name:      "should return error when restoring etcd from a snapshot stored in a Secret",
+			expectErr: true,
+			kcp:       etcdRestoreSecretStorage,
+		},
+		{
+			name:      "should return error when the S3 object store is not configured",
+			expectErr: true,
+			kcp:       etcdSnapshotS3StorageMissing,
+		},
+		{
+			name:      "should return error when the S3 object store is configured for the Secret storage type",
+			expectErr: true,
+			kcp:       etcdSnapshotSecretStorageWithS3,
+		},
+		{
+			name:      "should return error when rotating the etcd certificate authority when using external etcd",
+			expectErr: true,
+			kcp:       etcdCARotationExternalEtcd,
+		},
+		{
+			name:      "should succeed when rotating certificate authorities when using external etcd",
+			expectErr: false,
+			kcp:       caRotationExternalEtcd,
+		},
 		{
 			name:      "should succeed when given a valid semantic version with prepended 'v'",
 			expectErr: false,
@@ -614,6 +711,32 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 		KeyFile: "another key file",
 	}
 
+	etcdSnapshot := before.DeepCopy()
+	etcdSnapshot.Spec.Etcd.Snapshot = controlplanev1.KubeadmControlPlaneEtcdSnapshotSpec{
+		Enabled:   ptr.To(true),
+		Retention: 5,
+		Storage: controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{
+			Type: controlplanev1.EtcdSnapshotS3StorageType,
+			S3: controlplanev1.KubeadmControlPlaneEtcdSnapshotS3Storage{
+				Endpoint:              "https://s3.us-east-1.amazonaws.com",
+				Region:                "us-east-1",
+				Bucket:                "snapshots",
+				CredentialsSecretName: "credentials",
+			},
+		},
+	}
+	etcdRestore := etcdSnapshot.DeepCopy()
+	etcdRestore.Spec.Etcd.Restore.SnapshotName = "snapshot"
+
+	caRotation := before.DeepCopy()
+	caRotation.Spec.CertificateAuthorityRotation.RequestID = "rotation-1"
+
+	saKeyRotation := before.DeepCopy()
+	saKeyRotation.Spec.ServiceAccountKeyRotation = controlplanev1.KubeadmControlPlaneServiceAccountKeyRotationSpec{
+		RequestID:            "rotation-1",
+		OverlapPeriodSeconds: ptr.To[int32](600),
+	}
+
 	localDataDir := before.DeepCopy()
 	localDataDir.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local = bootstrapv1.LocalEtcd{
 		DataDir: "some local data dir",
@@ -985,6 +1108,30 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:    before,
 			kcp:       localExtraArgs,
 		},
+		{
+			name:      "should succeed when enabling etcd snapshots",
+			expectErr: false,
+			before:    before,
+			kcp:       etcdSnapshot,
+		},
+		{
+			name:      "should succeed when requesting an etcd restore",
+			expectErr: false,
+			before:    etcdSnapshot,
+			kcp:       etcdRestore,
+		},
+		{
+			name:      "should succeed when requesting a certificate authority rotation",
+			expectErr: false,
+			before:    before,
+			kcp:       caRotation,
+		},
+		{
+			name:      "should succeed when requesting a service account signing key rotation",
+			expectErr: false,
+			before:    before,
+			kcp:       saKeyRotation,
+		},
 		{
 			name:      "should succeed when making a change to the cluster config's external etcd's configuration",
 			expectErr: false,
@@ -17,6 +17,7 @@ limitations under the License.
 package internal
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/rand"
@@ -24,6 +25,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
+	"io"
 	"math/big"
 	"reflect"
 	"time"
@@ -35,7 +37,9 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/retry"
+	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -82,8 +86,14 @@ type WorkloadCluster interface {
 	UpdateCoreDNS(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) error
 	RemoveEtcdMember(ctx context.Context, m *etcd.Member, nodes []*Node) error
 	ForwardEtcdLeadership(ctx context.Context, machine *clusterv1.Machine, leaderCandidate *clusterv1.Machine, nodes []*Node) error
+	TakeEtcdSnapshot(ctx context.Context, out io.Writer, nodes []*Node) (string, error)
+	GetEtcdMemberDBStatus(ctx context.Context, nodeName string) (*etcd.DBStatus, error)
+	DefragmentEtcdMember(ctx context.Context, nodeName string) error
+	CompactEtcd(ctx context.Context, nodes []*Node, compactedRevision int64) (int64, error)
+	DisarmEtcdAlarms(ctx context.Context, alarms []etcd.MemberAlarm, nodes []*Node) error
 	EnsureKubeadmPermissions(ctx context.Context, version semver.Version) error
 	UpdateClusterConfiguration(ctx context.Context, version semver.Version, mutators ...func(*bootstrapv1.ClusterConfiguration)) error
+	UpdateClusterInfoCertificateAuthorities(ctx context.Context, caData []byte) error
 }
 
 // Workload defines operations on workload clusters.
@@ -396,6 +406,44 @@ func (w *Workload) UpdateKubeProxyImageInfo(ctx context.Context, kcp *controlpla
 	return nil
 }
 
+// UpdateClusterInfoCertificateAuthorities updates the certificate authorities in the cluster-info ConfigMap used by
+// kubeadm join for discovery; the signatures of the ConfigMap are then updated by the bootstrap signer in kube-controller-manager.
+func (w *Workload) UpdateClusterInfoCertificateAuthorities(ctx context.Context, caData []byte) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		key := client.ObjectKey{Name: bootstrapapi.ConfigMapClusterInfo, Namespace: metav1.NamespacePublic}
+		configMap, err := w.getConfigMap(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		config, err := clientcmd.Load([]byte(configMap.Data[bootstrapapi.KubeConfigKey]))
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse %s/%s configmap", key.Namespace, key.Name)
+		}
+
+		changed := false
+		for _, cluster := range config.Clusters {
+			if !bytes.Equal(cluster.CertificateAuthorityData, caData) {
+				cluster.CertificateAuthorityData = caData
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+
+		out, err := clientcmd.Write(*config)
+		if err != nil {
+			return errors.Wrapf(err, "failed to serialize %s/%s configmap", key.Namespace, key.Name)
+		}
+		configMap.Data[bootstrapapi.KubeConfigKey] = string(out)
+		if err := w.Client.Update(ctx, configMap); err != nil {
+			return errors.Wrapf(err, "failed to update %s/%s configmap", key.Namespace, key.Name)
+		}
+		return nil
+	})
+}
+
 func findKubeProxyContainer(ds *appsv1.DaemonSet) *corev1.Container {
 	containers := ds.Spec.Template.Spec.Containers
 	for idx := range containers {
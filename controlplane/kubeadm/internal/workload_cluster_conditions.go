@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -81,6 +82,19 @@ func (w *Workload) updateManagedEtcdConditions(ctx context.Context, controlPlane
 
 		v1beta1conditions.MarkUnknown(controlPlane.KCP, controlplanev1.EtcdClusterHealthyV1Beta1Condition, controlplanev1.EtcdClusterInspectionFailedV1Beta1Reason, "Failed to list Nodes which are hosting the etcd members")
 
+		// If the API server reports that etcd has no leader, surface that the etcd cluster lost quorum.
+		// Note: This is the only case where KCP can tell for sure that quorum is lost, because when etcd can't
+		// serve requests it is also impossible to connect to etcd members via the API server.
+		if isEtcdQuorumLostError(controlPlane.NodeListError) {
+			conditions.Set(controlPlane.KCP, metav1.Condition{
+				Type:    controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  controlplanev1.KubeadmControlPlaneEtcdClusterQuorumLostReason,
+				Message: fmt.Sprintf("Etcd cluster lost quorum: %s", unwrapAll(controlPlane.NodeListError).Error()),
+			})
+			return
+		}
+
 		conditions.Set(controlPlane.KCP, metav1.Condition{
 			Type:    controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition,
 			Status:  metav1.ConditionUnknown,
@@ -228,6 +242,26 @@ func (w *Workload) updateManagedEtcdConditions(ctx context.Context, controlPlane
 	})
 }
 
+// etcdQuorumLostErrors are the errors returned by etcd when it can't serve requests because there is no leader.
+var etcdQuorumLostErrors = []error{
+	rpctypes.ErrNoLeader,
+	rpctypes.ErrTimeoutDueToLeaderFail,
+}
+
+// isEtcdQuorumLostError returns true if err is an error returned by the API server because etcd has no leader.
+func isEtcdQuorumLostError(err error) bool {
+	var apiStatus apierrors.APIStatus
+	if !errors.As(err, &apiStatus) {
+		return false
+	}
+	for _, etcdErr := range etcdQuorumLostErrors {
+		if strings.Contains(apiStatus.Status().Message, etcdErr.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
 func unwrapAll(err error) error {
 	for {
 		newErr := errors.Unwrap(err)
@@ -18,8 +18,10 @@ package internal
 
 import (
 	"context"
+	"io"
 
 	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -136,6 +138,97 @@ func (w *Workload) ForwardEtcdLeadership(ctx context.Context, machine *clusterv1
 	return nil
 }
 
+// TakeEtcdSnapshot writes a snapshot of the etcd database to w and returns the version of the etcd member the snapshot
+// has been taken from.
+// Note: the snapshot is taken from the etcd leader, so it includes all the committed changes.
+func (w *Workload) TakeEtcdSnapshot(ctx context.Context, out io.Writer, nodes []*Node) (string, error) {
+	nodeNames := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	etcdClient, err := w.etcdClientGenerator.forLeader(ctx, nodeNames)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	if _, err := etcdClient.Snapshot(ctx, out); err != nil {
+		return "", err
+	}
+	return etcdClient.Version, nil
+}
+
+// GetEtcdMemberDBStatus returns the status of the backend database of the etcd member hosted on a node.
+func (w *Workload) GetEtcdMemberDBStatus(ctx context.Context, nodeName string) (*etcd.DBStatus, error) {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{nodeName})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	return etcdClient.DBStatus(ctx)
+}
+
+// DefragmentEtcdMember defragments the backend database of the etcd member hosted on a node.
+// Note: It is a responsibility of the caller to defragment one member at a time, because the member
+// does not serve requests while defragmentation is in progress.
+func (w *Workload) DefragmentEtcdMember(ctx context.Context, nodeName string) error {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{nodeName})
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	return etcdClient.Defragment(ctx)
+}
+
+// CompactEtcd compacts the etcd key-value store up to the current revision, and returns the compacted revision.
+// Note: If the current revision has already been compacted, i.e. it is not greater than compactedRevision, etcd is not compacted.
+func (w *Workload) CompactEtcd(ctx context.Context, nodes []*Node, compactedRevision int64) (int64, error) {
+	nodeNames := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	etcdClient, err := w.etcdClientGenerator.forLeader(ctx, nodeNames)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	status, err := etcdClient.DBStatus(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if status.Revision <= compactedRevision {
+		return compactedRevision, nil
+	}
+	if err := etcdClient.Compact(ctx, status.Revision); err != nil {
+		return 0, err
+	}
+	return status.Revision, nil
+}
+
+// DisarmEtcdAlarms disarms the given etcd alarms.
+func (w *Workload) DisarmEtcdAlarms(ctx context.Context, alarms []etcd.MemberAlarm, nodes []*Node) error {
+	nodeNames := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, nodeNames)
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	errs := []error{}
+	for _, alarm := range alarms {
+		if err := etcdClient.DisarmAlarm(ctx, alarm); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
 // EtcdMemberStatus contains status information for a single etcd member.
 type EtcdMemberStatus struct {
 	Name       string
@@ -17,6 +17,7 @@ limitations under the License.
 package internal
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
@@ -532,6 +533,149 @@ func TestForwardEtcdLeadership(t *testing.T) {
 	})
 }
 
+func TestTakeEtcdSnapshot(t *testing.T) {
+	tests := []struct {
+		name                string
+		etcdClientGenerator etcdClientFor
+		expectedVersion     string
+		expectedData        []byte
+		expectErr           bool
+	}{
+		{
+			name:                "returns an error if it can't create an etcd client",
+			etcdClientGenerator: &fakeEtcdClientGenerator{forLeaderErr: errors.New("no etcdClient")},
+			expectErr:           true,
+		},
+		{
+			name: "returns an error if it fails to take the snapshot",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forLeaderClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						SnapshotError: errors.New("cannot take snapshot"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "writes the snapshot and returns the etcd version",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forLeaderClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						SnapshotData: []byte("snapshot"),
+					},
+					Version: "3.6.4",
+				},
+			},
+			expectedVersion: "3.6.4",
+			expectedData:    []byte("snapshot"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			w := &Workload{
+				etcdClientGenerator: tt.etcdClientGenerator,
+			}
+			out := &bytes.Buffer{}
+			// Note: no need to pass the list of nodes because fakeEtcdClientGenerator is used to simulate various combinations of node availability.
+			version, err := w.TakeEtcdSnapshot(ctx, out, nil)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(version).To(Equal(tt.expectedVersion))
+			g.Expect(out.Bytes()).To(Equal(tt.expectedData))
+		})
+	}
+}
+
+func TestEtcdMaintenance(t *testing.T) {
+	t.Run("compacts etcd to the current revision", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &fake2.FakeEtcdClient{
+			StatusResponse: &clientv3.StatusResponse{
+				Header: &pb.ResponseHeader{Revision: 42},
+			},
+		}
+		w := &Workload{
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forLeaderClient: &etcd.Client{EtcdClient: fakeEtcdClient},
+			},
+		}
+		revision, err := w.CompactEtcd(ctx, nil, 0)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(revision).To(Equal(int64(42)))
+		g.Expect(fakeEtcdClient.CompactedRevision).To(Equal(int64(42)))
+	})
+	t.Run("does not compact etcd if the current revision has already been compacted", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &fake2.FakeEtcdClient{
+			StatusResponse: &clientv3.StatusResponse{
+				Header: &pb.ResponseHeader{Revision: 42},
+			},
+		}
+		w := &Workload{
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forLeaderClient: &etcd.Client{EtcdClient: fakeEtcdClient},
+			},
+		}
+		revision, err := w.CompactEtcd(ctx, nil, 42)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(revision).To(Equal(int64(42)))
+		g.Expect(fakeEtcdClient.CompactedRevision).To(BeZero())
+	})
+	t.Run("defragments an etcd member", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &fake2.FakeEtcdClient{}
+		w := &Workload{
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClientFunc: func(n []string) (*etcd.Client, error) {
+					g.Expect(n).To(ConsistOf("node-1"))
+					return &etcd.Client{EtcdClient: fakeEtcdClient, Endpoint: "etcd-node-1"}, nil
+				},
+			},
+		}
+		g.Expect(w.DefragmentEtcdMember(ctx, "node-1")).To(Succeed())
+		g.Expect(fakeEtcdClient.DefragmentedMember).To(Equal("etcd-node-1"))
+	})
+	t.Run("disarms etcd alarms", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &fake2.FakeEtcdClient{}
+		w := &Workload{
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{EtcdClient: fakeEtcdClient},
+			},
+		}
+		g.Expect(w.DisarmEtcdAlarms(ctx, []etcd.MemberAlarm{
+			{MemberID: 1, Type: etcd.AlarmNoSpace},
+			{MemberID: 2, Type: etcd.AlarmNoSpace},
+		}, nil)).To(Succeed())
+		g.Expect(fakeEtcdClient.DisarmedAlarms).To(HaveLen(2))
+	})
+	t.Run("returns an error if it can't create an etcd client", func(t *testing.T) {
+		g := NewWithT(t)
+
+		w := &Workload{
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesErr:  errors.New("no etcdClient"),
+				forLeaderErr: errors.New("no etcdClient"),
+			},
+		}
+		_, err := w.GetEtcdMemberDBStatus(ctx, "node-1")
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(w.DefragmentEtcdMember(ctx, "node-1")).ToNot(Succeed())
+		_, err = w.CompactEtcd(ctx, nil, 0)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(w.DisarmEtcdAlarms(ctx, nil, nil)).ToNot(Succeed())
+	})
+}
+
 type fakeEtcdClientGenerator struct {
 	forNodesClient     *etcd.Client
 	forNodesClientFunc func([]string) (*etcd.Client, error)
@@ -849,6 +849,43 @@ func TestHasKubeadmConfig(t *testing.T) {
 	}
 }
 
+func TestUpdateClusterInfoCertificateAuthorities(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterInfo := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-info",
+			Namespace: metav1.NamespacePublic,
+		},
+		Data: map[string]string{
+			"kubeconfig": `apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: b2xkLWNh
+    server: https://test-cluster-api:6443
+  name: ""
+contexts: null
+current-context: ""
+kind: Config
+users: null
+`,
+			"jws-kubeconfig-abcdef": "signature",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(clusterInfo).Build()
+	w := &Workload{
+		Client: fakeClient,
+	}
+	g.Expect(w.UpdateClusterInfoCertificateAuthorities(ctx, []byte("new-ca"))).To(Succeed())
+
+	got := &corev1.ConfigMap{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(clusterInfo), got)).To(Succeed())
+	g.Expect(got.Data["kubeconfig"]).To(ContainSubstring("certificate-authority-data: bmV3LWNh"))
+	g.Expect(got.Data["kubeconfig"]).To(ContainSubstring("server: https://test-cluster-api:6443"))
+	g.Expect(got.Data).To(HaveKeyWithValue("jws-kubeconfig-abcdef", "signature"))
+}
+
 func TestUpdateFeatureGatesInKubeadmConfigMap(t *testing.T) {
 	tests := []struct {
 		name                     string
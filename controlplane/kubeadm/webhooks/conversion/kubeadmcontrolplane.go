@@ -72,6 +72,12 @@ func ConvertKubeadmControlPlaneV1Beta1ToHub(ctx context.Context, src *controlpla
 	// Recover other values
 	if ok {
 		bootstrapconversion.RestoreKubeadmConfigSpec(&restored.Spec.KubeadmConfigSpec, &dst.Spec.KubeadmConfigSpec)
+		dst.Spec.Etcd = restored.Spec.Etcd
+		dst.Status.Etcd = restored.Status.Etcd
+		dst.Spec.CertificateAuthorityRotation = restored.Spec.CertificateAuthorityRotation
+		dst.Status.CertificateAuthorityRotation = restored.Status.CertificateAuthorityRotation
+		dst.Spec.ServiceAccountKeyRotation = restored.Spec.ServiceAccountKeyRotation
+		dst.Status.ServiceAccountKeyRotation = restored.Status.ServiceAccountKeyRotation
 	}
 
 	if src.Spec.RemediationStrategy != nil {
@@ -89,6 +89,17 @@ const (
 	//
 	// alpha: v1.12
 	MachineTaintPropagation featuregate.Feature = "MachineTaintPropagation"
+
+	// ClusterKubeconfigRequest is a feature gate for issuing per-user kubeconfigs for workload clusters
+	// via the ClusterKubeconfigRequest API.
+	//
+	// alpha: v1.14
+	ClusterKubeconfigRequest featuregate.Feature = "ClusterKubeconfigRequest"
+
+	// KubeconfigMirroring is a feature gate for mirroring the kubeconfig of a Cluster into additional Secrets.
+	//
+	// alpha: v1.14
+	KubeconfigMirroring featuregate.Feature = "KubeconfigMirroring"
 )
 
 func init() {
@@ -109,4 +120,6 @@ var defaultClusterAPIFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 	RuntimeSDK:                     {Default: false, PreRelease: featuregate.Alpha},
 	InPlaceUpdates:                 {Default: false, PreRelease: featuregate.Alpha},
 	MachineTaintPropagation:        {Default: false, PreRelease: featuregate.Alpha},
+	ClusterKubeconfigRequest:       {Default: false, PreRelease: featuregate.Alpha},
+	KubeconfigMirroring:            {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -42,6 +42,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/feature"
@@ -68,7 +69,8 @@ const (
 // See: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#ownerreferencespermissionenforcement
 //
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
-// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;patch;update
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;patch;update;delete
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status;clusters/finalizers,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
@@ -79,6 +81,10 @@ type Reconciler struct {
 	APIReader    client.Reader
 	ClusterCache clustercache.ClusterCache
 
+	// SecretCachingClient is a client reading Secrets with the cluster name label from the cache,
+	// e.g. Secrets mirroring the kubeconfig of a Cluster.
+	SecretCachingClient client.Client
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
@@ -89,8 +95,8 @@ type Reconciler struct {
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
-	if r.Client == nil || r.APIReader == nil || r.ClusterCache == nil || r.RemoteConnectionGracePeriod == time.Duration(0) {
-		return errors.New("Client, APIReader and ClusterCache must not be nil and RemoteConnectionGracePeriod must not be 0")
+	if r.Client == nil || r.APIReader == nil || r.ClusterCache == nil || r.SecretCachingClient == nil || r.RemoteConnectionGracePeriod == time.Duration(0) {
+		return errors.New("Client, APIReader, ClusterCache and SecretCachingClient must not be nil and RemoteConnectionGracePeriod must not be 0")
 	}
 
 	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "cluster")
@@ -106,6 +112,10 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		Watches(
 			&clusterv1.MachineDeployment{},
 			handler.EnqueueRequestsFromMapFunc(r.machineDeploymentToCluster),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.kubeconfigSecretToCluster),
 		)
 	if feature.Gates.Enabled(feature.MachinePool) {
 		b = b.Watches(
@@ -220,6 +230,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retRes ct
 	reconcileNormal := append(
 		alwaysReconcile,
 		r.reconcileKubeconfig,
+		r.reconcileKubeconfigRotation,
+		r.reconcileKubeconfigMirrors,
 		r.reconcileV1Beta1ControlPlaneInitialized,
 	)
 	return doReconcile(ctx, reconcileNormal, s)
@@ -319,6 +331,10 @@ type scope struct {
 	// getDescendantsSucceeded documents if getDescendants succeeded.
 	getDescendantsSucceeded bool
 
+	// kubeconfigSecret is the kubeconfig Secret of the Cluster.
+	// It is set after reconcileKubeconfigRotation is called.
+	kubeconfigSecret *corev1.Secret
+
 	// deletingReason is the reason that should be used when setting the Deleting condition.
 	deletingReason string
 
@@ -484,6 +500,24 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (reconcile.R
 		}
 	}
 
+	// Delete the Secrets mirroring the kubeconfig of the Cluster, given that mirrors in other namespaces
+	// can't be garbage collected via owner references.
+	if err := r.deleteKubeconfigMirrors(ctx, cluster); err != nil {
+		s.deletingReason = clusterv1.ClusterDeletingInternalErrorReason
+		s.deletingMessage = "Please check controller logs for errors"
+		return ctrl.Result{}, err
+	}
+
+	// If the RuntimeSDK and ClusterTopology flags are enabled, for clusters with managed topologies
+	// only remove the finalizer after the AfterClusterDelete hook has been called.
+	if feature.Gates.Enabled(feature.RuntimeSDK) && feature.Gates.Enabled(feature.ClusterTopology) {
+		if cluster.Spec.Topology.IsDefined() && hooks.IsPending(runtimehooksv1.AfterClusterDelete, cluster) {
+			s.deletingReason = clusterv1.ClusterDeletingWaitingForAfterDeleteHookReason
+			s.deletingMessage = "Waiting for AfterClusterDelete hook"
+			return ctrl.Result{}, nil
+		}
+	}
+
 	s.deletingReason = clusterv1.ClusterDeletingDeletionCompletedReason
 	s.deletingMessage = "Deletion completed"
 
@@ -0,0 +1,339 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+// reconcileKubeconfigRotation surfaces the expiration of the client certificate in the kubeconfig of the Cluster
+// and records when the kubeconfig is rotated, no matter if the kubeconfig is managed by the Cluster controller
+// or by the control plane provider.
+func (r *Reconciler) reconcileKubeconfigRotation(ctx context.Context, s *scope) (ctrl.Result, error) {
+	cluster := s.cluster
+
+	configSecret, err := secret.Get(ctx, r.Client, util.ObjectKey(cluster), secret.Kubeconfig)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve Kubeconfig Secret for Cluster %q in namespace %q", cluster.Name, cluster.Namespace)
+	}
+	s.kubeconfigSecret = configSecret
+
+	expiration, err := kubeconfig.ClientCertificateExpiration(configSecret)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get client certificate expiration from Kubeconfig Secret for Cluster %q in namespace %q", cluster.Name, cluster.Namespace)
+	}
+
+	status := clusterv1.ClusterKubeconfigStatus{}
+	if cluster.Status.Kubeconfig != nil {
+		status = *cluster.Status.Kubeconfig
+	}
+	previousExpiration := status.CertificateExpirationTime.Time
+	status.CertificateExpirationTime = metav1.NewTime(expiration)
+
+	// Note: a kubeconfig with a different client certificate expiration is considered as rotated; the first
+	// time the expiration is observed, e.g. when the kubeconfig is created, is not considered as a rotation.
+	if !previousExpiration.IsZero() && !expiration.IsZero() && !previousExpiration.Equal(expiration.Truncate(time.Second)) {
+		status.LastRotationTime = metav1.Now()
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, "KubeconfigRotated", "Kubeconfig for Cluster %s has been rotated, the new client certificate expires at %s", cluster.Name, expiration.UTC().Format(time.RFC3339))
+	}
+
+	cluster.Status.Kubeconfig = nil
+	if !reflect.DeepEqual(status, clusterv1.ClusterKubeconfigStatus{}) {
+		cluster.Status.Kubeconfig = &status
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileKubeconfigMirrors copies the kubeconfig of the Cluster into the Secrets defined in spec.kubeconfig.mirrors,
+// and deletes the mirrored Secrets that are not defined anymore.
+func (r *Reconciler) reconcileKubeconfigMirrors(ctx context.Context, s *scope) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	cluster := s.cluster
+
+	if !feature.Gates.Enabled(feature.KubeconfigMirroring) {
+		return ctrl.Result{}, nil
+	}
+
+	// Wait for the kubeconfig Secret to exist; existing mirrors are preserved in the meantime.
+	if s.kubeconfigSecret == nil {
+		return ctrl.Result{}, nil
+	}
+
+	desired, errs, err := r.desiredKubeconfigMirrors(ctx, cluster, s.kubeconfigSecret)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	current, err := r.getKubeconfigMirrors(ctx, r.SecretCachingClient, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for key, mirror := range desired {
+		existing, ok := current[key]
+		if !ok {
+			// Note: The Secret is read from the API server because the cache only contains mirrors, and it might
+			// not contain mirrors which have been just created.
+			existing = &corev1.Secret{}
+			if err := r.Client.Get(ctx, key, existing); err != nil {
+				if !apierrors.IsNotFound(err) {
+					errs = append(errs, errors.Wrapf(err, "failed to mirror Kubeconfig to Secret %s", key))
+					continue
+				}
+
+				log.Info("Mirroring Kubeconfig", "Secret", klog.KObj(mirror))
+				if err := r.Client.Create(ctx, mirror); err != nil {
+					errs = append(errs, errors.Wrapf(err, "failed to mirror Kubeconfig to Secret %s", key))
+				}
+				continue
+			}
+
+			// Never overwrite Secrets which are not mirrors of the kubeconfig of this Cluster.
+			if !isKubeconfigMirror(existing, cluster) {
+				errs = append(errs, errors.Errorf("failed to mirror Kubeconfig to Secret %s: Secret already exists and it is not a mirror of the Kubeconfig of Cluster %s", key, klog.KObj(cluster)))
+				continue
+			}
+		}
+
+		if reflect.DeepEqual(existing.Data, mirror.Data) && reflect.DeepEqual(existing.Labels, mirror.Labels) {
+			continue
+		}
+
+		log.Info("Updating mirrored Kubeconfig", "Secret", klog.KObj(mirror))
+		original := existing.DeepCopy()
+		existing.Labels = mirror.Labels
+		existing.Data = mirror.Data
+		if err := r.Client.Patch(ctx, existing, client.MergeFrom(original)); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to update mirrored Kubeconfig in Secret %s", key))
+		}
+	}
+
+	for key, existing := range current {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		log.Info("Deleting mirrored Kubeconfig", "Secret", klog.KObj(existing))
+		if err := r.Client.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to delete mirrored Kubeconfig in Secret %s", key))
+		}
+	}
+
+	return ctrl.Result{}, kerrors.NewAggregate(errs)
+}
+
+// desiredKubeconfigMirrors computes the Secrets mirroring the kubeconfig of the Cluster.
+// Mirrors targeting namespaces which do not allow kubeconfig mirrors are not included and reported as errors,
+// so the allowed mirrors can still be reconciled.
+func (r *Reconciler) desiredKubeconfigMirrors(ctx context.Context, cluster *clusterv1.Cluster, configSecret *corev1.Secret) (map[client.ObjectKey]*corev1.Secret, []error, error) {
+	desired := map[client.ObjectKey]*corev1.Secret{}
+	errs := []error{}
+	for i, m := range cluster.Spec.Kubeconfig.Mirrors {
+		name := m.Name
+		if name == "" {
+			name = configSecret.Name
+		}
+
+		namespaces := []string{}
+		if m.Namespace != "" {
+			allowed, err := r.kubeconfigMirrorsAllowed(ctx, cluster, m.Namespace)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to get Namespace for spec.kubeconfig.mirrors[%d]", i)
+			}
+			if !allowed {
+				errs = append(errs, errors.Errorf("failed to mirror Kubeconfig to namespace %s: Namespace does not exist or its %s annotation does not allow mirrors of Cluster %s", m.Namespace, clusterv1.KubeconfigMirrorsAllowedFromAnnotation, klog.KObj(cluster)))
+				continue
+			}
+			namespaces = append(namespaces, m.Namespace)
+		}
+		if m.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(m.NamespaceSelector)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to parse spec.kubeconfig.mirrors[%d].namespaceSelector", i)
+			}
+			namespaceList := &corev1.NamespaceList{}
+			if err := r.Client.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to list Namespaces for spec.kubeconfig.mirrors[%d]", i)
+			}
+			for _, ns := range namespaceList.Items {
+				if !kubeconfigMirrorsAllowedFrom(&ns, cluster) {
+					continue
+				}
+				namespaces = append(namespaces, ns.Name)
+			}
+		}
+
+		for _, namespace := range namespaces {
+			key := client.ObjectKey{Namespace: namespace, Name: name}
+			// Never overwrite the kubeconfig Secret with its own mirror.
+			if key == client.ObjectKeyFromObject(configSecret) {
+				continue
+			}
+
+			labels := map[string]string{}
+			maps.Copy(labels, m.Labels)
+			// Note: The cluster name label is required for mirrors to be cached and watched.
+			labels[clusterv1.ClusterNameLabel] = cluster.Name
+			labels[clusterv1.KubeconfigMirrorClusterNameLabel] = cluster.Name
+			labels[clusterv1.KubeconfigMirrorClusterNamespaceLabel] = cluster.Namespace
+
+			mirror := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Labels:    labels,
+				},
+				Data: map[string][]byte{
+					secret.KubeconfigDataName: configSecret.Data[secret.KubeconfigDataName],
+				},
+				Type: clusterv1.ClusterSecretType,
+			}
+			// Mirrors in the same namespace of the Cluster are garbage collected with the Cluster; mirrors in
+			// other namespaces are deleted by reconcileDelete.
+			if namespace == cluster.Namespace {
+				mirror.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind("Cluster"))}
+			}
+			desired[key] = mirror
+		}
+	}
+	return desired, errs, nil
+}
+
+// kubeconfigMirrorsAllowed returns true if the kubeconfig of the Cluster can be mirrored into the namespace.
+func (r *Reconciler) kubeconfigMirrorsAllowed(ctx context.Context, cluster *clusterv1.Cluster, namespace string) (bool, error) {
+	if namespace == cluster.Namespace {
+		return true, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return kubeconfigMirrorsAllowedFrom(ns, cluster), nil
+}
+
+// kubeconfigMirrorsAllowedFrom returns true if the kubeconfig of the Cluster can be mirrored into the Namespace,
+// i.e. if it is the namespace of the Cluster, or if the kubeconfig mirrors allowed from annotation of the Namespace
+// lists the namespace of the Cluster or the Cluster itself.
+func kubeconfigMirrorsAllowedFrom(ns *corev1.Namespace, cluster *clusterv1.Cluster) bool {
+	if ns.Name == cluster.Namespace {
+		return true
+	}
+	for _, source := range strings.Split(ns.Annotations[clusterv1.KubeconfigMirrorsAllowedFromAnnotation], ",") {
+		source = strings.TrimSpace(source)
+		if source == cluster.Namespace || source == fmt.Sprintf("%s/%s", cluster.Namespace, cluster.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// getKubeconfigMirrors returns the Secrets mirroring the kubeconfig of the Cluster.
+func (r *Reconciler) getKubeconfigMirrors(ctx context.Context, c client.Reader, cluster *clusterv1.Cluster) (map[client.ObjectKey]*corev1.Secret, error) {
+	secretList := &corev1.SecretList{}
+	if err := c.List(ctx, secretList, client.MatchingLabels{
+		clusterv1.KubeconfigMirrorClusterNameLabel:      cluster.Name,
+		clusterv1.KubeconfigMirrorClusterNamespaceLabel: cluster.Namespace,
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list mirrored Kubeconfig Secrets for Cluster %s", klog.KObj(cluster))
+	}
+
+	mirrors := make(map[client.ObjectKey]*corev1.Secret, len(secretList.Items))
+	for i := range secretList.Items {
+		mirrors[client.ObjectKeyFromObject(&secretList.Items[i])] = &secretList.Items[i]
+	}
+	return mirrors, nil
+}
+
+// deleteKubeconfigMirrors deletes all the Secrets mirroring the kubeconfig of the Cluster.
+// NOTE: Mirrors are deleted also if the KubeconfigMirroring feature gate has been disabled after creating them.
+// NOTE: Mirrors are listed from the API server, so mirrors which are not yet in the cache are deleted as well.
+func (r *Reconciler) deleteKubeconfigMirrors(ctx context.Context, cluster *clusterv1.Cluster) error {
+	mirrors, err := r.getKubeconfigMirrors(ctx, r.Client, cluster)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	for key, mirror := range mirrors {
+		if err := r.Client.Delete(ctx, mirror); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to delete mirrored Kubeconfig in Secret %s", key))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// isKubeconfigMirror returns true if the Secret is a mirror of the kubeconfig of the Cluster.
+func isKubeconfigMirror(s *corev1.Secret, cluster *clusterv1.Cluster) bool {
+	return s.Labels[clusterv1.KubeconfigMirrorClusterNameLabel] == cluster.Name &&
+		s.Labels[clusterv1.KubeconfigMirrorClusterNamespaceLabel] == cluster.Namespace
+}
+
+// kubeconfigSecretToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for Cluster to update when its kubeconfig Secret gets updated, e.g. when the kubeconfig is rotated,
+// or when one of the Secrets mirroring its kubeconfig gets updated or deleted.
+func (r *Reconciler) kubeconfigSecretToCluster(_ context.Context, o client.Object) []ctrl.Request {
+	s, ok := o.(*corev1.Secret)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Secret but got a %T", o))
+	}
+
+	if clusterName, ok := s.Labels[clusterv1.KubeconfigMirrorClusterNameLabel]; ok {
+		return []ctrl.Request{{
+			NamespacedName: client.ObjectKey{
+				Namespace: s.Labels[clusterv1.KubeconfigMirrorClusterNamespaceLabel],
+				Name:      clusterName,
+			},
+		}}
+	}
+
+	clusterName, purpose, err := secret.ParseSecretName(s.Name)
+	if err != nil || purpose != secret.Kubeconfig || s.Labels[clusterv1.ClusterNameLabel] != clusterName {
+		return nil
+	}
+
+	return []ctrl.Request{{
+		NamespacedName: client.ObjectKey{
+			Namespace: s.Namespace,
+			Name:      clusterName,
+		},
+	}}
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestClusterReconcileKubeconfigRotation(t *testing.T) {
+	newCluster := func() *clusterv1.Cluster {
+		return &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "test-namespace"},
+		}
+	}
+
+	t.Run("does nothing if the kubeconfig Secret does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newCluster()
+		r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(fakeScheme).Build(), recorder: record.NewFakeRecorder(32)}
+		s := &scope{cluster: cluster}
+
+		_, err := r.reconcileKubeconfigRotation(ctx, s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(cluster.Status.Kubeconfig).To(BeNil())
+		g.Expect(s.kubeconfigSecret).To(BeNil())
+	})
+
+	t.Run("reports the client certificate expiration", func(t *testing.T) {
+		g := NewWithT(t)
+
+		notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+		cluster := newCluster()
+		recorder := record.NewFakeRecorder(32)
+		r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(newKubeconfigSecret(t, cluster, notAfter)).Build(), recorder: recorder}
+		s := &scope{cluster: cluster}
+
+		_, err := r.reconcileKubeconfigRotation(ctx, s)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(s.kubeconfigSecret).ToNot(BeNil())
+		g.Expect(cluster.Status.Kubeconfig).ToNot(BeNil())
+		g.Expect(cluster.Status.Kubeconfig.CertificateExpirationTime.Time).To(BeTemporally("==", notAfter))
+		g.Expect(cluster.Status.Kubeconfig.LastRotationTime.IsZero()).To(BeTrue())
+		g.Expect(recorder.Events).To(BeEmpty())
+	})
+
+	t.Run("records the rotation of the kubeconfig", func(t *testing.T) {
+		g := NewWithT(t)
+
+		notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+		cluster := newCluster()
+		cluster.Status.Kubeconfig = &clusterv1.ClusterKubeconfigStatus{
+			CertificateExpirationTime: metav1.NewTime(notAfter.Add(-time.Hour)),
+		}
+		recorder := record.NewFakeRecorder(32)
+		r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(newKubeconfigSecret(t, cluster, notAfter)).Build(), recorder: recorder}
+
+		_, err := r.reconcileKubeconfigRotation(ctx, &scope{cluster: cluster})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(cluster.Status.Kubeconfig.CertificateExpirationTime.Time).To(BeTemporally("==", notAfter))
+		g.Expect(cluster.Status.Kubeconfig.LastRotationTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+		g.Expect(recorder.Events).To(Receive(ContainSubstring("KubeconfigRotated")))
+	})
+}
+
+func TestClusterReconcileKubeconfigMirrors(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeconfigMirroring, true)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "test-namespace", UID: "cluster-uid"},
+		Spec: clusterv1.ClusterSpec{
+			Kubeconfig: clusterv1.ClusterKubeconfig{
+				Mirrors: []clusterv1.ClusterKubeconfigMirror{
+					{
+						Namespace: "argocd",
+						Name:      "test-cluster",
+						Labels:    map[string]string{"argocd.argoproj.io/secret-type": "cluster"},
+					},
+					{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+					},
+				},
+			},
+		},
+	}
+	configSecret := newKubeconfigSecret(t, cluster, time.Now().Add(time.Hour))
+	allowedFrom := func(sources string) map[string]string {
+		return map[string]string{clusterv1.KubeconfigMirrorsAllowedFromAnnotation: sources}
+	}
+	namespaces := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "argocd", Annotations: allowedFrom("test-namespace")}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}, Annotations: allowedFrom("test-namespace/test-cluster")}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a-private", Labels: map[string]string{"team": "a"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a-other", Labels: map[string]string{"team": "a"}, Annotations: allowedFrom("other-namespace, test-namespace/other-cluster")}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}, Annotations: allowedFrom("other-namespace, test-namespace")}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+	}
+	newReconciler := func(objs ...client.Object) *Reconciler {
+		c := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(objs...).Build()
+		return &Reconciler{Client: c, SecretCachingClient: c, recorder: record.NewFakeRecorder(32)}
+	}
+	getSecret := func(r *Reconciler, namespace, name string) (*corev1.Secret, error) {
+		s := &corev1.Secret{}
+		err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, s)
+		return s, err
+	}
+
+	t.Run("creates, updates and deletes mirrors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		stale := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster-kubeconfig",
+				Namespace: "team-b",
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel:                      cluster.Name,
+					clusterv1.KubeconfigMirrorClusterNameLabel:      cluster.Name,
+					clusterv1.KubeconfigMirrorClusterNamespaceLabel: cluster.Namespace,
+				},
+			},
+		}
+		outdated := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "argocd",
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel:                      cluster.Name,
+					clusterv1.KubeconfigMirrorClusterNameLabel:      cluster.Name,
+					clusterv1.KubeconfigMirrorClusterNamespaceLabel: cluster.Namespace,
+				},
+			},
+			Data: map[string][]byte{secret.KubeconfigDataName: []byte("outdated")},
+		}
+		objs := append([]client.Object{cluster.DeepCopy(), configSecret.DeepCopy(), stale, outdated}, namespaces...)
+		r := newReconciler(objs...)
+
+		_, err := r.reconcileKubeconfigMirrors(ctx, &scope{cluster: cluster, kubeconfigSecret: configSecret})
+		g.Expect(err).ToNot(HaveOccurred())
+
+		mirror, err := getSecret(r, "argocd", "test-cluster")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(mirror.Data).To(Equal(configSecret.Data))
+		g.Expect(mirror.Labels).To(HaveKeyWithValue("argocd.argoproj.io/secret-type", "cluster"))
+
+		mirror, err = getSecret(r, "team-a", "test-cluster-kubeconfig")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(mirror.Data).To(Equal(configSecret.Data))
+		g.Expect(mirror.Labels).To(HaveKeyWithValue(clusterv1.KubeconfigMirrorClusterNamespaceLabel, cluster.Namespace))
+		g.Expect(mirror.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, cluster.Name))
+
+		// Namespaces matching the selector which do not allow mirrors of the Cluster are skipped.
+		for _, namespace := range []string{"team-a-private", "team-a-other"} {
+			_, err = getSecret(r, namespace, "test-cluster-kubeconfig")
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), namespace)
+		}
+
+		_, err = getSecret(r, "team-b", "test-cluster-kubeconfig")
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		g.Expect(r.deleteKubeconfigMirrors(ctx, cluster)).To(Succeed())
+		_, err = getSecret(r, "argocd", "test-cluster")
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		_, err = getSecret(r, "team-a", "test-cluster-kubeconfig")
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	t.Run("does not overwrite Secrets which are not mirrors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		existing := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "argocd"},
+			Data:       map[string][]byte{"foo": []byte("bar")},
+		}
+		objs := append([]client.Object{cluster.DeepCopy(), configSecret.DeepCopy(), existing}, namespaces...)
+		r := newReconciler(objs...)
+
+		_, err := r.reconcileKubeconfigMirrors(ctx, &scope{cluster: cluster, kubeconfigSecret: configSecret})
+		g.Expect(err).To(HaveOccurred())
+
+		s, err := getSecret(r, "argocd", "test-cluster")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(s.Data).To(Equal(existing.Data))
+	})
+
+	t.Run("only mirrors to the namespace of the Cluster or to namespaces which allow kubeconfig mirrors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Kubeconfig.Mirrors = []clusterv1.ClusterKubeconfigMirror{
+			{Namespace: "kube-system"},
+			{Namespace: "not-existing"},
+			{Namespace: cluster.Namespace, Name: "test-cluster-mirror"},
+			{NamespaceSelector: &metav1.LabelSelector{}},
+		}
+		objs := append([]client.Object{cluster.DeepCopy(), configSecret.DeepCopy()}, namespaces...)
+		objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cluster.Namespace}})
+		r := newReconciler(objs...)
+
+		_, err := r.reconcileKubeconfigMirrors(ctx, &scope{cluster: cluster, kubeconfigSecret: configSecret})
+		g.Expect(err).To(MatchError(And(ContainSubstring("kube-system"), ContainSubstring("not-existing"))))
+
+		_, err = getSecret(r, "kube-system", "test-cluster-kubeconfig")
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		for _, namespace := range []string{"team-a-private", "team-a-other"} {
+			_, err = getSecret(r, namespace, "test-cluster-kubeconfig")
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), namespace)
+		}
+
+		// The empty selector selects only the namespaces which allow mirrors of the Cluster.
+		for _, namespace := range []string{"argocd", "team-a", "team-b"} {
+			_, err = getSecret(r, namespace, "test-cluster-kubeconfig")
+			g.Expect(err).ToNot(HaveOccurred(), namespace)
+		}
+		mirror, err := getSecret(r, cluster.Namespace, "test-cluster-mirror")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(mirror.OwnerReferences).To(HaveLen(1))
+
+		// Mirrors are deleted when the namespace does not allow mirrors of the Cluster anymore.
+		ns := &corev1.Namespace{}
+		g.Expect(r.Client.Get(ctx, client.ObjectKey{Name: "team-b"}, ns)).To(Succeed())
+		ns.Annotations[clusterv1.KubeconfigMirrorsAllowedFromAnnotation] = "other-namespace"
+		g.Expect(r.Client.Update(ctx, ns)).To(Succeed())
+
+		_, err = r.reconcileKubeconfigMirrors(ctx, &scope{cluster: cluster, kubeconfigSecret: configSecret})
+		g.Expect(err).To(HaveOccurred())
+		_, err = getSecret(r, "team-b", "test-cluster-kubeconfig")
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+}
+
+func TestKubeconfigSecretToCluster(t *testing.T) {
+	g := NewWithT(t)
+	r := &Reconciler{}
+
+	kubeconfigSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-cluster-kubeconfig",
+		Namespace: "test-namespace",
+		Labels:    map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+	}}
+	g.Expect(r.kubeconfigSecretToCluster(ctx, kubeconfigSecret)).To(ConsistOf(ctrl.Request{
+		NamespacedName: client.ObjectKey{Namespace: "test-namespace", Name: "test-cluster"},
+	}))
+
+	caSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-cluster-ca",
+		Namespace: "test-namespace",
+		Labels:    map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+	}}
+	g.Expect(r.kubeconfigSecretToCluster(ctx, caSecret)).To(BeEmpty())
+
+	mirrorSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-cluster-kubeconfig",
+		Namespace: "argocd",
+		Labels: map[string]string{
+			clusterv1.ClusterNameLabel:                      "test-cluster",
+			clusterv1.KubeconfigMirrorClusterNameLabel:      "test-cluster",
+			clusterv1.KubeconfigMirrorClusterNamespaceLabel: "test-namespace",
+		},
+	}}
+	g.Expect(r.kubeconfigSecretToCluster(ctx, mirrorSecret)).To(ConsistOf(ctrl.Request{
+		NamespacedName: client.ObjectKey{Namespace: "test-namespace", Name: "test-cluster"},
+	}))
+}
+
+func newKubeconfigSecret(t *testing.T, cluster *clusterv1.Cluster, notAfter time.Time) *corev1.Secret {
+	t.Helper()
+	g := NewWithT(t)
+
+	ca := &secret.Certificate{Purpose: secret.ClusterCA}
+	g.Expect(ca.Generate()).To(Succeed())
+	caCert, err := certs.DecodeCertPEM(ca.KeyPair.Cert)
+	g.Expect(err).ToNot(HaveOccurred())
+	caKey, err := certs.DecodePrivateKeyPEM(ca.KeyPair.Key)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	config, err := kubeconfig.New(cluster.Name, "https://1.2.3.4:6443", caCert, caKey, kubeconfig.NotAfter(notAfter))
+	g.Expect(err).ToNot(HaveOccurred())
+	out, err := clientcmd.Write(*config)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	return kubeconfig.GenerateSecret(cluster, out)
+}
@@ -29,6 +29,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
@@ -554,6 +555,65 @@ func TestClusterReconciler_reconcileDelete(t *testing.T) {
 	}
 }
 
+func TestClusterReconciler_reconcileDeleteWaitsForAfterClusterDeleteHook(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)
+
+	tests := []struct {
+		name                string
+		pendingHooks        string
+		wantFinalizer       bool
+		wantDeletingReason  string
+		wantDeletingMessage string
+	}{
+		{
+			name:                "should not remove the finalizer if the AfterClusterDelete hook is pending",
+			pendingHooks:        "AfterClusterDelete",
+			wantFinalizer:       true,
+			wantDeletingReason:  clusterv1.ClusterDeletingWaitingForAfterDeleteHookReason,
+			wantDeletingMessage: "Waiting for AfterClusterDelete hook",
+		},
+		{
+			name:                "should remove the finalizer if the AfterClusterDelete hook is not pending",
+			wantFinalizer:       false,
+			wantDeletingReason:  clusterv1.ClusterDeletingDeletionCompletedReason,
+			wantDeletingMessage: "Deletion completed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := builder.Cluster("test-ns", "test-cluster").WithTopology(&clusterv1.Topology{ClassRef: clusterv1.ClusterClassRef{Name: "class"}}).Build()
+			cluster.Finalizers = []string{clusterv1.ClusterFinalizer}
+			cluster.Annotations = map[string]string{
+				runtimev1.OkToDeleteAnnotation: "",
+			}
+			if tt.pendingHooks != "" {
+				cluster.Annotations[runtimev1.PendingHooksAnnotation] = tt.pendingHooks
+			}
+
+			fakeClient := fake.NewClientBuilder().WithObjects(cluster).Build()
+			r := &Reconciler{
+				Client:    fakeClient,
+				APIReader: fakeClient,
+				recorder:  record.NewFakeRecorder(1),
+			}
+
+			s := &scope{
+				cluster:                 cluster,
+				getDescendantsSucceeded: true,
+			}
+			_, err := r.reconcileDelete(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(controllerutil.ContainsFinalizer(cluster, clusterv1.ClusterFinalizer)).To(Equal(tt.wantFinalizer))
+			g.Expect(s.deletingReason).To(Equal(tt.wantDeletingReason))
+			g.Expect(s.deletingMessage).To(Equal(tt.wantDeletingMessage))
+		})
+	}
+}
+
 func TestClusterReconcilerNodeRef(t *testing.T) {
 	t.Run("machine to cluster", func(t *testing.T) {
 		cluster := &clusterv1.Cluster{
@@ -0,0 +1,395 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterkubeconfigrequest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	capicontrollerutil "sigs.k8s.io/cluster-api/util/controller"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+const (
+	// renewAfterPercent is the percentage of the TTL after which a kubeconfig is renewed, if autoRenew is set.
+	renewAfterPercent = 80
+
+	// secretNameSuffix is the suffix of the name of the Secret storing the kubeconfig issued for a ClusterKubeconfigRequest.
+	secretNameSuffix = "user-kubeconfig"
+)
+
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusterkubeconfigrequests;clusterkubeconfigrequests/status;clusterkubeconfigrequests/finalizers,verbs=get;list;watch;update;patch
+
+// Reconciler reconciles a ClusterKubeconfigRequest object.
+type Reconciler struct {
+	Client client.Client
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+
+	recorder record.EventRecorder
+}
+
+func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	if r.Client == nil {
+		return errors.New("Client must not be nil")
+	}
+
+	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "clusterkubeconfigrequest")
+	err := capicontrollerutil.NewControllerManagedBy(mgr, predicateLog).
+		For(&clusterv1.ClusterKubeconfigRequest{}).
+		Owns(&corev1.Secret{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, r.WatchFilterValue)).
+		Watches(
+			&clusterv1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToClusterKubeconfigRequests),
+			predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, r.WatchFilterValue),
+		).
+		Complete(ctx, r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	r.recorder = mgr.GetEventRecorderFor("clusterkubeconfigrequest-controller")
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Fetch the ClusterKubeconfigRequest instance.
+	request := &clusterv1.ClusterKubeconfigRequest{}
+	if err := r.Client.Get(ctx, req.NamespacedName, request); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Object not found, return. The Secret is automatically garbage collected.
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	log = log.WithValues("Cluster", klog.KRef(request.Namespace, request.Spec.ClusterName))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	cluster, err := util.GetClusterByName(ctx, r.Client, request.Namespace, request.Spec.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Initialize the patch helper.
+	patchHelper, err := patch.NewHelper(request, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if isPaused, requeue, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, request); err != nil || isPaused || requeue {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to patch the object and status after each reconciliation.
+		// Patch ObservedGeneration only if the reconciliation completed successfully.
+		patchOpts := []patch.Option{
+			patch.WithOwnedConditions{Conditions: []string{
+				clusterv1.PausedCondition,
+				clusterv1.ClusterKubeconfigRequestReadyCondition,
+			}},
+		}
+		if reterr == nil {
+			patchOpts = append(patchOpts, patch.WithStatusObservedGeneration{})
+		}
+		if err := patchHelper.Patch(ctx, request, patchOpts...); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	// Reconcile labels and ensure the ClusterKubeconfigRequest is owned by the Cluster it belongs to.
+	if request.Labels == nil {
+		request.Labels = make(map[string]string)
+	}
+	request.Labels[clusterv1.ClusterNameLabel] = request.Spec.ClusterName
+	request.SetOwnerReferences(util.EnsureOwnerRef(request.GetOwnerReferences(), metav1.OwnerReference{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "Cluster",
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+	}))
+
+	return r.reconcile(ctx, cluster, request)
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster, request *clusterv1.ClusterKubeconfigRequest) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if !cluster.Spec.ControlPlaneEndpoint.IsValid() {
+		setWaitingForClusterCondition(request, "Waiting for Cluster control plane endpoint")
+		return ctrl.Result{}, nil
+	}
+
+	caSecret, err := secret.GetFromNamespacedName(ctx, r.Client, util.ObjectKey(cluster), secret.ClusterCA)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			setWaitingForClusterCondition(request, "Waiting for Cluster certificate authority")
+			return ctrl.Result{}, nil
+		}
+		setInternalErrorCondition(request)
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get Cluster certificate authority")
+	}
+
+	kubeconfigSecret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: request.Namespace, Name: secretName(request)}
+	if err := r.Client.Get(ctx, secretKey, kubeconfigSecret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			setInternalErrorCondition(request)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to get Secret %s", klog.KRef(secretKey.Namespace, secretKey.Name))
+		}
+		kubeconfigSecret = nil
+	}
+	if kubeconfigSecret != nil && !metav1.IsControlledBy(kubeconfigSecret, request) {
+		setInternalErrorCondition(request)
+		return ctrl.Result{}, errors.Errorf("Secret %s already exists and it is not controlled by the ClusterKubeconfigRequest", klog.KObj(kubeconfigSecret))
+	}
+
+	now := time.Now()
+	ttl := time.Duration(ptr.Deref(request.Spec.TTLSeconds, clusterv1.DefaultClusterKubeconfigRequestTTLSeconds)) * time.Second
+	autoRenew := ptr.Deref(request.Spec.AutoRenew, false)
+	// Kubeconfigs using OpenID Connect do not contain any credential, so they never expire.
+	expires := !isOIDC(request)
+
+	issueTime := request.Status.IssueTime.Time
+	expirationTime := request.Status.ExpirationTime.Time
+	issue := false
+	switch {
+	case issueTime.IsZero():
+		log.Info("Issuing kubeconfig")
+		issue = true
+		expirationTime = now.Add(ttl)
+	case expires && !now.Before(expirationTime) && !autoRenew:
+		if kubeconfigSecret != nil {
+			log.Info("Deleting expired kubeconfig", "Secret", klog.KObj(kubeconfigSecret))
+			if err := r.Client.Delete(ctx, kubeconfigSecret); err != nil && !apierrors.IsNotFound(err) {
+				setInternalErrorCondition(request)
+				return ctrl.Result{}, errors.Wrapf(err, "failed to delete Secret %s", klog.KObj(kubeconfigSecret))
+			}
+			r.recorder.Eventf(request, corev1.EventTypeNormal, "KubeconfigExpired", "Kubeconfig for Cluster %s expired", cluster.Name)
+		}
+		request.Status.SecretName = ""
+		conditions.Set(request, metav1.Condition{
+			Type:    clusterv1.ClusterKubeconfigRequestReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.ClusterKubeconfigRequestExpiredReason,
+			Message: fmt.Sprintf("Kubeconfig expired at %s", expirationTime.UTC().Format(time.RFC3339)),
+		})
+		return ctrl.Result{}, nil
+	case expires && autoRenew && !now.Before(renewTime(issueTime, expirationTime)):
+		log.Info("Renewing kubeconfig", "expirationTime", expirationTime.UTC().Format(time.RFC3339))
+		issue = true
+		expirationTime = now.Add(ttl)
+	case kubeconfigSecret == nil:
+		// Re-create the kubeconfig if the Secret has been deleted; the expiration time is preserved.
+		log.Info("Re-issuing kubeconfig, Secret not found", "Secret", klog.KRef(secretKey.Namespace, secretKey.Name))
+		issue = true
+	default:
+		needsUpdate, err := kubeconfig.NeedsCertificateAuthorityUpdate(kubeconfigSecret, caSecret)
+		if err != nil {
+			setInternalErrorCondition(request)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to check certificate authorities of Secret %s", klog.KObj(kubeconfigSecret))
+		}
+		if needsUpdate {
+			log.Info("Re-issuing kubeconfig, Cluster certificate authorities changed", "Secret", klog.KObj(kubeconfigSecret))
+			issue = true
+		}
+	}
+
+	if issue {
+		if err := r.issueKubeconfig(ctx, cluster, request, kubeconfigSecret, expirationTime); err != nil {
+			setInternalErrorCondition(request)
+			return ctrl.Result{}, err
+		}
+		request.Status.IssueTime = metav1.NewTime(now)
+		request.Status.ExpirationTime = metav1.Time{}
+		if expires {
+			request.Status.ExpirationTime = metav1.NewTime(expirationTime)
+			r.recorder.Eventf(request, corev1.EventTypeNormal, "KubeconfigIssued", "Issued kubeconfig for Cluster %s expiring at %s", cluster.Name, expirationTime.UTC().Format(time.RFC3339))
+		} else {
+			r.recorder.Eventf(request, corev1.EventTypeNormal, "KubeconfigIssued", "Issued kubeconfig for Cluster %s", cluster.Name)
+		}
+	}
+	request.Status.SecretName = secretKey.Name
+
+	conditions.Set(request, metav1.Condition{
+		Type:   clusterv1.ClusterKubeconfigRequestReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1.ClusterKubeconfigRequestIssuedReason,
+	})
+
+	if !expires {
+		return ctrl.Result{}, nil
+	}
+
+	// Requeue when the kubeconfig has to be renewed or deleted.
+	next := request.Status.ExpirationTime.Time
+	if autoRenew {
+		next = renewTime(request.Status.IssueTime.Time, next)
+	}
+	return ctrl.Result{RequeueAfter: max(time.Until(next), time.Second)}, nil
+}
+
+// issueKubeconfig generates a new kubeconfig and stores it in the Secret of the ClusterKubeconfigRequest,
+// creating the Secret if it does not exist.
+func (r *Reconciler) issueKubeconfig(ctx context.Context, cluster *clusterv1.Cluster, request *clusterv1.ClusterKubeconfigRequest, kubeconfigSecret *corev1.Secret, expirationTime time.Time) error {
+	server, err := url.JoinPath("https://", cluster.Spec.ControlPlaneEndpoint.String())
+	if err != nil {
+		return errors.Wrapf(err, "failed to compute the Cluster control plane endpoint")
+	}
+
+	options := []kubeconfig.KubeConfigOption{}
+	if request.Spec.User != "" {
+		options = append(options, kubeconfig.User{Name: request.Spec.User, Groups: request.Spec.Groups})
+	}
+	if isOIDC(request) {
+		options = append(options, kubeconfig.OIDC{
+			IssuerURL:   request.Spec.OIDC.IssuerURL,
+			ClientID:    request.Spec.OIDC.ClientID,
+			ExtraScopes: request.Spec.OIDC.ExtraScopes,
+		})
+	} else {
+		options = append(options, kubeconfig.NotAfter(expirationTime))
+	}
+
+	data, err := kubeconfig.Generate(ctx, r.Client, util.ObjectKey(cluster), server, options...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to generate kubeconfig")
+	}
+
+	if kubeconfigSecret == nil {
+		kubeconfigSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName(request),
+				Namespace: request.Namespace,
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel:                  cluster.Name,
+					clusterv1.ClusterKubeconfigRequestNameLabel: request.Name,
+				},
+			},
+			Data: map[string][]byte{
+				secret.KubeconfigDataName: data,
+			},
+			Type: clusterv1.ClusterSecretType,
+		}
+		if err := controllerutil.SetControllerReference(request, kubeconfigSecret, r.Client.Scheme()); err != nil {
+			return errors.Wrapf(err, "failed to set owner reference on Secret %s", klog.KObj(kubeconfigSecret))
+		}
+		if err := r.Client.Create(ctx, kubeconfigSecret); err != nil {
+			return errors.Wrapf(err, "failed to create Secret %s", klog.KObj(kubeconfigSecret))
+		}
+		return nil
+	}
+
+	if kubeconfigSecret.Data == nil {
+		kubeconfigSecret.Data = map[string][]byte{}
+	}
+	kubeconfigSecret.Data[secret.KubeconfigDataName] = data
+	if err := r.Client.Update(ctx, kubeconfigSecret); err != nil {
+		return errors.Wrapf(err, "failed to update Secret %s", klog.KObj(kubeconfigSecret))
+	}
+	return nil
+}
+
+// clusterToClusterKubeconfigRequests maps events from Cluster objects to the ClusterKubeconfigRequests of the Cluster.
+func (r *Reconciler) clusterToClusterKubeconfigRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	c, ok := o.(*clusterv1.Cluster)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Cluster, got %T", o))
+	}
+
+	requestList := &clusterv1.ClusterKubeconfigRequestList{}
+	if err := r.Client.List(
+		ctx,
+		requestList,
+		client.InNamespace(c.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: c.Name},
+	); err != nil {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, request := range requestList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: request.Namespace, Name: request.Name}})
+	}
+	return requests
+}
+
+// secretName returns the name of the Secret storing the kubeconfig issued for a ClusterKubeconfigRequest.
+func secretName(request *clusterv1.ClusterKubeconfigRequest) string {
+	return fmt.Sprintf("%s-%s", request.Name, secretNameSuffix)
+}
+
+// renewTime returns the time after which a kubeconfig must be renewed.
+func renewTime(issueTime, expirationTime time.Time) time.Time {
+	return issueTime.Add(expirationTime.Sub(issueTime) * renewAfterPercent / 100)
+}
+
+func isOIDC(request *clusterv1.ClusterKubeconfigRequest) bool {
+	return request.Spec.OIDC.IssuerURL != ""
+}
+
+func setWaitingForClusterCondition(request *clusterv1.ClusterKubeconfigRequest, message string) {
+	conditions.Set(request, metav1.Condition{
+		Type:    clusterv1.ClusterKubeconfigRequestReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  clusterv1.ClusterKubeconfigRequestWaitingForClusterReason,
+		Message: message,
+	})
+}
+
+func setInternalErrorCondition(request *clusterv1.ClusterKubeconfigRequest) {
+	conditions.Set(request, metav1.Condition{
+		Type:    clusterv1.ClusterKubeconfigRequestReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  clusterv1.ClusterKubeconfigRequestInternalErrorReason,
+		Message: "Please check controller logs for errors",
+	})
+}
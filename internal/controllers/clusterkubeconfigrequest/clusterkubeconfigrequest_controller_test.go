@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterkubeconfigrequest
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	caSecret := newCASecret(t)
+
+	newCluster := func() *clusterv1.Cluster {
+		return &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault, UID: "cluster-uid"},
+			Spec: clusterv1.ClusterSpec{
+				ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "127.0.0.1", Port: 6443},
+			},
+		}
+	}
+	newRequest := func() *clusterv1.ClusterKubeconfigRequest {
+		return &clusterv1.ClusterKubeconfigRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: metav1.NamespaceDefault, UID: "request-uid"},
+			Spec: clusterv1.ClusterKubeconfigRequestSpec{
+				ClusterName: "test-cluster",
+				User:        "alice",
+				Groups:      []string{"developers"},
+				TTLSeconds:  ptr.To[int32](3600),
+			},
+		}
+	}
+	newReconciler := func(objs ...client.Object) *Reconciler {
+		return &Reconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			recorder: record.NewFakeRecorder(32),
+		}
+	}
+	getKubeconfigSecret := func(r *Reconciler, request *clusterv1.ClusterKubeconfigRequest) (*corev1.Secret, error) {
+		s := &corev1.Secret{}
+		err := r.Client.Get(t.Context(), client.ObjectKey{Namespace: request.Namespace, Name: secretName(request)}, s)
+		return s, err
+	}
+
+	t.Run("waits for the control plane endpoint", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newCluster()
+		cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{}
+		request := newRequest()
+		r := newReconciler(caSecret.DeepCopy())
+
+		res, err := r.reconcile(t.Context(), cluster, request)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(conditions.GetReason(request, clusterv1.ClusterKubeconfigRequestReadyCondition)).To(Equal(clusterv1.ClusterKubeconfigRequestWaitingForClusterReason))
+	})
+
+	t.Run("waits for the certificate authority", func(t *testing.T) {
+		g := NewWithT(t)
+
+		request := newRequest()
+		r := newReconciler()
+
+		_, err := r.reconcile(t.Context(), newCluster(), request)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conditions.GetReason(request, clusterv1.ClusterKubeconfigRequestReadyCondition)).To(Equal(clusterv1.ClusterKubeconfigRequestWaitingForClusterReason))
+	})
+
+	t.Run("issues a kubeconfig for the user", func(t *testing.T) {
+		g := NewWithT(t)
+
+		request := newRequest()
+		r := newReconciler(caSecret.DeepCopy())
+
+		res, err := r.reconcile(t.Context(), newCluster(), request)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		g.Expect(conditions.IsTrue(request, clusterv1.ClusterKubeconfigRequestReadyCondition)).To(BeTrue())
+		g.Expect(request.Status.SecretName).To(Equal("alice-user-kubeconfig"))
+		g.Expect(request.Status.ExpirationTime.Sub(request.Status.IssueTime.Time)).To(Equal(time.Hour))
+
+		s, err := getKubeconfigSecret(r, request)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(metav1.IsControlledBy(s, request)).To(BeTrue())
+		g.Expect(s.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "test-cluster"))
+
+		config, err := clientcmd.Load(s.Data[secret.KubeconfigDataName])
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(config.Clusters["test-cluster"].Server).To(Equal("https://127.0.0.1:6443"))
+		cert, err := certs.DecodeCertPEM(config.AuthInfos["alice"].ClientCertificateData)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(cert.Subject.CommonName).To(Equal("alice"))
+		g.Expect(cert.Subject.Organization).To(Equal([]string{"developers"}))
+		g.Expect(cert.NotAfter).To(BeTemporally("~", request.Status.ExpirationTime.Time, time.Second))
+	})
+
+	t.Run("re-issues a deleted kubeconfig with the same expiration", func(t *testing.T) {
+		g := NewWithT(t)
+
+		request := newRequest()
+		expirationTime := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+		request.Status.IssueTime = metav1.NewTime(time.Now().Add(-30 * time.Minute))
+		request.Status.ExpirationTime = metav1.NewTime(expirationTime)
+		r := newReconciler(caSecret.DeepCopy())
+
+		_, err := r.reconcile(t.Context(), newCluster(), request)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(request.Status.ExpirationTime.Time).To(BeTemporally("==", expirationTime))
+
+		_, err = getKubeconfigSecret(r, request)
+		g.Expect(err).ToNot(HaveOccurred())
+	})
+
+	t.Run("renews a kubeconfig if autoRenew is set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		request := newRequest()
+		request.Spec.AutoRenew = ptr.To(true)
+		request.Status.IssueTime = metav1.NewTime(time.Now().Add(-50 * time.Minute))
+		request.Status.ExpirationTime = metav1.NewTime(time.Now().Add(10 * time.Minute))
+		r := newReconciler(caSecret.DeepCopy())
+
+		res, err := r.reconcile(t.Context(), newCluster(), request)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(request.Status.ExpirationTime.Time).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+		// Renew after 80% of the TTL.
+		g.Expect(res.RequeueAfter).To(BeNumerically("~", 48*time.Minute, time.Minute))
+	})
+
+	t.Run("deletes an expired kubeconfig if autoRenew is not set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		request := newRequest()
+		request.Status.IssueTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		request.Status.ExpirationTime = metav1.NewTime(time.Now().Add(-time.Hour))
+		request.Status.SecretName = secretName(request)
+		existing := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName(request), Namespace: request.Namespace}}
+		existing.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(request, clusterv1.GroupVersion.WithKind("ClusterKubeconfigRequest"))})
+		r := newReconciler(caSecret.DeepCopy(), existing)
+
+		res, err := r.reconcile(t.Context(), newCluster(), request)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(request.Status.SecretName).To(BeEmpty())
+		g.Expect(conditions.GetReason(request, clusterv1.ClusterKubeconfigRequestReadyCondition)).To(Equal(clusterv1.ClusterKubeconfigRequestExpiredReason))
+
+		_, err = getKubeconfigSecret(r, request)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	t.Run("does not overwrite a Secret not controlled by the request", func(t *testing.T) {
+		g := NewWithT(t)
+
+		request := newRequest()
+		existing := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName(request), Namespace: request.Namespace}}
+		r := newReconciler(caSecret.DeepCopy(), existing)
+
+		_, err := r.reconcile(t.Context(), newCluster(), request)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(conditions.GetReason(request, clusterv1.ClusterKubeconfigRequestReadyCondition)).To(Equal(clusterv1.ClusterKubeconfigRequestInternalErrorReason))
+	})
+
+	t.Run("issues a kubeconfig using OIDC", func(t *testing.T) {
+		g := NewWithT(t)
+
+		request := newRequest()
+		request.Spec.User = ""
+		request.Spec.Groups = nil
+		request.Spec.TTLSeconds = nil
+		request.Spec.OIDC = clusterv1.ClusterKubeconfigRequestOIDCSpec{IssuerURL: "https://issuer.example.com", ClientID: "capi"}
+		r := newReconciler(caSecret.DeepCopy())
+
+		res, err := r.reconcile(t.Context(), newCluster(), request)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(request.Status.ExpirationTime.IsZero()).To(BeTrue())
+
+		s, err := getKubeconfigSecret(r, request)
+		g.Expect(err).ToNot(HaveOccurred())
+		config, err := clientcmd.Load(s.Data[secret.KubeconfigDataName])
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(config.AuthInfos["oidc"].Exec).ToNot(BeNil())
+	})
+}
+
+func newCASecret(t *testing.T) *corev1.Secret {
+	t.Helper()
+	g := NewWithT(t)
+
+	ca := &secret.Certificate{Purpose: secret.ClusterCA}
+	g.Expect(ca.Generate()).To(Succeed())
+	return ca.AsSecret(client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "test-cluster"}, metav1.OwnerReference{})
+}
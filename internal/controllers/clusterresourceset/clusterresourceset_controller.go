@@ -19,6 +19,8 @@ package clusterresourceset
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -69,6 +71,12 @@ type Reconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// httpClient is the client used to fetch resources from remote sources.
+	httpClient *http.Client
+
+	// sourceCache caches the content fetched from remote sources.
+	sourceCache *sourceCache
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options, partialSecretCache cache.Cache) error {
@@ -114,6 +122,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
 
+	r.sourceCache = newSourceCache(sourceCacheTTL, maxSourceCacheSize)
 	return nil
 }
 
@@ -186,10 +195,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	}
 
 	errs := []error{}
+	allReady := true
 	for _, cluster := range clusters {
-		if err := r.ApplyClusterResourceSet(ctx, cluster, clusterResourceSet); err != nil {
+		ready, err := r.ApplyClusterResourceSet(ctx, cluster, clusterResourceSet)
+		if err != nil {
 			errs = append(errs, err)
 		}
+		allReady = allReady && ready
 	}
 
 	// Return an aggregated error if errors occurred.
@@ -213,6 +225,28 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, kerrors.NewAggregate(errs)
 	}
 
+	// ClusterResourceSets waiting for their dependencies or for their readiness checks to pass are checked again
+	// periodically, because changes to the workloads in the Clusters do not trigger reconciliation.
+	if !allReady && (len(clusterResourceSet.Spec.DependsOn) > 0 || len(clusterResourceSet.Spec.ReadinessChecks) > 0) {
+		return ctrl.Result{RequeueAfter: readinessResyncPeriod}, nil
+	}
+
+	// ClusterResourceSets with the ServerSideApply strategy periodically apply again their resources, to restore
+	// objects modified or deleted in the Clusters.
+	if addonsv1.ClusterResourceSetStrategy(clusterResourceSet.Spec.Strategy) == addonsv1.ClusterResourceSetStrategyServerSideApply {
+		return ctrl.Result{RequeueAfter: serverSideApplyResyncPeriod}, nil
+	}
+
+	// Remote sources could change without changes to the ClusterResourceSet, e.g. when an OCI tag is moved,
+	// so ClusterResourceSets with the Reconcile strategy periodically check them again.
+	if addonsv1.ClusterResourceSetStrategy(clusterResourceSet.Spec.Strategy) == addonsv1.ClusterResourceSetStrategyReconcile {
+		for _, resource := range clusterResourceSet.Spec.Resources {
+			if resource.IsRemote() {
+				return ctrl.Result{RequeueAfter: remoteSourceResyncPeriod}, nil
+			}
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -251,6 +285,10 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, clusters []*clusterv1.
 		} else if err := r.Client.Patch(ctx, clusterResourceSetBinding, client.MergeFrom(original)); err != nil {
 			return err
 		}
+
+		if err := r.reconcileClusterResourceSetsReadyCondition(ctx, cluster, clusterResourceSetBinding); err != nil {
+			return errors.Wrapf(err, "failed to patch Cluster %s", klog.KObj(cluster))
+		}
 	}
 
 	controllerutil.RemoveFinalizer(crs, addonsv1.ClusterResourceSetFinalizer)
@@ -293,8 +331,12 @@ func (r *Reconciler) getClustersByClusterResourceSetSelector(ctx context.Context
 // It applies resources best effort and continue on scenarios like: unsupported resource types, failure during creation, missing resources.
 // In Reconcile strategy, resources are re-applied to a particular cluster when their definition changes. The hash in ClusterResourceSetBinding is used to check
 // if a resource has changed or not.
+// In ServerSideApply strategy, resources are applied at every reconcile using server-side apply, restoring objects modified or deleted in the cluster.
+// The objects applied are recorded in ClusterResourceSetBinding, and objects removed from the resources are deleted from the cluster.
+// Resources are applied only when the ClusterResourceSets in dependsOn are ready on the cluster; ApplyClusterResourceSet returns true
+// if the ClusterResourceSet is ready on the cluster, i.e. all its resources are applied and all its readiness checks pass.
 // TODO: If a resource already exists in the cluster but not applied by ClusterResourceSet, the resource will be updated ?
-func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSet *addonsv1.ClusterResourceSet) (rerr error) {
+func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSet *addonsv1.ClusterResourceSet) (ready bool, rerr error) {
 	log := ctrl.LoggerFrom(ctx, "Cluster", klog.KObj(cluster))
 	ctx = ctrl.LoggerInto(ctx, log)
 
@@ -304,6 +346,11 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 	errList := []error{}
 	objList := make([]*unstructured.Unstructured, len(clusterResourceSet.Spec.Resources))
 	for i, resource := range clusterResourceSet.Spec.Resources {
+		// Resources from remote sources are fetched when applying them to the cluster.
+		if resource.IsRemote() {
+			continue
+		}
+
 		unstructuredObj, err := r.getResource(ctx, resource, cluster.GetNamespace())
 		if err != nil {
 			if errors.Is(err, ErrSecretTypeNotSupported) {
@@ -341,13 +388,13 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 		objList[i] = unstructuredObj
 	}
 	if len(errList) > 0 {
-		return kerrors.NewAggregate(errList)
+		return false, kerrors.NewAggregate(errList)
 	}
 
 	// Get ClusterResourceSetBinding object for the cluster.
 	clusterResourceSetBinding, err := r.getOrCreateClusterResourceSetBinding(ctx, cluster, clusterResourceSet)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	patch := client.MergeFromWithOptions(clusterResourceSetBinding.DeepCopy(), client.MergeFromWithOptimisticLock{})
@@ -358,6 +405,12 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 		// using the patch helper is unnecessary.
 		if err := r.Client.Patch(ctx, clusterResourceSetBinding, patch); err != nil {
 			rerr = kerrors.NewAggregate([]error{rerr, errors.Wrapf(err, "failed to patch ClusterResourceSetBinding %s", klog.KObj(clusterResourceSetBinding))})
+			return
+		}
+
+		// Surface the readiness of all the ClusterResourceSets applied to the Cluster on the Cluster.
+		if err := r.reconcileClusterResourceSetsReadyCondition(ctx, cluster, clusterResourceSetBinding); err != nil {
+			rerr = kerrors.NewAggregate([]error{rerr, errors.Wrapf(err, "failed to patch Cluster %s", klog.KObj(cluster))})
 		}
 	}()
 
@@ -371,7 +424,34 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 
 	resourceSetBinding := clusterResourceSetBinding.GetOrCreateBinding(clusterResourceSet)
 
-	remoteClient, err := r.ClusterCache.GetClient(ctx, util.ObjectKey(cluster))
+	// Apply resources only when all the ClusterResourceSets this ClusterResourceSet depends on are ready on the Cluster.
+	dependencyReasons, err := r.getDependenciesNotReady(ctx, cluster, clusterResourceSet, clusterResourceSetBinding)
+	if err != nil {
+		return false, err
+	}
+	if len(dependencyReasons) > 0 {
+		message := strings.Join(dependencyReasons, "; ")
+		log.Info(fmt.Sprintf("Waiting for dependencies to be ready before applying resources: %s", message))
+		resourceSetBinding.SetReady(false, message)
+		v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.WaitingForDependenciesV1Beta1Reason, clusterv1.ConditionSeverityInfo, "%s", message)
+		conditions.Set(clusterResourceSet, metav1.Condition{
+			Type:    addonsv1.ClusterResourceSetResourcesAppliedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  addonsv1.ClusterResourceSetResourcesWaitingForDependenciesReason,
+			Message: message,
+		})
+		return false, nil
+	}
+
+	// Note: with the ServerSideApply strategy objects are read directly from the API server to detect drift, and to avoid
+	// caching all the kinds of objects in the resources.
+	isServerSideApply := addonsv1.ClusterResourceSetStrategy(clusterResourceSet.Spec.Strategy) == addonsv1.ClusterResourceSetStrategyServerSideApply
+	var remoteClient client.Client
+	if isServerSideApply {
+		remoteClient, err = r.ClusterCache.GetUncachedClient(ctx, util.ObjectKey(cluster))
+	} else {
+		remoteClient, err = r.ClusterCache.GetClient(ctx, util.ObjectKey(cluster))
+	}
 	if err != nil {
 		v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.RemoteClusterClientFailedV1Beta1Reason, clusterv1.ConditionSeverityError, "%s", err.Error())
 		conditions.Set(clusterResourceSet, metav1.Condition{
@@ -380,24 +460,73 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 			Reason:  clusterv1.InternalErrorReason,
 			Message: "Please check controller logs for errors",
 		})
-		return err
+		return false, err
 	}
 
+	// desiredObjects are the objects of all the resources, used to delete objects removed from the resources
+	// with the ServerSideApply strategy.
+	desiredObjects := map[string]bool{}
+	missingResources := false
+
 	// Iterate all resources and apply them to the cluster and update the resource status in the ClusterResourceSetBinding object.
 	for i, resource := range clusterResourceSet.Spec.Resources {
-		unstructuredObj := objList[i]
-		if unstructuredObj == nil {
-			// Continue without adding the error to the aggregate if we can't find the resource.
-			continue
+		var resourceScope resourceReconcileScope
+		var digest string
+		var err error
+
+		// Objects applied with the ServerSideApply strategy are kept in the ResourceBinding until they are deleted.
+		var previousObjects []addonsv1.ResourceBindingObject
+		if previousBinding := resourceSetBinding.GetResource(resource); previousBinding != nil {
+			previousObjects = previousBinding.Objects
 		}
+		if resource.IsRemote() {
+			// Avoid fetching resources from remote sources if already applied with the ApplyOnce strategy.
+			if addonsv1.ClusterResourceSetStrategy(clusterResourceSet.Spec.Strategy) == addonsv1.ClusterResourceSetStrategyApplyOnce && resourceSetBinding.IsApplied(resource) {
+				continue
+			}
+
+			var remote *remoteResource
+			remote, err = r.resolveRemoteResource(ctx, cluster, clusterResourceSet, resource)
+			if err != nil {
+				log.Error(err, "Failed to fetch ClusterResourceSet resource from remote source", resource.Kind, resource.Name)
+				v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.RetrievingResourceFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+				conditions.Set(clusterResourceSet, metav1.Condition{
+					Type:    addonsv1.ClusterResourceSetResourcesAppliedCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  addonsv1.ClusterResourceSetResourcesFetchFailedReason,
+					Message: fmt.Sprintf("Failed to fetch %s resource %s, please check controller logs for errors", resource.Kind, resource.Name),
+				})
+				errList = append(errList, err)
+				continue
+			}
+			digest = remote.digest
+			resourceScope, err = reconcileScopeForRemoteResource(clusterResourceSet, cluster, resource, resourceSetBinding, remote)
+		} else {
+			unstructuredObj := objList[i]
+			if unstructuredObj == nil {
+				// Continue without adding the error to the aggregate if we can't find the resource.
+				missingResources = true
+				continue
+			}
 
-		resourceScope, err := reconcileScopeForResource(clusterResourceSet, resource, resourceSetBinding, unstructuredObj)
+			resourceScope, err = reconcileScopeForResource(clusterResourceSet, cluster, resource, resourceSetBinding, unstructuredObj)
+		}
 		if err != nil {
+			log.Error(err, "Failed to prepare ClusterResourceSet resource for Cluster", resource.Kind, resource.Name)
+			v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.ApplyFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			conditions.Set(clusterResourceSet, metav1.Condition{
+				Type:    addonsv1.ClusterResourceSetResourcesAppliedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  addonsv1.ClusterResourceSetResourcesNotAppliedReason,
+				Message: fmt.Sprintf("Failed to prepare %s resource %s for Cluster, please check controller logs for errors", resource.Kind, resource.Name),
+			})
 			resourceSetBinding.SetBinding(addonsv1.ResourceBinding{
 				ResourceRef:     resource,
+				Digest:          digest,
 				Hash:            "",
 				Applied:         ptr.To(false),
 				LastAppliedTime: metav1.Time{Time: time.Now().UTC()},
+				Objects:         previousObjects,
 			})
 
 			errList = append(errList, err)
@@ -412,9 +541,11 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 		// Set only when resource is retrieved successfully.
 		resourceSetBinding.SetBinding(addonsv1.ResourceBinding{
 			ResourceRef:     resource,
+			Digest:          digest,
 			Hash:            "",
 			Applied:         ptr.To(false),
 			LastAppliedTime: metav1.Time{Time: time.Now().UTC()},
+			Objects:         previousObjects,
 		})
 
 		// Apply all values in the key-value pair of the resource to the cluster.
@@ -433,15 +564,43 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 			errList = append(errList, err)
 		}
 
-		resourceSetBinding.SetBinding(addonsv1.ResourceBinding{
+		resourceBinding := addonsv1.ResourceBinding{
 			ResourceRef:     resource,
+			Digest:          digest,
 			Hash:            resourceScope.hash(),
 			Applied:         ptr.To(isSuccessful),
 			LastAppliedTime: metav1.Time{Time: time.Now().UTC()},
-		})
+		}
+		if serverSideApplyScope, ok := resourceScope.(*reconcileServerSideApplyScope); ok {
+			for _, obj := range serverSideApplyScope.appliedObjects {
+				desiredObjects[resourceBindingObjectKey(obj)] = true
+			}
+			if serverSideApplyScope.drifted() {
+				log.Info("Restored objects modified or deleted in the Cluster", resource.Kind, resource.Name)
+			}
+			resourceBinding = serverSideApplyResourceBinding(serverSideApplyScope, resourceBinding)
+		}
+		resourceSetBinding.SetBinding(resourceBinding)
 	}
 	if len(errList) > 0 {
-		return kerrors.NewAggregate(errList)
+		resourceSetBinding.SetReady(false, "Failed to apply resources, please check controller logs for errors")
+		return false, kerrors.NewAggregate(errList)
+	}
+
+	// Delete objects removed from the resources only if all the resources have been applied, so the objects
+	// of resources that could not be read or applied are never deleted.
+	if isServerSideApply && !missingResources {
+		if err := pruneObjects(ctx, remoteClient, clusterResourceSet, clusterResourceSetBinding, resourceSetBinding, desiredObjects); err != nil {
+			log.Error(err, "Failed to delete objects removed from ClusterResourceSet resources")
+			v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.ApplyFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			conditions.Set(clusterResourceSet, metav1.Condition{
+				Type:    addonsv1.ClusterResourceSetResourcesAppliedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  addonsv1.ClusterResourceSetResourcesNotAppliedReason,
+				Message: "Failed to delete objects removed from ClusterResourceSet resources from Cluster",
+			})
+			return false, err
+		}
 	}
 
 	v1beta1conditions.MarkTrue(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition)
@@ -451,7 +610,7 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 		Reason: addonsv1.ClusterResourceSetResourcesAppliedReason,
 	})
 
-	return nil
+	return r.reconcileReadiness(ctx, cluster, clusterResourceSet, resourceSetBinding)
 }
 
 // getResource retrieves the requested resource and convert it to unstructured type.
@@ -0,0 +1,376 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+// helmNotesFileSuffix is the suffix of the NOTES.txt files in Helm charts, which are not applied.
+const helmNotesFileSuffix = "/templates/NOTES.txt"
+
+// helmRepositoryIndex is the subset of the index.yaml file of a Helm repository used to fetch charts.
+type helmRepositoryIndex struct {
+	Entries map[string][]helmRepositoryIndexEntry `json:"entries"`
+}
+
+// helmRepositoryIndexEntry is a chart version in the index.yaml file of a Helm repository.
+type helmRepositoryIndexEntry struct {
+	Version string   `json:"version"`
+	Digest  string   `json:"digest,omitempty"`
+	URLs    []string `json:"urls"`
+}
+
+// helmRelease identifies the Helm release used when rendering a chart.
+type helmRelease struct {
+	name      string
+	namespace string
+}
+
+// resolveHelmChartResource fetches a Helm chart and renders it with the values for the Cluster.
+// Rendered objects without a namespace are applied in the release namespace, if namespaced.
+func (r *Reconciler) resolveHelmChartResource(ctx context.Context, cluster *clusterv1.Cluster, namespace string, resourceRef addonsv1.ResourceRef) (*remoteResource, error) {
+	source := resourceRef.HelmChart
+
+	digest, archive, err := r.fetchHelmChart(ctx, namespace, source)
+	if err != nil {
+		return nil, err
+	}
+	chrt, err := loader.LoadArchive(bytes.NewReader(archive))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load chart %s version %s", source.Chart, source.Version)
+	}
+
+	values, err := renderHelmValues(source.Values, cluster)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to render values for chart %s", source.Chart)
+	}
+
+	release := helmRelease{name: source.ReleaseName, namespace: source.Namespace}
+	if release.name == "" {
+		release.name = resourceRef.Name
+	}
+	if release.namespace == "" {
+		release.namespace = metav1.NamespaceDefault
+	}
+
+	// Like helm install, the Kubernetes version and the API versions are discovered from the Cluster, and lookup
+	// reads objects from the Cluster.
+	restConfig, err := r.ClusterCache.GetRESTConfig(ctx, util.ObjectKey(cluster))
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create discovery client")
+	}
+	capabilities, err := getHelmCapabilities(discoveryClient)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := renderHelmChart(chrt, values, release, capabilities, restConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to render chart %s version %s", source.Chart, source.Version)
+	}
+	return &remoteResource{digest: digest, data: [][]byte{manifest}, defaultNamespace: release.namespace}, nil
+}
+
+// fetchHelmChart returns the digest and the archive of a Helm chart, fetching it from the repository if not cached.
+func (r *Reconciler) fetchHelmChart(ctx context.Context, namespace string, source addonsv1.ResourceHelmChartSource) (string, []byte, error) {
+	repositoryURL := strings.TrimSuffix(source.RepositoryURL, "/")
+
+	if strings.HasPrefix(repositoryURL, "oci://") {
+		// Note: OCI tags do not support +, so Helm replaces it with _ when pushing charts.
+		ref, err := parseOCIReference(fmt.Sprintf("%s/%s:%s", repositoryURL, source.Chart, strings.ReplaceAll(source.Version, "+", "_")))
+		if err != nil {
+			return "", nil, err
+		}
+		c, err := r.newOCIClient(ctx, namespace, ref, source.SecretRef)
+		if err != nil {
+			return "", nil, err
+		}
+		digest, manifest, err := c.getManifest(ctx)
+		if err != nil {
+			return "", nil, err
+		}
+		if entry, ok := r.getCachedSource(digest); ok {
+			return entry.digest, entry.data[0], nil
+		}
+		layers, err := c.getLayers(ctx, manifest, helmChartContentLayerMediaType)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(layers) != 1 {
+			return "", nil, errors.Errorf("%s is not a Helm chart: expected 1 layer with media type %s, found %d", ref, helmChartContentLayerMediaType, len(layers))
+		}
+		r.cacheSource(sourceCacheEntry{key: digest, digest: digest, data: [][]byte{layers[0].content}})
+		return digest, layers[0].content, nil
+	}
+
+	cacheKey := fmt.Sprintf("%s/%s:%s", repositoryURL, source.Chart, source.Version)
+	if entry, ok := r.getCachedSource(cacheKey); ok {
+		return entry.digest, entry.data[0], nil
+	}
+
+	var username, password string
+	if source.SecretRef.Name != "" {
+		var err error
+		if username, password, err = r.getSourceCredentials(ctx, namespace, source.SecretRef); err != nil {
+			return "", nil, err
+		}
+	}
+	baseURL, err := url.Parse(repositoryURL + "/")
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "invalid repository URL %s", source.RepositoryURL)
+	}
+	// Note: credentials are sent only to the host of the repository.
+	setHeaders := func(requestURL *url.URL) func(header http.Header) {
+		return func(header http.Header) {
+			if username != "" && requestURL.Host == baseURL.Host {
+				header.Set("Authorization", basicAuthorization(username, password))
+			}
+		}
+	}
+
+	indexURL := baseURL.JoinPath("index.yaml")
+	indexContent, err := r.httpGet(ctx, indexURL.String(), setHeaders(indexURL))
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to get index of repository %s", source.RepositoryURL)
+	}
+	index := &helmRepositoryIndex{}
+	if err := yaml.Unmarshal(indexContent, index); err != nil {
+		return "", nil, errors.Wrapf(err, "failed to unmarshal index of repository %s", source.RepositoryURL)
+	}
+
+	var entry *helmRepositoryIndexEntry
+	for i := range index.Entries[source.Chart] {
+		if index.Entries[source.Chart][i].Version == source.Version {
+			entry = &index.Entries[source.Chart][i]
+			break
+		}
+	}
+	if entry == nil || len(entry.URLs) == 0 {
+		return "", nil, errors.Errorf("chart %s version %s not found in repository %s", source.Chart, source.Version, source.RepositoryURL)
+	}
+
+	chartURL, err := url.Parse(entry.URLs[0])
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "invalid URL for chart %s version %s", source.Chart, source.Version)
+	}
+	chartURL = baseURL.ResolveReference(chartURL)
+	archive, err := r.httpGet(ctx, chartURL.String(), setHeaders(chartURL))
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to get chart %s version %s", source.Chart, source.Version)
+	}
+	digest := computeDigest(archive)
+	if entry.Digest != "" && digest != "sha256:"+entry.Digest {
+		return "", nil, errors.Errorf("digest %s of chart %s version %s does not match the digest in the repository index", digest, source.Chart, source.Version)
+	}
+
+	r.cacheSource(sourceCacheEntry{key: cacheKey, digest: digest, data: [][]byte{archive}})
+	return digest, archive, nil
+}
+
+// renderHelmValues renders the values template of a Helm chart for a Cluster.
+func renderHelmValues(valuesTemplate string, cluster *clusterv1.Cluster) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if valuesTemplate == "" {
+		return values, nil
+	}
+
+	tpl, err := template.New("values").Funcs(sprig.HermeticTxtFuncMap()).Parse(valuesTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse values template")
+	}
+	data, err := clusterTemplateData(cluster)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return nil, errors.Wrap(err, "failed to execute values template")
+	}
+
+	if err := yaml.Unmarshal(bytes.ReplaceAll(buf.Bytes(), []byte("<no value>"), nil), &values); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal values")
+	}
+	return values, nil
+}
+
+// renderHelmChart renders a Helm chart like helm install does, and returns the CRDs in the chart, the install hooks
+// and the rendered objects as a single YAML document stream.
+// If restConfig is set, the lookup template function reads objects from the Cluster, otherwise it always returns
+// an empty object.
+// NOTE: NOTES.txt, test hooks and hooks not run on install are not rendered.
+func renderHelmChart(chrt *chart.Chart, values map[string]interface{}, release helmRelease, capabilities *chartutil.Capabilities, restConfig *rest.Config) ([]byte, error) {
+	if chrt.Metadata.Type != "" && chrt.Metadata.Type != "application" {
+		return nil, errors.Errorf("charts of type %s cannot be rendered", chrt.Metadata.Type)
+	}
+	if chrt.Metadata.KubeVersion != "" && !chartutil.IsCompatibleRange(chrt.Metadata.KubeVersion, capabilities.KubeVersion.String()) {
+		return nil, errors.Errorf("chart requires Kubernetes version %s, which is incompatible with Kubernetes version %s", chrt.Metadata.KubeVersion, capabilities.KubeVersion.String())
+	}
+	// Note: Like helm install, dependencies must be included in the chart archive.
+	if missing := missingHelmChartDependencies(chrt); len(missing) > 0 {
+		return nil, errors.Errorf("dependencies %s are missing in the charts directory of the chart", strings.Join(missing, ", "))
+	}
+	if err := chartutil.ProcessDependenciesWithMerge(chrt, values); err != nil {
+		return nil, errors.Wrap(err, "failed to process chart dependencies")
+	}
+
+	renderValues, err := chartutil.ToRenderValues(chrt, values, chartutil.ReleaseOptions{
+		Name:      release.name,
+		Namespace: release.namespace,
+		Revision:  1,
+		IsInstall: true,
+	}, capabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	var files map[string]string
+	if restConfig != nil {
+		files, err = engine.RenderWithClient(chrt, renderValues, restConfig)
+	} else {
+		files, err = engine.Render(chrt, renderValues)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for name := range files {
+		if strings.HasSuffix(name, helmNotesFileSuffix) {
+			delete(files, name)
+		}
+	}
+
+	hooks, manifests, err := releaseutil.SortManifests(files, capabilities.APIVersions, releaseutil.InstallOrder)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[i].Weight < hooks[j].Weight })
+
+	var manifest bytes.Buffer
+	write := func(content string) {
+		manifest.WriteString("---\n")
+		manifest.WriteString(strings.TrimSpace(content))
+		manifest.WriteString("\n")
+	}
+	for _, crd := range chrt.CRDObjects() {
+		write(string(crd.File.Data))
+	}
+	for _, hook := range hooks {
+		if hasHelmHookEvent(hook, helmrelease.HookPreInstall) {
+			write(hook.Manifest)
+		}
+	}
+	for _, m := range manifests {
+		write(m.Content)
+	}
+	for _, hook := range hooks {
+		if hasHelmHookEvent(hook, helmrelease.HookPostInstall) {
+			write(hook.Manifest)
+		}
+	}
+	return manifest.Bytes(), nil
+}
+
+// getHelmCapabilities returns the capabilities of a Cluster exposed to Helm charts via .Capabilities.
+func getHelmCapabilities(discoveryClient discovery.DiscoveryInterface) (*chartutil.Capabilities, error) {
+	serverVersion, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get Kubernetes version")
+	}
+
+	// Note: Like in Helm, API versions are exposed both as group/version and as group/version/kind,
+	// and failures to discover some of the groups are ignored.
+	groups, resources, err := discoveryClient.ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, errors.Wrap(err, "failed to get API versions")
+	}
+	apiVersions := sets.Set[string]{}
+	for _, group := range groups {
+		for _, gv := range group.Versions {
+			apiVersions.Insert(gv.GroupVersion)
+		}
+	}
+	for _, resourceList := range resources {
+		for _, resource := range resourceList.APIResources {
+			apiVersions.Insert(path.Join(resourceList.GroupVersion, resource.Kind))
+		}
+	}
+
+	return &chartutil.Capabilities{
+		KubeVersion: chartutil.KubeVersion{
+			Version: serverVersion.GitVersion,
+			Major:   serverVersion.Major,
+			Minor:   serverVersion.Minor,
+		},
+		APIVersions: chartutil.VersionSet(sets.List(apiVersions)),
+		HelmVersion: chartutil.DefaultCapabilities.HelmVersion,
+	}, nil
+}
+
+// missingHelmChartDependencies returns the dependencies of a chart which are not included in the chart.
+func missingHelmChartDependencies(chrt *chart.Chart) []string {
+	included := sets.Set[string]{}
+	for _, dependency := range chrt.Dependencies() {
+		included.Insert(dependency.Name())
+	}
+	missing := []string{}
+	for _, dependency := range chrt.Metadata.Dependencies {
+		if !included.Has(dependency.Name) {
+			missing = append(missing, dependency.Name)
+		}
+	}
+	return missing
+}
+
+// hasHelmHookEvent returns true if a Helm hook is run on the given event.
+func hasHelmHookEvent(hook *helmrelease.Hook, event helmrelease.HookEvent) bool {
+	for _, e := range hook.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,454 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+var testHelmChartFiles = map[string]string{
+	"test/Chart.yaml": `apiVersion: v2
+name: test
+version: 0.1.0
+appVersion: "1.0"
+`,
+	"test/values.yaml": `replicas: 1
+image:
+  repository: nginx
+  tag: "1.0"
+labels:
+  app: test
+`,
+	"test/templates/_helpers.tpl": `{{- define "test.fullname" -}}
+{{ .Release.Name }}-{{ .Chart.Name }}
+{{- end -}}
+`,
+	"test/templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "test.fullname" . }}
+  labels:
+    {{- toYaml .Values.labels | nindent 4 }}
+spec:
+  replicas: {{ .Values.replicas }}
+  template:
+    spec:
+      containers:
+      - name: test
+        image: {{ .Values.image.repository }}:{{ .Values.image.tag | default .Chart.AppVersion }}
+`,
+	"test/templates/configmap.yaml": `{{- if .Values.config }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "test.fullname" . }}
+  namespace: {{ .Release.Namespace }}
+data:
+  kubeVersion: {{ .Capabilities.KubeVersion.Version | quote }}
+  hasExampleAPI: {{ .Capabilities.APIVersions.Has "example.com/v1" | quote }}
+  readme: {{ .Files.Get "files/README.md" | quote }}
+  config: {{ tpl .Values.config . | quote }}
+{{- end }}
+`,
+	"test/templates/job.yaml": `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: {{ include "test.fullname" . }}-install
+  annotations:
+    helm.sh/hook: pre-install
+`,
+	"test/templates/upgrade-job.yaml": `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: {{ include "test.fullname" . }}-upgrade
+  annotations:
+    helm.sh/hook: pre-upgrade
+`,
+	"test/files/README.md": "readme",
+	"test/templates/tests/test-connection.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ include "test.fullname" . }}-test
+  annotations:
+    helm.sh/hook: test
+`,
+	"test/templates/NOTES.txt": `Thank you for installing {{ .Chart.Name }}.`,
+	"test/crds/crd.yaml": `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: tests.example.com
+`,
+}
+
+func TestRenderHelmChart(t *testing.T) {
+	g := NewWithT(t)
+
+	chrt, err := loader.LoadArchive(bytes.NewReader(newTarGz(t, testHelmChartFiles)))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	values := map[string]interface{}{
+		"replicas": 3,
+		"image":    map[string]interface{}{"tag": nil},
+		"labels":   map[string]interface{}{"cluster": "test-cluster"},
+		"config":   "release={{ .Release.Name }}",
+	}
+	manifest, err := renderHelmChart(chrt, values, helmRelease{name: "my-release", namespace: "addons"}, testHelmCapabilities("v1.35.1"), nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	objs, err := utilyaml.ToUnstructured(manifest)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// CRDs are rendered first, then pre-install hooks and the other objects in the order used by helm install;
+	// NOTES.txt, test hooks and hooks not run on install are not rendered.
+	g.Expect(objs).To(HaveLen(4))
+	g.Expect(objs[0].GetKind()).To(Equal("CustomResourceDefinition"))
+
+	g.Expect(objs[1].GetKind()).To(Equal("Job"))
+	g.Expect(objs[1].GetName()).To(Equal("my-release-test-install"))
+
+	g.Expect(objs[2].GetKind()).To(Equal("ConfigMap"))
+	g.Expect(objs[2].GetName()).To(Equal("my-release-test"))
+	g.Expect(objs[2].GetNamespace()).To(Equal("addons"))
+	g.Expect(objs[2].Object["data"]).To(Equal(map[string]interface{}{
+		"kubeVersion":   "v1.35.1",
+		"hasExampleAPI": "true",
+		"readme":        "readme",
+		"config":        "release=my-release",
+	}))
+
+	g.Expect(objs[3].GetKind()).To(Equal("Deployment"))
+	g.Expect(objs[3].GetName()).To(Equal("my-release-test"))
+	// Namespaces are defaulted when applying the objects to the cluster.
+	g.Expect(objs[3].GetNamespace()).To(BeEmpty())
+	g.Expect(objs[3].GetLabels()).To(Equal(map[string]string{"app": "test", "cluster": "test-cluster"}))
+	g.Expect(objs[3].Object["spec"]).To(HaveKeyWithValue("replicas", BeNumerically("==", 3)))
+	g.Expect(manifest).To(ContainSubstring("image: nginx:1.0"))
+}
+
+func TestRenderHelmChartWithDependencies(t *testing.T) {
+	newFiles := func(chartYAML string, subchart bool) map[string]string {
+		files := map[string]string{
+			"test/Chart.yaml":               chartYAML,
+			"test/values.yaml":              "common:\n  data: parent\n",
+			"test/templates/configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: parent\n",
+		}
+		if subchart {
+			files["test/charts/common/Chart.yaml"] = "apiVersion: v2\nname: common\nversion: 0.1.0\n"
+			files["test/charts/common/values.yaml"] = "data: default\n"
+			files["test/charts/common/templates/configmap.yaml"] = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: common\ndata:\n  data: {{ .Values.data }}\n"
+		}
+		return files
+	}
+
+	tests := []struct {
+		name      string
+		files     map[string]string
+		values    map[string]interface{}
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			name:      "renders subcharts with the values of the parent chart",
+			files:     newFiles("apiVersion: v2\nname: test\nversion: 0.1.0\ndependencies:\n- name: common\n  version: 0.1.0\n", true),
+			wantNames: []string{"common", "parent"},
+		},
+		{
+			name:      "skips disabled subcharts",
+			files:     newFiles("apiVersion: v2\nname: test\nversion: 0.1.0\ndependencies:\n- name: common\n  version: 0.1.0\n  condition: common.enabled\n", true),
+			values:    map[string]interface{}{"common": map[string]interface{}{"enabled": false}},
+			wantNames: []string{"parent"},
+		},
+		{
+			name:    "fails if dependencies are not included in the chart",
+			files:   newFiles("apiVersion: v2\nname: test\nversion: 0.1.0\ndependencies:\n- name: common\n  version: 0.1.0\n", false),
+			wantErr: "dependencies common are missing",
+		},
+		{
+			name:    "fails for library charts",
+			files:   newFiles("apiVersion: v2\nname: test\nversion: 0.1.0\ntype: library\n", false),
+			wantErr: "charts of type library cannot be rendered",
+		},
+		{
+			name:    "fails if the Kubernetes version is not supported by the chart",
+			files:   newFiles("apiVersion: v2\nname: test\nversion: 0.1.0\nkubeVersion: '>= 1.36.0'\n", false),
+			wantErr: "chart requires Kubernetes version >= 1.36.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			chrt, err := loader.LoadArchive(bytes.NewReader(newTarGz(t, tt.files)))
+			g.Expect(err).ToNot(HaveOccurred())
+
+			manifest, err := renderHelmChart(chrt, tt.values, helmRelease{name: "my-release", namespace: "addons"}, testHelmCapabilities("v1.35.1"), nil)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			objs, err := utilyaml.ToUnstructured(manifest)
+			g.Expect(err).ToNot(HaveOccurred())
+			names := []string{}
+			for _, obj := range objs {
+				names = append(names, obj.GetName())
+			}
+			g.Expect(names).To(ConsistOf(tt.wantNames))
+			if len(tt.wantNames) > 1 {
+				g.Expect(manifest).To(ContainSubstring("data: parent"))
+			}
+		})
+	}
+}
+
+func TestRenderHelmChartWithRecursiveTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{
+			name:     "recursive include",
+			template: `{{ include "test.loop" . }}`,
+		},
+		{
+			name:     "recursive tpl",
+			template: `{{ tpl "{{ include \"test.tpl-loop\" . }}" . }}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			chrt, err := loader.LoadArchive(bytes.NewReader(newTarGz(t, map[string]string{
+				"test/Chart.yaml": "apiVersion: v2\nname: test\nversion: 0.1.0\n",
+				"test/templates/_helpers.tpl": `{{- define "test.loop" -}}{{ include "test.loop" . }}{{- end -}}
+{{- define "test.tpl-loop" -}}{{ tpl "{{ include \"test.tpl-loop\" . }}" . }}{{- end -}}
+`,
+				"test/templates/configmap.yaml": tt.template,
+			})))
+			g.Expect(err).ToNot(HaveOccurred())
+
+			_, err = renderHelmChart(chrt, map[string]interface{}{}, helmRelease{name: "my-release", namespace: "addons"}, testHelmCapabilities("v1.35.1"), nil)
+			g.Expect(err).To(MatchError(ContainSubstring("nested reference")))
+		})
+	}
+}
+
+func TestGetHelmCapabilities(t *testing.T) {
+	g := NewWithT(t)
+
+	discoveryClient := &fakediscovery.FakeDiscovery{
+		Fake: &clienttesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{{Name: "configmaps", Kind: "ConfigMap"}},
+				},
+				{
+					GroupVersion: "monitoring.coreos.com/v1",
+					APIResources: []metav1.APIResource{{Name: "servicemonitors", Kind: "ServiceMonitor"}},
+				},
+			},
+		},
+		FakedServerVersion: &version.Info{GitVersion: "v1.34.2", Major: "1", Minor: "34"},
+	}
+
+	capabilities, err := getHelmCapabilities(discoveryClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(capabilities.KubeVersion.Version).To(Equal("v1.34.2"))
+	g.Expect(capabilities.KubeVersion.Major).To(Equal("1"))
+	g.Expect(capabilities.KubeVersion.Minor).To(Equal("34"))
+	g.Expect(capabilities.APIVersions.Has("v1")).To(BeTrue())
+	g.Expect(capabilities.APIVersions.Has("v1/ConfigMap")).To(BeTrue())
+	g.Expect(capabilities.APIVersions.Has("monitoring.coreos.com/v1")).To(BeTrue())
+	g.Expect(capabilities.APIVersions.Has("monitoring.coreos.com/v1/ServiceMonitor")).To(BeTrue())
+	g.Expect(capabilities.APIVersions.Has("apps/v1")).To(BeFalse())
+}
+
+func TestRenderHelmValues(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault},
+		Spec: clusterv1.ClusterSpec{
+			ClusterNetwork: clusterv1.ClusterNetwork{
+				Pods: clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0/16"}},
+			},
+		},
+	}
+
+	values, err := renderHelmValues(`clusterName: {{ .Cluster.metadata.name }}
+podCIDR: {{ index .Cluster.spec.clusterNetwork.pods.cidrBlocks 0 }}
+version: {{ .Cluster.spec.topology.version | default "unknown" }}
+`, cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(values).To(Equal(map[string]interface{}{
+		"clusterName": "test-cluster",
+		"podCIDR":     "192.168.0.0/16",
+		"version":     "unknown",
+	}))
+
+	_, err = renderHelmValues(`clusterName: {{ .Cluster.metadata.name`, cluster)
+	g.Expect(err).To(MatchError(ContainSubstring("failed to parse values template")))
+}
+
+func TestResolveHelmChartResource(t *testing.T) {
+	archive := newTarGz(t, testHelmChartFiles)
+
+	digest := strings.TrimPrefix(computeDigest(archive), "sha256:")
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/charts/index.yaml":
+			_, _ = fmt.Fprintf(w, `apiVersion: v1
+entries:
+  test:
+  - version: 0.2.0
+    urls:
+    - packages/test-0.2.0.tgz
+  - version: 0.1.0
+    digest: %s
+    urls:
+    - packages/test-0.1.0.tgz
+  - version: 0.0.1
+    digest: 0000000000000000000000000000000000000000000000000000000000000000
+    urls:
+    - packages/test-0.1.0.tgz
+`, digest)
+		case "/charts/packages/test-0.1.0.tgz":
+			_, _ = w.Write(archive)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault},
+	}
+	// The Kubernetes version is discovered from the Cluster, which is the test environment.
+	serverVersion, err := discovery.NewDiscoveryClientForConfigOrDie(env.Config).ServerVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		source  addonsv1.ResourceHelmChartSource
+		wantErr string
+	}{
+		{
+			name: "renders a chart from an HTTP repository",
+			source: addonsv1.ResourceHelmChartSource{
+				RepositoryURL: server.URL + "/charts/",
+				Chart:         "test",
+				Version:       "0.1.0",
+				Namespace:     "addons",
+				Values:        "config: cluster={{ .Cluster.metadata.name }}",
+			},
+		},
+		{
+			name: "fails if the version does not exist",
+			source: addonsv1.ResourceHelmChartSource{
+				RepositoryURL: server.URL + "/charts",
+				Chart:         "test",
+				Version:       "1.0.0",
+			},
+			wantErr: "chart test version 1.0.0 not found",
+		},
+		{
+			name: "fails if the digest does not match",
+			source: addonsv1.ResourceHelmChartSource{
+				RepositoryURL: server.URL + "/charts",
+				Chart:         "test",
+				Version:       "0.0.1",
+			},
+			wantErr: "does not match the digest in the repository index",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &Reconciler{
+				httpClient:   server.Client(),
+				ClusterCache: &fakeRESTConfigClusterCache{restConfig: env.Config},
+			}
+			resourceRef := addonsv1.ResourceRef{Name: "my-release", Kind: string(addonsv1.HelmChartClusterResourceSetResourceKind), HelmChart: tt.source}
+			got, err := r.resolveHelmChartResource(ctx, cluster, metav1.NamespaceDefault, resourceRef)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.digest).To(Equal(computeDigest(archive)))
+			g.Expect(got.defaultNamespace).To(Equal("addons"))
+			g.Expect(got.data).To(HaveLen(1))
+			g.Expect(string(got.data[0])).To(ContainSubstring("name: my-release-test"))
+			g.Expect(string(got.data[0])).To(ContainSubstring("config: cluster=test-cluster"))
+			g.Expect(string(got.data[0])).To(ContainSubstring(fmt.Sprintf("kubeVersion: %s", serverVersion.GitVersion)))
+		})
+	}
+}
+
+// testHelmCapabilities returns the capabilities of a Cluster with the given Kubernetes version, with the
+// example.com/v1 API version.
+func testHelmCapabilities(kubeVersion string) *chartutil.Capabilities {
+	v := utilversion.MustParseGeneric(kubeVersion)
+	return &chartutil.Capabilities{
+		KubeVersion: chartutil.KubeVersion{
+			Version: kubeVersion,
+			Major:   fmt.Sprintf("%d", v.Major()),
+			Minor:   fmt.Sprintf("%d", v.Minor()),
+		},
+		APIVersions: append(chartutil.DefaultVersionSet, "example.com/v1"),
+		HelmVersion: chartutil.DefaultCapabilities.HelmVersion,
+	}
+}
+
+// fakeRESTConfigClusterCache is a ClusterCache returning the same rest.Config for all the Clusters.
+type fakeRESTConfigClusterCache struct {
+	clustercache.ClusterCache
+	restConfig *rest.Config
+}
+
+func (cc *fakeRESTConfigClusterCache) GetRESTConfig(_ context.Context, _ client.ObjectKey) (*rest.Config, error) {
+	return cc.restConfig, nil
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+)
+
+const (
+	// helmChartContentLayerMediaType is the media type of the layer containing a Helm chart in an OCI artifact.
+	helmChartContentLayerMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+)
+
+// ociManifestMediaTypes are the media types of the manifests accepted when fetching OCI artifacts.
+var ociManifestMediaTypes = []string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// ociReference is a reference to an OCI artifact, e.g. oci://registry.example.com/addons/cni:v1.0.0.
+type ociReference struct {
+	registry   string
+	repository string
+	// reference is either a tag or a digest.
+	reference string
+}
+
+// isDigest returns true if the artifact is referenced by digest.
+func (r ociReference) isDigest() bool {
+	return strings.HasPrefix(r.reference, "sha256:")
+}
+
+func (r ociReference) String() string {
+	separator := ":"
+	if r.isDigest() {
+		separator = "@"
+	}
+	return fmt.Sprintf("oci://%s/%s%s%s", r.registry, r.repository, separator, r.reference)
+}
+
+// parseOCIReference parses a reference to an OCI artifact; if no tag or digest is specified, the latest tag is used.
+func parseOCIReference(ref string) (ociReference, error) {
+	name, ok := strings.CutPrefix(ref, "oci://")
+	if !ok {
+		return ociReference{}, errors.Errorf("invalid OCI reference %q: must start with oci://", ref)
+	}
+	registry, repository, ok := strings.Cut(name, "/")
+	if !ok || registry == "" || repository == "" {
+		return ociReference{}, errors.Errorf("invalid OCI reference %q: must contain a registry and a repository", ref)
+	}
+
+	reference := "latest"
+	if repo, digest, ok := strings.Cut(repository, "@"); ok {
+		repository, reference = repo, digest
+	} else if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository, reference = repository[:i], repository[i+1:]
+	}
+	if repository == "" || reference == "" {
+		return ociReference{}, errors.Errorf("invalid OCI reference %q", ref)
+	}
+	return ociReference{registry: registry, repository: repository, reference: reference}, nil
+}
+
+// ociManifest is the subset of an OCI image manifest used to fetch artifacts.
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+// ociDescriptor is the subset of an OCI content descriptor used to fetch artifacts.
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+// ociLayer is a layer of an OCI artifact.
+type ociLayer struct {
+	mediaType string
+	content   []byte
+}
+
+// ociClient fetches artifacts from an OCI registry implementing the OCI distribution spec.
+// Registries are always accessed over HTTPS; anonymous and basic authentication are supported,
+// both directly and through the bearer token flow.
+type ociClient struct {
+	r        *Reconciler
+	ref      ociReference
+	username string
+	password string
+	// token is the bearer token obtained from the registry, if any.
+	token string
+}
+
+// resolveOCIResource fetches the content of a resource from an OCI artifact.
+func (r *Reconciler) resolveOCIResource(ctx context.Context, namespace string, source addonsv1.ResourceOCISource) (*remoteResource, error) {
+	ref, err := parseOCIReference(source.URL)
+	if err != nil {
+		return nil, err
+	}
+	c, err := r.newOCIClient(ctx, namespace, ref, source.SecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, manifest, err := c.getManifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if entry, ok := r.getCachedSource(digest); ok {
+		return &remoteResource{digest: entry.digest, data: entry.data}, nil
+	}
+
+	layers, err := c.getLayers(ctx, manifest, "")
+	if err != nil {
+		return nil, err
+	}
+	data := [][]byte{}
+	for _, layer := range layers {
+		layerData, err := documentsFromContent(layer.content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read content of %s", ref)
+		}
+		data = append(data, layerData...)
+	}
+
+	r.cacheSource(sourceCacheEntry{key: digest, digest: digest, data: data})
+	return &remoteResource{digest: digest, data: data}, nil
+}
+
+// newOCIClient returns a client for an OCI artifact, using the credentials in secretRef, if set.
+func (r *Reconciler) newOCIClient(ctx context.Context, namespace string, ref ociReference, secretRef addonsv1.ResourceSourceSecretReference) (*ociClient, error) {
+	c := &ociClient{r: r, ref: ref}
+	if secretRef.Name != "" {
+		username, password, err := r.getSourceCredentials(ctx, namespace, secretRef)
+		if err != nil {
+			return nil, err
+		}
+		c.username, c.password = username, password
+	}
+	return c, nil
+}
+
+// getManifest returns the digest and the manifest of the artifact.
+func (c *ociClient) getManifest(ctx context.Context) (string, *ociManifest, error) {
+	content, header, err := c.get(ctx, fmt.Sprintf("manifests/%s", c.ref.reference), strings.Join(ociManifestMediaTypes, ", "))
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to get manifest of %s", c.ref)
+	}
+
+	digest := computeDigest(content)
+	if c.ref.isDigest() && digest != c.ref.reference {
+		return "", nil, errors.Errorf("digest %s of the manifest of %s does not match the expected digest", digest, c.ref)
+	}
+	if headerDigest := header.Get("Docker-Content-Digest"); headerDigest != "" && headerDigest != digest {
+		return "", nil, errors.Errorf("digest %s of the manifest of %s does not match the digest %s returned by the registry", digest, c.ref, headerDigest)
+	}
+
+	manifest := &ociManifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return "", nil, errors.Wrapf(err, "failed to unmarshal manifest of %s", c.ref)
+	}
+	if len(manifest.Layers) == 0 {
+		return "", nil, errors.Errorf("manifest of %s does not have any layer", c.ref)
+	}
+	return digest, manifest, nil
+}
+
+// getLayers returns the layers of the artifact with the given media type, or all the layers if mediaType is empty,
+// verifying their digest.
+func (c *ociClient) getLayers(ctx context.Context, manifest *ociManifest, mediaType string) ([]ociLayer, error) {
+	layers := []ociLayer{}
+	for _, descriptor := range manifest.Layers {
+		if mediaType != "" && descriptor.MediaType != mediaType {
+			continue
+		}
+		content, _, err := c.get(ctx, fmt.Sprintf("blobs/%s", descriptor.Digest), "")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get layer %s of %s", descriptor.Digest, c.ref)
+		}
+		if digest := computeDigest(content); digest != descriptor.Digest {
+			return nil, errors.Errorf("digest %s of layer %s of %s does not match the expected digest", digest, descriptor.Digest, c.ref)
+		}
+		layers = append(layers, ociLayer{mediaType: descriptor.MediaType, content: content})
+	}
+	return layers, nil
+}
+
+// get sends a request to the registry API for the repository of the artifact, authenticating if required.
+func (c *ociClient) get(ctx context.Context, apiPath, accept string) ([]byte, http.Header, error) {
+	apiURL := fmt.Sprintf("https://%s/v2/%s/%s", c.ref.registry, c.ref.repository, apiPath)
+	setHeaders := func(header http.Header) {
+		if accept != "" {
+			header.Set("Accept", accept)
+		}
+		switch {
+		case c.token != "":
+			header.Set("Authorization", "Bearer "+c.token)
+		case c.username != "":
+			header.Set("Authorization", basicAuthorization(c.username, c.password))
+		}
+	}
+
+	content, header, err := c.r.httpDo(ctx, apiURL, setHeaders)
+	var statusErr *httpStatusError
+	if err == nil || !errors.As(err, &statusErr) || statusErr.statusCode != http.StatusUnauthorized || c.token != "" {
+		return content, header, err
+	}
+
+	// The registry requires a bearer token; get one and try again.
+	if err := c.authenticate(ctx, statusErr.header.Get("WWW-Authenticate")); err != nil {
+		return nil, nil, err
+	}
+	return c.r.httpDo(ctx, apiURL, setHeaders)
+}
+
+// authenticate gets a bearer token from the authorization server in the challenge returned by the registry.
+func (c *ociClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params := parseAuthChallenge(challenge)
+	if !strings.EqualFold(scheme, "Bearer") || params["realm"] == "" {
+		return errors.Errorf("failed to authenticate to registry %s: unsupported challenge %q", c.ref.registry, challenge)
+	}
+
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil {
+		return errors.Wrapf(err, "failed to authenticate to registry %s: invalid realm", c.ref.registry)
+	}
+	query := tokenURL.Query()
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", c.ref.repository)
+	}
+	query.Set("scope", scope)
+	tokenURL.RawQuery = query.Encode()
+
+	content, err := c.r.httpGet(ctx, tokenURL.String(), func(header http.Header) {
+		if c.username != "" {
+			header.Set("Authorization", basicAuthorization(c.username, c.password))
+		}
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to authenticate to registry %s", c.ref.registry)
+	}
+
+	response := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.Unmarshal(content, &response); err != nil {
+		return errors.Wrapf(err, "failed to authenticate to registry %s: invalid token response", c.ref.registry)
+	}
+	c.token = response.Token
+	if c.token == "" {
+		c.token = response.AccessToken
+	}
+	if c.token == "" {
+		return errors.Errorf("failed to authenticate to registry %s: no token returned", c.ref.registry)
+	}
+	return nil
+}
+
+// parseAuthChallenge parses a WWW-Authenticate header, e.g. Bearer realm="https://auth.example.com/token",service="registry.example.com".
+func parseAuthChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, ", "), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key = strings.TrimSpace(key); key != "" {
+			params[strings.ToLower(key)] = value
+		}
+	}
+	return scheme, params
+}
+
+// basicAuthorization returns the value of the Authorization header for basic authentication.
+func basicAuthorization(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
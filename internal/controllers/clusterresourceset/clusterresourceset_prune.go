@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+)
+
+const (
+	// clusterResourceSetManagerName is the field manager used to apply objects with the ServerSideApply strategy.
+	clusterResourceSetManagerName = "capi-clusterresourceset"
+
+	// serverSideApplyResyncPeriod is the interval after which ClusterResourceSets with the ServerSideApply strategy
+	// apply again their resources, restoring objects modified or deleted in the Clusters.
+	serverSideApplyResyncPeriod = 5 * time.Minute
+)
+
+// resourceBindingObject returns the ResourceBindingObject identifying an object.
+func resourceBindingObject(obj *unstructured.Unstructured) addonsv1.ResourceBindingObject {
+	return addonsv1.ResourceBindingObject{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+// resourceBindingObjectKey returns a key identifying an object independently of the version of its API.
+func resourceBindingObjectKey(obj addonsv1.ResourceBindingObject) string {
+	gvk := schema.FromAPIVersionAndKind(obj.APIVersion, obj.Kind)
+	return fmt.Sprintf("%s/%s/%s/%s", gvk.Group, gvk.Kind, obj.Namespace, obj.Name)
+}
+
+// serverSideApplyResourceBinding sets the objects applied with the ServerSideApply strategy and the time
+// of the last drift correction in the ResourceBinding of a resource.
+// NOTE: Objects recorded in the previous ResourceBinding are preserved, so they are deleted by pruneObjects
+// if they are not part of the resources anymore.
+func serverSideApplyResourceBinding(scope *reconcileServerSideApplyScope, resourceBinding addonsv1.ResourceBinding) addonsv1.ResourceBinding {
+	objects := map[string]addonsv1.ResourceBindingObject{}
+	if scope.previousBinding != nil {
+		for _, obj := range scope.previousBinding.Objects {
+			objects[resourceBindingObjectKey(obj)] = obj
+		}
+	}
+	for _, obj := range scope.appliedObjects {
+		objects[resourceBindingObjectKey(obj)] = obj
+	}
+	resourceBinding.Objects = sortedResourceBindingObjects(objects)
+
+	if scope.previousBinding != nil {
+		resourceBinding.LastDriftCorrectedTime = scope.previousBinding.LastDriftCorrectedTime
+		// Avoid updating the ClusterResourceSetBinding at every resync if nothing changed in the Cluster.
+		if !scope.changed && ptr.Deref(resourceBinding.Applied, false) && ptr.Deref(scope.previousBinding.Applied, false) && scope.previousBinding.Hash == resourceBinding.Hash {
+			resourceBinding.LastAppliedTime = scope.previousBinding.LastAppliedTime
+		}
+	}
+	if scope.drifted() {
+		resourceBinding.LastDriftCorrectedTime = metav1.Time{Time: time.Now().UTC()}
+	}
+	return resourceBinding
+}
+
+// sortedResourceBindingObjects returns the objects sorted by key.
+func sortedResourceBindingObjects(objects map[string]addonsv1.ResourceBindingObject) []addonsv1.ResourceBindingObject {
+	keys := make([]string, 0, len(objects))
+	for key := range objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	sorted := make([]addonsv1.ResourceBindingObject, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, objects[key])
+	}
+	return sorted
+}
+
+// pruneObjects deletes from the Cluster the objects recorded in the ResourceSetBinding of a ClusterResourceSet
+// with the ServerSideApply strategy which are not part of its resources anymore, and removes the ResourceBindings
+// of resources that have been removed from the ClusterResourceSet once all their objects are deleted.
+// desiredObjects are the keys of the objects of all the resources of the ClusterResourceSet; objects recorded
+// by other ClusterResourceSets applied to the same Cluster are never deleted.
+func pruneObjects(ctx context.Context, c client.Client, clusterResourceSet *addonsv1.ClusterResourceSet, clusterResourceSetBinding *addonsv1.ClusterResourceSetBinding, resourceSetBinding *addonsv1.ResourceSetBinding, desiredObjects map[string]bool) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	protectedObjects := map[string]bool{}
+	for _, binding := range clusterResourceSetBinding.Spec.Bindings {
+		if binding.ClusterResourceSetName == resourceSetBinding.ClusterResourceSetName {
+			continue
+		}
+		for _, resource := range binding.Resources {
+			for _, obj := range resource.Objects {
+				protectedObjects[resourceBindingObjectKey(obj)] = true
+			}
+		}
+	}
+
+	isResource := map[string]bool{}
+	for _, resource := range clusterResourceSet.Spec.Resources {
+		isResource[resource.Kind+"/"+resource.Name] = true
+	}
+
+	errList := []error{}
+	resources := make([]addonsv1.ResourceBinding, 0, len(resourceSetBinding.Resources))
+	for _, resource := range resourceSetBinding.Resources {
+		objects := make([]addonsv1.ResourceBindingObject, 0, len(resource.Objects))
+		for _, obj := range resource.Objects {
+			key := resourceBindingObjectKey(obj)
+			if desiredObjects[key] || protectedObjects[key] {
+				objects = append(objects, obj)
+				continue
+			}
+
+			log.Info(fmt.Sprintf("Deleting %s %s removed from ClusterResourceSet", obj.Kind, klog.KRef(obj.Namespace, obj.Name)), resource.Kind, resource.Name)
+			if err := deleteResourceBindingObject(ctx, c, obj); err != nil {
+				errList = append(errList, err)
+				objects = append(objects, obj)
+			}
+		}
+		resource.Objects = objects
+
+		if !isResource[resource.Kind+"/"+resource.Name] && len(resource.Objects) == 0 {
+			continue
+		}
+		resources = append(resources, resource)
+	}
+	resourceSetBinding.Resources = resources
+
+	return kerrors.NewAggregate(errList)
+}
+
+// deleteResourceBindingObject deletes an object from the Cluster; objects already deleted are ignored.
+func deleteResourceBindingObject(ctx context.Context, c client.Client, obj addonsv1.ResourceBindingObject) error {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(obj.APIVersion)
+	u.SetKind(obj.Kind)
+	u.SetNamespace(obj.Namespace)
+	u.SetName(obj.Name)
+	if err := c.Delete(ctx, u); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return errors.Wrapf(err, "deleting object %s %s", u.GroupVersionKind(), klog.KObj(u))
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+)
+
+func TestReconcileServerSideApplyScopeApply(t *testing.T) {
+	g := NewWithT(t)
+
+	c := fake.NewClientBuilder().Build()
+	crs := &addonsv1.ClusterResourceSet{
+		Spec: addonsv1.ClusterResourceSetSpec{
+			Strategy: string(addonsv1.ClusterResourceSetStrategyServerSideApply),
+		},
+	}
+	resourceRef := addonsv1.ResourceRef{Name: "my-cm", Kind: string(addonsv1.ConfigMapClusterResourceSetResourceKind)}
+	data := [][]byte{[]byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-cm
+  namespace: that-ns
+data:
+  key: value`)}
+
+	// newScope returns the scope for the resource, using the ResourceBinding set after the previous apply.
+	resourceSetBinding := &addonsv1.ResourceSetBinding{}
+	newScope := func() *reconcileServerSideApplyScope {
+		objs, err := objsFromYamlData(data)
+		g.Expect(err).ToNot(HaveOccurred())
+		scope, err := newResourceReconcileScope(crs, resourceRef, resourceSetBinding, data, objs, "")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(scope.needsApply()).To(BeTrue())
+		return scope.(*reconcileServerSideApplyScope)
+	}
+	applyScope := func() *reconcileServerSideApplyScope {
+		scope := newScope()
+		g.Expect(scope.apply(ctx, c)).To(Succeed())
+		resourceSetBinding.SetBinding(serverSideApplyResourceBinding(scope, addonsv1.ResourceBinding{
+			ResourceRef:     resourceRef,
+			Hash:            scope.hash(),
+			Applied:         ptr.To(true),
+			LastAppliedTime: metav1.Time{Time: time.Now().UTC()},
+		}))
+		return scope
+	}
+
+	// The object is created.
+	scope := applyScope()
+	g.Expect(scope.changed).To(BeTrue())
+	g.Expect(scope.drifted()).To(BeFalse())
+	g.Expect(scope.appliedObjects).To(Equal([]addonsv1.ResourceBindingObject{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "that-ns", Name: "my-cm"}}))
+	cm := &corev1.ConfigMap{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "that-ns", Name: "my-cm"}, cm)).To(Succeed())
+	g.Expect(cm.Data).To(Equal(map[string]string{"key": "value"}))
+	lastAppliedTime := resourceSetBinding.GetResource(resourceRef).LastAppliedTime
+
+	// Nothing changes if the object matches the resource.
+	scope = applyScope()
+	g.Expect(scope.changed).To(BeFalse())
+	g.Expect(scope.drifted()).To(BeFalse())
+	g.Expect(resourceSetBinding.GetResource(resourceRef).LastAppliedTime).To(Equal(lastAppliedTime))
+	g.Expect(resourceSetBinding.GetResource(resourceRef).LastDriftCorrectedTime.IsZero()).To(BeTrue())
+
+	// Changes to the object are detected and reverted.
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "that-ns", Name: "my-cm"}, cm)).To(Succeed())
+	cm.Data["key"] = "changed"
+	g.Expect(c.Update(ctx, cm)).To(Succeed())
+	scope = applyScope()
+	g.Expect(scope.changed).To(BeTrue())
+	g.Expect(scope.drifted()).To(BeTrue())
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "that-ns", Name: "my-cm"}, cm)).To(Succeed())
+	g.Expect(cm.Data).To(Equal(map[string]string{"key": "value"}))
+	g.Expect(resourceSetBinding.GetResource(resourceRef).LastDriftCorrectedTime.IsZero()).To(BeFalse())
+
+	// Deleted objects are detected and restored.
+	g.Expect(c.Delete(ctx, cm)).To(Succeed())
+	scope = applyScope()
+	g.Expect(scope.drifted()).To(BeTrue())
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "that-ns", Name: "my-cm"}, cm)).To(Succeed())
+
+	// Changes to the resource are not drift.
+	data = [][]byte{[]byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-cm
+  namespace: that-ns
+data:
+  key: new-value`)}
+	scope = applyScope()
+	g.Expect(scope.changed).To(BeTrue())
+	g.Expect(scope.drifted()).To(BeFalse())
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "that-ns", Name: "my-cm"}, cm)).To(Succeed())
+	g.Expect(cm.Data).To(Equal(map[string]string{"key": "new-value"}))
+}
+
+func TestServerSideApplyResourceBinding(t *testing.T) {
+	g := NewWithT(t)
+
+	previousObjects := []addonsv1.ResourceBindingObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "that-ns", Name: "removed"},
+		{APIVersion: "apps/v1beta2", Kind: "Deployment", Namespace: "that-ns", Name: "my-deployment"},
+	}
+	scope := &reconcileServerSideApplyScope{
+		baseResourceReconcileScope: baseResourceReconcileScope{computedHash: "xyz"},
+		previousBinding: &addonsv1.ResourceBinding{
+			Hash:    "abc",
+			Applied: ptr.To(true),
+			Objects: previousObjects,
+		},
+		appliedObjects: []addonsv1.ResourceBindingObject{
+			{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "that-ns", Name: "my-deployment"},
+			{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "my-cluster-role"},
+		},
+	}
+
+	got := serverSideApplyResourceBinding(scope, addonsv1.ResourceBinding{Hash: "xyz", Applied: ptr.To(true)})
+	// Objects previously applied are preserved until they are deleted, objects are identified by group and kind.
+	g.Expect(got.Objects).To(Equal([]addonsv1.ResourceBindingObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "that-ns", Name: "removed"},
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "that-ns", Name: "my-deployment"},
+		{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "my-cluster-role"},
+	}))
+	g.Expect(got.LastDriftCorrectedTime.IsZero()).To(BeTrue())
+}
+
+func TestPruneObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	newConfigMap := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "that-ns"}}
+	}
+	bindingObject := func(name string) addonsv1.ResourceBindingObject {
+		return addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: "that-ns", Name: name}
+	}
+
+	c := fake.NewClientBuilder().WithObjects(
+		newConfigMap("desired"),
+		newConfigMap("removed"),
+		newConfigMap("removed-resource"),
+		newConfigMap("other-crs"),
+	).Build()
+
+	crs := &addonsv1.ClusterResourceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "crs", Namespace: metav1.NamespaceDefault},
+		Spec: addonsv1.ClusterResourceSetSpec{
+			Strategy:  string(addonsv1.ClusterResourceSetStrategyServerSideApply),
+			Resources: []addonsv1.ResourceRef{{Name: "resource", Kind: string(addonsv1.ConfigMapClusterResourceSetResourceKind)}},
+		},
+	}
+	clusterResourceSetBinding := &addonsv1.ClusterResourceSetBinding{
+		Spec: addonsv1.ClusterResourceSetBindingSpec{
+			Bindings: []addonsv1.ResourceSetBinding{
+				{
+					ClusterResourceSetName: "crs",
+					Resources: []addonsv1.ResourceBinding{
+						{
+							ResourceRef: addonsv1.ResourceRef{Name: "resource", Kind: string(addonsv1.ConfigMapClusterResourceSetResourceKind)},
+							Applied:     ptr.To(true),
+							Objects:     []addonsv1.ResourceBindingObject{bindingObject("desired"), bindingObject("removed"), bindingObject("other-crs"), bindingObject("already-deleted")},
+						},
+						{
+							ResourceRef: addonsv1.ResourceRef{Name: "removed-resource", Kind: string(addonsv1.ConfigMapClusterResourceSetResourceKind)},
+							Applied:     ptr.To(true),
+							Objects:     []addonsv1.ResourceBindingObject{bindingObject("removed-resource")},
+						},
+					},
+				},
+				{
+					ClusterResourceSetName: "other-crs",
+					Resources: []addonsv1.ResourceBinding{
+						{
+							ResourceRef: addonsv1.ResourceRef{Name: "other-resource", Kind: string(addonsv1.ConfigMapClusterResourceSetResourceKind)},
+							Applied:     ptr.To(true),
+							Objects:     []addonsv1.ResourceBindingObject{bindingObject("other-crs")},
+						},
+					},
+				},
+			},
+		},
+	}
+	resourceSetBinding := &clusterResourceSetBinding.Spec.Bindings[0]
+
+	desiredObjects := map[string]bool{resourceBindingObjectKey(bindingObject("desired")): true}
+	g.Expect(pruneObjects(ctx, c, crs, clusterResourceSetBinding, resourceSetBinding, desiredObjects)).To(Succeed())
+
+	// Objects removed from the resources are deleted, unless they are applied by other ClusterResourceSets.
+	for name, wantExists := range map[string]bool{"desired": true, "removed": false, "removed-resource": false, "other-crs": true} {
+		err := c.Get(ctx, client.ObjectKey{Namespace: "that-ns", Name: name}, &corev1.ConfigMap{})
+		if wantExists {
+			g.Expect(err).ToNot(HaveOccurred(), name)
+		} else {
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), name)
+		}
+	}
+
+	// Deleted objects and resources removed from the ClusterResourceSet are removed from the ResourceSetBinding.
+	g.Expect(resourceSetBinding.Resources).To(HaveLen(1))
+	g.Expect(resourceSetBinding.Resources[0].Name).To(Equal("resource"))
+	g.Expect(resourceSetBinding.Resources[0].Objects).To(Equal([]addonsv1.ResourceBindingObject{bindingObject("desired"), bindingObject("other-crs")}))
+}
+
+func TestDeleteResourceBindingObject(t *testing.T) {
+	g := NewWithT(t)
+
+	c := fake.NewClientBuilder().Build()
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("example.com/v1")
+	obj.SetKind("Unknown")
+	obj.SetName("my-obj")
+
+	// Objects whose kind is not served anymore are considered deleted.
+	g.Expect(deleteResourceBindingObject(ctx, c, resourceBindingObject(obj))).To(Succeed())
+}
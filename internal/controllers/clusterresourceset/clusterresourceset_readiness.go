@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// readinessResyncPeriod is the interval after which ClusterResourceSets with dependencies or readiness checks
+// that are not ready on all the matching Clusters are reconciled again.
+const readinessResyncPeriod = 20 * time.Second
+
+// getDependenciesNotReady returns the reasons why the ClusterResourceSets a ClusterResourceSet depends on are not ready
+// on a Cluster, using the readiness recorded in the ClusterResourceSetBinding of the Cluster.
+func (r *Reconciler) getDependenciesNotReady(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSet *addonsv1.ClusterResourceSet, clusterResourceSetBinding *addonsv1.ClusterResourceSetBinding) ([]string, error) {
+	if len(clusterResourceSet.Spec.DependsOn) == 0 {
+		return nil, nil
+	}
+
+	crsList := &addonsv1.ClusterResourceSetList{}
+	if err := r.Client.List(ctx, crsList, client.InNamespace(clusterResourceSet.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list ClusterResourceSets")
+	}
+	clusterResourceSets := map[string]*addonsv1.ClusterResourceSet{}
+	for i := range crsList.Items {
+		clusterResourceSets[crsList.Items[i].Name] = &crsList.Items[i]
+	}
+	clusterResourceSets[clusterResourceSet.Name] = clusterResourceSet
+
+	if cycle := dependencyCycle(clusterResourceSet.Name, clusterResourceSets); len(cycle) > 0 {
+		return []string{fmt.Sprintf("Circular dependency between ClusterResourceSets %s", strings.Join(cycle, " -> "))}, nil
+	}
+
+	reasons := []string{}
+	for _, dependency := range clusterResourceSet.Spec.DependsOn {
+		dependencyCRS, ok := clusterResourceSets[dependency.Name]
+		if !ok {
+			reasons = append(reasons, fmt.Sprintf("ClusterResourceSet %s does not exist", dependency.Name))
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(&dependencyCRS.Spec.ClusterSelector)
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(cluster.GetLabels())) {
+			reasons = append(reasons, fmt.Sprintf("ClusterResourceSet %s does not select the Cluster", dependency.Name))
+			continue
+		}
+
+		if binding := clusterResourceSetBinding.GetBinding(dependency.Name); binding == nil || !binding.IsReady() {
+			reasons = append(reasons, fmt.Sprintf("Waiting for ClusterResourceSet %s to be ready", dependency.Name))
+		}
+	}
+	return reasons, nil
+}
+
+// dependencyCycle returns the names of the ClusterResourceSets in a circular dependency starting and ending with
+// the ClusterResourceSet, or nil if the ClusterResourceSet is not part of a circular dependency.
+func dependencyCycle(name string, clusterResourceSets map[string]*addonsv1.ClusterResourceSet) []string {
+	visited := map[string]bool{}
+	var visit func(path []string) []string
+	visit = func(path []string) []string {
+		current, ok := clusterResourceSets[path[len(path)-1]]
+		if !ok {
+			return nil
+		}
+		for _, dependency := range current.Spec.DependsOn {
+			if dependency.Name == name {
+				return append(slices.Clone(path), name)
+			}
+			if visited[dependency.Name] {
+				continue
+			}
+			visited[dependency.Name] = true
+			if cycle := visit(append(slices.Clone(path), dependency.Name)); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return visit([]string{name})
+}
+
+// reconcileReadiness records in the ResourceSetBinding if a ClusterResourceSet is ready on a Cluster, i.e. if all its
+// resources are applied and all its readiness checks pass, and returns true if it is ready.
+func (r *Reconciler) reconcileReadiness(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSet *addonsv1.ClusterResourceSet, resourceSetBinding *addonsv1.ResourceSetBinding) (bool, error) {
+	notApplied := []string{}
+	for _, resource := range clusterResourceSet.Spec.Resources {
+		if !resourceSetBinding.IsApplied(resource) {
+			notApplied = append(notApplied, fmt.Sprintf("%s %s", resource.Kind, resource.Name))
+		}
+	}
+	if len(notApplied) > 0 {
+		resourceSetBinding.SetReady(false, fmt.Sprintf("Waiting for resources to be applied: %s", strings.Join(notApplied, ", ")))
+		return false, nil
+	}
+
+	if len(clusterResourceSet.Spec.ReadinessChecks) == 0 {
+		resourceSetBinding.SetReady(true, "")
+		return true, nil
+	}
+
+	// Note: workloads are read directly from the API server, to avoid caching all the Deployments and DaemonSets of the Cluster.
+	remoteClient, err := r.ClusterCache.GetUncachedClient(ctx, util.ObjectKey(cluster))
+	if err != nil {
+		return false, err
+	}
+	reasons, err := checkReadiness(ctx, remoteClient, clusterResourceSet.Spec.ReadinessChecks)
+	if err != nil {
+		return false, err
+	}
+	resourceSetBinding.SetReady(len(reasons) == 0, strings.Join(reasons, "; "))
+	return len(reasons) == 0, nil
+}
+
+// checkReadiness returns the reasons why the workloads of readiness checks are not available in a Cluster.
+func checkReadiness(ctx context.Context, c client.Client, readinessChecks []addonsv1.ClusterResourceSetReadinessCheck) ([]string, error) {
+	reasons := []string{}
+	for _, check := range readinessChecks {
+		key := client.ObjectKey{Namespace: check.Namespace, Name: check.Name}
+
+		var reason string
+		switch addonsv1.ClusterResourceSetReadinessCheckKind(check.Kind) {
+		case addonsv1.DeploymentClusterResourceSetReadinessCheckKind:
+			deployment := &appsv1.Deployment{}
+			if err := c.Get(ctx, key, deployment); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, errors.Wrapf(err, "failed to get Deployment %s", klog.KRef(check.Namespace, check.Name))
+				}
+				reason = "does not exist"
+				break
+			}
+			reason = deploymentNotAvailableReason(deployment)
+		case addonsv1.DaemonSetClusterResourceSetReadinessCheckKind:
+			daemonSet := &appsv1.DaemonSet{}
+			if err := c.Get(ctx, key, daemonSet); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, errors.Wrapf(err, "failed to get DaemonSet %s", klog.KRef(check.Namespace, check.Name))
+				}
+				reason = "does not exist"
+				break
+			}
+			reason = daemonSetNotAvailableReason(daemonSet)
+		default:
+			return nil, errors.Errorf("unsupported readiness check kind %q", check.Kind)
+		}
+
+		if reason != "" {
+			reasons = append(reasons, fmt.Sprintf("%s %s %s", check.Kind, klog.KRef(check.Namespace, check.Name), reason))
+		}
+	}
+	return reasons, nil
+}
+
+// deploymentNotAvailableReason returns why a Deployment is not rolled out and available, or an empty string if it is.
+func deploymentNotAvailableReason(deployment *appsv1.Deployment) string {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return "is not yet observed by the Deployment controller"
+	}
+	replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+	if deployment.Status.UpdatedReplicas < replicas {
+		return fmt.Sprintf("is rolling out, %d out of %d replicas updated", deployment.Status.UpdatedReplicas, replicas)
+	}
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return fmt.Sprintf("is rolling out, %d old replicas pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	}
+	if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		return fmt.Sprintf("is not available, %d out of %d replicas available", deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+	}
+	return ""
+}
+
+// daemonSetNotAvailableReason returns why a DaemonSet is not rolled out and available, or an empty string if it is.
+func daemonSetNotAvailableReason(daemonSet *appsv1.DaemonSet) string {
+	if daemonSet.Status.ObservedGeneration < daemonSet.Generation {
+		return "is not yet observed by the DaemonSet controller"
+	}
+	if daemonSet.Status.UpdatedNumberScheduled < daemonSet.Status.DesiredNumberScheduled {
+		return fmt.Sprintf("is rolling out, %d out of %d Pods updated", daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.DesiredNumberScheduled)
+	}
+	if daemonSet.Status.NumberAvailable < daemonSet.Status.DesiredNumberScheduled {
+		return fmt.Sprintf("is not available, %d out of %d Pods available", daemonSet.Status.NumberAvailable, daemonSet.Status.DesiredNumberScheduled)
+	}
+	return ""
+}
+
+// reconcileClusterResourceSetsReadyCondition sets the ClusterResourceSetsReady condition on a Cluster.
+func (r *Reconciler) reconcileClusterResourceSetsReadyCondition(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSetBinding *addonsv1.ClusterResourceSetBinding) error {
+	patchHelper, err := patch.NewHelper(cluster, r.Client)
+	if err != nil {
+		return err
+	}
+	setClusterResourceSetsReadyCondition(cluster, clusterResourceSetBinding)
+	return patchHelper.Patch(ctx, cluster)
+}
+
+// setClusterResourceSetsReadyCondition sets the ClusterResourceSetsReady condition on a Cluster using the readiness
+// of the ClusterResourceSets recorded in the ClusterResourceSetBinding of the Cluster.
+// The condition is removed when no ClusterResourceSets are applied to the Cluster.
+func setClusterResourceSetsReadyCondition(cluster *clusterv1.Cluster, clusterResourceSetBinding *addonsv1.ClusterResourceSetBinding) {
+	if clusterResourceSetBinding == nil || len(clusterResourceSetBinding.Spec.Bindings) == 0 {
+		conditions.Delete(cluster, clusterv1.ClusterClusterResourceSetsReadyCondition)
+		return
+	}
+
+	bindings := slices.Clone(clusterResourceSetBinding.Spec.Bindings)
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].ClusterResourceSetName < bindings[j].ClusterResourceSetName
+	})
+
+	messages := []string{}
+	for _, binding := range bindings {
+		if binding.IsReady() {
+			continue
+		}
+		message := binding.Message
+		if message == "" {
+			message = "Not ready"
+		}
+		messages = append(messages, fmt.Sprintf("* ClusterResourceSet %s: %s", binding.ClusterResourceSetName, message))
+	}
+
+	if len(messages) > 0 {
+		conditions.Set(cluster, metav1.Condition{
+			Type:    clusterv1.ClusterClusterResourceSetsReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.ClusterClusterResourceSetsNotReadyReason,
+			Message: strings.Join(messages, "\n"),
+		})
+		return
+	}
+
+	conditions.Set(cluster, metav1.Condition{
+		Type:   clusterv1.ClusterClusterResourceSetsReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1.ClusterClusterResourceSetsReadyReason,
+	})
+}
@@ -0,0 +1,324 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func newDependentClusterResourceSet(name string, dependsOn ...string) *addonsv1.ClusterResourceSet {
+	crs := &addonsv1.ClusterResourceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+		Spec: addonsv1.ClusterResourceSetSpec{
+			ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"cni": "calico"}},
+		},
+	}
+	for _, dependency := range dependsOn {
+		crs.Spec.DependsOn = append(crs.Spec.DependsOn, addonsv1.ClusterResourceSetDependency{Name: dependency})
+	}
+	return crs
+}
+
+func TestDependencyCycle(t *testing.T) {
+	clusterResourceSets := map[string]*addonsv1.ClusterResourceSet{}
+	for _, crs := range []*addonsv1.ClusterResourceSet{
+		newDependentClusterResourceSet("cni"),
+		newDependentClusterResourceSet("csi", "cni"),
+		newDependentClusterResourceSet("monitoring", "cni", "csi"),
+		newDependentClusterResourceSet("a", "b"),
+		newDependentClusterResourceSet("b", "c", "missing"),
+		newDependentClusterResourceSet("c", "a"),
+		newDependentClusterResourceSet("d", "a"),
+	} {
+		clusterResourceSets[crs.Name] = crs
+	}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "cni", want: nil},
+		{name: "monitoring", want: nil},
+		{name: "a", want: []string{"a", "b", "c", "a"}},
+		{name: "c", want: []string{"c", "a", "b", "c"}},
+		// d depends on a cycle, but it is not part of the cycle.
+		{name: "d", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(dependencyCycle(tt.name, clusterResourceSets)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetDependenciesNotReady(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"cni": "calico"},
+		},
+	}
+	otherSelector := newDependentClusterResourceSet("other-selector")
+	otherSelector.Spec.ClusterSelector.MatchLabels = map[string]string{"cni": "cilium"}
+
+	c := fake.NewClientBuilder().WithObjects(
+		newDependentClusterResourceSet("cni"),
+		newDependentClusterResourceSet("csi", "cni"),
+		newDependentClusterResourceSet("cycle-a", "cycle-b"),
+		newDependentClusterResourceSet("cycle-b", "cycle-a"),
+		otherSelector,
+	).Build()
+	r := &Reconciler{Client: c}
+
+	tests := []struct {
+		name        string
+		crs         *addonsv1.ClusterResourceSet
+		bindings    []addonsv1.ResourceSetBinding
+		wantReasons []string
+	}{
+		{
+			name:        "no dependencies",
+			crs:         newDependentClusterResourceSet("cni"),
+			wantReasons: nil,
+		},
+		{
+			name:        "dependency not applied to the Cluster yet",
+			crs:         newDependentClusterResourceSet("csi", "cni"),
+			wantReasons: []string{"Waiting for ClusterResourceSet cni to be ready"},
+		},
+		{
+			name: "dependency not ready",
+			crs:  newDependentClusterResourceSet("csi", "cni"),
+			bindings: []addonsv1.ResourceSetBinding{
+				{ClusterResourceSetName: "cni", Ready: ptr.To(false), Message: "Deployment kube-system/calico-kube-controllers does not exist"},
+			},
+			wantReasons: []string{"Waiting for ClusterResourceSet cni to be ready"},
+		},
+		{
+			name: "dependencies ready",
+			crs:  newDependentClusterResourceSet("monitoring", "cni", "csi"),
+			bindings: []addonsv1.ResourceSetBinding{
+				{ClusterResourceSetName: "cni", Ready: ptr.To(true)},
+				{ClusterResourceSetName: "csi", Ready: ptr.To(true)},
+			},
+			wantReasons: []string{},
+		},
+		{
+			name: "dependencies missing or not selecting the Cluster",
+			crs:  newDependentClusterResourceSet("monitoring", "cni", "missing", "other-selector"),
+			bindings: []addonsv1.ResourceSetBinding{
+				{ClusterResourceSetName: "cni", Ready: ptr.To(true)},
+			},
+			wantReasons: []string{
+				"ClusterResourceSet missing does not exist",
+				"ClusterResourceSet other-selector does not select the Cluster",
+			},
+		},
+		{
+			name:        "circular dependency",
+			crs:         newDependentClusterResourceSet("cycle-a", "cycle-b"),
+			wantReasons: []string{"Circular dependency between ClusterResourceSets cycle-a -> cycle-b -> cycle-a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterResourceSetBinding := &addonsv1.ClusterResourceSetBinding{
+				Spec: addonsv1.ClusterResourceSetBindingSpec{Bindings: tt.bindings},
+			}
+			reasons, err := r.getDependenciesNotReady(ctx, cluster, tt.crs, clusterResourceSetBinding)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(reasons).To(Equal(tt.wantReasons))
+		})
+	}
+}
+
+func TestCheckReadiness(t *testing.T) {
+	deployment := func(name string, replicas int32, status appsv1.DeploymentStatus) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem, Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(replicas)},
+			Status:     status,
+		}
+	}
+	daemonSet := func(name string, status appsv1.DaemonSetStatus) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem, Generation: 1},
+			Status:     status,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		objs        []client.Object
+		kind        addonsv1.ClusterResourceSetReadinessCheckKind
+		wantReasons []string
+	}{
+		{
+			name:        "Deployment does not exist",
+			kind:        addonsv1.DeploymentClusterResourceSetReadinessCheckKind,
+			wantReasons: []string{"Deployment kube-system/test does not exist"},
+		},
+		{
+			name:        "Deployment not observed",
+			objs:        []client.Object{deployment("test", 2, appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2})},
+			kind:        addonsv1.DeploymentClusterResourceSetReadinessCheckKind,
+			wantReasons: []string{"Deployment kube-system/test is not yet observed by the Deployment controller"},
+		},
+		{
+			name:        "Deployment with replicas not updated",
+			objs:        []client.Object{deployment("test", 2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 1, AvailableReplicas: 2})},
+			kind:        addonsv1.DeploymentClusterResourceSetReadinessCheckKind,
+			wantReasons: []string{"Deployment kube-system/test is rolling out, 1 out of 2 replicas updated"},
+		},
+		{
+			name:        "Deployment with old replicas",
+			objs:        []client.Object{deployment("test", 2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 3})},
+			kind:        addonsv1.DeploymentClusterResourceSetReadinessCheckKind,
+			wantReasons: []string{"Deployment kube-system/test is rolling out, 1 old replicas pending termination"},
+		},
+		{
+			name:        "Deployment not available",
+			objs:        []client.Object{deployment("test", 2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1})},
+			kind:        addonsv1.DeploymentClusterResourceSetReadinessCheckKind,
+			wantReasons: []string{"Deployment kube-system/test is not available, 1 out of 2 replicas available"},
+		},
+		{
+			name:        "Deployment available",
+			objs:        []client.Object{deployment("test", 2, appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2})},
+			kind:        addonsv1.DeploymentClusterResourceSetReadinessCheckKind,
+			wantReasons: []string{},
+		},
+		{
+			name:        "DaemonSet does not exist",
+			kind:        addonsv1.DaemonSetClusterResourceSetReadinessCheckKind,
+			wantReasons: []string{"DaemonSet kube-system/test does not exist"},
+		},
+		{
+			name:        "DaemonSet with Pods not updated",
+			objs:        []client.Object{daemonSet("test", appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 2, NumberAvailable: 3})},
+			kind:        addonsv1.DaemonSetClusterResourceSetReadinessCheckKind,
+			wantReasons: []string{"DaemonSet kube-system/test is rolling out, 2 out of 3 Pods updated"},
+		},
+		{
+			name:        "DaemonSet not available",
+			objs:        []client.Object{daemonSet("test", appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 1})},
+			kind:        addonsv1.DaemonSetClusterResourceSetReadinessCheckKind,
+			wantReasons: []string{"DaemonSet kube-system/test is not available, 1 out of 3 Pods available"},
+		},
+		{
+			name:        "DaemonSet available",
+			objs:        []client.Object{daemonSet("test", appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3})},
+			kind:        addonsv1.DaemonSetClusterResourceSetReadinessCheckKind,
+			wantReasons: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithObjects(tt.objs...).WithStatusSubresource(tt.objs...).Build()
+			readinessChecks := []addonsv1.ClusterResourceSetReadinessCheck{
+				{Kind: string(tt.kind), Namespace: metav1.NamespaceSystem, Name: "test"},
+			}
+			reasons, err := checkReadiness(ctx, c, readinessChecks)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(reasons).To(Equal(tt.wantReasons))
+		})
+	}
+}
+
+func TestSetClusterResourceSetsReadyCondition(t *testing.T) {
+	tests := []struct {
+		name          string
+		bindings      []addonsv1.ResourceSetBinding
+		wantCondition *metav1.Condition
+	}{
+		{
+			name:          "no ClusterResourceSets",
+			wantCondition: nil,
+		},
+		{
+			name: "all ClusterResourceSets ready",
+			bindings: []addonsv1.ResourceSetBinding{
+				{ClusterResourceSetName: "cni", Ready: ptr.To(true)},
+				{ClusterResourceSetName: "csi", Ready: ptr.To(true)},
+			},
+			wantCondition: &metav1.Condition{
+				Type:   clusterv1.ClusterClusterResourceSetsReadyCondition,
+				Status: metav1.ConditionTrue,
+				Reason: clusterv1.ClusterClusterResourceSetsReadyReason,
+			},
+		},
+		{
+			name: "ClusterResourceSets not ready",
+			bindings: []addonsv1.ResourceSetBinding{
+				{ClusterResourceSetName: "monitoring", Ready: ptr.To(false), Message: "Waiting for ClusterResourceSet csi to be ready"},
+				{ClusterResourceSetName: "cni", Ready: ptr.To(true)},
+				{ClusterResourceSetName: "csi", Ready: ptr.To(false), Message: "DaemonSet kube-system/csi-node is not available, 1 out of 3 Pods available"},
+				{ClusterResourceSetName: "legacy"},
+			},
+			wantCondition: &metav1.Condition{
+				Type:   clusterv1.ClusterClusterResourceSetsReadyCondition,
+				Status: metav1.ConditionFalse,
+				Reason: clusterv1.ClusterClusterResourceSetsNotReadyReason,
+				Message: "* ClusterResourceSet csi: DaemonSet kube-system/csi-node is not available, 1 out of 3 Pods available\n" +
+					"* ClusterResourceSet legacy: Not ready\n" +
+					"* ClusterResourceSet monitoring: Waiting for ClusterResourceSet csi to be ready",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := &clusterv1.Cluster{}
+			conditions.Set(cluster, metav1.Condition{
+				Type:   clusterv1.ClusterClusterResourceSetsReadyCondition,
+				Status: metav1.ConditionUnknown,
+				Reason: clusterv1.ClusterClusterResourceSetsNotReadyReason,
+			})
+			clusterResourceSetBinding := &addonsv1.ClusterResourceSetBinding{
+				Spec: addonsv1.ClusterResourceSetBindingSpec{Bindings: tt.bindings},
+			}
+
+			setClusterResourceSetsReadyCondition(cluster, clusterResourceSetBinding)
+
+			condition := conditions.Get(cluster, clusterv1.ClusterClusterResourceSetsReadyCondition)
+			if tt.wantCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(*condition).To(conditions.MatchCondition(*tt.wantCondition, conditions.IgnoreLastTransitionTime(true)))
+		})
+	}
+}
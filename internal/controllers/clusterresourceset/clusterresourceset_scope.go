@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -28,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
 // resourceReconcileScope contains the scope for a CRS's resource
@@ -45,6 +47,7 @@ type resourceReconcileScope interface {
 
 func reconcileScopeForResource(
 	crs *addonsv1.ClusterResourceSet,
+	cluster *clusterv1.Cluster,
 	resourceRef addonsv1.ResourceRef,
 	resourceSetBinding *addonsv1.ResourceSetBinding,
 	resource *unstructured.Unstructured,
@@ -54,12 +57,37 @@ func reconcileScopeForResource(
 		return nil, err
 	}
 
+	normalizedData, err = renderResourceTemplate(resourceRef, cluster, normalizedData)
+	if err != nil {
+		return nil, err
+	}
+
 	objs, err := objsFromYamlData(normalizedData)
 	if err != nil {
 		return nil, err
 	}
 
-	return newResourceReconcileScope(crs, resourceRef, resourceSetBinding, normalizedData, objs)
+	return newResourceReconcileScope(crs, resourceRef, resourceSetBinding, normalizedData, objs, "")
+}
+
+func reconcileScopeForRemoteResource(
+	crs *addonsv1.ClusterResourceSet,
+	cluster *clusterv1.Cluster,
+	resourceRef addonsv1.ResourceRef,
+	resourceSetBinding *addonsv1.ResourceSetBinding,
+	resource *remoteResource,
+) (resourceReconcileScope, error) {
+	data, err := renderResourceTemplate(resourceRef, cluster, resource.data)
+	if err != nil {
+		return nil, err
+	}
+
+	objs, err := objsFromYamlData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return newResourceReconcileScope(crs, resourceRef, resourceSetBinding, data, objs, resource.defaultNamespace)
 }
 
 func newResourceReconcileScope(
@@ -68,6 +96,7 @@ func newResourceReconcileScope(
 	resourceSetBinding *addonsv1.ResourceSetBinding,
 	normalizedData [][]byte,
 	objs []unstructured.Unstructured,
+	defaultNamespace string,
 ) (resourceReconcileScope, error) {
 	base := baseResourceReconcileScope{
 		clusterResourceSet: clusterResourceSet,
@@ -76,6 +105,7 @@ func newResourceReconcileScope(
 		data:               normalizedData,
 		normalizedObjs:     objs,
 		computedHash:       computeHash(normalizedData),
+		defaultNamespace:   defaultNamespace,
 	}
 
 	switch addonsv1.ClusterResourceSetStrategy(clusterResourceSet.Spec.Strategy) {
@@ -83,6 +113,8 @@ func newResourceReconcileScope(
 		return &reconcileApplyOnceScope{base}, nil
 	case addonsv1.ClusterResourceSetStrategyReconcile:
 		return &reconcileStrategyScope{base}, nil
+	case addonsv1.ClusterResourceSetStrategyServerSideApply:
+		return &reconcileServerSideApplyScope{baseResourceReconcileScope: base, previousBinding: resourceSetBinding.GetResource(resourceRef)}, nil
 	default:
 		return nil, errors.Errorf("unsupported or empty resource strategy: %q", clusterResourceSet.Spec.Strategy)
 	}
@@ -95,12 +127,36 @@ type baseResourceReconcileScope struct {
 	normalizedObjs     []unstructured.Unstructured
 	data               [][]byte
 	computedHash       string
+	// defaultNamespace, if set, is the namespace for the namespaced objects without a namespace.
+	defaultNamespace string
 }
 
 func (b baseResourceReconcileScope) objs() []unstructured.Unstructured {
 	return b.normalizedObjs
 }
 
+// objsForCluster returns the objects to be applied to the target cluster,
+// setting the default namespace on namespaced objects without a namespace.
+func (b baseResourceReconcileScope) objsForCluster(c client.Client) []unstructured.Unstructured {
+	if b.defaultNamespace == "" {
+		return b.objs()
+	}
+
+	objs := make([]unstructured.Unstructured, len(b.normalizedObjs))
+	for i := range b.normalizedObjs {
+		objs[i] = *b.normalizedObjs[i].DeepCopy()
+		if objs[i].GetNamespace() != "" {
+			continue
+		}
+		// Note: if it is not possible to determine if the object is namespaced, e.g. because it is a custom
+		// resource whose CRD is not yet installed, the object is applied as is.
+		if namespaced, err := c.IsObjectNamespaced(&objs[i]); err == nil && namespaced {
+			objs[i].SetNamespace(b.defaultNamespace)
+		}
+	}
+	return objs
+}
+
 func (b baseResourceReconcileScope) hash() string {
 	return b.computedHash
 }
@@ -116,7 +172,7 @@ func (r *reconcileStrategyScope) needsApply() bool {
 }
 
 func (r *reconcileStrategyScope) apply(ctx context.Context, c client.Client) error {
-	return apply(ctx, c, r.applyObj, r.objs())
+	return apply(ctx, c, r.applyObj, r.objsForCluster(c))
 }
 
 func (r *reconcileStrategyScope) applyObj(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
@@ -159,7 +215,7 @@ func (r *reconcileApplyOnceScope) needsApply() bool {
 }
 
 func (r *reconcileApplyOnceScope) apply(ctx context.Context, c client.Client) error {
-	return apply(ctx, c, r.applyObj, r.objs())
+	return apply(ctx, c, r.applyObj, r.objsForCluster(c))
 }
 
 func (r *reconcileApplyOnceScope) applyObj(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
@@ -172,6 +228,79 @@ func (r *reconcileApplyOnceScope) applyObj(ctx context.Context, c client.Client,
 	return nil
 }
 
+// reconcileServerSideApplyScope applies the objects of a resource using server-side apply at every reconcile,
+// so that objects modified or deleted in the cluster are restored.
+type reconcileServerSideApplyScope struct {
+	baseResourceReconcileScope
+	// previousBinding is the ResourceBinding of the resource before it is applied, if any.
+	previousBinding *addonsv1.ResourceBinding
+	// appliedObjects are the objects of the resource applied to the cluster.
+	appliedObjects []addonsv1.ResourceBindingObject
+	// changed is true if applying the resource created or modified objects in the cluster.
+	changed bool
+}
+
+func (r *reconcileServerSideApplyScope) needsApply() bool {
+	return true
+}
+
+func (r *reconcileServerSideApplyScope) apply(ctx context.Context, c client.Client) error {
+	objs := r.objsForCluster(c)
+	r.appliedObjects = make([]addonsv1.ResourceBindingObject, 0, len(objs))
+	for i := range objs {
+		r.appliedObjects = append(r.appliedObjects, resourceBindingObject(&objs[i]))
+	}
+	return apply(ctx, c, r.applyObj, objs)
+}
+
+func (r *reconcileServerSideApplyScope) applyObj(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+	currentObj := &unstructured.Unstructured{}
+	currentObj.SetAPIVersion(obj.GetAPIVersion())
+	currentObj.SetKind(obj.GetKind())
+	err := c.Get(ctx, client.ObjectKeyFromObject(obj), currentObj)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(
+			err,
+			"reading object %s %s",
+			obj.GroupVersionKind(),
+			klog.KObj(obj),
+		)
+	}
+	exists := err == nil
+
+	// Note: the applied object is updated with the object returned by the API server.
+	if err := c.Apply(ctx, client.ApplyConfigurationFromUnstructured(obj), client.ForceOwnership, client.FieldOwner(clusterResourceSetManagerName)); err != nil {
+		return errors.Wrapf(
+			err,
+			"applying object %s %s",
+			obj.GroupVersionKind(),
+			klog.KObj(obj),
+		)
+	}
+
+	if !exists || !equalIgnoringServerFields(currentObj, obj) {
+		r.changed = true
+	}
+	return nil
+}
+
+// equalIgnoringServerFields returns true if two versions of an object are equal, ignoring the fields
+// that are changed by the API server on every write.
+func equalIgnoringServerFields(a, b *unstructured.Unstructured) bool {
+	a, b = a.DeepCopy(), b.DeepCopy()
+	for _, obj := range []*unstructured.Unstructured{a, b} {
+		obj.SetResourceVersion("")
+		obj.SetManagedFields(nil)
+	}
+	return equality.Semantic.DeepEqual(a.Object, b.Object)
+}
+
+// drifted returns true if objects of a resource that was already applied with the same content were found
+// modified or deleted in the cluster, and thus restored by apply.
+func (r *reconcileServerSideApplyScope) drifted() bool {
+	return r.changed && r.previousBinding != nil && ptr.Deref(r.previousBinding.Applied, false) && r.previousBinding.Hash == r.computedHash
+}
+
 type applyObj func(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error
 
 // apply reconciles unstructured objects using applyObj and aggregates the error if present.
@@ -22,6 +22,8 @@ import (
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/ptr"
@@ -250,3 +252,53 @@ func TestReconcileApplyOnceScopeApplyObj(t *testing.T) {
 		})
 	}
 }
+
+func TestObjsForCluster(t *testing.T) {
+	g := NewWithT(t)
+
+	scope := baseResourceReconcileScope{
+		normalizedObjs: []unstructured.Unstructured{
+			{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata": map[string]interface{}{
+						"name": "my-cm",
+					},
+				},
+			},
+			{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata": map[string]interface{}{
+						"name":      "my-other-cm",
+						"namespace": "that-ns",
+					},
+				},
+			},
+			{
+				Object: map[string]interface{}{
+					"apiVersion": "rbac.authorization.k8s.io/v1",
+					"kind":       "ClusterRole",
+					"metadata": map[string]interface{}{
+						"name": "my-cluster-role",
+					},
+				},
+			},
+		},
+		defaultNamespace: "addons",
+	}
+
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	restMapper.Add(rbacv1.SchemeGroupVersion.WithKind("ClusterRole"), meta.RESTScopeRoot)
+
+	objs := scope.objsForCluster(fake.NewClientBuilder().WithRESTMapper(restMapper).Build())
+	g.Expect(objs).To(HaveLen(3))
+	g.Expect(objs[0].GetNamespace()).To(Equal("addons"))
+	g.Expect(objs[1].GetNamespace()).To(Equal("that-ns"))
+	g.Expect(objs[2].GetNamespace()).To(BeEmpty())
+	// The normalized objects are not modified.
+	g.Expect(scope.objs()[0].GetNamespace()).To(BeEmpty())
+}
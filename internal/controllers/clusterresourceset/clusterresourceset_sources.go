@@ -0,0 +1,366 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+const (
+	// maxRemoteSourceSize is the maximum size of the content fetched from a remote source.
+	maxRemoteSourceSize = 32 * 1024 * 1024
+
+	// maxSourceCacheSize is the maximum total size of the content fetched from remote sources kept in memory.
+	maxSourceCacheSize = 4 * maxRemoteSourceSize
+
+	// sourceCacheTTL is the time after which the content fetched from remote sources is evicted from the cache.
+	sourceCacheTTL = 10 * time.Minute
+
+	// remoteSourceRequestTimeout is the timeout for requests sent to remote sources.
+	remoteSourceRequestTimeout = 30 * time.Second
+
+	// remoteSourceResyncPeriod is the interval after which ClusterResourceSets with the Reconcile strategy
+	// check again remote sources that could have changed without changes to the ClusterResourceSet, e.g. OCI tags.
+	remoteSourceResyncPeriod = 10 * time.Minute
+)
+
+// remoteResource is the content of a resource resolved from a remote source.
+type remoteResource struct {
+	// digest is the digest of the content fetched from the remote source.
+	digest string
+	// data is the list of YAML/JSON documents of the resource.
+	data [][]byte
+	// defaultNamespace, if set, is the namespace for the namespaced objects of the resource without a namespace.
+	defaultNamespace string
+}
+
+// sourceCacheEntry is an entry of the cache of the content fetched from remote sources.
+// Entries are keyed by digest, so they never become stale, with the exception of Helm charts
+// from HTTP repositories, which are keyed by repository, chart and version.
+type sourceCacheEntry struct {
+	key    string
+	digest string
+	data   [][]byte
+}
+
+// size returns the size of the content of a sourceCacheEntry.
+func (e sourceCacheEntry) size() int {
+	size := 0
+	for _, d := range e.data {
+		size += len(d)
+	}
+	return size
+}
+
+// sourceCache is a cache of the content fetched from remote sources.
+// Entries expire after a TTL, and the least recently used entries are evicted when the total size of the
+// cached content exceeds maxSize, so the memory used by the cache is bounded.
+type sourceCache struct {
+	lock    sync.Mutex
+	ttl     time.Duration
+	maxSize int
+	size    int
+	// entries are the elements of lru by key; the front of lru is the most recently used entry.
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// sourceCacheItem is an entry of the sourceCache with its expiration time.
+type sourceCacheItem struct {
+	entry     sourceCacheEntry
+	expiresAt time.Time
+}
+
+// newSourceCache returns a sourceCache.
+func newSourceCache(ttl time.Duration, maxSize int) *sourceCache {
+	return &sourceCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+// Get returns the entry for a key, if cached and not expired.
+func (c *sourceCache) Get(key string) (sourceCacheEntry, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return sourceCacheEntry{}, false
+	}
+	item := element.Value.(*sourceCacheItem)
+	if time.Now().After(item.expiresAt) {
+		c.remove(element)
+		return sourceCacheEntry{}, false
+	}
+	c.lru.MoveToFront(element)
+	return item.entry, true
+}
+
+// Add adds an entry to the cache, evicting the least recently used entries if the total size
+// of the cached content exceeds the maximum size; entries bigger than the maximum size are not cached.
+func (c *sourceCache) Add(entry sourceCacheEntry) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if element, ok := c.entries[entry.key]; ok {
+		c.remove(element)
+	}
+	if entry.size() > c.maxSize {
+		return
+	}
+
+	c.entries[entry.key] = c.lru.PushFront(&sourceCacheItem{entry: entry, expiresAt: time.Now().Add(c.ttl)})
+	c.size += entry.size()
+	for c.size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (c *sourceCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.lru.Len()
+}
+
+// remove removes an element from the cache; it must be called with the lock held.
+func (c *sourceCache) remove(element *list.Element) {
+	item := c.lru.Remove(element).(*sourceCacheItem)
+	delete(c.entries, item.entry.key)
+	c.size -= item.entry.size()
+}
+
+// getCachedSource returns the content cached for a key, if any.
+func (r *Reconciler) getCachedSource(key string) (sourceCacheEntry, bool) {
+	if r.sourceCache == nil {
+		return sourceCacheEntry{}, false
+	}
+	return r.sourceCache.Get(key)
+}
+
+// cacheSource adds content fetched from a remote source to the cache.
+func (r *Reconciler) cacheSource(entry sourceCacheEntry) {
+	if r.sourceCache == nil {
+		return
+	}
+	r.sourceCache.Add(entry)
+}
+
+// getHTTPClient returns the client used to fetch content from remote sources.
+func (r *Reconciler) getHTTPClient() *http.Client {
+	if r.httpClient != nil {
+		return r.httpClient
+	}
+	return &http.Client{Timeout: remoteSourceRequestTimeout}
+}
+
+// resolveRemoteResource fetches the content of a resource from its remote source.
+func (r *Reconciler) resolveRemoteResource(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSet *addonsv1.ClusterResourceSet, resourceRef addonsv1.ResourceRef) (*remoteResource, error) {
+	switch addonsv1.ClusterResourceSetResourceKind(resourceRef.Kind) {
+	case addonsv1.OCIClusterResourceSetResourceKind:
+		return r.resolveOCIResource(ctx, clusterResourceSet.Namespace, resourceRef.OCI)
+	case addonsv1.HTTPClusterResourceSetResourceKind:
+		return r.resolveHTTPResource(ctx, resourceRef.HTTP)
+	case addonsv1.HelmChartClusterResourceSetResourceKind:
+		return r.resolveHelmChartResource(ctx, cluster, clusterResourceSet.Namespace, resourceRef)
+	default:
+		return nil, errors.Errorf("resource kind %q is not a remote source", resourceRef.Kind)
+	}
+}
+
+// resolveHTTPResource fetches the content of a resource from an HTTP URL and verifies its checksum.
+func (r *Reconciler) resolveHTTPResource(ctx context.Context, source addonsv1.ResourceHTTPSource) (*remoteResource, error) {
+	if entry, ok := r.getCachedSource(source.Checksum); ok {
+		return &remoteResource{digest: entry.digest, data: entry.data}, nil
+	}
+
+	content, err := r.httpGet(ctx, source.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if digest := computeDigest(content); digest != source.Checksum {
+		return nil, errors.Errorf("checksum %s of content from %s does not match the expected checksum %s", digest, source.URL, source.Checksum)
+	}
+
+	data, err := documentsFromContent(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read content from %s", source.URL)
+	}
+
+	r.cacheSource(sourceCacheEntry{key: source.Checksum, digest: source.Checksum, data: data})
+	return &remoteResource{digest: source.Checksum, data: data}, nil
+}
+
+// httpGet sends a GET request to url and returns the body of the response.
+// setHeaders, if not nil, can be used to set additional headers, e.g. for authentication.
+func (r *Reconciler) httpGet(ctx context.Context, url string, setHeaders func(header http.Header)) ([]byte, error) {
+	content, _, err := r.httpDo(ctx, url, setHeaders)
+	return content, err
+}
+
+// httpDo sends a GET request to url and returns the body and the headers of the response.
+func (r *Reconciler) httpDo(ctx context.Context, url string, setHeaders func(header http.Header)) ([]byte, http.Header, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteSourceRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create request for %s", url)
+	}
+	if setHeaders != nil {
+		setHeaders(req.Header)
+	}
+
+	resp, err := r.getHTTPClient().Do(req)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get %s", url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, &httpStatusError{url: url, statusCode: resp.StatusCode, header: resp.Header}
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteSourceSize+1))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read response from %s", url)
+	}
+	if len(content) > maxRemoteSourceSize {
+		return nil, nil, errors.Errorf("content from %s exceeds the maximum size of %d bytes", url, maxRemoteSourceSize)
+	}
+	return content, resp.Header, nil
+}
+
+// httpStatusError is returned when a remote source responds with an unexpected status code.
+type httpStatusError struct {
+	url        string
+	statusCode int
+	header     http.Header
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("failed to get %s: unexpected status code %d", e.url, e.statusCode)
+}
+
+// documentsFromContent returns the YAML/JSON documents in content; content is either a single document
+// or a tar+gzip archive of documents, in which case only files with a .yaml, .yml or .json extension are read.
+func documentsFromContent(content []byte) ([][]byte, error) {
+	if !isGzip(content) {
+		return [][]byte{content}, nil
+	}
+
+	files, err := filesFromTarGz(content)
+	if err != nil {
+		return nil, err
+	}
+	data := [][]byte{}
+	for _, f := range files {
+		switch strings.ToLower(path.Ext(f.name)) {
+		case ".yaml", ".yml", ".json":
+			if len(bytes.TrimSpace(f.content)) > 0 {
+				data = append(data, f.content)
+			}
+		}
+	}
+	if len(data) == 0 {
+		return nil, errors.New("archive does not contain any YAML or JSON file")
+	}
+	return data, nil
+}
+
+// archiveFile is a regular file read from an archive.
+type archiveFile struct {
+	name    string
+	content []byte
+}
+
+// filesFromTarGz returns the regular files in a tar+gzip archive, in the order they are stored in the archive.
+func filesFromTarGz(content []byte) ([]archiveFile, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read gzip archive")
+	}
+	defer gzipReader.Close()
+
+	files := []archiveFile{}
+	size := 0
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read tar archive")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		size += int(header.Size)
+		if size > maxRemoteSourceSize {
+			return nil, errors.Errorf("content of the archive exceeds the maximum size of %d bytes", maxRemoteSourceSize)
+		}
+		fileContent, err := io.ReadAll(io.LimitReader(tarReader, header.Size))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s from tar archive", header.Name)
+		}
+		files = append(files, archiveFile{name: path.Clean(header.Name), content: fileContent})
+	}
+	return files, nil
+}
+
+// isGzip returns true if content starts with the gzip magic number.
+func isGzip(content []byte) bool {
+	return len(content) > 2 && content[0] == 0x1f && content[1] == 0x8b
+}
+
+// computeDigest returns the sha256 digest of content in the sha256:<hex> format.
+func computeDigest(content []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+}
+
+// getSourceCredentials returns the username and password stored in the Secret referenced by a remote source.
+func (r *Reconciler) getSourceCredentials(ctx context.Context, namespace string, secretRef addonsv1.ResourceSourceSecretReference) (username, password string, err error) {
+	secret, err := getSecret(ctx, r.Client, types.NamespacedName{Namespace: namespace, Name: secretRef.Name})
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to get Secret %s/%s with credentials", namespace, secretRef.Name)
+	}
+	return string(secret.Data["username"]), string(secret.Data["password"]), nil
+}
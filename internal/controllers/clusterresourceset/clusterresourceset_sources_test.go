@@ -0,0 +1,368 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+)
+
+func TestResolveHTTPResource(t *testing.T) {
+	manifest := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cni\n")
+	archive := newTarGz(t, map[string]string{
+		"bundle/README.md":     "not a manifest",
+		"bundle/cm.yaml":       "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n",
+		"bundle/secret.json":   `{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "secret"}}`,
+		"bundle/empty.yaml":    "",
+		"bundle/sub/other.yml": "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: sa\n",
+	})
+
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/cni.yaml":
+			_, _ = w.Write(manifest)
+		case "/bundle.tar.gz":
+			_, _ = w.Write(archive)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		source   addonsv1.ResourceHTTPSource
+		wantData []string
+		wantErr  string
+	}{
+		{
+			name:     "fetches a document",
+			source:   addonsv1.ResourceHTTPSource{URL: server.URL + "/cni.yaml", Checksum: computeDigest(manifest)},
+			wantData: []string{string(manifest)},
+		},
+		{
+			name:   "fetches the YAML and JSON documents in a tar+gzip archive",
+			source: addonsv1.ResourceHTTPSource{URL: server.URL + "/bundle.tar.gz", Checksum: computeDigest(archive)},
+			wantData: []string{
+				"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n",
+				`{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "secret"}}`,
+				"apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: sa\n",
+			},
+		},
+		{
+			name:    "fails if the checksum does not match",
+			source:  addonsv1.ResourceHTTPSource{URL: server.URL + "/cni.yaml", Checksum: computeDigest([]byte("something else"))},
+			wantErr: "does not match the expected checksum",
+		},
+		{
+			name:    "fails if the URL does not exist",
+			source:  addonsv1.ResourceHTTPSource{URL: server.URL + "/not-found.yaml", Checksum: computeDigest(manifest)},
+			wantErr: "unexpected status code 404",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &Reconciler{httpClient: server.Client()}
+			got, err := r.resolveHTTPResource(ctx, tt.source)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.digest).To(Equal(tt.source.Checksum))
+			g.Expect(got.data).To(HaveLen(len(tt.wantData)))
+			for i := range tt.wantData {
+				g.Expect(string(got.data[i])).To(Equal(tt.wantData[i]))
+			}
+		})
+	}
+
+	t.Run("content is cached by checksum", func(t *testing.T) {
+		g := NewWithT(t)
+
+		r := &Reconciler{httpClient: server.Client(), sourceCache: newSourceCache(sourceCacheTTL, maxSourceCacheSize)}
+		source := addonsv1.ResourceHTTPSource{URL: server.URL + "/cni.yaml", Checksum: computeDigest(manifest)}
+
+		requests = 0
+		for range 2 {
+			got, err := r.resolveHTTPResource(ctx, source)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.data).To(Equal([][]byte{manifest}))
+		}
+		g.Expect(requests).To(Equal(1))
+	})
+}
+
+func TestSourceCache(t *testing.T) {
+	newEntry := func(key string, size int) sourceCacheEntry {
+		return sourceCacheEntry{key: key, digest: key, data: [][]byte{bytes.Repeat([]byte("a"), size)}}
+	}
+
+	t.Run("evicts the least recently used entries when exceeding the maximum size", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := newSourceCache(time.Hour, 10)
+		c.Add(newEntry("a", 4))
+		c.Add(newEntry("b", 4))
+		_, ok := c.Get("a")
+		g.Expect(ok).To(BeTrue())
+
+		c.Add(newEntry("c", 4))
+		g.Expect(c.Len()).To(Equal(2))
+		_, ok = c.Get("b")
+		g.Expect(ok).To(BeFalse())
+		_, ok = c.Get("a")
+		g.Expect(ok).To(BeTrue())
+		_, ok = c.Get("c")
+		g.Expect(ok).To(BeTrue())
+
+		// Replacing an entry does not count its previous size.
+		c.Add(newEntry("c", 6))
+		g.Expect(c.Len()).To(Equal(2))
+	})
+
+	t.Run("does not cache entries bigger than the maximum size", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := newSourceCache(time.Hour, 10)
+		c.Add(newEntry("a", 4))
+		c.Add(newEntry("b", 11))
+		g.Expect(c.Len()).To(Equal(1))
+		_, ok := c.Get("a")
+		g.Expect(ok).To(BeTrue())
+	})
+
+	t.Run("expires entries after the TTL", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := newSourceCache(-time.Second, 10)
+		c.Add(newEntry("a", 4))
+		_, ok := c.Get("a")
+		g.Expect(ok).To(BeFalse())
+		g.Expect(c.Len()).To(Equal(0))
+	})
+}
+
+func TestParseOCIReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    ociReference
+		wantErr bool
+	}{
+		{
+			ref:  "oci://registry.example.com/addons/cni:v1.0.0",
+			want: ociReference{registry: "registry.example.com", repository: "addons/cni", reference: "v1.0.0"},
+		},
+		{
+			ref:  "oci://registry.example.com:5000/cni",
+			want: ociReference{registry: "registry.example.com:5000", repository: "cni", reference: "latest"},
+		},
+		{
+			ref:  "oci://registry.example.com/addons/cni@sha256:0123",
+			want: ociReference{registry: "registry.example.com", repository: "addons/cni", reference: "sha256:0123"},
+		},
+		{
+			ref:     "https://registry.example.com/addons/cni:v1.0.0",
+			wantErr: true,
+		},
+		{
+			ref:     "oci://registry.example.com",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := parseOCIReference(tt.ref)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestResolveOCIResource(t *testing.T) {
+	g := NewWithT(t)
+
+	layers := [][]byte{
+		[]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cni\n"),
+		newTarGz(t, map[string]string{"manifests/sa.yaml": "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: cni\n"}),
+	}
+	manifest := ociManifest{}
+	for _, layer := range layers {
+		manifest.Layers = append(manifest.Layers, ociDescriptor{MediaType: "application/vnd.oci.image.layer.v1.tar", Digest: computeDigest(layer)})
+	}
+	manifestContent, err := json.Marshal(manifest)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	server := newTestOCIRegistry(t, "addons/cni", "v1.0.0", manifestContent, layers)
+	defer server.Close()
+	registry := strings.TrimPrefix(server.URL, "https://")
+
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-credentials", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"username": []byte("user"), "password": []byte("pass")},
+	}
+	r := &Reconciler{
+		Client:     fake.NewClientBuilder().WithObjects(credentials).Build(),
+		httpClient: server.Client(),
+	}
+
+	tests := []struct {
+		name    string
+		source  addonsv1.ResourceOCISource
+		wantErr string
+	}{
+		{
+			name: "fetches an artifact by tag",
+			source: addonsv1.ResourceOCISource{
+				URL:       fmt.Sprintf("oci://%s/addons/cni:v1.0.0", registry),
+				SecretRef: addonsv1.ResourceSourceSecretReference{Name: credentials.Name},
+			},
+		},
+		{
+			name: "fetches an artifact by digest",
+			source: addonsv1.ResourceOCISource{
+				URL:       fmt.Sprintf("oci://%s/addons/cni@%s", registry, computeDigest(manifestContent)),
+				SecretRef: addonsv1.ResourceSourceSecretReference{Name: credentials.Name},
+			},
+		},
+		{
+			name:    "fails without credentials",
+			source:  addonsv1.ResourceOCISource{URL: fmt.Sprintf("oci://%s/addons/cni:v1.0.0", registry)},
+			wantErr: "failed to authenticate",
+		},
+		{
+			name: "fails if the artifact does not exist",
+			source: addonsv1.ResourceOCISource{
+				URL:       fmt.Sprintf("oci://%s/addons/cni:v2.0.0", registry),
+				SecretRef: addonsv1.ResourceSourceSecretReference{Name: credentials.Name},
+			},
+			wantErr: "unexpected status code 404",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := r.resolveOCIResource(ctx, metav1.NamespaceDefault, tt.source)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.digest).To(Equal(computeDigest(manifestContent)))
+			g.Expect(got.data).To(Equal([][]byte{
+				layers[0],
+				[]byte("apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: cni\n"),
+			}))
+		})
+	}
+}
+
+// newTestOCIRegistry returns a registry serving a single artifact, requiring a bearer token
+// obtained with basic authentication with user/pass.
+func newTestOCIRegistry(t *testing.T, repository, tag string, manifest []byte, layers [][]byte) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token": "test-token"}`))
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test-registry",scope="repository:%s:pull"`, server.URL, repository))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case fmt.Sprintf("/v2/%s/manifests/%s", repository, tag), fmt.Sprintf("/v2/%s/manifests/%s", repository, computeDigest(manifest)):
+			w.Header().Set("Docker-Content-Digest", computeDigest(manifest))
+			_, _ = w.Write(manifest)
+			return
+		}
+		for _, layer := range layers {
+			if r.URL.Path == fmt.Sprintf("/v2/%s/blobs/%s", repository, computeDigest(layer)) {
+				_, _ = w.Write(layer)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	return server
+}
+
+// newTarGz returns a tar+gzip archive with the given files.
+func newTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, name := range names {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(files[name])), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(files[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
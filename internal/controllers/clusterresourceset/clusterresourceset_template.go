@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// clusterTemplateData returns the data available to templates rendered for a Cluster,
+// with the Cluster exposed as .Cluster, e.g. {{ .Cluster.metadata.name }}, and the values
+// of the Cluster topology variables exposed as .Variables, e.g. {{ .Variables.region }}.
+func clusterTemplateData(cluster *clusterv1.Cluster) (map[string]interface{}, error) {
+	clusterData, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert Cluster to unstructured")
+	}
+
+	variables := map[string]interface{}{}
+	for _, variable := range cluster.Spec.Topology.Variables {
+		if _, ok := variables[variable.Name]; ok {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(variable.Value.Raw, &value); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal value of variable %q", variable.Name)
+		}
+		variables[variable.Name] = value
+	}
+
+	return map[string]interface{}{
+		"Cluster":   clusterData,
+		"Variables": variables,
+	}, nil
+}
+
+// renderResourceTemplate renders the content of a resource for a Cluster, if templating is enabled for the resource.
+// NOTE: Templates fail to render if they refer to missing fields, so that resources are never applied with
+// incomplete values; the index and dig functions can be used to access fields that could be missing.
+func renderResourceTemplate(resourceRef addonsv1.ResourceRef, cluster *clusterv1.Cluster, data [][]byte) ([][]byte, error) {
+	if resourceRef.Templating != addonsv1.ClusterResourceSetResourceTemplatingGoTemplate {
+		return data, nil
+	}
+
+	templateData, err := clusterTemplateData(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	renderedData := make([][]byte, 0, len(data))
+	for i := range data {
+		name := fmt.Sprintf("%s/%s[%d]", resourceRef.Kind, resourceRef.Name, i)
+		tpl, err := template.New(name).Option("missingkey=error").Funcs(sprig.HermeticTxtFuncMap()).Parse(string(data[i]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse template for %s resource %s", resourceRef.Kind, resourceRef.Name)
+		}
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, templateData); err != nil {
+			return nil, errors.Wrapf(err, "failed to render template for %s resource %s", resourceRef.Kind, resourceRef.Name)
+		}
+		renderedData = append(renderedData, buf.Bytes())
+	}
+	return renderedData, nil
+}
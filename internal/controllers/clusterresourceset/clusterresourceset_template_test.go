@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresourceset
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+func TestRenderResourceTemplate(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"cloud": "openstack"},
+		},
+		Spec: clusterv1.ClusterSpec{
+			ClusterNetwork: clusterv1.ClusterNetwork{
+				Pods:     clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0/16"}},
+				Services: clusterv1.NetworkRanges{CIDRBlocks: []string{"10.128.0.0/12"}},
+			},
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "10.0.0.1", Port: 6443},
+			Topology: clusterv1.Topology{
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "region", Value: apiextensionsv1.JSON{Raw: []byte(`"eu-west-1"`)}},
+					{Name: "network", Value: apiextensionsv1.JSON{Raw: []byte(`{"mtu": 1450}`)}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		templating addonsv1.ClusterResourceSetResourceTemplating
+		data       []string
+		want       []string
+		wantErr    string
+	}{
+		{
+			name:       "content is not rendered if templating is not enabled",
+			templating: addonsv1.ClusterResourceSetResourceTemplatingNone,
+			data:       []string{"name: {{ .Cluster.metadata.name }}"},
+			want:       []string{"name: {{ .Cluster.metadata.name }}"},
+		},
+		{
+			name:       "content is rendered with Cluster fields and variables",
+			templating: addonsv1.ClusterResourceSetResourceTemplatingGoTemplate,
+			data: []string{
+				`name: {{ .Cluster.metadata.name }}
+namespace: {{ .Cluster.metadata.namespace }}
+cloud: {{ .Cluster.metadata.labels.cloud }}
+podCIDR: {{ index .Cluster.spec.clusterNetwork.pods.cidrBlocks 0 }}
+serviceCIDR: {{ index .Cluster.spec.clusterNetwork.services.cidrBlocks 0 }}
+endpoint: {{ .Cluster.spec.controlPlaneEndpoint.host }}:{{ .Cluster.spec.controlPlaneEndpoint.port }}`,
+				`region: {{ .Variables.region | upper }}
+mtu: {{ .Variables.network.mtu }}
+zone: {{ dig "metadata" "annotations" "zone" "none" .Cluster }}`,
+			},
+			want: []string{
+				`name: test-cluster
+namespace: default
+cloud: openstack
+podCIDR: 192.168.0.0/16
+serviceCIDR: 10.128.0.0/12
+endpoint: 10.0.0.1:6443`,
+				`region: EU-WEST-1
+mtu: 1450
+zone: none`,
+			},
+		},
+		{
+			name:       "fails if the template refers to a missing field",
+			templating: addonsv1.ClusterResourceSetResourceTemplatingGoTemplate,
+			data:       []string{"zone: {{ .Variables.zone }}"},
+			wantErr:    "failed to render template for ConfigMap resource test-cm",
+		},
+		{
+			name:       "fails if the template is invalid",
+			templating: addonsv1.ClusterResourceSetResourceTemplatingGoTemplate,
+			data:       []string{"name: {{ .Cluster.metadata.name"},
+			wantErr:    "failed to parse template for ConfigMap resource test-cm",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			data := make([][]byte, 0, len(tt.data))
+			for _, d := range tt.data {
+				data = append(data, []byte(d))
+			}
+			resourceRef := addonsv1.ResourceRef{Name: "test-cm", Kind: string(addonsv1.ConfigMapClusterResourceSetResourceKind), Templating: tt.templating}
+
+			got, err := renderResourceTemplate(resourceRef, cluster, data)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(HaveLen(len(tt.want)))
+			for i := range tt.want {
+				g.Expect(string(got[i])).To(Equal(tt.want[i]))
+			}
+		})
+	}
+}
+
+func TestReconcileScopeForTemplatedResource(t *testing.T) {
+	g := NewWithT(t)
+
+	crs := &addonsv1.ClusterResourceSet{
+		Spec: addonsv1.ClusterResourceSetSpec{
+			Strategy: string(addonsv1.ClusterResourceSetStrategyReconcile),
+		},
+	}
+	resourceRef := addonsv1.ResourceRef{
+		Name:       "cloud-config",
+		Kind:       string(addonsv1.ConfigMapClusterResourceSetResourceKind),
+		Templating: addonsv1.ClusterResourceSetResourceTemplatingGoTemplate,
+	}
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "cloud-config",
+				"namespace": metav1.NamespaceDefault,
+			},
+			"data": map[string]interface{}{
+				"cm": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cloud-config
+  namespace: kube-system
+data:
+  cluster-name: {{ .Cluster.metadata.name }}`,
+			},
+		},
+	}
+
+	scopes := []resourceReconcileScope{}
+	for _, name := range []string{"cluster-1", "cluster-2"} {
+		cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault}}
+		scope, err := reconcileScopeForResource(crs, cluster, resourceRef, &addonsv1.ResourceSetBinding{}, resource)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(scope).To(BeAssignableToTypeOf(&reconcileStrategyScope{}))
+		objs := scope.(*reconcileStrategyScope).objs()
+		g.Expect(objs).To(HaveLen(1))
+		g.Expect(objs[0].Object["data"]).To(HaveKeyWithValue("cluster-name", name))
+		scopes = append(scopes, scope)
+	}
+
+	// The hash is computed from the rendered content, so changes to the Cluster fields used in templates are detected.
+	g.Expect(scopes[0].hash()).ToNot(Equal(scopes[1].hash()))
+}
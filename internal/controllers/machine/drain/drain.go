@@ -19,6 +19,7 @@ package drain
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"math"
@@ -32,6 +33,7 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -40,9 +42,20 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/internal/webhooks"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	clog "sigs.k8s.io/cluster-api/util/log"
 )
 
+const (
+	// evictAfterDelayStage is the stage at which a Pod with EvictAfter set is waiting for the delay
+	// to expire, because all the other Pods with the same order have been removed from the Node.
+	evictAfterDelayStage = "EvictAfterDelay"
+
+	// evictionBlockedStage is the stage at which the eviction of a Pod with ForceDelete set
+	// is blocked by a PodDisruptionBudget.
+	evictionBlockedStage = "EvictionBlocked"
+)
+
 // Helper contains the parameters to control the behaviour of the drain helper.
 type Helper struct {
 	// Client is the client for the management cluster.
@@ -61,6 +74,41 @@ type Helper struct {
 	// DeletionTimeStamp > N seconds. This can be used e.g. when a Node is unreachable
 	// and the Pods won't drain because of that.
 	SkipWaitForDeleteTimeoutSeconds int
+
+	// PodStages records since when the eviction of Pods with EvictAfter or ForceDelete set has been delayed or blocked.
+	// PodStages is updated by EvictPods, and it has to be persisted by the caller so the delay of EvictAfter and
+	// the timeout of ForceDelete are preserved across reconciles and restarts of the controller.
+	PodStages PodStages
+}
+
+// PodStages records since when the drain of Pods is at a given stage, indexed by stage and Pod UID.
+type PodStages map[string]map[types.UID]metav1.Time
+
+// PodStagesFromMachine gets the PodStages stored on a Machine.
+func PodStagesFromMachine(machine *clusterv1.Machine) (PodStages, error) {
+	podStages := PodStages{}
+	value, ok := machine.Annotations[clusterv1.MachineDrainPodStagesAnnotation]
+	if !ok {
+		return podStages, nil
+	}
+	if err := json.Unmarshal([]byte(value), &podStages); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal value %s for %s annotation", value, clusterv1.MachineDrainPodStagesAnnotation)
+	}
+	return podStages, nil
+}
+
+// SetPodStages stores PodStages on a Machine; the annotation is removed if there are no Pods at any stage.
+func SetPodStages(machine *clusterv1.Machine, podStages PodStages) error {
+	if len(podStages) == 0 {
+		delete(machine.Annotations, clusterv1.MachineDrainPodStagesAnnotation)
+		return nil
+	}
+	b, err := json.Marshal(podStages)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal value for %s annotation", clusterv1.MachineDrainPodStagesAnnotation)
+	}
+	annotations.AddAnnotations(machine, map[string]string{clusterv1.MachineDrainPodStagesAnnotation: string(b)})
+	return nil
 }
 
 // CordonNode cordons a Node.
@@ -279,8 +327,17 @@ func (d *Helper) EvictPods(ctx context.Context, podDeleteList *PodDeleteList) Ev
 	// deletionTimestamps that have a lower order.
 	minDrainOrder := minDrainOrderOfPodsToDrain(podDeleteList.items)
 
+	// Pods with EvictAfter set are only evicted after all the other Pods with the minimum order have been
+	// removed from the Node and then the EvictAfter delay expired.
+	otherPodsWithMinDrainOrder := hasPodsToDrainWithoutEvictAfter(podDeleteList.items, minDrainOrder)
+	now := time.Now()
+
+	// Drop stages of Pods that are not on the Node anymore.
+	d.prunePodStages(podDeleteList.items)
+
 	var podsToTriggerEvictionNow []PodDelete
 	var podsToTriggerEvictionLater []PodDelete
+	var podsEvictionDelayed []PodDelete
 	var podsWithDeletionTimestamp []PodDelete
 	var podsToBeIgnored []PodDelete
 	var podsToWaitCompletedNow []PodDelete
@@ -288,10 +345,16 @@ func (d *Helper) EvictPods(ctx context.Context, podDeleteList *PodDeleteList) Ev
 	for _, pod := range podDeleteList.items {
 		switch {
 		case pod.Status.DrainBehavior == clusterv1.MachineDrainRuleDrainBehaviorDrain && pod.Pod.DeletionTimestamp.IsZero():
-			if ptr.Deref(pod.Status.DrainOrder, 0) == minDrainOrder {
-				podsToTriggerEvictionNow = append(podsToTriggerEvictionNow, pod)
-			} else {
+			switch {
+			case ptr.Deref(pod.Status.DrainOrder, 0) != minDrainOrder:
+				podsToTriggerEvictionLater = append(podsToTriggerEvictionLater, pod)
+			case pod.Status.EvictAfter > 0 && otherPodsWithMinDrainOrder:
+				// Note: The delay only starts after all the other Pods have been removed from the Node.
 				podsToTriggerEvictionLater = append(podsToTriggerEvictionLater, pod)
+			case pod.Status.EvictAfter > 0 && now.Before(d.stageStartedAt(pod.Pod, evictAfterDelayStage, now).Add(pod.Status.EvictAfter)):
+				podsEvictionDelayed = append(podsEvictionDelayed, pod)
+			default:
+				podsToTriggerEvictionNow = append(podsToTriggerEvictionNow, pod)
 			}
 		case pod.Status.DrainBehavior == clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted:
 			if ptr.Deref(pod.Status.DrainOrder, 0) == minDrainOrder {
@@ -309,6 +372,7 @@ func (d *Helper) EvictPods(ctx context.Context, podDeleteList *PodDeleteList) Ev
 	log.Info("Drain not completed yet, there are still Pods on the Node that have to be drained",
 		"podsToTriggerEvictionNow", podDeleteListToString(podsToTriggerEvictionNow, 5),
 		"podsToTriggerEvictionLater", podDeleteListToString(podsToTriggerEvictionLater, 5),
+		"podsEvictionDelayed", podDeleteListToString(podsEvictionDelayed, 5),
 		"podsWithDeletionTimestamp", podDeleteListToString(podsWithDeletionTimestamp, 5),
 		"podsToWaitCompletedNow", podDeleteListToString(podsToWaitCompletedNow, 5),
 		"podsToWaitCompletedLater", podDeleteListToString(podsToWaitCompletedLater, 5),
@@ -387,6 +451,25 @@ evictionLoop:
 				err = errors.New(errorMessage)
 			}
 
+			if pd.Status.ForceDelete != nil {
+				blockedSince := d.stageStartedAt(pd.Pod, evictionBlockedStage, now)
+				if !now.Before(blockedSince.Add(pd.Status.ForceDelete.Timeout)) {
+					log.Info(fmt.Sprintf("Deleting Pod, because its eviction is blocked by a PodDisruptionBudget since %s", blockedSince.Format(time.RFC3339)), "err", err)
+					deleteErr := d.deletePod(ctx, pd.Pod, pd.Status.ForceDelete.GracePeriodSeconds)
+					switch {
+					case deleteErr == nil:
+						res.PodsDeletionTimestampSet = append(res.PodsDeletionTimestampSet, pd.Pod)
+					case apierrors.IsNotFound(deleteErr):
+						res.PodsNotFound = append(res.PodsNotFound, pd.Pod)
+					default:
+						log.V(4).Info("Error when deleting Pod", "err", deleteErr)
+						msg := fmt.Sprintf("eviction blocked by a PodDisruptionBudget and deletion failed: %v", deleteErr)
+						res.PodsFailedEviction[msg] = append(res.PodsFailedEviction[msg], pd.Pod)
+					}
+					continue evictionLoop
+				}
+			}
+
 			log.V(4).Info("Error when evicting Pod", "err", err)
 			res.PodsFailedEviction[err.Error()] = append(res.PodsFailedEviction[err.Error()], pd.Pod)
 		case apierrors.IsForbidden(err) && apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause):
@@ -405,6 +488,9 @@ evictionLoop:
 	for _, pd := range podsToTriggerEvictionLater {
 		res.PodsToTriggerEvictionLater = append(res.PodsToTriggerEvictionLater, pd.Pod)
 	}
+	for _, pd := range podsEvictionDelayed {
+		res.PodsEvictionDelayed = append(res.PodsEvictionDelayed, pd.Pod)
+	}
 	for _, pd := range podsToWaitCompletedNow {
 		res.PodsToWaitCompletedNow = append(res.PodsToWaitCompletedNow, pd.Pod)
 	}
@@ -430,6 +516,56 @@ func minDrainOrderOfPodsToDrain(pds []PodDelete) int32 {
 	return minOrder
 }
 
+// hasPodsToDrainWithoutEvictAfter returns true if there are Pods with the given order that still have to be drained
+// and that don't have EvictAfter set.
+func hasPodsToDrainWithoutEvictAfter(pds []PodDelete, order int32) bool {
+	for _, pd := range pds {
+		if pd.Status.DrainBehavior != clusterv1.MachineDrainRuleDrainBehaviorDrain &&
+			pd.Status.DrainBehavior != clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted {
+			continue
+		}
+		if ptr.Deref(pd.Status.DrainOrder, 0) == order && pd.Status.EvictAfter == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// prunePodStages removes from PodStages the Pods which are not in the given list.
+func (d *Helper) prunePodStages(pds []PodDelete) {
+	podUIDs := map[types.UID]bool{}
+	for _, pd := range pds {
+		podUIDs[pd.Pod.UID] = true
+	}
+	for stage, pods := range d.PodStages {
+		for podUID := range pods {
+			if !podUIDs[podUID] {
+				delete(pods, podUID)
+			}
+		}
+		if len(pods) == 0 {
+			delete(d.PodStages, stage)
+		}
+	}
+}
+
+// stageStartedAt returns since when the drain of a Pod is at the given stage.
+// If the drain of the Pod wasn't at the given stage before, the stage is recorded in PodStages as started now.
+func (d *Helper) stageStartedAt(pod *corev1.Pod, stage string, now time.Time) time.Time {
+	if since, ok := d.PodStages[stage][pod.UID]; ok {
+		return since.Time
+	}
+	if d.PodStages == nil {
+		d.PodStages = PodStages{}
+	}
+	if d.PodStages[stage] == nil {
+		d.PodStages[stage] = map[types.UID]metav1.Time{}
+	}
+	since := metav1.NewTime(now).Rfc3339Copy()
+	d.PodStages[stage][pod.UID] = since
+	return since.Time
+}
+
 // evictPod evicts the given Pod, or return an error if it couldn't.
 func (d *Helper) evictPod(ctx context.Context, pod *corev1.Pod) error {
 	delOpts := metav1.DeleteOptions{}
@@ -449,11 +585,22 @@ func (d *Helper) evictPod(ctx context.Context, pod *corev1.Pod) error {
 	return d.RemoteClient.SubResource("eviction").Create(ctx, pod, eviction)
 }
 
+// deletePod deletes the given Pod, or return an error if it couldn't.
+// Note: Contrary to evictions, deletions are not blocked by PodDisruptionBudgets.
+func (d *Helper) deletePod(ctx context.Context, pod *corev1.Pod, gracePeriodSeconds *int64) error {
+	var opts []client.DeleteOption
+	if gracePeriodSeconds != nil {
+		opts = append(opts, client.GracePeriodSeconds(*gracePeriodSeconds))
+	}
+	return d.RemoteClient.Delete(ctx, pod, opts...)
+}
+
 // EvictionResult contains the results of an eviction.
 type EvictionResult struct {
 	PodsDeletionTimestampSet   []*corev1.Pod
 	PodsFailedEviction         map[string][]*corev1.Pod
 	PodsToTriggerEvictionLater []*corev1.Pod
+	PodsEvictionDelayed        []*corev1.Pod
 	PodsToWaitCompletedNow     []*corev1.Pod
 	PodsToWaitCompletedLater   []*corev1.Pod
 	PodsNotFound               []*corev1.Pod
@@ -463,7 +610,8 @@ type EvictionResult struct {
 // DrainCompleted returns if a Node is entirely drained, i.e. if all relevant Pods have gone away.
 func (r EvictionResult) DrainCompleted() bool {
 	return len(r.PodsDeletionTimestampSet) == 0 && len(r.PodsFailedEviction) == 0 &&
-		len(r.PodsToTriggerEvictionLater) == 0 && len(r.PodsToWaitCompletedLater) == 0 &&
+		len(r.PodsToTriggerEvictionLater) == 0 && len(r.PodsEvictionDelayed) == 0 &&
+		len(r.PodsToWaitCompletedLater) == 0 &&
 		len(r.PodsToWaitCompletedNow) == 0
 }
 
@@ -530,6 +678,14 @@ func (r EvictionResult) ConditionMessage(nodeDrainStartTime metav1.Time) string
 		conditionMessage = fmt.Sprintf("%s\n* %s %s: waiting for completion",
 			conditionMessage, kind, PodListToString(r.PodsToWaitCompletedNow, 3))
 	}
+	if len(r.PodsEvictionDelayed) > 0 {
+		kind := "Pod"
+		if len(r.PodsEvictionDelayed) > 1 {
+			kind = "Pods"
+		}
+		conditionMessage = fmt.Sprintf("%s\n* %s %s: waiting for evictAfter delay to expire",
+			conditionMessage, kind, PodListToString(r.PodsEvictionDelayed, 3))
+	}
 	if len(r.PodsToTriggerEvictionLater) > 0 {
 		conditionMessage = fmt.Sprintf("%s\nAfter above Pods have been removed from the Node, the following Pods will be evicted: %s",
 			conditionMessage, PodListToString(r.PodsToTriggerEvictionLater, 3))
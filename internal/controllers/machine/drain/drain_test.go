@@ -19,7 +19,10 @@ package drain
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,6 +33,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -1719,6 +1723,326 @@ func TestEvictPods(t *testing.T) {
 	}
 }
 
+func TestEvictPodsWithEvictAfterAndForceDelete(t *testing.T) {
+	pdbViolatedErr := &apierrors.StatusError{
+		ErrStatus: metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusTooManyRequests,
+			Reason:  metav1.StatusReasonTooManyRequests,
+			Message: "Cannot evict pod as it would violate the pod's disruption budget.",
+		},
+	}
+
+	tests := []struct {
+		name               string
+		podDeleteList      *PodDeleteList
+		podStages          PodStages
+		wantEvictionResult EvictionResult
+		wantDeletedPods    map[string]*int64
+		wantPodStages      map[string][]types.UID
+	}{
+		{
+			name: "Don't evict EvictAfter Pods until all the other Pods with the same order are removed",
+			podDeleteList: &PodDeleteList{items: []PodDelete{
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "pod-1-deletionTimestamp-set",
+							UID:               "pod-1",
+							DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-2-evict-after",
+							UID:  "pod-2",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						EvictAfter:    time.Minute,
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+			}},
+			wantEvictionResult: EvictionResult{
+				PodsDeletionTimestampSet: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-1-deletionTimestamp-set",
+						},
+					},
+				},
+				PodsFailedEviction: map[string][]*corev1.Pod{},
+				PodsToTriggerEvictionLater: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-2-evict-after",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Delay eviction of EvictAfter Pods after all the other Pods with the same order are removed",
+			podDeleteList: &PodDeleteList{items: []PodDelete{
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-1-evict-after",
+							UID:  "pod-1",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						EvictAfter:    time.Minute,
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-2-evict-after",
+							UID:  "pod-2",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						EvictAfter:    time.Minute,
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-3-to-trigger-eviction-later",
+							UID:  "pod-3",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](1),
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+			}},
+			podStages: PodStages{
+				evictAfterDelayStage: {
+					"pod-1": metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+					"pod-4": metav1.NewTime(time.Now().Add(-2 * time.Minute)), // Pod not on the Node anymore.
+				},
+			},
+			wantEvictionResult: EvictionResult{
+				PodsDeletionTimestampSet: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-1-evict-after",
+						},
+					},
+				},
+				PodsFailedEviction: map[string][]*corev1.Pod{},
+				PodsToTriggerEvictionLater: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-3-to-trigger-eviction-later",
+						},
+					},
+				},
+				PodsEvictionDelayed: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-2-evict-after",
+						},
+					},
+				},
+			},
+			wantPodStages: map[string][]types.UID{
+				evictAfterDelayStage: {"pod-1", "pod-2"},
+			},
+		},
+		{
+			name: "Delete ForceDelete Pods if eviction is blocked by a PDB for longer than the timeout",
+			podDeleteList: &PodDeleteList{items: []PodDelete{
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-1-force-delete-timeout-not-expired",
+							UID:  "pod-1",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						ForceDelete:   &ForceDeleteConfig{Timeout: 10 * time.Minute},
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-2-force-delete-timeout-expired",
+							UID:  "pod-2",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						ForceDelete:   &ForceDeleteConfig{Timeout: 10 * time.Minute, GracePeriodSeconds: ptr.To[int64](5)},
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-3-force-delete-no-timeout",
+							UID:  "pod-3",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						ForceDelete:   &ForceDeleteConfig{},
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+			}},
+			podStages: PodStages{
+				evictionBlockedStage: {
+					"pod-2": metav1.NewTime(time.Now().Add(-20 * time.Minute)),
+				},
+			},
+			wantEvictionResult: EvictionResult{
+				PodsDeletionTimestampSet: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-2-force-delete-timeout-expired",
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-3-force-delete-no-timeout",
+						},
+					},
+				},
+				PodsFailedEviction: map[string][]*corev1.Pod{
+					"Cannot evict pod as it would violate the pod's disruption budget.": {
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "pod-1-force-delete-timeout-not-expired",
+							},
+						},
+					},
+				},
+			},
+			wantDeletedPods: map[string]*int64{
+				"pod-2-force-delete-timeout-expired": ptr.To[int64](5),
+				"pod-3-force-delete-no-timeout":      nil,
+			},
+			wantPodStages: map[string][]types.UID{
+				evictionBlockedStage: {"pod-1", "pod-2", "pod-3"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			gotDeletedPods := map[string]*int64{}
+			fakeClient := interceptor.NewClient(fake.NewClientBuilder().Build(), interceptor.Funcs{
+				SubResourceCreate: func(_ context.Context, _ client.Client, subResourceName string, obj client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+					g.Expect(subResourceName).To(Equal("eviction"))
+					if strings.Contains(obj.GetName(), "force-delete") {
+						return pdbViolatedErr
+					}
+					return nil // Successful eviction.
+				},
+				Delete: func(_ context.Context, _ client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					deleteOptions := &client.DeleteOptions{}
+					deleteOptions.ApplyOptions(opts)
+					gotDeletedPods[obj.GetName()] = deleteOptions.GracePeriodSeconds
+					return nil
+				},
+			})
+
+			drainer := &Helper{
+				RemoteClient: fakeClient,
+				PodStages:    tt.podStages,
+			}
+
+			gotEvictionResult := drainer.EvictPods(context.Background(), tt.podDeleteList)
+			// Cleanup for easier diff.
+			for i, pod := range gotEvictionResult.PodsDeletionTimestampSet {
+				gotEvictionResult.PodsDeletionTimestampSet[i] = &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: pod.Name,
+					},
+				}
+			}
+			for _, pods := range [][]*corev1.Pod{gotEvictionResult.PodsToTriggerEvictionLater, gotEvictionResult.PodsEvictionDelayed} {
+				for i, pod := range pods {
+					pods[i] = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: pod.Name}}
+				}
+			}
+			for _, pods := range gotEvictionResult.PodsFailedEviction {
+				for i, pod := range pods {
+					pods[i] = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: pod.Name}}
+				}
+			}
+			g.Expect(gotEvictionResult).To(BeComparableTo(tt.wantEvictionResult))
+			if tt.wantDeletedPods == nil {
+				tt.wantDeletedPods = map[string]*int64{}
+			}
+			g.Expect(gotDeletedPods).To(Equal(tt.wantDeletedPods))
+
+			gotPodStages := map[string][]types.UID{}
+			for stage, pods := range drainer.PodStages {
+				gotPodStages[stage] = slices.Sorted(maps.Keys(pods))
+			}
+			if tt.wantPodStages == nil {
+				tt.wantPodStages = map[string][]types.UID{}
+			}
+			g.Expect(gotPodStages).To(Equal(tt.wantPodStages))
+		})
+	}
+}
+
+func TestPodStages(t *testing.T) {
+	g := NewWithT(t)
+
+	since := metav1.NewTime(time.Now().Add(-time.Minute)).Rfc3339Copy()
+	machine := &clusterv1.Machine{}
+
+	podStages, err := PodStagesFromMachine(machine)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(podStages).To(BeEmpty())
+
+	g.Expect(SetPodStages(machine, PodStages{evictionBlockedStage: {"pod-1": since}})).To(Succeed())
+	g.Expect(machine.Annotations).To(HaveKey(clusterv1.MachineDrainPodStagesAnnotation))
+
+	podStages, err = PodStagesFromMachine(machine)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(podStages).To(HaveKey(evictionBlockedStage))
+	g.Expect(podStages[evictionBlockedStage]["pod-1"].Time).To(BeTemporally("==", since.Time))
+
+	g.Expect(SetPodStages(machine, nil)).To(Succeed())
+	g.Expect(machine.Annotations).ToNot(HaveKey(clusterv1.MachineDrainPodStagesAnnotation))
+
+	machine.Annotations = map[string]string{clusterv1.MachineDrainPodStagesAnnotation: "invalid"}
+	_, err = PodStagesFromMachine(machine)
+	g.Expect(err).To(HaveOccurred())
+}
+
 func TestEvictionResult_ConditionMessage(t *testing.T) {
 	g := NewWithT(t)
 
@@ -1781,6 +2105,20 @@ func TestEvictionResult_ConditionMessage(t *testing.T) {
 * Pod pod-5-to-trigger-eviction-pdb-violated-1: cannot evict pod as it would violate the pod's disruption budget. The disruption budget pod-5-pdb needs 20 healthy pods and has 20 currently
 * Pod pod-6-to-trigger-eviction-some-other-error: failed to evict Pod, some other error 1
 After above Pods have been removed from the Node, the following Pods will be evicted: pod-7-eviction-later, pod-8-eviction-later`,
+		},
+		{
+			name: "Compute condition message with delayed evictions correctly",
+			evictionResult: EvictionResult{
+				PodsEvictionDelayed: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-1-evict-after",
+						},
+					},
+				},
+			},
+			wantConditionMessage: `Drain not completed yet (started at 2024-10-09T16:13:59Z):
+* Pod pod-1-evict-after: waiting for evictAfter delay to expire`,
 		},
 		{
 			name: "Compute long condition message correctly",
@@ -113,10 +113,30 @@ type PodDeleteStatus struct {
 	// DrainOrder is only used if DrainBehavior is "Drain".
 	DrainOrder *int32
 
+	// EvictAfter defines how long all the other Pods with the same order must have been removed
+	// from the Node before the Pod is evicted.
+	// EvictAfter is only used if DrainBehavior is "Drain".
+	EvictAfter time.Duration
+
+	// ForceDelete defines if and how the Pod is deleted when its eviction is blocked by a PodDisruptionBudget.
+	// ForceDelete is only used if DrainBehavior is "Drain".
+	ForceDelete *ForceDeleteConfig
+
 	Reason  string
 	Message string
 }
 
+// ForceDeleteConfig defines how a Pod is deleted when its eviction is blocked by a PodDisruptionBudget.
+type ForceDeleteConfig struct {
+	// Timeout is how long the eviction of the Pod can be blocked by a PodDisruptionBudget
+	// before the Pod is deleted.
+	Timeout time.Duration
+
+	// GracePeriodSeconds is the grace period used when deleting the Pod.
+	// If not set, the terminationGracePeriodSeconds of the Pod is used.
+	GracePeriodSeconds *int64
+}
+
 // PodFilter takes a pod and returns a PodDeleteStatus.
 type PodFilter func(context.Context, *corev1.Pod) PodDeleteStatus
 
@@ -317,6 +337,19 @@ func (d *Helper) machineDrainRulesFilter(machineDrainRules []*clusterv1.MachineD
 			switch mdr.Spec.Drain.Behavior {
 			case clusterv1.MachineDrainRuleDrainBehaviorDrain:
 				return MakePodDeleteStatusOkayWithOrder(mdr.Spec.Drain.Order)
+			case clusterv1.MachineDrainRuleDrainBehaviorEvictAfter:
+				status := MakePodDeleteStatusOkayWithOrder(mdr.Spec.Drain.Order)
+				status.EvictAfter = time.Duration(mdr.Spec.Drain.EvictAfter.DelaySeconds) * time.Second
+				return status
+			case clusterv1.MachineDrainRuleDrainBehaviorForceDelete:
+				status := MakePodDeleteStatusOkayWithOrder(mdr.Spec.Drain.Order)
+				status.ForceDelete = &ForceDeleteConfig{
+					Timeout: time.Duration(ptr.Deref(mdr.Spec.Drain.ForceDelete.TimeoutSeconds, 0)) * time.Second,
+				}
+				if mdr.Spec.Drain.ForceDelete.GracePeriodSeconds != nil {
+					status.ForceDelete.GracePeriodSeconds = ptr.To(int64(*mdr.Spec.Drain.ForceDelete.GracePeriodSeconds))
+				}
+				return status
 			case clusterv1.MachineDrainRuleDrainBehaviorSkip:
 				log.V(4).Info(fmt.Sprintf("Skip evicting Pod, because MachineDrainRule %s with behavior %s applies to the Pod", mdr.Name, clusterv1.MachineDrainRuleDrainBehaviorSkip))
 				return MakePodDeleteStatusSkip()
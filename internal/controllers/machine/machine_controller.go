@@ -284,6 +284,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ct
 	reconcileNormal := append(
 		alwaysReconcile,
 		r.reconcileInPlaceUpdate,
+		r.reconcileLifecycleHooks,
 	)
 
 	return doReconcile(ctx, reconcileNormal, s)
@@ -471,6 +472,11 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 	s.deletingReason = clusterv1.MachineDeletingReason
 	s.deletingMessage = "Deletion started"
 
+	// Give Runtime Extensions the chance to block the Machine deletion, e.g. to deregister the Machine from external systems.
+	if result, err := r.reconcileBeforeMachineDeletionHook(ctx, s); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	err := r.isDeleteNodeAllowed(ctx, cluster, m, s.infraMachine)
 	isDeleteNodeAllowed := err == nil
 	if err != nil {
@@ -861,10 +867,18 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 		return ctrl.Result{}, errors.Wrapf(err, "unable to get Node %s", nodeName)
 	}
 
+	podStages, err := drain.PodStagesFromMachine(machine)
+	if err != nil {
+		// Start over if the annotation is invalid.
+		log.Error(err, "Failed to get the drain stages of Pods")
+		podStages = drain.PodStages{}
+	}
+
 	drainer := &drain.Helper{
 		Client:             r.Client,
 		RemoteClient:       remoteClient,
 		GracePeriodSeconds: -1,
+		PodStages:          podStages,
 	}
 
 	if noderefutil.IsNodeUnreachable(node) {
@@ -895,6 +909,26 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 		return ctrl.Result{}, errors.Wrapf(err, "failed to cordon Node %s", node.Name)
 	}
 
+	// Give Runtime Extensions the chance to augment or take over draining of the Node.
+	hookResult, hookMessage, skipNodeDrain, err := r.callBeforeMachineDrainHook(ctx, s)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !hookResult.IsZero() {
+		conditionMessage := fmt.Sprintf("Drain not completed yet (started at %s):\n* Waiting for BeforeMachineDrain hook to complete", machine.Status.Deletion.NodeDrainStartTime.Format(time.RFC3339))
+		if hookMessage != "" {
+			conditionMessage = fmt.Sprintf("%s: %s", conditionMessage, hookMessage)
+		}
+		v1beta1conditions.MarkFalse(machine, clusterv1.DrainingSucceededV1Beta1Condition, clusterv1.DrainingV1Beta1Reason, clusterv1.ConditionSeverityInfo, "%s", conditionMessage)
+		s.deletingReason = clusterv1.MachineDeletingDrainingNodeReason
+		s.deletingMessage = conditionMessage
+		return hookResult, nil
+	}
+	if skipNodeDrain {
+		log.Info("Drain completed, Node has been drained by Runtime Extensions")
+		return ctrl.Result{}, nil
+	}
+
 	podDeleteList, err := drainer.GetPodsForEviction(ctx, cluster, machine, nodeName)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -903,7 +937,7 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 	podsToBeDrained := podDeleteList.Pods()
 	if len(podsToBeDrained) == 0 {
 		log.Info("Drain completed")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, drain.SetPodStages(machine, nil)
 	}
 
 	log.Info("Draining Node")
@@ -912,7 +946,12 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 
 	if evictionResult.DrainCompleted() {
 		log.Info("Drain completed, remaining Pods on the Node have been evicted")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, drain.SetPodStages(machine, nil)
+	}
+
+	// Persist since when the eviction of Pods has been delayed or blocked; the Machine is patched at the end of the reconcile.
+	if err := drain.SetPodStages(machine, drainer.PodStages); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	// Slow down the reconcile frequency, because Node drain is a slow process.
@@ -930,6 +969,7 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 		"podsFailedEviction", drain.PodListToString(podsFailedEviction, 5),
 		"podsWithDeletionTimestamp", drain.PodListToString(evictionResult.PodsDeletionTimestampSet, 5),
 		"podsToTriggerEvictionLater", drain.PodListToString(evictionResult.PodsToTriggerEvictionLater, 5),
+		"podsEvictionDelayed", drain.PodListToString(evictionResult.PodsEvictionDelayed, 5),
 		"podsToWaitCompletedNow", drain.PodListToString(evictionResult.PodsToWaitCompletedNow, 5),
 		"podsToWaitCompletedLater", drain.PodListToString(evictionResult.PodsToWaitCompletedLater, 5),
 	)
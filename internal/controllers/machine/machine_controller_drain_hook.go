@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/cache"
+)
+
+// callBeforeMachineDrainHook calls the BeforeMachineDrain runtime hook for the machine.
+// It returns a non-zero result if the drain should wait for the extensions, and skipNodeDrain
+// if one of the extensions took over draining of the Node.
+// Note: The hook is called on every reconcile while the Node is being drained, so that the decision
+// of the extensions to take over draining of the Node does not have to be persisted.
+func (r *Reconciler) callBeforeMachineDrainHook(ctx context.Context, s *scope) (_ ctrl.Result, message string, skipNodeDrain bool, _ error) {
+	if !feature.Gates.Enabled(feature.RuntimeSDK) || r.RuntimeClient == nil {
+		return ctrl.Result{}, "", false, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+
+	extensions, err := r.RuntimeClient.GetAllExtensions(ctx, runtimehooksv1.BeforeMachineDrain, s.machine)
+	if err != nil {
+		return ctrl.Result{}, "", false, err
+	}
+	if len(extensions) == 0 {
+		return ctrl.Result{}, "", false, nil
+	}
+
+	if cacheEntry, ok := r.hookCache.Has(cache.NewHookEntryKey(s.machine, runtimehooksv1.BeforeMachineDrain)); ok {
+		if requeueAfter, requeue := cacheEntry.ShouldRequeue(time.Now()); requeue {
+			log.V(5).Info(fmt.Sprintf("Skip calling BeforeMachineDrain hook, retry after %s", requeueAfter))
+			return ctrl.Result{RequeueAfter: requeueAfter}, cacheEntry.ResponseMessage, false, nil
+		}
+	}
+
+	request := &runtimehooksv1.BeforeMachineDrainRequest{
+		Cluster:  *cleanupCluster(s.cluster),
+		Machine:  *cleanupMachine(s.machine),
+		NodeName: s.machine.Status.NodeRef.Name,
+	}
+
+	// Call the extensions one by one, so it is possible to know if any of them took over draining of the Node.
+	// Note: The responses are aggregated like with CallAllExtensions, i.e. retryAfterSeconds is the lowest
+	// non-zero value and messages are joined.
+	var retryAfterSeconds int32
+	messages := []string{}
+	for _, extension := range extensions {
+		response := &runtimehooksv1.BeforeMachineDrainResponse{}
+		if err := r.RuntimeClient.CallExtension(ctx, runtimehooksv1.BeforeMachineDrain, s.machine, extension, request, response); err != nil {
+			return ctrl.Result{}, "", false, errors.Wrap(err, "failed to call BeforeMachineDrain hook")
+		}
+		retryAfterSeconds = util.LowestNonZeroInt32(retryAfterSeconds, response.GetRetryAfterSeconds())
+		if response.GetMessage() != "" {
+			messages = append(messages, response.GetMessage())
+		}
+		// Note: If any extension took over draining of the Node, Cluster API must not drain the Node.
+		skipNodeDrain = skipNodeDrain || ptr.Deref(response.SkipNodeDrain, false)
+	}
+	message = strings.Join(messages, ", ")
+
+	if retryAfterSeconds != 0 {
+		log.Info(fmt.Sprintf("BeforeMachineDrain hook requested retry after %d seconds", retryAfterSeconds))
+		requeueAfter := time.Duration(retryAfterSeconds) * time.Second
+		r.hookCache.Add(cache.NewHookEntry(s.machine, runtimehooksv1.BeforeMachineDrain, time.Now().Add(requeueAfter), message))
+		return ctrl.Result{RequeueAfter: requeueAfter}, message, false, nil
+	}
+
+	return ctrl.Result{}, message, skipNodeDrain, nil
+}
+
+func cleanupCluster(cluster *clusterv1.Cluster) *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		// Set GVK because object is later marshalled with json.Marshal when the hook request is sent.
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cluster.Name,
+			Namespace:   cluster.Namespace,
+			Labels:      cluster.Labels,
+			Annotations: cluster.Annotations,
+		},
+		Spec: *cluster.Spec.DeepCopy(),
+	}
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/api/runtime/catalog"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+	"sigs.k8s.io/cluster-api/util/cache"
+)
+
+func TestCallBeforeMachineDrainHook(t *testing.T) {
+	catalog := runtimecatalog.New()
+	if err := runtimehooksv1.AddToCatalog(catalog); err != nil {
+		t.Fatalf("failed to add hooks to catalog: %v", err)
+	}
+	drainGVH, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineDrain)
+	if err != nil {
+		t.Fatalf("failed to determine BeforeMachineDrain hook: %v", err)
+	}
+
+	tests := []struct {
+		name                     string
+		featureEnabled           bool
+		getAllExtensionResponses map[runtimecatalog.GroupVersionHook][]string
+		callExtensionResponses   map[string]runtimehooksv1.ResponseObject
+		wantResult               ctrl.Result
+		wantMessage              string
+		wantSkipNodeDrain        bool
+		wantErr                  bool
+		wantHookCacheEntry       bool
+	}{
+		{
+			name:           "does nothing if the feature gate is disabled",
+			featureEnabled: false,
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				drainGVH: {"ext"},
+			},
+			wantResult: ctrl.Result{},
+		},
+		{
+			name:                     "does nothing if no extensions are registered",
+			featureEnabled:           true,
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{},
+			wantResult:               ctrl.Result{},
+		},
+		{
+			name:           "fails when hook invocation returns error",
+			featureEnabled: true,
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				drainGVH: {"ext"},
+			},
+			callExtensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"ext": &runtimehooksv1.BeforeMachineDrainResponse{
+					CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+						CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:           "returns requeue when hook succeeds with retry",
+			featureEnabled: true,
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				drainGVH: {"ext"},
+			},
+			callExtensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"ext": &runtimehooksv1.BeforeMachineDrainResponse{
+					CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+						CommonResponse: runtimehooksv1.CommonResponse{
+							Status:  runtimehooksv1.ResponseStatusSuccess,
+							Message: "migrating VMs",
+						},
+						RetryAfterSeconds: 30,
+					},
+					SkipNodeDrain: ptr.To(true),
+				},
+			},
+			wantResult:         ctrl.Result{RequeueAfter: 30 * time.Second},
+			wantMessage:        "migrating VMs",
+			wantHookCacheEntry: true,
+		},
+		{
+			name:           "returns skipNodeDrain when hook completes",
+			featureEnabled: true,
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				drainGVH: {"ext"},
+			},
+			callExtensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"ext": &runtimehooksv1.BeforeMachineDrainResponse{
+					CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+						CommonResponse: runtimehooksv1.CommonResponse{
+							Status:  runtimehooksv1.ResponseStatusSuccess,
+							Message: "done",
+						},
+					},
+					SkipNodeDrain: ptr.To(true),
+				},
+			},
+			wantResult:        ctrl.Result{},
+			wantMessage:       "done",
+			wantSkipNodeDrain: true,
+		},
+		{
+			name:           "returns skipNodeDrain when one of the extensions took over draining of the Node",
+			featureEnabled: true,
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				drainGVH: {"ext1", "ext2", "ext3"},
+			},
+			callExtensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"ext1": beforeMachineDrainResponse(0, "", nil),
+				"ext2": beforeMachineDrainResponse(0, "drained by ext2", ptr.To(true)),
+				"ext3": beforeMachineDrainResponse(0, "done", ptr.To(false)),
+			},
+			wantResult:        ctrl.Result{},
+			wantMessage:       "drained by ext2, done",
+			wantSkipNodeDrain: true,
+		},
+		{
+			name:           "returns requeue with the lowest retry when one of the extensions requested retry",
+			featureEnabled: true,
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				drainGVH: {"ext1", "ext2", "ext3"},
+			},
+			callExtensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"ext1": beforeMachineDrainResponse(0, "", ptr.To(true)),
+				"ext2": beforeMachineDrainResponse(60, "waiting for ext2", nil),
+				"ext3": beforeMachineDrainResponse(30, "waiting for ext3", nil),
+			},
+			wantResult:         ctrl.Result{RequeueAfter: 30 * time.Second},
+			wantMessage:        "waiting for ext2, waiting for ext3",
+			wantHookCacheEntry: true,
+		},
+		{
+			name:           "returns without skipNodeDrain when hook completes",
+			featureEnabled: true,
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				drainGVH: {"ext"},
+			},
+			callExtensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"ext": &runtimehooksv1.BeforeMachineDrainResponse{
+					CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+						CommonResponse: runtimehooksv1.CommonResponse{
+							Status: runtimehooksv1.ResponseStatusSuccess,
+						},
+					},
+				},
+			},
+			wantResult: ctrl.Result{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, tt.featureEnabled)
+
+			runtimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCatalog(catalog).
+				WithGetAllExtensionResponses(tt.getAllExtensionResponses).
+				WithCallExtensionResponses(tt.callExtensionResponses).
+				WithCallExtensionValidations(func(_ string, req runtimehooksv1.RequestObject) error {
+					request := req.(*runtimehooksv1.BeforeMachineDrainRequest)
+					g.Expect(request.Cluster.Name).To(Equal("cluster"))
+					g.Expect(request.Machine.Name).To(Equal("machine"))
+					g.Expect(request.NodeName).To(Equal("node"))
+					return nil
+				}).
+				Build()
+
+			r := &Reconciler{
+				RuntimeClient: runtimeClient,
+				hookCache:     cache.New[cache.HookEntry](ctx, cache.HookCacheDefaultTTL),
+			}
+			machine := newTestMachine()
+			machine.Status.NodeRef = clusterv1.MachineNodeReference{Name: "node"}
+			s := &scope{
+				cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cluster",
+						Namespace: "default",
+					},
+				},
+				machine: machine,
+			}
+
+			result, message, skipNodeDrain, err := r.callBeforeMachineDrainHook(t.Context(), s)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result).To(Equal(tt.wantResult))
+			g.Expect(message).To(Equal(tt.wantMessage))
+			g.Expect(skipNodeDrain).To(Equal(tt.wantSkipNodeDrain))
+
+			if !tt.wantHookCacheEntry {
+				g.Expect(r.hookCache.Len()).To(Equal(0))
+				return
+			}
+
+			// Call callBeforeMachineDrainHook again and verify the cache hit.
+			secondResult, secondMessage, secondSkipNodeDrain, err := r.callBeforeMachineDrainHook(t.Context(), s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(secondMessage).To(Equal(tt.wantMessage))
+			g.Expect(secondSkipNodeDrain).To(BeFalse())
+			g.Expect(secondResult.RequeueAfter).To(BeNumerically("<", result.RequeueAfter))
+			g.Expect(runtimeClient.CallCount(runtimehooksv1.BeforeMachineDrain)).To(Equal(len(tt.callExtensionResponses)))
+		})
+	}
+}
+
+func beforeMachineDrainResponse(retryAfterSeconds int32, message string, skipNodeDrain *bool) *runtimehooksv1.BeforeMachineDrainResponse {
+	return &runtimehooksv1.BeforeMachineDrainResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			CommonResponse: runtimehooksv1.CommonResponse{
+				Status:  runtimehooksv1.ResponseStatusSuccess,
+				Message: message,
+			},
+			RetryAfterSeconds: retryAfterSeconds,
+		},
+		SkipNodeDrain: skipNodeDrain,
+	}
+}
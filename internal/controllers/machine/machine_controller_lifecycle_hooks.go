@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/api/runtime/catalog"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/hooks"
+	"sigs.k8s.io/cluster-api/util/cache"
+)
+
+// reconcileLifecycleHooks calls the AfterMachineProvisioned and AfterNodeReady hooks.
+// The intent to call those hooks is tracked in the PendingHooksAnnotation as soon as the Machine is created, so
+// hooks are called exactly once even if the controller restarts, and they are not called for pre-existing Machines.
+func (r *Reconciler) reconcileLifecycleHooks(ctx context.Context, s *scope) (ctrl.Result, error) {
+	if !feature.Gates.Enabled(feature.RuntimeSDK) || r.RuntimeClient == nil {
+		return ctrl.Result{}, nil
+	}
+
+	// Track the intent to call hooks for Machines not yet provisioned or without a Node.
+	// Note: The intent is tracked only if there are extensions registered for the hook, so Machines
+	// are not annotated when the hooks are not used.
+	pendingHooks := []runtimecatalog.Hook{}
+	if !ptr.Deref(s.machine.Status.Initialization.InfrastructureProvisioned, false) {
+		pendingHooks = append(pendingHooks, runtimehooksv1.AfterMachineProvisioned)
+	}
+	if !s.machine.Status.NodeRef.IsDefined() {
+		pendingHooks = append(pendingHooks, runtimehooksv1.AfterNodeReady)
+	}
+	hooksToMark := []runtimecatalog.Hook{}
+	for _, hook := range pendingHooks {
+		if hooks.IsPending(hook, s.machine) {
+			continue
+		}
+		extensions, err := r.RuntimeClient.GetAllExtensions(ctx, hook, s.machine)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(extensions) > 0 {
+			hooksToMark = append(hooksToMark, hook)
+		}
+	}
+	if len(hooksToMark) > 0 {
+		// Note: This call will not update the resourceVersion on machine, so that the patchHelper in the main
+		// Reconcile func won't get a conflict.
+		if err := hooks.MarkAsPending(ctx, r.Client, s.machine, false, hooksToMark...); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if hooks.IsPending(runtimehooksv1.AfterMachineProvisioned, s.machine) && ptr.Deref(s.machine.Status.Initialization.InfrastructureProvisioned, false) {
+		request := &runtimehooksv1.AfterMachineProvisionedRequest{
+			Cluster: *cleanupCluster(s.cluster),
+			Machine: *cleanupMachineWithStatus(s.machine),
+		}
+		response := &runtimehooksv1.AfterMachineProvisionedResponse{}
+		if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.AfterMachineProvisioned, s.machine, request, response); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to call AfterMachineProvisioned hook")
+		}
+		if err := hooks.MarkAsDone(ctx, r.Client, s.machine, false, runtimehooksv1.AfterMachineProvisioned); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if hooks.IsPending(runtimehooksv1.AfterNodeReady, s.machine) && s.node != nil && noderefutil.IsNodeReady(s.node) {
+		request := &runtimehooksv1.AfterNodeReadyRequest{
+			Cluster:  *cleanupCluster(s.cluster),
+			Machine:  *cleanupMachineWithStatus(s.machine),
+			NodeName: s.node.Name,
+		}
+		response := &runtimehooksv1.AfterNodeReadyResponse{}
+		if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.AfterNodeReady, s.machine, request, response); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to call AfterNodeReady hook")
+		}
+		if err := hooks.MarkAsDone(ctx, r.Client, s.machine, false, runtimehooksv1.AfterNodeReady); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileBeforeMachineDeletionHook calls the BeforeMachineDeletion hook and blocks the Machine deletion until
+// all the extensions allow the deletion to proceed; after this happens, the Machine is marked with the OkToDeleteAnnotation
+// so the hook is not called anymore.
+func (r *Reconciler) reconcileBeforeMachineDeletionHook(ctx context.Context, s *scope) (ctrl.Result, error) {
+	if !feature.Gates.Enabled(feature.RuntimeSDK) || r.RuntimeClient == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if hooks.IsOkToDelete(s.machine) {
+		return ctrl.Result{}, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+
+	// Return quickly if the hook is not defined.
+	extensions, err := r.RuntimeClient.GetAllExtensions(ctx, runtimehooksv1.BeforeMachineDeletion, s.machine)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(extensions) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	if cacheEntry, ok := r.hookCache.Has(cache.NewHookEntryKey(s.machine, runtimehooksv1.BeforeMachineDeletion)); ok {
+		if requeueAfter, requeue := cacheEntry.ShouldRequeue(time.Now()); requeue {
+			log.V(5).Info(fmt.Sprintf("Skip calling BeforeMachineDeletion hook, retry after %s", requeueAfter))
+			s.deletingReason = clusterv1.MachineDeletingWaitingForBeforeMachineDeletionHookReason
+			s.deletingMessage = beforeMachineDeletionHookMessage(cacheEntry.ResponseMessage)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+
+	request := &runtimehooksv1.BeforeMachineDeletionRequest{
+		Cluster: *cleanupCluster(s.cluster),
+		Machine: *cleanupMachineWithStatus(s.machine),
+	}
+	response := &runtimehooksv1.BeforeMachineDeletionResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.BeforeMachineDeletion, s.machine, request, response); err != nil {
+		s.deletingReason = clusterv1.MachineDeletingWaitingForBeforeMachineDeletionHookReason
+		s.deletingMessage = "BeforeMachineDeletion hook failed, please check controller logs for errors"
+		return ctrl.Result{}, errors.Wrap(err, "failed to call BeforeMachineDeletion hook")
+	}
+
+	if response.GetRetryAfterSeconds() != 0 {
+		log.Info(fmt.Sprintf("Machine deletion is blocked by BeforeMachineDeletion hook, retry after %ds", response.GetRetryAfterSeconds()))
+		requeueAfter := time.Duration(response.GetRetryAfterSeconds()) * time.Second
+		r.hookCache.Add(cache.NewHookEntry(s.machine, runtimehooksv1.BeforeMachineDeletion, time.Now().Add(requeueAfter), response.GetMessage()))
+		s.deletingReason = clusterv1.MachineDeletingWaitingForBeforeMachineDeletionHookReason
+		s.deletingMessage = beforeMachineDeletionHookMessage(response.GetMessage())
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// Note: This call will not update the resourceVersion on machine, so that the patchHelper in the main
+	// Reconcile func won't get a conflict.
+	if err := hooks.MarkAsOkToDelete(ctx, r.Client, s.machine, false); err != nil {
+		return ctrl.Result{}, err
+	}
+	log.Info("Machine deletion is unblocked by BeforeMachineDeletion hook")
+	return ctrl.Result{}, nil
+}
+
+func beforeMachineDeletionHookMessage(responseMessage string) string {
+	if responseMessage == "" {
+		return "Waiting for BeforeMachineDeletion hook to succeed"
+	}
+	return fmt.Sprintf("Waiting for BeforeMachineDeletion hook to succeed: %s", responseMessage)
+}
+
+// cleanupMachineWithStatus returns a copy of the Machine to be used in hook requests which
+// also require the status of the Machine.
+func cleanupMachineWithStatus(machine *clusterv1.Machine) *clusterv1.Machine {
+	cleanedUpMachine := cleanupMachine(machine)
+	cleanedUpMachine.Status = *machine.Status.DeepCopy()
+	return cleanedUpMachine
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/api/runtime/catalog"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/hooks"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+	"sigs.k8s.io/cluster-api/util/cache"
+)
+
+func TestReconcileLifecycleHooks(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+	catalog := runtimecatalog.New()
+	if err := runtimehooksv1.AddToCatalog(catalog); err != nil {
+		t.Fatalf("failed to add hooks to catalog: %v", err)
+	}
+	provisionedGVH, err := catalog.GroupVersionHook(runtimehooksv1.AfterMachineProvisioned)
+	if err != nil {
+		t.Fatalf("failed to determine AfterMachineProvisioned hook: %v", err)
+	}
+	nodeReadyGVH, err := catalog.GroupVersionHook(runtimehooksv1.AfterNodeReady)
+	if err != nil {
+		t.Fatalf("failed to determine AfterNodeReady hook: %v", err)
+	}
+	successResponses := map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+		provisionedGVH: &runtimehooksv1.AfterMachineProvisionedResponse{
+			CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+		},
+		nodeReadyGVH: &runtimehooksv1.AfterNodeReadyResponse{
+			CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+		},
+	}
+	readyNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	tests := []struct {
+		name                     string
+		getAllExtensionResponses map[runtimecatalog.GroupVersionHook][]string
+		machine                  func(m *clusterv1.Machine)
+		node                     *corev1.Node
+		wantPendingHooks         []runtimecatalog.Hook
+		wantCalledHooks          []runtimecatalog.Hook
+	}{
+		{
+			name:                     "does not track hooks if there are no extensions",
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{},
+		},
+		{
+			name: "tracks hooks for a new Machine",
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				provisionedGVH: {"ext"},
+				nodeReadyGVH:   {"ext"},
+			},
+			wantPendingHooks: []runtimecatalog.Hook{runtimehooksv1.AfterMachineProvisioned, runtimehooksv1.AfterNodeReady},
+		},
+		{
+			name: "does not track hooks for a Machine already provisioned with a Node",
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				provisionedGVH: {"ext"},
+				nodeReadyGVH:   {"ext"},
+			},
+			machine: func(m *clusterv1.Machine) {
+				m.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+				m.Status.NodeRef = clusterv1.MachineNodeReference{Name: "node"}
+			},
+			node: readyNode,
+		},
+		{
+			name: "calls AfterMachineProvisioned when the Machine is provisioned",
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				provisionedGVH: {"ext"},
+				nodeReadyGVH:   {"ext"},
+			},
+			machine: func(m *clusterv1.Machine) {
+				hooks.MarkObjectAsPending(m, runtimehooksv1.AfterMachineProvisioned, runtimehooksv1.AfterNodeReady)
+				m.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+			},
+			wantPendingHooks: []runtimecatalog.Hook{runtimehooksv1.AfterNodeReady},
+			wantCalledHooks:  []runtimecatalog.Hook{runtimehooksv1.AfterMachineProvisioned},
+		},
+		{
+			name: "does not call AfterNodeReady while the Node is not ready",
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				nodeReadyGVH: {"ext"},
+			},
+			machine: func(m *clusterv1.Machine) {
+				hooks.MarkObjectAsPending(m, runtimehooksv1.AfterNodeReady)
+				m.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+				m.Status.NodeRef = clusterv1.MachineNodeReference{Name: "node"}
+			},
+			node:             &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}},
+			wantPendingHooks: []runtimecatalog.Hook{runtimehooksv1.AfterNodeReady},
+		},
+		{
+			name: "calls AfterNodeReady when the Node is ready",
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				nodeReadyGVH: {"ext"},
+			},
+			machine: func(m *clusterv1.Machine) {
+				hooks.MarkObjectAsPending(m, runtimehooksv1.AfterNodeReady)
+				m.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+				m.Status.NodeRef = clusterv1.MachineNodeReference{Name: "node"}
+			},
+			node:            readyNode,
+			wantCalledHooks: []runtimecatalog.Hook{runtimehooksv1.AfterNodeReady},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := newTestMachine()
+			if tt.machine != nil {
+				tt.machine(machine)
+			}
+
+			runtimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCatalog(catalog).
+				WithGetAllExtensionResponses(tt.getAllExtensionResponses).
+				WithCallAllExtensionResponses(successResponses).
+				Build()
+
+			c := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(machine).Build()
+			r := &Reconciler{
+				Client:        c,
+				RuntimeClient: runtimeClient,
+			}
+			s := &scope{
+				cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}},
+				machine: machine,
+				node:    tt.node,
+			}
+
+			res, err := r.reconcileLifecycleHooks(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res).To(Equal(ctrl.Result{}))
+
+			// Verify pending hooks are tracked both on the in-memory and on the persisted Machine.
+			gotMachine := &clusterv1.Machine{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(machine), gotMachine)).To(Succeed())
+			for _, m := range []*clusterv1.Machine{s.machine, gotMachine} {
+				for _, hook := range []runtimecatalog.Hook{runtimehooksv1.AfterMachineProvisioned, runtimehooksv1.AfterNodeReady} {
+					g.Expect(hooks.IsPending(hook, m)).To(Equal(containsHook(tt.wantPendingHooks, hook)), "unexpected pending state for hook %s", runtimecatalog.HookName(hook))
+				}
+			}
+			for _, hook := range []runtimecatalog.Hook{runtimehooksv1.AfterMachineProvisioned, runtimehooksv1.AfterNodeReady} {
+				wantCalls := 0
+				if containsHook(tt.wantCalledHooks, hook) {
+					wantCalls = 1
+				}
+				g.Expect(runtimeClient.CallAllCount(hook)).To(Equal(wantCalls), "unexpected number of calls for hook %s", runtimecatalog.HookName(hook))
+			}
+		})
+	}
+}
+
+func TestReconcileBeforeMachineDeletionHook(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+	catalog := runtimecatalog.New()
+	if err := runtimehooksv1.AddToCatalog(catalog); err != nil {
+		t.Fatalf("failed to add hooks to catalog: %v", err)
+	}
+	deletionGVH, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineDeletion)
+	if err != nil {
+		t.Fatalf("failed to determine BeforeMachineDeletion hook: %v", err)
+	}
+
+	tests := []struct {
+		name                      string
+		okToDelete                bool
+		getAllExtensionResponses  map[runtimecatalog.GroupVersionHook][]string
+		callAllExtensionResponses map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject
+		wantResult                ctrl.Result
+		wantErr                   bool
+		wantOkToDelete            bool
+		wantDeletingReason        string
+		wantDeletingMessage       string
+	}{
+		{
+			name:                     "does nothing if there are no extensions",
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{},
+		},
+		{
+			name:       "does nothing if the Machine is already ok to delete",
+			okToDelete: true,
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				deletionGVH: {"ext"},
+			},
+			wantOkToDelete: true,
+		},
+		{
+			name: "blocks deletion when hook requests retry",
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				deletionGVH: {"ext"},
+			},
+			callAllExtensionResponses: map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+				deletionGVH: &runtimehooksv1.BeforeMachineDeletionResponse{
+					CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+						CommonResponse: runtimehooksv1.CommonResponse{
+							Status:  runtimehooksv1.ResponseStatusSuccess,
+							Message: "deregistering from load balancer",
+						},
+						RetryAfterSeconds: 20,
+					},
+				},
+			},
+			wantResult:          ctrl.Result{RequeueAfter: 20 * time.Second},
+			wantDeletingReason:  clusterv1.MachineDeletingWaitingForBeforeMachineDeletionHookReason,
+			wantDeletingMessage: "Waiting for BeforeMachineDeletion hook to succeed: deregistering from load balancer",
+		},
+		{
+			name: "blocks deletion when hook fails",
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				deletionGVH: {"ext"},
+			},
+			callAllExtensionResponses: map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+				deletionGVH: &runtimehooksv1.BeforeMachineDeletionResponse{
+					CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+						CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure},
+					},
+				},
+			},
+			wantErr:             true,
+			wantDeletingReason:  clusterv1.MachineDeletingWaitingForBeforeMachineDeletionHookReason,
+			wantDeletingMessage: "BeforeMachineDeletion hook failed, please check controller logs for errors",
+		},
+		{
+			name: "marks the Machine as ok to delete when hook allows deletion",
+			getAllExtensionResponses: map[runtimecatalog.GroupVersionHook][]string{
+				deletionGVH: {"ext"},
+			},
+			callAllExtensionResponses: map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+				deletionGVH: &runtimehooksv1.BeforeMachineDeletionResponse{
+					CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+						CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+					},
+				},
+			},
+			wantOkToDelete: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := newTestMachine()
+			if tt.okToDelete {
+				machine.Annotations[runtimev1.OkToDeleteAnnotation] = ""
+			}
+
+			runtimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCatalog(catalog).
+				WithGetAllExtensionResponses(tt.getAllExtensionResponses).
+				WithCallAllExtensionResponses(tt.callAllExtensionResponses).
+				Build()
+
+			c := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(machine).Build()
+			r := &Reconciler{
+				Client:        c,
+				RuntimeClient: runtimeClient,
+				hookCache:     cache.New[cache.HookEntry](ctx, cache.HookCacheDefaultTTL),
+			}
+			s := &scope{
+				cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}},
+				machine: machine,
+			}
+
+			res, err := r.reconcileBeforeMachineDeletionHook(ctx, s)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(res).To(Equal(tt.wantResult))
+			g.Expect(s.deletingReason).To(Equal(tt.wantDeletingReason))
+			g.Expect(s.deletingMessage).To(Equal(tt.wantDeletingMessage))
+
+			gotMachine := &clusterv1.Machine{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(machine), gotMachine)).To(Succeed())
+			g.Expect(hooks.IsOkToDelete(gotMachine)).To(Equal(tt.wantOkToDelete))
+
+			if !tt.wantResult.IsZero() {
+				// Call the hook again and verify the response is served from the cache.
+				secondRes, err := r.reconcileBeforeMachineDeletionHook(ctx, s)
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(secondRes.RequeueAfter).To(BeNumerically("<=", tt.wantResult.RequeueAfter))
+				g.Expect(runtimeClient.CallAllCount(runtimehooksv1.BeforeMachineDeletion)).To(Equal(1))
+				g.Expect(s.deletingMessage).To(Equal(tt.wantDeletingMessage))
+			}
+		})
+	}
+}
+
+func containsHook(hookList []runtimecatalog.Hook, hook runtimecatalog.Hook) bool {
+	for _, h := range hookList {
+		if runtimecatalog.HookName(h) == runtimecatalog.HookName(hook) {
+			return true
+		}
+	}
+	return false
+}
@@ -58,6 +58,10 @@ type rolloutPlanner struct {
 	scaleIntents map[string]int32
 	notes        map[string][]string
 
+	stagedRolloutReplicasLimit *int32
+	stagedRolloutMessage       string
+	stagedRolloutRequeueAfter  time.Duration
+
 	overrideComputeDesiredMS              func(ctx context.Context, deployment *clusterv1.MachineDeployment, currentMS *clusterv1.MachineSet) (*clusterv1.MachineSet, error)
 	overrideCanUpdateMachineSetInPlace    func(ctx context.Context, oldMS, newMS *clusterv1.MachineSet) (bool, error)
 	overrideCanExtensionsUpdateMachineSet func(ctx context.Context, oldMS, newMS *clusterv1.MachineSet, templateObjects *templateObjects, extensionHandlers []string) (bool, []string, error)
@@ -198,6 +198,12 @@ func (p *rolloutPlanner) reconcileNewMachineSet(ctx context.Context) error {
 		return err
 	}
 
+	// When using the Staged strategy, do not scale up beyond the replicas of the current step.
+	if p.stagedRolloutReplicasLimit != nil && newReplicasCount > *p.stagedRolloutReplicasLimit {
+		newReplicasCount = max(*p.stagedRolloutReplicasLimit, *(p.newMS.Spec.Replicas))
+		note = fmt.Sprintf("%s, limited to %d replicas by the current step of the staged rollout", note, newReplicasCount)
+	}
+
 	if newReplicasCount < *(p.newMS.Spec.Replicas) {
 		scaleDownCount := *(p.newMS.Spec.Replicas) - newReplicasCount
 		p.addNotef(p.newMS, "%s", note)
@@ -259,6 +265,12 @@ func (p *rolloutPlanner) reconcileOldMachineSetsRollingUpdate(ctx context.Contex
 	// will make additional checks to ensure scale down actually happens without breaching MaxUnavailable, and
 	// if necessary, it will reduce the extent of the scale down accordingly.
 	totalScaleDownCount := max(totalSpecReplicas-totalPendingScaleDown-minAvailable-newMSUnavailableMachineCount, 0)
+
+	// When using the Staged strategy, do not scale down oldMSs below the replicas which are not yet replaced
+	// according to the current step, so the capacity of the MachineDeployment is preserved while the rollout is paused.
+	if p.stagedRolloutReplicasLimit != nil {
+		totalScaleDownCount = min(totalScaleDownCount, max(mdutil.GetReplicaCountForMachineSets(p.oldMSs)-(ptr.Deref(p.md.Spec.Replicas, 0)-*p.stagedRolloutReplicasLimit), 0))
+	}
 	if totalScaleDownCount <= 0 {
 		return nil
 	}
@@ -575,6 +587,11 @@ func (p *rolloutPlanner) reconcileDeadlockBreaker(ctx context.Context) {
 	log := ctrl.LoggerFrom(ctx)
 	allMSs := append(p.oldMSs, p.newMS)
 
+	// if a Staged rollout is paused at the current step, no deadlock.
+	if p.stagedRolloutReplicasLimit != nil && ptr.Deref(p.newMS.Spec.Replicas, 0) >= *p.stagedRolloutReplicasLimit {
+		return
+	}
+
 	// if there are no replicas on the old MS, rollout is completed, no deadlock (actually no rollout in progress).
 	if ptr.Deref(mdutil.GetActualReplicaCountForMachineSets(p.oldMSs), 0) == 0 {
 		return
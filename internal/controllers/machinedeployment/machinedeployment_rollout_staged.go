@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// rolloutStaged reconcile machine sets controlled by a MachineDeployment that is using the Staged strategy.
+func (r *Reconciler) rolloutStaged(ctx context.Context, s *scope, templateExists bool) error {
+	md := s.machineDeployment
+
+	planner := newRolloutPlanner(r.Client, r.RuntimeClient, r.canUpdateMachineSetCache)
+	if err := planner.init(ctx, md, s.machineSets, s.machines.UnsortedList(), true, templateExists); err != nil {
+		return err
+	}
+
+	if err := planner.planStaged(ctx); err != nil {
+		return err
+	}
+
+	if err := r.createOrUpdateMachineSetsAndSyncMachineDeploymentRevision(ctx, planner); err != nil {
+		return err
+	}
+
+	// Surface the step the rollout is waiting for, if any, and make sure the MachineDeployment is reconciled
+	// again when the step's gate has to be checked again.
+	s.stagedRolloutMessage = planner.stagedRolloutMessage
+	s.requeueAfter = planner.stagedRolloutRequeueAfter
+
+	newMS := planner.newMS
+	oldMSs := planner.oldMSs
+	allMSs := append(oldMSs, newMS)
+
+	if err := r.syncDeploymentStatus(allMSs, newMS, md); err != nil {
+		return err
+	}
+
+	if mdutil.DeploymentComplete(md, &md.Status) {
+		if err := r.cleanupDeployment(ctx, oldMSs, md); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// planStaged determine how to proceed with the rollout when using the Staged strategy if the system is not yet at the desired state.
+// Note: A Staged rollout is a RollingUpdate rollout where the number of replicas of the newMS is limited by the current step.
+func (p *rolloutPlanner) planStaged(ctx context.Context) error {
+	if err := p.reconcileStagedRolloutStep(ctx); err != nil {
+		return err
+	}
+	return p.planRollingUpdate(ctx)
+}
+
+// reconcileStagedRolloutStep determines the current step of a Staged rollout, and limits the number of replicas
+// of the newMS to the replicas of this step until all the step's replicas are available and the step's gate passes.
+// Note: The current step is computed at every reconcile from the replicas of the newMS, so there is no need to
+// persist the state of the rollout.
+func (p *rolloutPlanner) reconcileStagedRolloutStep(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Steps apply only when replacing Machines, i.e. if there are still replicas on oldMSs.
+	if mdutil.GetReplicaCountForMachineSets(p.oldMSs) == 0 {
+		return nil
+	}
+
+	mdReplicas := ptr.Deref(p.md.Spec.Replicas, 0)
+	if mdReplicas == 0 {
+		return nil
+	}
+
+	newMSReplicas := ptr.Deref(p.newMS.Spec.Replicas, 0)
+	steps := p.md.Spec.Rollout.Strategy.Staged.Steps
+	for i, step := range steps {
+		stepReplicas, err := intstrutil.GetScaledValueFromIntOrPercent(step.Replicas, int(mdReplicas), true)
+		if err != nil {
+			return errors.Wrapf(err, "failed to compute replicas for step %d of the staged rollout", i+1)
+		}
+		stepReplicasLimit := min(int32(stepReplicas), mdReplicas)
+
+		// If the newMS is already beyond this step, move to the next step.
+		if newMSReplicas > stepReplicasLimit {
+			continue
+		}
+
+		p.stagedRolloutReplicasLimit = ptr.To(stepReplicasLimit)
+
+		// If the newMS has not yet reached the replicas of this step, scale it up to the step's replicas.
+		if newMSReplicas < stepReplicasLimit {
+			p.addNotef(p.newMS, "staged rollout step %d/%d: scale up to %d replicas", i+1, len(steps), stepReplicasLimit)
+			return nil
+		}
+
+		// If not all the step's replicas are available, wait.
+		if availableReplicas := ptr.Deref(p.newMS.Status.AvailableReplicas, 0); availableReplicas < stepReplicasLimit {
+			p.stagedRolloutMessage = fmt.Sprintf("Waiting for step %d/%d: %d of %d replicas available", i+1, len(steps), availableReplicas, stepReplicasLimit)
+			return nil
+		}
+
+		// If the step's gate does not pass yet, wait.
+		passed, message, requeueAfter, err := p.checkStagedRolloutStepGate(ctx, int32(i+1), step.Gate)
+		if err != nil {
+			return err
+		}
+		if !passed {
+			p.stagedRolloutMessage = fmt.Sprintf("Waiting for step %d/%d: %s", i+1, len(steps), message)
+			p.stagedRolloutRequeueAfter = requeueAfter
+			log.V(5).Info(fmt.Sprintf("Staged rollout paused at step %d/%d: %s", i+1, len(steps), message), "MachineSet", klog.KObj(p.newMS))
+			return nil
+		}
+
+		// The step is completed, move to the next step.
+		p.stagedRolloutReplicasLimit = nil
+	}
+
+	// All the steps are completed, the rollout continues like a RollingUpdate rollout.
+	return nil
+}
+
+// checkStagedRolloutStepGate returns true if the gate of a step passes; if not, a message with the reason why
+// and the time after which the gate should be checked again, if any, are returned.
+func (p *rolloutPlanner) checkStagedRolloutStepGate(ctx context.Context, step int32, gate clusterv1.MachineDeploymentRolloutStepGate) (bool, string, time.Duration, error) {
+	// Note: The step's replicas are considered available since the last of them became available.
+	stepCompletedTime := p.newMSLastAvailableTime()
+
+	if gate.SoakSeconds != nil {
+		soakDuration := time.Duration(*gate.SoakSeconds) * time.Second
+		if elapsed := time.Since(stepCompletedTime); elapsed < soakDuration {
+			remaining := soakDuration - elapsed
+			return false, fmt.Sprintf("soak time not yet elapsed, %s left", remaining.Truncate(time.Second)), remaining, nil
+		}
+	}
+
+	if gate.ConditionType != "" {
+		condition := conditions.Get(p.md, gate.ConditionType)
+		if condition == nil || condition.Status != metav1.ConditionTrue || condition.LastTransitionTime.Time.Before(stepCompletedTime) {
+			return false, fmt.Sprintf("condition %s is not yet True", gate.ConditionType), 0, nil
+		}
+	}
+
+	if gate.ExtensionName != "" {
+		if !feature.Gates.Enabled(feature.RuntimeSDK) || p.RuntimeClient == nil {
+			return false, "", 0, errors.Errorf("failed to call extension %s: RuntimeSDK feature gate must be enabled", gate.ExtensionName)
+		}
+
+		req := &runtimehooksv1.CanMachineDeploymentRolloutProceedRequest{
+			MachineDeployment: *p.md.DeepCopy(),
+			MachineSet:        *p.newMS.DeepCopy(),
+			Step:              step,
+		}
+		resp := &runtimehooksv1.CanMachineDeploymentRolloutProceedResponse{}
+		if err := p.RuntimeClient.CallExtension(ctx, runtimehooksv1.CanMachineDeploymentRolloutProceed, p.md, gate.ExtensionName, req, resp); err != nil {
+			return false, "", 0, err
+		}
+		if resp.RetryAfterSeconds > 0 {
+			message := fmt.Sprintf("extension %s does not allow the rollout to proceed", gate.ExtensionName)
+			if resp.Message != "" {
+				message += fmt.Sprintf(": %s", resp.Message)
+			}
+			return false, message, time.Duration(resp.RetryAfterSeconds) * time.Second, nil
+		}
+	}
+
+	return true, "", 0, nil
+}
+
+// newMSLastAvailableTime returns the time the last Machine of the newMS became available.
+func (p *rolloutPlanner) newMSLastAvailableTime() time.Time {
+	var lastAvailableTime time.Time
+	for _, m := range p.machines {
+		if !util.IsControlledBy(m, p.newMS, clusterv1.GroupVersion.WithKind("MachineSet").GroupKind()) {
+			continue
+		}
+		availableCondition := conditions.Get(m, clusterv1.MachineAvailableCondition)
+		if availableCondition == nil || availableCondition.Status != metav1.ConditionTrue {
+			continue
+		}
+		if availableCondition.LastTransitionTime.Time.After(lastAvailableTime) {
+			lastAvailableTime = availableCondition.LastTransitionTime.Time
+		}
+	}
+	return lastAvailableTime
+}
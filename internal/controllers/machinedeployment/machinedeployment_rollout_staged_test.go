@@ -0,0 +1,302 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/api/runtime/catalog"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+)
+
+func TestReconcileStagedRolloutStep(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	now := time.Now()
+	defaultSteps := []clusterv1.MachineDeploymentRolloutStep{
+		{Replicas: ptr.To(intstr.FromInt32(1)), Gate: clusterv1.MachineDeploymentRolloutStepGate{SoakSeconds: ptr.To[int32](600)}},
+		{Replicas: ptr.To(intstr.FromString("50%")), Gate: clusterv1.MachineDeploymentRolloutStepGate{ConditionType: "AnalysisPassed"}},
+	}
+
+	tests := []struct {
+		name                      string
+		steps                     []clusterv1.MachineDeploymentRolloutStep
+		mdConditions              []metav1.Condition
+		newMS                     *clusterv1.MachineSet
+		oldMS                     *clusterv1.MachineSet
+		machines                  []*clusterv1.Machine
+		extensionResponse         *runtimehooksv1.CanMachineDeploymentRolloutProceedResponse
+		expectedReplicasLimit     *int32
+		expectedMessage           string
+		expectedRequeueAfterAbove time.Duration
+	}{
+		{
+			name:  "no limit if there are no replicas on old MachineSets",
+			newMS: createMS("ms2", "v2", 4),
+			oldMS: createMS("ms1", "v1", 0),
+		},
+		{
+			name:                  "scale up to the replicas of the first step",
+			newMS:                 createMS("ms2", "v2", 0),
+			oldMS:                 createMS("ms1", "v1", 4),
+			expectedReplicasLimit: ptr.To[int32](1),
+		},
+		{
+			name:                  "wait for the replicas of the first step to become available",
+			newMS:                 createMS("ms2", "v2", 1, withStatusAvailableReplicas(0)),
+			oldMS:                 createMS("ms1", "v1", 3),
+			expectedReplicasLimit: ptr.To[int32](1),
+			expectedMessage:       "Waiting for step 1/2: 0 of 1 replicas available",
+		},
+		{
+			name:  "wait for the soak time of the first step",
+			newMS: createMS("ms2", "v2", 1),
+			oldMS: createMS("ms1", "v1", 3),
+			machines: []*clusterv1.Machine{
+				createM("m1", "ms2", "v2", withMAvailableSince(now.Add(-1*time.Minute))),
+			},
+			expectedReplicasLimit:     ptr.To[int32](1),
+			expectedMessage:           "Waiting for step 1/2: soak time not yet elapsed",
+			expectedRequeueAfterAbove: 8 * time.Minute,
+		},
+		{
+			name:  "scale up to the replicas of the second step after the soak time of the first step elapsed",
+			newMS: createMS("ms2", "v2", 1),
+			oldMS: createMS("ms1", "v1", 3),
+			machines: []*clusterv1.Machine{
+				createM("m1", "ms2", "v2", withMAvailableSince(now.Add(-20*time.Minute))),
+			},
+			expectedReplicasLimit: ptr.To[int32](2),
+		},
+		{
+			name:  "wait for the condition of the second step",
+			newMS: createMS("ms2", "v2", 2),
+			oldMS: createMS("ms1", "v1", 2),
+			machines: []*clusterv1.Machine{
+				createM("m1", "ms2", "v2", withMAvailableSince(now.Add(-20*time.Minute))),
+				createM("m2", "ms2", "v2", withMAvailableSince(now.Add(-1*time.Minute))),
+			},
+			expectedReplicasLimit: ptr.To[int32](2),
+			expectedMessage:       "Waiting for step 2/2: condition AnalysisPassed is not yet True",
+		},
+		{
+			name: "wait for the condition of the second step if it transitioned to True before the step's replicas became available",
+			mdConditions: []metav1.Condition{
+				{Type: "AnalysisPassed", Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-10 * time.Minute))},
+			},
+			newMS: createMS("ms2", "v2", 2),
+			oldMS: createMS("ms1", "v1", 2),
+			machines: []*clusterv1.Machine{
+				createM("m1", "ms2", "v2", withMAvailableSince(now.Add(-20*time.Minute))),
+				createM("m2", "ms2", "v2", withMAvailableSince(now.Add(-1*time.Minute))),
+			},
+			expectedReplicasLimit: ptr.To[int32](2),
+			expectedMessage:       "Waiting for step 2/2: condition AnalysisPassed is not yet True",
+		},
+		{
+			name: "no limit if all the steps are completed",
+			mdConditions: []metav1.Condition{
+				{Type: "AnalysisPassed", Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(now)},
+			},
+			newMS: createMS("ms2", "v2", 2),
+			oldMS: createMS("ms1", "v1", 2),
+			machines: []*clusterv1.Machine{
+				createM("m1", "ms2", "v2", withMAvailableSince(now.Add(-20*time.Minute))),
+				createM("m2", "ms2", "v2", withMAvailableSince(now.Add(-1*time.Minute))),
+			},
+		},
+		{
+			name: "wait for the extension to allow the rollout to proceed",
+			steps: []clusterv1.MachineDeploymentRolloutStep{
+				{Replicas: ptr.To(intstr.FromInt32(1)), Gate: clusterv1.MachineDeploymentRolloutStepGate{ExtensionName: "analysis"}},
+			},
+			newMS: createMS("ms2", "v2", 1),
+			oldMS: createMS("ms1", "v1", 3),
+			machines: []*clusterv1.Machine{
+				createM("m1", "ms2", "v2", withMAvailableSince(now.Add(-1*time.Minute))),
+			},
+			extensionResponse: &runtimehooksv1.CanMachineDeploymentRolloutProceedResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					CommonResponse:    runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess, Message: "error rate too high"},
+					RetryAfterSeconds: 30,
+				},
+			},
+			expectedReplicasLimit:     ptr.To[int32](1),
+			expectedMessage:           "Waiting for step 1/1: extension analysis does not allow the rollout to proceed: error rate too high",
+			expectedRequeueAfterAbove: 29 * time.Second,
+		},
+		{
+			name: "no limit if the extension allows the rollout to proceed",
+			steps: []clusterv1.MachineDeploymentRolloutStep{
+				{Replicas: ptr.To(intstr.FromInt32(1)), Gate: clusterv1.MachineDeploymentRolloutStepGate{ExtensionName: "analysis"}},
+			},
+			newMS: createMS("ms2", "v2", 1),
+			oldMS: createMS("ms1", "v1", 3),
+			machines: []*clusterv1.Machine{
+				createM("m1", "ms2", "v2", withMAvailableSince(now.Add(-1*time.Minute))),
+			},
+			extensionResponse: &runtimehooksv1.CanMachineDeploymentRolloutProceedResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			steps := defaultSteps
+			if tt.steps != nil {
+				steps = tt.steps
+			}
+			md := createMD("v2", 4, withStagedStrategy(1, 0, steps...))
+			md.Status.Conditions = tt.mdConditions
+
+			planner := newRolloutPlanner(nil, nil, nil)
+			if tt.extensionResponse != nil {
+				planner.RuntimeClient = fakeruntimeclient.NewRuntimeClientBuilder().
+					WithCatalog(catalog).
+					WithCallExtensionResponses(map[string]runtimehooksv1.ResponseObject{
+						"analysis": tt.extensionResponse,
+					}).
+					Build()
+			}
+			planner.md = md
+			planner.newMS = tt.newMS
+			planner.oldMSs = []*clusterv1.MachineSet{tt.oldMS}
+			planner.machines = tt.machines
+
+			g.Expect(planner.reconcileStagedRolloutStep(ctx)).To(Succeed())
+			g.Expect(planner.stagedRolloutReplicasLimit).To(Equal(tt.expectedReplicasLimit))
+			if tt.expectedMessage == "" {
+				g.Expect(planner.stagedRolloutMessage).To(BeEmpty())
+			} else {
+				g.Expect(planner.stagedRolloutMessage).To(HavePrefix(tt.expectedMessage))
+			}
+			g.Expect(planner.stagedRolloutRequeueAfter).To(BeNumerically(">=", tt.expectedRequeueAfterAbove))
+		})
+	}
+}
+
+func TestPlanStaged(t *testing.T) {
+	now := time.Now()
+	steps := []clusterv1.MachineDeploymentRolloutStep{
+		{Replicas: ptr.To(intstr.FromInt32(1)), Gate: clusterv1.MachineDeploymentRolloutStepGate{SoakSeconds: ptr.To[int32](600)}},
+	}
+
+	tests := []struct {
+		name                  string
+		md                    *clusterv1.MachineDeployment
+		newMS                 *clusterv1.MachineSet
+		oldMS                 *clusterv1.MachineSet
+		machines              []*clusterv1.Machine
+		expectedScaleIntents  map[string]int32
+		expectedReplicasLimit *int32
+	}{
+		{
+			name:                  "scale up the new MachineSet up to the replicas of the first step using maxSurge",
+			md:                    createMD("v2", 4, withStagedStrategy(3, 0, steps...)),
+			newMS:                 createMS("ms2", "v2", 0),
+			oldMS:                 createMS("ms1", "v1", 4),
+			expectedScaleIntents:  map[string]int32{"ms2": 1},
+			expectedReplicasLimit: ptr.To[int32](1),
+		},
+		{
+			name:                  "scale down the old MachineSet to make room for the first step using maxUnavailable",
+			md:                    createMD("v2", 4, withStagedStrategy(0, 3, steps...)),
+			newMS:                 createMS("ms2", "v2", 0),
+			oldMS:                 createMS("ms1", "v1", 4),
+			expectedScaleIntents:  map[string]int32{"ms1": 3},
+			expectedReplicasLimit: ptr.To[int32](1),
+		},
+		{
+			name:  "hold the capacity of the MachineDeployment while waiting for the gate of the first step",
+			md:    createMD("v2", 4, withStagedStrategy(1, 0, steps...)),
+			newMS: createMS("ms2", "v2", 1),
+			oldMS: createMS("ms1", "v1", 4),
+			machines: []*clusterv1.Machine{
+				createM("m5", "ms2", "v2", withMAvailableSince(now)),
+			},
+			expectedScaleIntents:  map[string]int32{"ms1": 3},
+			expectedReplicasLimit: ptr.To[int32](1),
+		},
+		{
+			name:  "continue like a RollingUpdate rollout after the last step",
+			md:    createMD("v2", 4, withStagedStrategy(1, 0, steps...)),
+			newMS: createMS("ms2", "v2", 1),
+			oldMS: createMS("ms1", "v1", 3),
+			machines: []*clusterv1.Machine{
+				createM("m5", "ms2", "v2", withMAvailableSince(now.Add(-20*time.Minute))),
+			},
+			expectedScaleIntents: map[string]int32{"ms2": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			planner := newRolloutPlanner(nil, nil, nil)
+			planner.md = tt.md
+			planner.newMS = tt.newMS
+			planner.oldMSs = []*clusterv1.MachineSet{tt.oldMS}
+			planner.machines = tt.machines
+
+			g.Expect(planner.planStaged(ctx)).To(Succeed())
+			g.Expect(planner.scaleIntents).To(Equal(tt.expectedScaleIntents))
+			g.Expect(planner.stagedRolloutReplicasLimit).To(Equal(tt.expectedReplicasLimit))
+		})
+	}
+}
+
+func withStagedStrategy(maxSurge, maxUnavailable int32, steps ...clusterv1.MachineDeploymentRolloutStep) func(md *clusterv1.MachineDeployment) {
+	return func(md *clusterv1.MachineDeployment) {
+		md.Spec.Rollout.Strategy = clusterv1.MachineDeploymentRolloutStrategy{
+			Type: clusterv1.StagedMachineDeploymentStrategyType,
+			RollingUpdate: clusterv1.MachineDeploymentRolloutStrategyRollingUpdate{
+				MaxSurge:       ptr.To(intstr.FromInt32(maxSurge)),
+				MaxUnavailable: ptr.To(intstr.FromInt32(maxUnavailable)),
+			},
+			Staged: clusterv1.MachineDeploymentRolloutStrategyStaged{
+				Steps: steps,
+			},
+		}
+	}
+}
+
+func withMAvailableSince(t time.Time) fakeMachinesOption {
+	return func(m *clusterv1.Machine) {
+		m.Status.Conditions = append(m.Status.Conditions, metav1.Condition{
+			Type:               clusterv1.MachineAvailableCondition,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(t),
+		})
+	}
+}
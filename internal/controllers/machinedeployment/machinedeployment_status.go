@@ -58,7 +58,7 @@ func (r *Reconciler) updateStatus(ctx context.Context, s *scope) (retErr error)
 
 	setAvailableCondition(ctx, s.machineDeployment, s.getAndAdoptMachineSetsForDeploymentSucceeded)
 
-	setRollingOutCondition(ctx, s.machineDeployment, s.machines)
+	setRollingOutCondition(ctx, s.machineDeployment, s.machines, s.stagedRolloutMessage)
 	setScalingUpCondition(ctx, s.machineDeployment, s.machineSets, s.bootstrapTemplateNotFound, s.infrastructureTemplateNotFound, s.getAndAdoptMachineSetsForDeploymentSucceeded)
 	setScalingDownCondition(ctx, s.machineDeployment, s.machineSets, s.machines, s.getAndAdoptMachineSetsForDeploymentSucceeded)
 
@@ -173,7 +173,7 @@ func setAvailableCondition(_ context.Context, machineDeployment *clusterv1.Machi
 	})
 }
 
-func setRollingOutCondition(_ context.Context, machineDeployment *clusterv1.MachineDeployment, machines collections.Machines) {
+func setRollingOutCondition(_ context.Context, machineDeployment *clusterv1.MachineDeployment, machines collections.Machines, stagedRolloutMessage string) {
 	// Count machines rolling out and collect reasons why a rollout is happening.
 	// Note: The code below collects all the reasons for which at least a machine is rolling out; under normal circumstances
 	// all the machines are rolling out for the same reasons, however, in case of changes to
@@ -204,7 +204,13 @@ func setRollingOutCondition(_ context.Context, machineDeployment *clusterv1.Mach
 	}
 
 	// Rolling out.
+	reason := clusterv1.MachineDeploymentRollingOutReason
 	message := fmt.Sprintf("Rolling out %d not up-to-date replicas", rollingOutReplicas)
+	// If a Staged rollout is paused, surface the step the rollout is waiting for.
+	if stagedRolloutMessage != "" {
+		reason = clusterv1.MachineDeploymentRollingOutWaitingForStepGateReason
+		message += fmt.Sprintf("\n* %s", stagedRolloutMessage)
+	}
 	if rolloutReasons.Len() > 0 {
 		// Surface rollout reasons ensuring that if there is a version change, it goes first.
 		reasons := rolloutReasons.UnsortedList()
@@ -222,7 +228,7 @@ func setRollingOutCondition(_ context.Context, machineDeployment *clusterv1.Mach
 	conditions.Set(machineDeployment, metav1.Condition{
 		Type:    clusterv1.MachineDeploymentRollingOutCondition,
 		Status:  metav1.ConditionTrue,
-		Reason:  clusterv1.MachineDeploymentRollingOutReason,
+		Reason:  reason,
 		Message: message,
 	})
 }
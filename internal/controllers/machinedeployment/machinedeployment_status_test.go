@@ -249,10 +249,11 @@ func Test_setRollingOutCondition(t *testing.T) {
 	}
 
 	tests := []struct {
-		name              string
-		machineDeployment *clusterv1.MachineDeployment
-		machines          []*clusterv1.Machine
-		expectCondition   metav1.Condition
+		name                 string
+		machineDeployment    *clusterv1.MachineDeployment
+		machines             []*clusterv1.Machine
+		stagedRolloutMessage string
+		expectCondition      metav1.Condition
 	}{
 		{
 			name:              "no machines",
@@ -311,6 +312,28 @@ func Test_setRollingOutCondition(t *testing.T) {
 					"* InfrastructureMachine is not up-to-date",
 			},
 		},
+		{
+			name:              "not up-to-date machines, staged rollout waiting for step gate",
+			machineDeployment: &clusterv1.MachineDeployment{},
+			machines: []*clusterv1.Machine{
+				fakeMachine("machine-1", withCondition(upToDateCondition)),
+				fakeMachine("machine-2", withCondition(metav1.Condition{
+					Type:    clusterv1.MachineUpToDateCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  clusterv1.MachineNotUpToDateReason,
+					Message: "* Version v1.25.0, v1.26.0 required",
+				})),
+			},
+			stagedRolloutMessage: "Waiting for step 1/2: condition AnalysisPassed is not yet True",
+			expectCondition: metav1.Condition{
+				Type:   clusterv1.MachineDeploymentRollingOutCondition,
+				Status: metav1.ConditionTrue,
+				Reason: clusterv1.MachineDeploymentRollingOutWaitingForStepGateReason,
+				Message: "Rolling out 1 not up-to-date replicas\n" +
+					"* Waiting for step 1/2: condition AnalysisPassed is not yet True\n" +
+					"* Version v1.25.0, v1.26.0 required",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -320,7 +343,7 @@ func Test_setRollingOutCondition(t *testing.T) {
 			if tt.machines != nil {
 				machines = collections.FromMachines(tt.machines...)
 			}
-			setRollingOutCondition(ctx, tt.machineDeployment, machines)
+			setRollingOutCondition(ctx, tt.machineDeployment, machines, tt.stagedRolloutMessage)
 
 			condition := conditions.Get(tt.machineDeployment, clusterv1.MachineDeploymentRollingOutCondition)
 			g.Expect(condition).ToNot(BeNil())
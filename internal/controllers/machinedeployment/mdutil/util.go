@@ -641,8 +641,10 @@ func GetUptoDateReplicaCountForMachineSets(machineSets []*clusterv1.MachineSet)
 }
 
 // IsRollingUpdate returns true if the strategy type is a rolling update.
+// Note: Staged rollouts are rolling updates where the new MachineSet is scaled up in steps.
 func IsRollingUpdate(deployment *clusterv1.MachineDeployment) bool {
-	return deployment.Spec.Rollout.Strategy.Type == clusterv1.RollingUpdateMachineDeploymentStrategyType
+	return deployment.Spec.Rollout.Strategy.Type == clusterv1.RollingUpdateMachineDeploymentStrategyType ||
+		deployment.Spec.Rollout.Strategy.Type == clusterv1.StagedMachineDeploymentStrategyType
 }
 
 // DeploymentComplete considers a deployment to be complete once all of its desired replicas
@@ -660,11 +662,11 @@ func DeploymentComplete(deployment *clusterv1.MachineDeployment, newStatus *clus
 // NewMSNewReplicas calculates the number of replicas a deployment's new MS should have.
 // When one of the following is true, we're rolling out the deployment; otherwise, we're scaling it.
 // 1) The new MS is saturated: newMS's replicas == deployment's replicas
-// 2) For RollingUpdateStrategy: Max number of machines allowed is reached: deployment's replicas + maxSurge == all MSs' replicas.
+// 2) For RollingUpdateStrategy and StagedStrategy: Max number of machines allowed is reached: deployment's replicas + maxSurge == all MSs' replicas.
 // 3) For OnDeleteStrategy: Max number of machines allowed is reached: deployment's replicas == all MSs' replicas.
 func NewMSNewReplicas(deployment *clusterv1.MachineDeployment, allMSs []*clusterv1.MachineSet, newMSReplicas int32) (int32, string, error) {
 	switch deployment.Spec.Rollout.Strategy.Type {
-	case clusterv1.RollingUpdateMachineDeploymentStrategyType:
+	case clusterv1.RollingUpdateMachineDeploymentStrategyType, clusterv1.StagedMachineDeploymentStrategyType:
 		// Check if we can scale up.
 		maxSurge, err := intstrutil.GetScaledValueFromIntOrPercent(deployment.Spec.Rollout.Strategy.RollingUpdate.MaxSurge, int(*(deployment.Spec.Replicas)), true)
 		if err != nil {
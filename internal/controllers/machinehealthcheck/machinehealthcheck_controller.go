@@ -265,6 +265,11 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to fetch targets from MachineHealthCheck")
 	}
+
+	// run probes against the workload cluster for the Nodes of all targets
+	if err := r.runProbes(ctx, cluster, m, targets); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to run probes")
+	}
 	totalTargets := len(targets)
 	m.Status.ExpectedMachines = ptr.To(int32(totalTargets))
 	m.Status.Targets = make([]string, totalTargets)
@@ -283,6 +288,12 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 	healthy, unhealthy, nextCheckTimes := r.healthCheckTargets(targets, logger, metav1.Duration{Duration: time.Duration(*nodeStartupTimeout) * time.Second})
 	m.Status.CurrentHealthy = ptr.To(int32(len(healthy)))
 
+	// track remediations triggered within the time window defined in rateLimit
+	rateLimiter, err := r.newRemediationRateLimiter(ctx, m, time.Now())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// check MHC current health against UnhealthyLessThanOrEqualTo
 	remediationAllowed, remediationCount, err := isAllowedRemediation(m)
 	if err != nil {
@@ -393,15 +404,57 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 		Reason: clusterv1.MachineHealthCheckRemediationAllowedReason,
 	})
 
-	errList := r.patchUnhealthyTargets(ctx, logger, unhealthy, cluster, m)
+	errList := r.patchUnhealthyTargets(ctx, logger, unhealthy, cluster, m, rateLimiter)
 	errList = append(errList, r.patchHealthyTargets(ctx, logger, healthy, m)...)
 
+	// Remediation is allowed, but the number of remediations triggered within the time window might be limited.
+	if remaining := rateLimiter.remaining(); remaining != nil && *remaining < remediationCount {
+		m.Status.RemediationsAllowed = remaining
+	}
+	if rateLimiter.limited {
+		message := rateLimiter.message()
+		logger.V(3).Info("Rate limiting remediation", "rateLimit", message)
+
+		v1beta1conditions.Set(m, &clusterv1.Condition{
+			Type:     clusterv1.RemediationAllowedV1Beta1Condition,
+			Status:   corev1.ConditionFalse,
+			Severity: clusterv1.ConditionSeverityWarning,
+			Reason:   clusterv1.RemediationRateLimitedV1Beta1Reason,
+			Message:  message,
+		})
+
+		conditions.Set(m, metav1.Condition{
+			Type:    clusterv1.MachineHealthCheckRemediationAllowedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.MachineHealthCheckRemediationRateLimitedReason,
+			Message: message,
+		})
+
+		r.recorder.Event(
+			m,
+			corev1.EventTypeWarning,
+			EventRemediationRestricted,
+			message,
+		)
+	}
+
 	// handle update errors
 	if len(errList) > 0 {
 		logger.V(3).Info("Error(s) marking machine, requeuing")
 		return reconcile.Result{}, kerrors.NewAggregate(errList)
 	}
 
+	// Ensure a requeue happens when the oldest remediation exits the time window, so remediations
+	// that have been rate limited can be triggered.
+	if rateLimiter.limited {
+		nextCheckTimes = append(nextCheckTimes, rateLimiter.requeueAfter())
+	}
+
+	// Ensure a requeue happens when the current step of a remediation escalation times out.
+	if m.Spec.Remediation.Escalation.IsDefined() {
+		nextCheckTimes = append(nextCheckTimes, remediationEscalationNextCheck(m, unhealthy, time.Now())...)
+	}
+
 	if minNextCheck := minDuration(nextCheckTimes); minNextCheck > 0 {
 		logger.V(3).Info("Some targets might go unhealthy. Ensuring a requeue happens", "requeueAfter", minNextCheck.Truncate(time.Second).String())
 		return ctrl.Result{RequeueAfter: minNextCheck}, nil
@@ -416,9 +469,17 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 func (r *Reconciler) patchHealthyTargets(ctx context.Context, logger logr.Logger, healthy []healthCheckTarget, m *clusterv1.MachineHealthCheck) []error {
 	errList := []error{}
 	for _, t := range healthy {
-		if m.Spec.Remediation.TemplateRef.IsDefined() {
+		if m.Spec.Remediation.Escalation.IsDefined() {
+			if err := r.deleteRemediationEscalationRequests(ctx, m, t); err != nil {
+				errList = append(errList, err)
+				continue
+			}
+			// The Machine is healthy again, so the remediation escalation is completed.
+			delete(t.Machine.Annotations, clusterv1.RemediationEscalationAnnotation)
+			conditions.Delete(t.Machine, clusterv1.MachineRemediationEscalationCondition)
+		} else if m.Spec.Remediation.TemplateRef.IsDefined() {
 			// Get remediation request object
-			obj, err := r.getExternalRemediationRequest(ctx, m, t.Machine.Name)
+			obj, err := r.getExternalRemediationRequest(ctx, m, m.Spec.Remediation.TemplateRef, t.Machine.Name)
 			if err != nil {
 				if !apierrors.IsNotFound(errors.Cause(err)) {
 					wrappedErr := errors.Wrapf(err, "failed to fetch remediation request for machine %q in namespace %q within cluster %q", t.Machine.Name, t.Machine.Namespace, t.Machine.Spec.ClusterName)
@@ -443,6 +504,7 @@ func (r *Reconciler) patchHealthyTargets(ctx context.Context, logger logr.Logger
 			}},
 			patch.WithOwnedConditions{Conditions: []string{
 				clusterv1.MachineHealthCheckSucceededCondition,
+				clusterv1.MachineRemediationEscalationCondition,
 				// Note: intentionally leaving out OwnerRemediated condition which is mostly controlled by the owner.
 				// (Same for ExternallyRemediated condition)
 			}},
@@ -456,7 +518,8 @@ func (r *Reconciler) patchHealthyTargets(ctx context.Context, logger logr.Logger
 }
 
 // patchUnhealthyTargets patches machines with MachineOwnerRemediatedCondition for remediation.
-func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logger, unhealthy []healthCheckTarget, cluster *clusterv1.Cluster, m *clusterv1.MachineHealthCheck) []error {
+// Remediations are triggered only if allowed by the rateLimiter.
+func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logger, unhealthy []healthCheckTarget, cluster *clusterv1.Cluster, m *clusterv1.MachineHealthCheck, rateLimiter *remediationRateLimiter) []error {
 	// mark for remediation
 	errList := []error{}
 	for _, t := range unhealthy {
@@ -465,91 +528,29 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 
 		if annotations.IsPaused(cluster, t.Machine) {
 			logger.Info("Machine has failed health check, but machine is paused so skipping remediation", "reason", condition.Reason, "message", condition.Message)
+		} else if r.isNewRemediation(ctx, m, t) && !rateLimiter.allow() {
+			logger.Info("Machine has failed health check, but remediation is rate limited so skipping remediation", "reason", condition.Reason, "message", condition.Message)
 		} else {
-			if m.Spec.Remediation.TemplateRef.IsDefined() {
-				// If external remediation request already exists,
-				// return early
-				if r.externalRemediationRequestExists(ctx, m, t.Machine.Name) {
-					return errList
-				}
-
-				cloneOwnerRef := &metav1.OwnerReference{
-					APIVersion: clusterv1.GroupVersion.String(),
-					Kind:       "Machine",
-					Name:       t.Machine.Name,
-					UID:        t.Machine.UID,
-				}
-
-				from, err := external.Get(ctx, r.Client, m.Spec.Remediation.TemplateRef.ToObjectReference(m.Namespace))
-				if err != nil {
-					v1beta1conditions.MarkFalse(m, clusterv1.ExternalRemediationTemplateAvailableV1Beta1Condition, clusterv1.ExternalRemediationTemplateNotFoundV1Beta1Reason, clusterv1.ConditionSeverityError, "%s", err.Error())
-
-					conditions.Set(t.Machine, metav1.Condition{
-						Type:    clusterv1.MachineExternallyRemediatedCondition,
-						Status:  metav1.ConditionFalse,
-						Reason:  clusterv1.MachineExternallyRemediatedRemediationTemplateNotFoundReason,
-						Message: fmt.Sprintf("Error retrieving remediation template %s %s", m.Spec.Remediation.TemplateRef.Kind, klog.KRef(m.Namespace, m.Spec.Remediation.TemplateRef.Name)),
-					})
-					errList = append(errList, errors.Wrapf(err, "error retrieving remediation template %v %q for machine %q in namespace %q within cluster %q", m.Spec.Remediation.TemplateRef.GroupVersionKind(), m.Spec.Remediation.TemplateRef.Name, t.Machine.Name, t.Machine.Namespace, m.Spec.ClusterName))
+			if m.Spec.Remediation.Escalation.IsDefined() {
+				if err := r.escalateRemediation(ctx, logger, m, t, rateLimiter); err != nil {
+					errList = append(errList, err)
 					return errList
 				}
-
-				generateTemplateInput := &external.GenerateTemplateInput{
-					Template:    from,
-					TemplateRef: m.Spec.Remediation.TemplateRef.ToObjectReference(m.Namespace),
-					Namespace:   t.Machine.Namespace,
-					ClusterName: t.Machine.Spec.ClusterName,
-					OwnerRef:    cloneOwnerRef,
-				}
-				to, err := external.GenerateTemplate(generateTemplateInput)
-				if err != nil {
-					errList = append(errList, errors.Wrapf(err, "failed to create template for remediation request %v %q for machine %q in namespace %q within cluster %q", m.Spec.Remediation.TemplateRef.GroupVersionKind(), m.Spec.Remediation.TemplateRef.Name, t.Machine.Name, t.Machine.Namespace, m.Spec.ClusterName))
+			} else if m.Spec.Remediation.TemplateRef.IsDefined() {
+				// If external remediation request already exists,
+				// return early
+				if r.externalRemediationRequestExists(ctx, m, m.Spec.Remediation.TemplateRef, t.Machine.Name) {
 					return errList
 				}
 
-				// Set the Remediation Request to match the Machine name, the name is used to
-				// guarantee uniqueness between runs. A Machine should only ever have a single
-				// remediation object of a specific GVK created.
-				//
-				// NOTE: This doesn't guarantee uniqueness across different MHC objects watching
-				// the same Machine, users are in charge of setting health checks and remediation properly.
-				to.SetName(t.Machine.Name)
-
-				logger.Info("Machine has failed health check, creating an external remediation request", "remediation request name", to.GetName(), "reason", condition.Reason, "message", condition.Message)
-				// Create the external clone.
-				if err := r.Client.Create(ctx, to); err != nil {
-					v1beta1conditions.MarkFalse(m, clusterv1.ExternalRemediationRequestAvailableV1Beta1Condition, clusterv1.ExternalRemediationRequestCreationFailedV1Beta1Reason, clusterv1.ConditionSeverityError, "%s", err.Error())
-
-					conditions.Set(t.Machine, metav1.Condition{
-						Type:    clusterv1.MachineExternallyRemediatedCondition,
-						Status:  metav1.ConditionFalse,
-						Reason:  clusterv1.MachineExternallyRemediatedRemediationRequestCreationFailedReason,
-						Message: "Please check controller logs for errors",
-					})
-					errList = append(errList, errors.Wrapf(err, "error creating remediation request for machine %q in namespace %q within cluster %q", t.Machine.Name, t.Machine.Namespace, t.Machine.Spec.ClusterName))
+				if err := r.createExternalRemediationRequest(ctx, logger, m, t, m.Spec.Remediation.TemplateRef); err != nil {
+					errList = append(errList, err)
 					return errList
 				}
-
-				conditions.Set(t.Machine, metav1.Condition{
-					Type:   clusterv1.MachineExternallyRemediatedCondition,
-					Status: metav1.ConditionFalse,
-					Reason: clusterv1.MachineExternallyRemediatedWaitingForRemediationReason,
-				})
+				rateLimiter.record(t.Machine.Name)
 			} else if t.Machine.DeletionTimestamp.IsZero() { // Only setting the OwnerRemediated conditions when machine is not already in deletion.
-				logger.Info("Machine has failed health check, marking for remediation", "reason", condition.Reason, "message", condition.Message)
-				// NOTE: MHC is responsible for creating MachineOwnerRemediatedCondition if missing or to trigger another remediation if the previous one is completed;
-				// instead, if a remediation is in already progress, the remediation owner is responsible for completing the process and MHC should not overwrite the condition.
-				if !v1beta1conditions.Has(t.Machine, clusterv1.MachineOwnerRemediatedV1Beta1Condition) || v1beta1conditions.IsTrue(t.Machine, clusterv1.MachineOwnerRemediatedV1Beta1Condition) {
-					v1beta1conditions.MarkFalse(t.Machine, clusterv1.MachineOwnerRemediatedV1Beta1Condition, clusterv1.WaitingForRemediationV1Beta1Reason, clusterv1.ConditionSeverityWarning, "")
-				}
-
-				if ownerRemediatedCondition := conditions.Get(t.Machine, clusterv1.MachineOwnerRemediatedCondition); ownerRemediatedCondition == nil || ownerRemediatedCondition.Status == metav1.ConditionTrue {
-					conditions.Set(t.Machine, metav1.Condition{
-						Type:    clusterv1.MachineOwnerRemediatedCondition,
-						Status:  metav1.ConditionFalse,
-						Reason:  clusterv1.MachineOwnerRemediatedWaitingForRemediationReason,
-						Message: "Waiting for remediation",
-					})
+				if markForOwnerRemediation(logger, t) {
+					rateLimiter.record(t.Machine.Name)
 				}
 			}
 		}
@@ -561,6 +562,7 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 			}},
 			patch.WithOwnedConditions{Conditions: []string{
 				clusterv1.MachineHealthCheckSucceededCondition,
+				clusterv1.MachineRemediationEscalationCondition,
 				// Note: intentionally leaving out OwnerRemediated condition which is mostly controlled by the owner.
 				// (Same for ExternallyRemediated condition)
 			}},
@@ -581,6 +583,112 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 	return errList
 }
 
+// markForOwnerRemediation sets the OwnerRemediated condition on an unhealthy target so the owner of the Machine
+// remediates it; it returns true if a new remediation has been triggered.
+func markForOwnerRemediation(logger logr.Logger, t healthCheckTarget) bool {
+	condition := conditions.Get(t.Machine, clusterv1.MachineHealthCheckSucceededCondition)
+	logger.Info("Machine has failed health check, marking for remediation", "reason", condition.Reason, "message", condition.Message)
+	// NOTE: MHC is responsible for creating MachineOwnerRemediatedCondition if missing or to trigger another remediation if the previous one is completed;
+	// instead, if a remediation is in already progress, the remediation owner is responsible for completing the process and MHC should not overwrite the condition.
+	if !v1beta1conditions.Has(t.Machine, clusterv1.MachineOwnerRemediatedV1Beta1Condition) || v1beta1conditions.IsTrue(t.Machine, clusterv1.MachineOwnerRemediatedV1Beta1Condition) {
+		v1beta1conditions.MarkFalse(t.Machine, clusterv1.MachineOwnerRemediatedV1Beta1Condition, clusterv1.WaitingForRemediationV1Beta1Reason, clusterv1.ConditionSeverityWarning, "")
+	}
+
+	if ownerRemediatedCondition := conditions.Get(t.Machine, clusterv1.MachineOwnerRemediatedCondition); ownerRemediatedCondition == nil || ownerRemediatedCondition.Status == metav1.ConditionTrue {
+		conditions.Set(t.Machine, metav1.Condition{
+			Type:    clusterv1.MachineOwnerRemediatedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.MachineOwnerRemediatedWaitingForRemediationReason,
+			Message: "Waiting for remediation",
+		})
+		return true
+	}
+	return false
+}
+
+// createExternalRemediationRequest creates an external remediation request for an unhealthy target from a remediation template.
+func (r *Reconciler) createExternalRemediationRequest(ctx context.Context, logger logr.Logger, m *clusterv1.MachineHealthCheck, t healthCheckTarget, templateRef clusterv1.MachineHealthCheckRemediationTemplateReference) error {
+	condition := conditions.Get(t.Machine, clusterv1.MachineHealthCheckSucceededCondition)
+
+	cloneOwnerRef := &metav1.OwnerReference{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "Machine",
+		Name:       t.Machine.Name,
+		UID:        t.Machine.UID,
+	}
+
+	from, err := external.Get(ctx, r.Client, templateRef.ToObjectReference(m.Namespace))
+	if err != nil {
+		v1beta1conditions.MarkFalse(m, clusterv1.ExternalRemediationTemplateAvailableV1Beta1Condition, clusterv1.ExternalRemediationTemplateNotFoundV1Beta1Reason, clusterv1.ConditionSeverityError, "%s", err.Error())
+
+		conditions.Set(t.Machine, metav1.Condition{
+			Type:    clusterv1.MachineExternallyRemediatedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.MachineExternallyRemediatedRemediationTemplateNotFoundReason,
+			Message: fmt.Sprintf("Error retrieving remediation template %s %s", templateRef.Kind, klog.KRef(m.Namespace, templateRef.Name)),
+		})
+		return errors.Wrapf(err, "error retrieving remediation template %v %q for machine %q in namespace %q within cluster %q", templateRef.GroupVersionKind(), templateRef.Name, t.Machine.Name, t.Machine.Namespace, m.Spec.ClusterName)
+	}
+
+	generateTemplateInput := &external.GenerateTemplateInput{
+		Template:    from,
+		TemplateRef: templateRef.ToObjectReference(m.Namespace),
+		Namespace:   t.Machine.Namespace,
+		ClusterName: t.Machine.Spec.ClusterName,
+		OwnerRef:    cloneOwnerRef,
+	}
+	to, err := external.GenerateTemplate(generateTemplateInput)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create template for remediation request %v %q for machine %q in namespace %q within cluster %q", templateRef.GroupVersionKind(), templateRef.Name, t.Machine.Name, t.Machine.Namespace, m.Spec.ClusterName)
+	}
+
+	// Set the Remediation Request to match the Machine name, the name is used to
+	// guarantee uniqueness between runs. A Machine should only ever have a single
+	// remediation object of a specific GVK created.
+	//
+	// NOTE: This doesn't guarantee uniqueness across different MHC objects watching
+	// the same Machine, users are in charge of setting health checks and remediation properly.
+	to.SetName(t.Machine.Name)
+
+	logger.Info("Machine has failed health check, creating an external remediation request", "remediation request name", to.GetName(), "reason", condition.Reason, "message", condition.Message)
+	// Create the external clone.
+	if err := r.Client.Create(ctx, to); err != nil {
+		v1beta1conditions.MarkFalse(m, clusterv1.ExternalRemediationRequestAvailableV1Beta1Condition, clusterv1.ExternalRemediationRequestCreationFailedV1Beta1Reason, clusterv1.ConditionSeverityError, "%s", err.Error())
+
+		conditions.Set(t.Machine, metav1.Condition{
+			Type:    clusterv1.MachineExternallyRemediatedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1.MachineExternallyRemediatedRemediationRequestCreationFailedReason,
+			Message: "Please check controller logs for errors",
+		})
+		return errors.Wrapf(err, "error creating remediation request for machine %q in namespace %q within cluster %q", t.Machine.Name, t.Machine.Namespace, t.Machine.Spec.ClusterName)
+	}
+
+	conditions.Set(t.Machine, metav1.Condition{
+		Type:   clusterv1.MachineExternallyRemediatedCondition,
+		Status: metav1.ConditionFalse,
+		Reason: clusterv1.MachineExternallyRemediatedWaitingForRemediationReason,
+	})
+	return nil
+}
+
+// isNewRemediation returns true if the MachineHealthCheck is going to trigger a new remediation for an unhealthy target,
+// i.e. there is no remediation in progress for the target.
+func (r *Reconciler) isNewRemediation(ctx context.Context, m *clusterv1.MachineHealthCheck, t healthCheckTarget) bool {
+	if m.Spec.Remediation.Escalation.IsDefined() {
+		_, ok := t.Machine.Annotations[clusterv1.RemediationEscalationAnnotation]
+		return !ok
+	}
+	if m.Spec.Remediation.TemplateRef.IsDefined() {
+		return !r.externalRemediationRequestExists(ctx, m, m.Spec.Remediation.TemplateRef, t.Machine.Name)
+	}
+	if !t.Machine.DeletionTimestamp.IsZero() {
+		return false
+	}
+	ownerRemediatedCondition := conditions.Get(t.Machine, clusterv1.MachineOwnerRemediatedCondition)
+	return ownerRemediatedCondition == nil || ownerRemediatedCondition.Status == metav1.ConditionTrue
+}
+
 // clusterToMachineHealthCheck maps events from Cluster objects to
 // MachineHealthCheck objects that belong to the Cluster.
 func (r *Reconciler) clusterToMachineHealthCheck(ctx context.Context, o client.Object) []reconcile.Request {
@@ -793,10 +901,10 @@ func unhealthyMachineCount(mhc *clusterv1.MachineHealthCheck) int {
 }
 
 // getExternalRemediationRequest gets reference to External Remediation Request, unstructured object.
-func (r *Reconciler) getExternalRemediationRequest(ctx context.Context, m *clusterv1.MachineHealthCheck, machineName string) (*unstructured.Unstructured, error) {
+func (r *Reconciler) getExternalRemediationRequest(ctx context.Context, m *clusterv1.MachineHealthCheck, templateRef clusterv1.MachineHealthCheckRemediationTemplateReference, machineName string) (*unstructured.Unstructured, error) {
 	remediationRef := &corev1.ObjectReference{
-		APIVersion: m.Spec.Remediation.TemplateRef.APIVersion,
-		Kind:       strings.TrimSuffix(m.Spec.Remediation.TemplateRef.Kind, clusterv1.TemplateSuffix),
+		APIVersion: templateRef.APIVersion,
+		Kind:       strings.TrimSuffix(templateRef.Kind, clusterv1.TemplateSuffix),
 		Name:       machineName,
 		Namespace:  m.Namespace,
 	}
@@ -809,8 +917,8 @@ func (r *Reconciler) getExternalRemediationRequest(ctx context.Context, m *clust
 
 // externalRemediationRequestExists checks if the External Remediation Request is created
 // for the machine.
-func (r *Reconciler) externalRemediationRequestExists(ctx context.Context, m *clusterv1.MachineHealthCheck, machineName string) bool {
-	remediationReq, err := r.getExternalRemediationRequest(ctx, m, machineName)
+func (r *Reconciler) externalRemediationRequestExists(ctx context.Context, m *clusterv1.MachineHealthCheck, templateRef clusterv1.MachineHealthCheckRemediationTemplateReference, machineName string) bool {
+	remediationReq, err := r.getExternalRemediationRequest(ctx, m, templateRef, machineName)
 	if err != nil {
 		return false
 	}
@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// remediationRequestDeletionRequeueAfter is the time to wait before checking again if the remediation request
+// of the previous attempt has been deleted, so the remediation request for the next attempt can be created.
+const remediationRequestDeletionRequeueAfter = 5 * time.Second
+
+// remediationEscalationData is the progress of a remediation escalation for a Machine.
+// It is stored in the RemediationEscalationAnnotation on the Machine.
+type remediationEscalationData struct {
+	// step is the index of the current remediation step; when equal to the number of steps,
+	// the Machine is remediated by its owner.
+	Step int `json:"step"`
+
+	// attempt is the current attempt of the current remediation step, starting from 1.
+	Attempt int `json:"attempt"`
+
+	// timestamp is when the remediation request for the current attempt has been created.
+	// It is not set while waiting for the remediation request of the previous attempt to be deleted.
+	Timestamp *metav1.Time `json:"timestamp,omitempty"`
+}
+
+// remediationEscalationDataFromMachine gets the remediationEscalationData from a Machine;
+// it returns nil if there is no remediation escalation in progress for the Machine.
+func remediationEscalationDataFromMachine(machine *clusterv1.Machine) (*remediationEscalationData, error) {
+	value, ok := machine.Annotations[clusterv1.RemediationEscalationAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	data := &remediationEscalationData{}
+	if err := json.Unmarshal([]byte(value), data); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal value %s for %s annotation", value, clusterv1.RemediationEscalationAnnotation)
+	}
+	return data, nil
+}
+
+// setRemediationEscalationData stores the remediationEscalationData on a Machine.
+func setRemediationEscalationData(machine *clusterv1.Machine, data *remediationEscalationData) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal value for %s annotation", clusterv1.RemediationEscalationAnnotation)
+	}
+	annotations.AddAnnotations(machine, map[string]string{clusterv1.RemediationEscalationAnnotation: string(b)})
+	return nil
+}
+
+// maxAttempts returns the number of times a remediation step is attempted.
+func maxAttempts(step clusterv1.MachineHealthCheckRemediationEscalationStep) int {
+	return int(ptr.Deref(step.MaxAttempts, 1))
+}
+
+// stepTimeout returns the time to wait for a Machine to become healthy after each attempt of a remediation step.
+func stepTimeout(step clusterv1.MachineHealthCheckRemediationEscalationStep) time.Duration {
+	return time.Duration(ptr.Deref(step.TimeoutSeconds, 0)) * time.Second
+}
+
+// escalateRemediation moves an unhealthy target through the remediation steps defined in the MachineHealthCheck.
+// Each attempt of a step creates an external remediation request; if the Machine is still unhealthy when the attempt
+// times out, the request is deleted and the step is attempted again or the next step is tried. Once all the steps
+// have been tried, the Machine is remediated by its owner.
+// The progress of the escalation is stored in an annotation on the Machine and surfaced in the RemediationEscalation condition.
+func (r *Reconciler) escalateRemediation(ctx context.Context, logger logr.Logger, m *clusterv1.MachineHealthCheck, t healthCheckTarget, rateLimiter *remediationRateLimiter) error {
+	steps := m.Spec.Remediation.Escalation.Steps
+
+	data, err := remediationEscalationDataFromMachine(t.Machine)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		// Do not start a new remediation for Machines already in deletion.
+		if !t.Machine.DeletionTimestamp.IsZero() {
+			return nil
+		}
+		data = &remediationEscalationData{Step: 0, Attempt: 1}
+		rateLimiter.record(t.Machine.Name)
+	}
+	// Steps might have been removed from the MachineHealthCheck while the escalation is in progress.
+	if data.Step > len(steps) {
+		data.Step = len(steps)
+	}
+
+	now := time.Now()
+	for data.Step < len(steps) {
+		step := steps[data.Step]
+
+		obj, err := r.getExternalRemediationRequest(ctx, m, step.TemplateRef, t.Machine.Name)
+		if err != nil {
+			if !apierrors.IsNotFound(errors.Cause(err)) {
+				return errors.Wrapf(err, "failed to fetch remediation request for machine %q in namespace %q within cluster %q", t.Machine.Name, t.Machine.Namespace, t.Machine.Spec.ClusterName)
+			}
+			obj = nil
+		}
+
+		if data.Timestamp == nil {
+			// The remediation request of the previous attempt must be deleted before creating
+			// the request for the current attempt, because they might have the same name.
+			if obj != nil {
+				if err := r.deleteExternalRemediationRequest(ctx, obj, t.Machine); err != nil {
+					return err
+				}
+				break
+			}
+
+			if err := r.createExternalRemediationRequest(ctx, logger, m, t, step.TemplateRef); err != nil {
+				return err
+			}
+			data.Timestamp = ptr.To(metav1.NewTime(now))
+			break
+		}
+
+		if now.Before(data.Timestamp.Add(stepTimeout(step))) {
+			break
+		}
+
+		// The current attempt timed out, so move to the next attempt or to the next step.
+		logger.Info("Machine is still unhealthy after remediation step timed out", "step", step.Name, "attempt", data.Attempt)
+		if obj != nil {
+			if err := r.deleteExternalRemediationRequest(ctx, obj, t.Machine); err != nil {
+				return err
+			}
+		}
+		if data.Attempt < maxAttempts(step) {
+			data.Attempt++
+		} else {
+			data.Step++
+			data.Attempt = 1
+		}
+		data.Timestamp = nil
+	}
+
+	if data.Step == len(steps) {
+		if t.Machine.DeletionTimestamp.IsZero() {
+			markForOwnerRemediation(logger, t)
+		}
+		conditions.Set(t.Machine, metav1.Condition{
+			Type:    clusterv1.MachineRemediationEscalationCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  clusterv1.MachineRemediationEscalationOwnerRemediationReason,
+			Message: fmt.Sprintf("All %d remediation steps have been tried, waiting for remediation by the owner of the Machine", len(steps)),
+		})
+	} else {
+		step := steps[data.Step]
+		message := fmt.Sprintf("Remediation step %s (%d of %d), attempt %d of %d", step.Name, data.Step+1, len(steps), data.Attempt, maxAttempts(step))
+		if data.Timestamp == nil {
+			message += ", waiting for the remediation request of the previous attempt to be deleted"
+		}
+		conditions.Set(t.Machine, metav1.Condition{
+			Type:    clusterv1.MachineRemediationEscalationCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  clusterv1.MachineRemediationEscalationStepInProgressReason,
+			Message: message,
+		})
+	}
+
+	return setRemediationEscalationData(t.Machine, data)
+}
+
+// deleteRemediationEscalationRequests deletes the external remediation requests created for a target
+// by the remediation steps of the MachineHealthCheck.
+func (r *Reconciler) deleteRemediationEscalationRequests(ctx context.Context, m *clusterv1.MachineHealthCheck, t healthCheckTarget) error {
+	for _, step := range m.Spec.Remediation.Escalation.Steps {
+		obj, err := r.getExternalRemediationRequest(ctx, m, step.TemplateRef, t.Machine.Name)
+		if err != nil {
+			if !apierrors.IsNotFound(errors.Cause(err)) {
+				return errors.Wrapf(err, "failed to fetch remediation request for machine %q in namespace %q within cluster %q", t.Machine.Name, t.Machine.Namespace, t.Machine.Spec.ClusterName)
+			}
+			continue
+		}
+		if err := r.deleteExternalRemediationRequest(ctx, obj, t.Machine); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteExternalRemediationRequest deletes an external remediation request, if not already in deletion.
+func (r *Reconciler) deleteExternalRemediationRequest(ctx context.Context, obj *unstructured.Unstructured, machine *clusterv1.Machine) error {
+	// Check that obj has no DeletionTimestamp to avoid hot loop
+	if obj.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	if err := r.Client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete %v %q for Machine %q", obj.GroupVersionKind(), obj.GetName(), machine.Name)
+	}
+	return nil
+}
+
+// remediationEscalationNextCheck returns the durations after which the remediation escalations in progress
+// for the unhealthy targets must be checked again, i.e. when the current attempts time out.
+func remediationEscalationNextCheck(m *clusterv1.MachineHealthCheck, unhealthy []healthCheckTarget, now time.Time) []time.Duration {
+	steps := m.Spec.Remediation.Escalation.Steps
+
+	nextCheckTimes := []time.Duration{}
+	for _, t := range unhealthy {
+		data, err := remediationEscalationDataFromMachine(t.Machine)
+		if err != nil || data == nil || data.Step >= len(steps) {
+			continue
+		}
+		if data.Timestamp == nil {
+			nextCheckTimes = append(nextCheckTimes, remediationRequestDeletionRequeueAfter)
+			continue
+		}
+		nextCheckTimes = append(nextCheckTimes, data.Timestamp.Add(stepTimeout(steps[data.Step])).Sub(now)+time.Second)
+	}
+	return nextCheckTimes
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+)
+
+func TestEscalateRemediation(t *testing.T) {
+	g := NewWithT(t)
+
+	namespace := metav1.NamespaceDefault
+	labels := map[string]string{"cluster": "foo", "nodepool": "bar"}
+
+	remediationTemplate := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{},
+				},
+			},
+		},
+	}
+	remediationTemplate.SetKind("GenericExternalRemediationTemplate")
+	remediationTemplate.SetAPIVersion(builder.RemediationGroupVersion.String())
+	remediationTemplate.SetName("reboot")
+	remediationTemplate.SetNamespace(namespace)
+
+	mhc := newMachineHealthCheckWithLabels("mhc", namespace, testClusterName, labels)
+	mhc.Spec.Remediation.Escalation = clusterv1.MachineHealthCheckRemediationEscalation{
+		Steps: []clusterv1.MachineHealthCheckRemediationEscalationStep{
+			{
+				Name: "reboot",
+				TemplateRef: clusterv1.MachineHealthCheckRemediationTemplateReference{
+					APIVersion: builder.RemediationGroupVersion.String(),
+					Kind:       "GenericExternalRemediationTemplate",
+					Name:       "reboot",
+				},
+				TimeoutSeconds: ptr.To[int32](300),
+				MaxAttempts:    ptr.To[int32](2),
+			},
+		},
+	}
+	mhc.Spec.Remediation.RateLimit = clusterv1.MachineHealthCheckRemediationRateLimit{
+		WindowSeconds:   600,
+		MaxRemediations: ptr.To[int32](5),
+	}
+
+	machine := newTestMachine("machine1", namespace, testClusterName, "node1", labels)
+	conditions.Set(machine, metav1.Condition{
+		Type:   clusterv1.MachineHealthCheckSucceededCondition,
+		Status: metav1.ConditionFalse,
+		Reason: clusterv1.MachineHealthCheckUnhealthyNodeReason,
+	})
+	target := healthCheckTarget{MHC: mhc, Machine: machine}
+
+	r := &Reconciler{Client: fake.NewClientBuilder().WithObjects(remediationTemplate, machine.DeepCopy()).Build()}
+	rateLimiter := &remediationRateLimiter{mhc: mhc, now: time.Now()}
+	logger := logr.New(log.NullLogSink{})
+
+	getRemediationRequest := func() (*unstructured.Unstructured, error) {
+		return r.getExternalRemediationRequest(ctx, mhc, mhc.Spec.Remediation.Escalation.Steps[0].TemplateRef, machine.Name)
+	}
+	expireCurrentAttempt := func() {
+		data, err := remediationEscalationDataFromMachine(machine)
+		g.Expect(err).ToNot(HaveOccurred())
+		data.Timestamp = ptr.To(metav1.NewTime(time.Now().Add(-10 * time.Minute)))
+		g.Expect(setRemediationEscalationData(machine, data)).To(Succeed())
+	}
+
+	// The first attempt of the first step creates a remediation request.
+	g.Expect(r.isNewRemediation(ctx, mhc, target)).To(BeTrue())
+	g.Expect(r.escalateRemediation(ctx, logger, mhc, target, rateLimiter)).To(Succeed())
+	g.Expect(r.isNewRemediation(ctx, mhc, target)).To(BeFalse())
+
+	firstRequest, err := getRemediationRequest()
+	g.Expect(err).ToNot(HaveOccurred())
+	// Mark the remediation request of the first attempt, so it is possible to check it gets replaced.
+	firstRequest.SetAnnotations(map[string]string{"attempt": "1"})
+	g.Expect(r.Client.Update(ctx, firstRequest)).To(Succeed())
+	data, err := remediationEscalationDataFromMachine(machine)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(data.Step).To(Equal(0))
+	g.Expect(data.Attempt).To(Equal(1))
+	g.Expect(conditions.Get(machine, clusterv1.MachineRemediationEscalationCondition)).To(HaveField("Message", "Remediation step reboot (1 of 1), attempt 1 of 2"))
+	g.Expect(remediationEscalationNextCheck(mhc, []healthCheckTarget{target}, time.Now())).To(ConsistOf(BeNumerically("~", 301*time.Second, time.Second)))
+
+	// Nothing changes until the attempt times out.
+	g.Expect(r.escalateRemediation(ctx, logger, mhc, target, rateLimiter)).To(Succeed())
+	data, err = remediationEscalationDataFromMachine(machine)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(data.Attempt).To(Equal(1))
+
+	// When the attempt times out, the remediation request is replaced for the next attempt.
+	expireCurrentAttempt()
+	g.Expect(r.escalateRemediation(ctx, logger, mhc, target, rateLimiter)).To(Succeed())
+
+	secondRequest, err := getRemediationRequest()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(secondRequest.GetAnnotations()).ToNot(HaveKey("attempt"))
+	g.Expect(conditions.Get(machine, clusterv1.MachineRemediationEscalationCondition)).To(HaveField("Message", "Remediation step reboot (1 of 1), attempt 2 of 2"))
+	g.Expect(conditions.Get(machine, clusterv1.MachineOwnerRemediatedCondition)).To(BeNil())
+
+	// When all the steps have been tried, the Machine is remediated by its owner.
+	expireCurrentAttempt()
+	g.Expect(r.escalateRemediation(ctx, logger, mhc, target, rateLimiter)).To(Succeed())
+
+	_, err = getRemediationRequest()
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	data, err = remediationEscalationDataFromMachine(machine)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(data.Step).To(Equal(1))
+	g.Expect(conditions.Get(machine, clusterv1.MachineRemediationEscalationCondition)).To(HaveField("Reason", clusterv1.MachineRemediationEscalationOwnerRemediationReason))
+	g.Expect(conditions.Get(machine, clusterv1.MachineOwnerRemediatedCondition)).To(HaveField("Reason", clusterv1.MachineOwnerRemediatedWaitingForRemediationReason))
+	g.Expect(remediationEscalationNextCheck(mhc, []healthCheckTarget{target}, time.Now())).To(BeEmpty())
+
+	// The escalation has been counted as a single remediation.
+	g.Expect(mhc.Status.Remediations).To(HaveLen(1))
+}
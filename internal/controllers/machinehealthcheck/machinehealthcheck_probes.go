@@ -0,0 +1,439 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+)
+
+const (
+	// defaultProbeRequestTimeout is the default timeout for requests sent by HTTP probes.
+	defaultProbeRequestTimeout = 5 * time.Second
+
+	// maxConcurrentHTTPProbes is the maximum number of Nodes probed in parallel by an HTTP probe.
+	maxConcurrentHTTPProbes = 10
+)
+
+// probeFailure is a probe failing for the Node of a Machine.
+type probeFailure struct {
+	probe   clusterv1.MachineHealthCheckProbe
+	message string
+	// since is the time when the probe has been observed failing for the first time.
+	since time.Time
+}
+
+// probeFailuresData is the time when the probes of a MachineHealthCheck started failing for a Machine.
+// It is stored in the MachineHealthCheckFailingProbesAnnotation on the Machine, indexed by MachineHealthCheck name,
+// so probe timeouts are preserved across restarts of the controller.
+type probeFailuresData struct {
+	// uid is the UID of the MachineHealthCheck; data of a deleted MachineHealthCheck with the same name is ignored.
+	UID types.UID `json:"uid"`
+
+	// failingSince is the time when each failing probe has been observed failing for the first time, indexed by probe name.
+	FailingSince map[string]metav1.Time `json:"failingSince"`
+}
+
+// probeFailuresDataFromMachine gets the probeFailuresData of all the MachineHealthChecks from a Machine.
+func probeFailuresDataFromMachine(machine *clusterv1.Machine) (map[string]probeFailuresData, error) {
+	data := map[string]probeFailuresData{}
+	value, ok := machine.Annotations[clusterv1.MachineHealthCheckFailingProbesAnnotation]
+	if !ok {
+		return data, nil
+	}
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal value %s for %s annotation", value, clusterv1.MachineHealthCheckFailingProbesAnnotation)
+	}
+	return data, nil
+}
+
+// setProbeFailuresData stores the probeFailuresData of all the MachineHealthChecks on a Machine;
+// the annotation is removed if there are no failing probes.
+func setProbeFailuresData(machine *clusterv1.Machine, data map[string]probeFailuresData) error {
+	if len(data) == 0 {
+		delete(machine.Annotations, clusterv1.MachineHealthCheckFailingProbesAnnotation)
+		return nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal value for %s annotation", clusterv1.MachineHealthCheckFailingProbesAnnotation)
+	}
+	annotations.AddAnnotations(machine, map[string]string{clusterv1.MachineHealthCheckFailingProbesAnnotation: string(b)})
+	return nil
+}
+
+// runProbes runs the probes defined in the MachineHealthCheck against the workload cluster for the Node of each target,
+// and records the failing probes in the targets.
+// The time when each probe started failing is stored on the Machines, and the Machines are patched when it changes,
+// because Machines waiting for a probe timeout are not patched by the rest of the reconcile.
+// Note: If a probe cannot be run, e.g. because listing Pods in the workload cluster fails, the probe is skipped, because
+// this is not a failure of the Nodes; failures previously observed for the probe are preserved until the probe can be run again.
+func (r *Reconciler) runProbes(ctx context.Context, cluster *clusterv1.Cluster, m *clusterv1.MachineHealthCheck, targets []healthCheckTarget) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	nodes := []*corev1.Node{}
+	for _, t := range targets {
+		if t.Node != nil {
+			nodes = append(nodes, t.Node)
+		}
+	}
+
+	// Collect failure messages, indexed by probe name and by Node name.
+	messages := map[string]map[string]string{}
+	skipped := sets.New[string]()
+	if len(nodes) > 0 {
+		for _, probe := range m.Spec.Checks.Probes {
+			var probeMessages map[string]string
+			var err error
+			switch {
+			case probe.DaemonSetPod.Namespace != "":
+				probeMessages, err = r.probeDaemonSetPods(ctx, cluster, probe.DaemonSetPod, nodes)
+			case probe.HTTP.Port != 0:
+				probeMessages, err = r.probeHTTP(ctx, cluster, probe.HTTP, nodes)
+			}
+			if err != nil {
+				log.Error(err, fmt.Sprintf("Failed to run probe %s, skipping it", probe.Name))
+				skipped.Insert(probe.Name)
+				continue
+			}
+			messages[probe.Name] = probeMessages
+		}
+	}
+
+	now := metav1.Now().Rfc3339Copy()
+	errList := []error{}
+	for i := range targets {
+		t := &targets[i]
+		if t.Node == nil {
+			continue
+		}
+
+		data, err := probeFailuresDataFromMachine(t.Machine)
+		if err != nil {
+			// Start over if the annotation is invalid.
+			log.Error(err, "Failed to get the time when probes started failing", "Machine", klog.KObj(t.Machine))
+			data = map[string]probeFailuresData{}
+		}
+		previous := data[m.Name]
+		if previous.UID != m.UID {
+			previous = probeFailuresData{}
+		}
+
+		current := probeFailuresData{UID: m.UID, FailingSince: map[string]metav1.Time{}}
+		for _, probe := range m.Spec.Checks.Probes {
+			if skipped.Has(probe.Name) {
+				if since, ok := previous.FailingSince[probe.Name]; ok {
+					current.FailingSince[probe.Name] = since
+				}
+				continue
+			}
+			message, ok := messages[probe.Name][t.Node.Name]
+			if !ok {
+				continue
+			}
+			since, ok := previous.FailingSince[probe.Name]
+			if !ok {
+				since = now
+			}
+			current.FailingSince[probe.Name] = since
+			t.probeFailures = append(t.probeFailures, probeFailure{
+				probe:   probe,
+				message: message,
+				since:   since.Time,
+			})
+		}
+
+		// Patch the Machine only if the probes failing for the MachineHealthCheck changed.
+		existing, hasExisting := data[m.Name]
+		if !hasExisting && len(current.FailingSince) == 0 {
+			continue
+		}
+		if hasExisting && existing.UID == current.UID && reflect.DeepEqual(existing.FailingSince, current.FailingSince) {
+			continue
+		}
+
+		patchBase := client.MergeFrom(t.Machine.DeepCopy())
+		if len(current.FailingSince) == 0 {
+			delete(data, m.Name)
+		} else {
+			data[m.Name] = current
+		}
+		if err := setProbeFailuresData(t.Machine, data); err != nil {
+			errList = append(errList, err)
+			continue
+		}
+		if err := r.Client.Patch(ctx, t.Machine, patchBase); err != nil {
+			errList = append(errList, errors.Wrapf(err, "failed to patch Machine %s", klog.KObj(t.Machine)))
+		}
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+// probeDaemonSetPods checks that the Pods matching the probe selector are ready on each Node,
+// and returns failure messages indexed by Node name.
+// Nodes without Pods are reported as failing only if a DaemonSet with a Pod template matching the probe selector
+// should run on them according to its nodeSelector, node affinity and tolerations.
+func (r *Reconciler) probeDaemonSetPods(ctx context.Context, cluster *clusterv1.Cluster, probe clusterv1.MachineHealthCheckDaemonSetPodProbe, nodes []*corev1.Node) (map[string]string, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	selector, err := metav1.LabelSelectorAsSelector(&probe.Selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build selector")
+	}
+
+	// Note: Pods are read with an uncached client to avoid caching all the Pods of the workload cluster.
+	c, err := r.ClusterCache.GetUncachedClient(ctx, util.ObjectKey(cluster))
+	if err != nil {
+		return nil, err
+	}
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, client.InNamespace(probe.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list Pods in namespace %s", probe.Namespace)
+	}
+	daemonSetList := &appsv1.DaemonSetList{}
+	if err := c.List(ctx, daemonSetList, client.InNamespace(probe.Namespace)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list DaemonSets in namespace %s", probe.Namespace)
+	}
+	daemonSets := []*appsv1.DaemonSet{}
+	for i := range daemonSetList.Items {
+		ds := &daemonSetList.Items[i]
+		if selector.Matches(labels.Set(ds.Spec.Template.Labels)) {
+			daemonSets = append(daemonSets, ds)
+		}
+	}
+
+	podsByNode := map[string][]*corev1.Pod{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		// Ignore Pods being deleted, e.g. during a rollout of the DaemonSet.
+		if pod.Spec.NodeName == "" || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+	}
+
+	messages := map[string]string{}
+	for _, node := range nodes {
+		pods := podsByNode[node.Name]
+		if len(pods) == 0 {
+			shouldRun := slices.ContainsFunc(daemonSets, func(ds *appsv1.DaemonSet) bool {
+				return daemonSetShouldRunOnNode(log, ds, node)
+			})
+			if shouldRun {
+				messages[node.Name] = fmt.Sprintf("no Pods matching selector %s in namespace %s are scheduled on the Node", selector, probe.Namespace)
+			}
+			continue
+		}
+
+		notReady := []string{}
+		for _, pod := range pods {
+			if !isPodReady(pod) {
+				notReady = append(notReady, pod.Name)
+			}
+		}
+		if len(notReady) > 0 {
+			messages[node.Name] = fmt.Sprintf("Pods %s in namespace %s are not ready", strings.Join(notReady, ", "), probe.Namespace)
+		}
+	}
+	return messages, nil
+}
+
+// daemonSetShouldRunOnNode returns true if the Pods of a DaemonSet should be scheduled on a Node according to the
+// nodeSelector, the required node affinity and the tolerations of the DaemonSet Pod template.
+// Note: Taints tolerated by default by the DaemonSet controller, e.g. node.kubernetes.io/not-ready, are ignored.
+func daemonSetShouldRunOnNode(log logr.Logger, ds *appsv1.DaemonSet, node *corev1.Node) bool {
+	podSpec := ds.Spec.Template.Spec
+
+	if !labels.SelectorFromSet(podSpec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	if podSpec.Affinity != nil && podSpec.Affinity.NodeAffinity != nil && podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		if !nodeSelectorTermsMatch(podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms, node) {
+			return false
+		}
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect != corev1.TaintEffectNoSchedule && taint.Effect != corev1.TaintEffectNoExecute {
+			continue
+		}
+		if daemonSetDefaultTolerations.Has(taint.Key) || (taint.Key == corev1.TaintNodeNetworkUnavailable && podSpec.HostNetwork) {
+			continue
+		}
+		tolerated := slices.ContainsFunc(podSpec.Tolerations, func(toleration corev1.Toleration) bool {
+			return toleration.ToleratesTaint(log, taint, false)
+		})
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// daemonSetDefaultTolerations are the taints tolerated by the Pods of all the DaemonSets, because the DaemonSet controller
+// adds the corresponding tolerations to them.
+var daemonSetDefaultTolerations = sets.New[string](
+	corev1.TaintNodeNotReady,
+	corev1.TaintNodeUnreachable,
+	corev1.TaintNodeDiskPressure,
+	corev1.TaintNodeMemoryPressure,
+	corev1.TaintNodePIDPressure,
+	corev1.TaintNodeUnschedulable,
+)
+
+// nodeSelectorOperators maps the operators of node selector requirements to the corresponding label selector operators.
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+// nodeSelectorTermsMatch returns true if a Node matches any of the node selector terms; terms without requirements do not match any Node.
+func nodeSelectorTermsMatch(terms []corev1.NodeSelectorTerm, node *corev1.Node) bool {
+	for _, term := range terms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		if nodeLabelRequirementsMatch(term.MatchExpressions, node) && nodeFieldRequirementsMatch(term.MatchFields, node) {
+			return true
+		}
+	}
+	return false
+}
+
+func nodeLabelRequirementsMatch(requirements []corev1.NodeSelectorRequirement, node *corev1.Node) bool {
+	for _, requirement := range requirements {
+		operator, ok := nodeSelectorOperators[requirement.Operator]
+		if !ok {
+			return false
+		}
+		labelRequirement, err := labels.NewRequirement(requirement.Key, operator, requirement.Values)
+		if err != nil || !labelRequirement.Matches(labels.Set(node.Labels)) {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeFieldRequirementsMatch returns true if a Node matches all the field requirements; only metadata.name is supported,
+// like in the Kubernetes scheduler.
+func nodeFieldRequirementsMatch(requirements []corev1.NodeSelectorRequirement, node *corev1.Node) bool {
+	for _, requirement := range requirements {
+		if requirement.Key != metav1.ObjectNameField {
+			return false
+		}
+		switch requirement.Operator {
+		case corev1.NodeSelectorOpIn:
+			if !slices.Contains(requirement.Values, node.Name) {
+				return false
+			}
+		case corev1.NodeSelectorOpNotIn:
+			if slices.Contains(requirement.Values, node.Name) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// probeHTTP checks that the endpoint defined by the probe responds successfully on each Node,
+// and returns failure messages indexed by Node name.
+func (r *Reconciler) probeHTTP(ctx context.Context, cluster *clusterv1.Cluster, probe clusterv1.MachineHealthCheckHTTPProbe, nodes []*corev1.Node) (map[string]string, error) {
+	restClient, err := r.ClusterCache.GetRESTClient(ctx, util.ObjectKey(cluster))
+	if err != nil {
+		return nil, err
+	}
+
+	var lock sync.Mutex
+	messages := map[string]string{}
+	workqueue.ParallelizeUntil(ctx, maxConcurrentHTTPProbes, len(nodes), func(i int) {
+		if err := probeNodeHTTP(ctx, restClient, nodes[i].Name, probe); err != nil {
+			lock.Lock()
+			defer lock.Unlock()
+			messages[nodes[i].Name] = fmt.Sprintf("HTTP probe failed: %v", err)
+		}
+	})
+	return messages, nil
+}
+
+// probeNodeHTTP sends a request to the endpoint defined by the probe via the node proxy of the API server of the workload cluster.
+func probeNodeHTTP(ctx context.Context, restClient clustercache.RESTClient, nodeName string, probe clusterv1.MachineHealthCheckHTTPProbe) error {
+	scheme := strings.ToLower(string(probe.Scheme))
+	if scheme == "" {
+		scheme = strings.ToLower(string(corev1.URISchemeHTTP))
+	}
+	path := probe.Path
+	if path == "" {
+		path = "/"
+	}
+	timeout := defaultProbeRequestTimeout
+	if probe.RequestTimeoutSeconds != nil {
+		timeout = time.Duration(*probe.RequestTimeoutSeconds) * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err := restClient.Get().
+		AbsPath("/api/v1/nodes", utilnet.JoinSchemeNamePort(scheme, nodeName, strconv.Itoa(int(probe.Port))), "proxy", path).
+		DoRaw(ctx)
+	return err
+}
+
+// isPodReady returns true if the Pod has the Ready condition set to true.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
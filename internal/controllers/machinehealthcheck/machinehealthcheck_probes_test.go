@@ -0,0 +1,362 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+)
+
+func TestProbeFailuresData(t *testing.T) {
+	g := NewWithT(t)
+
+	machine := &clusterv1.Machine{}
+	data, err := probeFailuresDataFromMachine(machine)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(data).To(BeEmpty())
+
+	since := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	data["mhc"] = probeFailuresData{UID: "uid", FailingSince: map[string]metav1.Time{"cni": since}}
+	g.Expect(setProbeFailuresData(machine, data)).To(Succeed())
+	g.Expect(machine.Annotations).To(HaveKeyWithValue(clusterv1.MachineHealthCheckFailingProbesAnnotation,
+		`{"mhc":{"uid":"uid","failingSince":{"cni":"2026-01-01T00:00:00Z"}}}`))
+
+	got, err := probeFailuresDataFromMachine(machine)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(BeComparableTo(data))
+
+	// The annotation is removed if there are no failing probes.
+	g.Expect(setProbeFailuresData(machine, map[string]probeFailuresData{})).To(Succeed())
+	g.Expect(machine.Annotations).ToNot(HaveKey(clusterv1.MachineHealthCheckFailingProbesAnnotation))
+
+	machine.Annotations[clusterv1.MachineHealthCheckFailingProbesAnnotation] = "invalid"
+	_, err = probeFailuresDataFromMachine(machine)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestRunProbesDaemonSetPod(t *testing.T) {
+	g := NewWithT(t)
+
+	namespace := metav1.NamespaceDefault
+	labels := map[string]string{"cluster": "foo", "nodepool": "bar"}
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: namespace}}
+
+	mhc := newMachineHealthCheckWithLabels("mhc", namespace, testClusterName, labels)
+	mhc.UID = "mhc-uid"
+	mhc.Spec.Checks.Probes = []clusterv1.MachineHealthCheckProbe{
+		{
+			Name: "cni",
+			DaemonSetPod: clusterv1.MachineHealthCheckDaemonSetPodProbe{
+				Namespace: metav1.NamespaceSystem,
+				Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"k8s-app": "cni"}},
+			},
+			TimeoutSeconds: ptr.To[int32](300),
+		},
+	}
+
+	newPod := func(name, nodeName string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem, Labels: map[string]string{"k8s-app": "cni"}},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "cni", Namespace: metav1.NamespaceSystem},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"k8s-app": "cni"}},
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+					Tolerations:  []corev1.Toleration{{Key: "node-role.kubernetes.io/control-plane", Operator: corev1.TolerationOpExists}},
+				},
+			},
+		},
+	}
+	workloadClient := fake.NewClientBuilder().WithObjects(
+		daemonSet,
+		newPod("cni-1", "node1", corev1.ConditionTrue),
+		newPod("cni-2", "node2", corev1.ConditionFalse),
+	).Build()
+	linux := map[string]string{"kubernetes.io/os": "linux"}
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: linux}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: linux}},
+		// The DaemonSet should run on node3, because it tolerates the control plane taint and because the not-ready taint
+		// is tolerated by all the DaemonSets.
+		{ObjectMeta: metav1.ObjectMeta{Name: "node3", Labels: linux}, Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+			{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule},
+			{Key: corev1.TaintNodeNotReady, Effect: corev1.TaintEffectNoExecute},
+		}}},
+		// The DaemonSet should not run on node4, because of its nodeSelector, and on node5, because of an untolerated taint.
+		{ObjectMeta: metav1.ObjectMeta{Name: "node4", Labels: map[string]string{"kubernetes.io/os": "windows"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node5", Labels: linux}, Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+			{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+		}}},
+	}
+	machines := []client.Object{}
+	for _, node := range nodes {
+		machines = append(machines, newTestMachine("machine-"+node.Name, namespace, testClusterName, node.Name, labels))
+	}
+	// Machines without a Node are not probed.
+	machines = append(machines, newTestMachine("machine-without-node", namespace, testClusterName, "", labels))
+	managementClient := fake.NewClientBuilder().WithObjects(machines...).Build()
+	r := &Reconciler{
+		Client:       managementClient,
+		ClusterCache: clustercache.NewFakeClusterCache(workloadClient, client.ObjectKeyFromObject(cluster)),
+	}
+
+	getTargets := func() []healthCheckTarget {
+		targets := []healthCheckTarget{}
+		for i, m := range machines {
+			machine := &clusterv1.Machine{}
+			g.Expect(managementClient.Get(ctx, client.ObjectKeyFromObject(m), machine)).To(Succeed())
+			target := healthCheckTarget{Machine: machine}
+			if i < len(nodes) {
+				target.Node = nodes[i]
+			}
+			targets = append(targets, target)
+		}
+		return targets
+	}
+
+	targets := getTargets()
+	g.Expect(r.runProbes(ctx, cluster, mhc, targets)).To(Succeed())
+	g.Expect(targets[0].probeFailures).To(BeEmpty())
+	g.Expect(targets[1].probeFailures).To(HaveLen(1))
+	g.Expect(targets[1].probeFailures[0].message).To(ContainSubstring("Pods cni-2 in namespace kube-system are not ready"))
+	g.Expect(targets[2].probeFailures).To(HaveLen(1))
+	g.Expect(targets[2].probeFailures[0].message).To(ContainSubstring("no Pods matching selector k8s-app=cni"))
+	g.Expect(targets[3].probeFailures).To(BeEmpty())
+	g.Expect(targets[4].probeFailures).To(BeEmpty())
+	g.Expect(targets[5].probeFailures).To(BeEmpty())
+
+	// The Machines are considered unhealthy only when the probe fails for longer than its timeout.
+	_, nextCheck := targets[1].probeChecks(logr.New(log.NullLogSink{}))
+	g.Expect(nextCheck).To(BeNumerically("~", 301*time.Second, time.Second))
+
+	// The time when the probe started failing is stored on the Machines.
+	for i, target := range getTargets() {
+		if len(targets[i].probeFailures) == 0 {
+			g.Expect(target.Machine.Annotations).ToNot(HaveKey(clusterv1.MachineHealthCheckFailingProbesAnnotation))
+			continue
+		}
+		data, err := probeFailuresDataFromMachine(target.Machine)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(data).To(HaveKey("mhc"))
+		g.Expect(data["mhc"].FailingSince).To(HaveKey("cni"))
+		g.Expect(data["mhc"].FailingSince["cni"].Time).To(BeTemporally("==", targets[i].probeFailures[0].since))
+	}
+
+	// The time when the probe started failing is preserved, e.g. across restarts of the controller.
+	since := metav1.NewTime(time.Now().Add(-10 * time.Minute).Truncate(time.Second))
+	machine := targets[1].Machine.DeepCopy()
+	g.Expect(setProbeFailuresData(machine, map[string]probeFailuresData{"mhc": {UID: mhc.UID, FailingSince: map[string]metav1.Time{"cni": since}}})).To(Succeed())
+	g.Expect(managementClient.Update(ctx, machine)).To(Succeed())
+
+	targets = getTargets()
+	g.Expect(r.runProbes(ctx, cluster, mhc, targets)).To(Succeed())
+	g.Expect(targets[1].probeFailures).To(HaveLen(1))
+	g.Expect(targets[1].probeFailures[0].since).To(BeTemporally("==", since.Time))
+	messages, _ := targets[1].probeChecks(logr.New(log.NullLogSink{}))
+	g.Expect(messages).To(ConsistOf(ContainSubstring("Probe cni is failing for more than 5m0s")))
+
+	// The time is not preserved for a different MachineHealthCheck with the same name.
+	otherMHC := mhc.DeepCopy()
+	otherMHC.UID = "other-uid"
+	targets = getTargets()
+	g.Expect(r.runProbes(ctx, cluster, otherMHC, targets)).To(Succeed())
+	g.Expect(targets[1].probeFailures).To(HaveLen(1))
+	g.Expect(targets[1].probeFailures[0].since).To(BeTemporally(">", since.Time))
+
+	// The time is dropped when the probe is not failing anymore.
+	g.Expect(r.runProbes(ctx, cluster, &clusterv1.MachineHealthCheck{ObjectMeta: otherMHC.ObjectMeta}, getTargets())).To(Succeed())
+	for _, target := range getTargets() {
+		g.Expect(target.Machine.Annotations).ToNot(HaveKey(clusterv1.MachineHealthCheckFailingProbesAnnotation))
+	}
+}
+
+func TestRunProbesError(t *testing.T) {
+	g := NewWithT(t)
+
+	namespace := metav1.NamespaceDefault
+	labels := map[string]string{"cluster": "foo", "nodepool": "bar"}
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: namespace}}
+
+	mhc := newMachineHealthCheckWithLabels("mhc", namespace, testClusterName, labels)
+	mhc.Spec.Checks.Probes = []clusterv1.MachineHealthCheckProbe{
+		{
+			Name: "cni",
+			DaemonSetPod: clusterv1.MachineHealthCheckDaemonSetPodProbe{
+				Namespace: metav1.NamespaceSystem,
+				Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"k8s-app": "cni"}},
+			},
+		},
+	}
+	// The probe has been failing before.
+	machine := newTestMachine("machine-node1", namespace, testClusterName, "node1", labels)
+	since := metav1.NewTime(time.Now().Add(-10 * time.Minute).Truncate(time.Second))
+	g.Expect(setProbeFailuresData(machine, map[string]probeFailuresData{"mhc": {FailingSince: map[string]metav1.Time{"cni": since}}})).To(Succeed())
+	managementClient := fake.NewClientBuilder().WithObjects(machine).Build()
+
+	// The workload cluster is not connected, so the probe cannot be run.
+	r := &Reconciler{
+		Client:       managementClient,
+		ClusterCache: clustercache.NewFakeEmptyClusterCache(),
+	}
+
+	targets := []healthCheckTarget{
+		{
+			Machine: machine,
+			Node:    &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+		},
+	}
+	g.Expect(r.runProbes(ctx, cluster, mhc, targets)).To(Succeed())
+	// Probes that cannot be run are skipped, so the Nodes are not reported as failing.
+	g.Expect(targets[0].probeFailures).To(BeEmpty())
+
+	// The time when the probe started failing is preserved until the probe can be run again.
+	g.Expect(managementClient.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+	data, err := probeFailuresDataFromMachine(machine)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(data["mhc"].FailingSince).To(HaveKey("cni"))
+	g.Expect(data["mhc"].FailingSince["cni"].Time).To(BeTemporally("==", since.Time))
+}
+
+func TestNodeSelectorTermsMatch(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node1",
+			Labels: map[string]string{"kubernetes.io/os": "linux", "cpus": "8"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		terms []corev1.NodeSelectorTerm
+		want  bool
+	}{
+		{
+			name:  "matches label expressions",
+			terms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "kubernetes.io/os", Operator: corev1.NodeSelectorOpIn, Values: []string{"linux"}}, {Key: "cpus", Operator: corev1.NodeSelectorOpGt, Values: []string{"4"}}}}},
+			want:  true,
+		},
+		{
+			name:  "does not match label expressions",
+			terms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "kubernetes.io/os", Operator: corev1.NodeSelectorOpIn, Values: []string{"linux"}}, {Key: "cpus", Operator: corev1.NodeSelectorOpLt, Values: []string{"4"}}}}},
+			want:  false,
+		},
+		{
+			name:  "matches fields",
+			terms: []corev1.NodeSelectorTerm{{MatchFields: []corev1.NodeSelectorRequirement{{Key: metav1.ObjectNameField, Operator: corev1.NodeSelectorOpIn, Values: []string{"node1"}}}}},
+			want:  true,
+		},
+		{
+			name:  "does not match fields",
+			terms: []corev1.NodeSelectorTerm{{MatchFields: []corev1.NodeSelectorRequirement{{Key: metav1.ObjectNameField, Operator: corev1.NodeSelectorOpNotIn, Values: []string{"node1"}}}}},
+			want:  false,
+		},
+		{
+			name: "matches any of the terms",
+			terms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "kubernetes.io/os", Operator: corev1.NodeSelectorOpIn, Values: []string{"windows"}}}},
+				{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "cpus", Operator: corev1.NodeSelectorOpExists}}},
+			},
+			want: true,
+		},
+		{
+			name:  "empty terms do not match",
+			terms: []corev1.NodeSelectorTerm{{}},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(nodeSelectorTermsMatch(tt.terms, node)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestProbeNodeHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/nodes/http:node1:8080/proxy/healthz", "/api/v1/nodes/https:node1:8443/proxy":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	clientSet, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	tests := []struct {
+		name     string
+		nodeName string
+		probe    clusterv1.MachineHealthCheckHTTPProbe
+		wantErr  bool
+	}{
+		{
+			name:     "succeeds if the endpoint responds successfully",
+			nodeName: "node1",
+			probe:    clusterv1.MachineHealthCheckHTTPProbe{Port: 8080, Path: "/healthz"},
+		},
+		{
+			name:     "succeeds using HTTPS and the default path",
+			nodeName: "node1",
+			probe:    clusterv1.MachineHealthCheckHTTPProbe{Scheme: corev1.URISchemeHTTPS, Port: 8443},
+		},
+		{
+			name:     "fails if the endpoint does not respond successfully",
+			nodeName: "node2",
+			probe:    clusterv1.MachineHealthCheckHTTPProbe{Port: 8080, Path: "/healthz"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := probeNodeHTTP(ctx, clientSet.CoreV1().RESTClient(), tt.nodeName, tt.probe)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
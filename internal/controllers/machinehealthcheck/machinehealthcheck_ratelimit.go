@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// maxRemediationRecords is the maximum number of remediations tracked in status.remediations.
+const maxRemediationRecords = 1000
+
+// remediationRateLimiter enforces spec.remediation.rateLimit of a MachineHealthCheck, by limiting the number
+// of remediations triggered within a sliding time window.
+// Remediations triggered by the MachineHealthCheck are tracked in status.remediations.
+type remediationRateLimiter struct {
+	mhc *clusterv1.MachineHealthCheck
+	now time.Time
+
+	// recordWindow is the time window for which remediations are tracked in status.remediations; it is the longest
+	// among the time window of the MachineHealthCheck and the time windows of the MachineHealthChecks of the same Cluster
+	// with maxClusterRemediations, so remediations are counted by the cluster-wide rate limits even if the MachineHealthCheck
+	// has no rate limit. If zero, remediations are not tracked.
+	recordWindow time.Duration
+
+	// clusterRemediations are the times of the remediations triggered within the time window by the
+	// other MachineHealthChecks of the same Cluster; they are only collected if maxClusterRemediations is set.
+	clusterRemediations []time.Time
+
+	// limited is set when a remediation has not been triggered because the rate limit has been reached.
+	limited bool
+}
+
+// newRemediationRateLimiter returns a remediationRateLimiter for the MachineHealthCheck.
+// NOTE: Remediations which are outside of the time window are dropped from status.remediations; if neither the
+// MachineHealthCheck nor any other MachineHealthCheck of the same Cluster with maxClusterRemediations has a rate limit,
+// status.remediations is cleaned up entirely.
+func (r *Reconciler) newRemediationRateLimiter(ctx context.Context, m *clusterv1.MachineHealthCheck, now time.Time) (*remediationRateLimiter, error) {
+	l := &remediationRateLimiter{mhc: m, now: now}
+
+	mhcList := &clusterv1.MachineHealthCheckList{}
+	if err := r.Client.List(ctx, mhcList,
+		client.InNamespace(m.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: m.Spec.ClusterName},
+	); err != nil {
+		return nil, errors.Wrapf(err, "failed to list MachineHealthChecks for Cluster %s", m.Spec.ClusterName)
+	}
+
+	if l.isDefined() {
+		l.recordWindow = l.window()
+	}
+	for _, other := range mhcList.Items {
+		if other.Name == m.Name || other.Spec.Remediation.RateLimit.MaxClusterRemediations == nil {
+			continue
+		}
+		l.recordWindow = max(l.recordWindow, time.Duration(other.Spec.Remediation.RateLimit.WindowSeconds)*time.Second)
+	}
+
+	var remediations []clusterv1.MachineHealthCheckRemediationRecord
+	for _, remediation := range m.Status.Remediations {
+		if remediation.Time.After(now.Add(-l.recordWindow)) {
+			remediations = append(remediations, remediation)
+		}
+	}
+	m.Status.Remediations = remediations
+
+	if m.Spec.Remediation.RateLimit.MaxClusterRemediations == nil {
+		return l, nil
+	}
+
+	for _, other := range mhcList.Items {
+		if other.Name == m.Name {
+			continue
+		}
+		for _, remediation := range other.Status.Remediations {
+			if l.inWindow(remediation.Time.Time) {
+				l.clusterRemediations = append(l.clusterRemediations, remediation.Time.Time)
+			}
+		}
+	}
+	return l, nil
+}
+
+// isDefined returns true if the MachineHealthCheck has a rate limit.
+func (l *remediationRateLimiter) isDefined() bool {
+	return l.mhc.Spec.Remediation.RateLimit.IsDefined()
+}
+
+// window returns the length of the time window.
+func (l *remediationRateLimiter) window() time.Duration {
+	return time.Duration(l.mhc.Spec.Remediation.RateLimit.WindowSeconds) * time.Second
+}
+
+// inWindow returns true if t is within the time window.
+func (l *remediationRateLimiter) inWindow(t time.Time) bool {
+	return t.After(l.now.Add(-l.window()))
+}
+
+// remediations returns the times of the remediations triggered by the MachineHealthCheck within the time window.
+// NOTE: status.remediations might also contain older remediations, which are kept for the cluster-wide rate limits
+// of other MachineHealthChecks with a longer time window.
+func (l *remediationRateLimiter) remediations() []time.Time {
+	var times []time.Time
+	for _, remediation := range l.mhc.Status.Remediations {
+		if l.inWindow(remediation.Time.Time) {
+			times = append(times, remediation.Time.Time)
+		}
+	}
+	return times
+}
+
+// remaining returns the number of remediations that can still be triggered within the time window,
+// or nil if the number of remediations is not limited.
+func (l *remediationRateLimiter) remaining() *int32 {
+	if !l.isDefined() {
+		return nil
+	}
+
+	rateLimit := l.mhc.Spec.Remediation.RateLimit
+	remediations := len(l.remediations())
+	var remaining *int32
+	if rateLimit.MaxRemediations != nil {
+		remaining = ptr.To(max(*rateLimit.MaxRemediations-int32(remediations), 0))
+	}
+	if rateLimit.MaxClusterRemediations != nil {
+		clusterRemaining := max(*rateLimit.MaxClusterRemediations-int32(remediations+len(l.clusterRemediations)), 0)
+		if remaining == nil || clusterRemaining < *remaining {
+			remaining = ptr.To(clusterRemaining)
+		}
+	}
+	return remaining
+}
+
+// allow returns true if a new remediation can be triggered; if not, the rate limiter is marked as limited.
+func (l *remediationRateLimiter) allow() bool {
+	if remaining := l.remaining(); remaining != nil && *remaining == 0 {
+		l.limited = true
+		return false
+	}
+	return true
+}
+
+// record records a new remediation in status.remediations, if remediations are tracked.
+func (l *remediationRateLimiter) record(machineName string) {
+	if l.recordWindow == 0 {
+		return
+	}
+	// Drop the oldest remediations if status.remediations is full; this can only happen if the MachineHealthCheck
+	// is not limited by its own rate limit.
+	if len(l.mhc.Status.Remediations) >= maxRemediationRecords {
+		l.mhc.Status.Remediations = l.mhc.Status.Remediations[len(l.mhc.Status.Remediations)-maxRemediationRecords+1:]
+	}
+	l.mhc.Status.Remediations = append(l.mhc.Status.Remediations, clusterv1.MachineHealthCheckRemediationRecord{
+		MachineName: machineName,
+		Time:        metav1.NewTime(l.now),
+	})
+}
+
+// requeueAfter returns the time after which the oldest remediation in the time window expires,
+// and thus a new remediation might be allowed.
+func (l *remediationRateLimiter) requeueAfter() time.Duration {
+	var oldest time.Time
+	times := append(l.remediations(), l.clusterRemediations...)
+	for _, t := range times {
+		if oldest.IsZero() || t.Before(oldest) {
+			oldest = t
+		}
+	}
+	if oldest.IsZero() {
+		return 0
+	}
+	// Note: add one second to account for the precision of the timestamps stored in status.
+	return oldest.Add(l.window()).Sub(l.now) + time.Second
+}
+
+// message returns a message explaining why remediations are rate limited.
+func (l *remediationRateLimiter) message() string {
+	rateLimit := l.mhc.Spec.Remediation.RateLimit
+	remediations := len(l.remediations())
+	if rateLimit.MaxRemediations != nil && int32(remediations) >= *rateLimit.MaxRemediations {
+		return fmt.Sprintf("Remediation is not allowed, the number of remediations triggered in the last %s reached maxRemediations (remediations: %d, maxRemediations: %d)",
+			l.window(),
+			remediations,
+			*rateLimit.MaxRemediations)
+	}
+	return fmt.Sprintf("Remediation is not allowed, the number of remediations triggered for the Cluster in the last %s reached maxClusterRemediations (remediations: %d, maxClusterRemediations: %d)",
+		l.window(),
+		remediations+len(l.clusterRemediations),
+		ptr.Deref(rateLimit.MaxClusterRemediations, 0))
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+func TestRemediationRateLimiter(t *testing.T) {
+	now := time.Now()
+	labels := map[string]string{"cluster": "foo", "nodepool": "bar"}
+	remediation := func(machineName string, age time.Duration) clusterv1.MachineHealthCheckRemediationRecord {
+		return clusterv1.MachineHealthCheckRemediationRecord{MachineName: machineName, Time: metav1.NewTime(now.Add(-age))}
+	}
+
+	t.Run("does not limit remediations if rateLimit is not set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		mhc := newMachineHealthCheckWithLabels("mhc", metav1.NamespaceDefault, testClusterName, labels)
+		mhc.Status.Remediations = []clusterv1.MachineHealthCheckRemediationRecord{remediation("m1", time.Minute)}
+		r := &Reconciler{Client: fake.NewClientBuilder().Build()}
+
+		l, err := r.newRemediationRateLimiter(ctx, mhc, now)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(mhc.Status.Remediations).To(BeEmpty())
+		g.Expect(l.remaining()).To(BeNil())
+		g.Expect(l.allow()).To(BeTrue())
+		l.record("m2")
+		g.Expect(mhc.Status.Remediations).To(BeEmpty())
+	})
+
+	t.Run("limits remediations triggered by the MachineHealthCheck", func(t *testing.T) {
+		g := NewWithT(t)
+
+		mhc := newMachineHealthCheckWithLabels("mhc", metav1.NamespaceDefault, testClusterName, labels)
+		mhc.Spec.Remediation.RateLimit = clusterv1.MachineHealthCheckRemediationRateLimit{
+			WindowSeconds:   600,
+			MaxRemediations: ptr.To[int32](2),
+		}
+		mhc.Status.Remediations = []clusterv1.MachineHealthCheckRemediationRecord{
+			remediation("m1", 20*time.Minute),
+			remediation("m2", 5*time.Minute),
+		}
+		r := &Reconciler{Client: fake.NewClientBuilder().Build()}
+
+		l, err := r.newRemediationRateLimiter(ctx, mhc, now)
+		g.Expect(err).ToNot(HaveOccurred())
+		// Remediations outside of the time window are dropped.
+		g.Expect(mhc.Status.Remediations).To(HaveLen(1))
+		g.Expect(l.remaining()).To(HaveValue(BeEquivalentTo(1)))
+		g.Expect(l.allow()).To(BeTrue())
+
+		l.record("m3")
+		g.Expect(mhc.Status.Remediations).To(HaveLen(2))
+		g.Expect(l.remaining()).To(HaveValue(BeEquivalentTo(0)))
+		g.Expect(l.allow()).To(BeFalse())
+		g.Expect(l.limited).To(BeTrue())
+		g.Expect(l.message()).To(ContainSubstring("maxRemediations: 2"))
+		// The next remediation is allowed when m2 exits the time window.
+		g.Expect(l.requeueAfter()).To(Equal(5*time.Minute + time.Second))
+	})
+
+	t.Run("limits remediations triggered by all the MachineHealthChecks of the Cluster", func(t *testing.T) {
+		g := NewWithT(t)
+
+		mhc := newMachineHealthCheckWithLabels("mhc", metav1.NamespaceDefault, testClusterName, labels)
+		mhc.Spec.Remediation.RateLimit = clusterv1.MachineHealthCheckRemediationRateLimit{
+			WindowSeconds:          600,
+			MaxRemediations:        ptr.To[int32](5),
+			MaxClusterRemediations: ptr.To[int32](3),
+		}
+		mhc.Status.Remediations = []clusterv1.MachineHealthCheckRemediationRecord{remediation("m1", time.Minute)}
+
+		other := newMachineHealthCheckWithLabels("other", metav1.NamespaceDefault, testClusterName, labels)
+		other.Status.Remediations = []clusterv1.MachineHealthCheckRemediationRecord{
+			remediation("m2", 20*time.Minute),
+			remediation("m3", 8*time.Minute),
+			remediation("m4", 2*time.Minute),
+		}
+		otherCluster := newMachineHealthCheckWithLabels("other-cluster", metav1.NamespaceDefault, "other-cluster", labels)
+		otherCluster.Status.Remediations = []clusterv1.MachineHealthCheckRemediationRecord{remediation("m5", time.Minute)}
+
+		r := &Reconciler{Client: fake.NewClientBuilder().WithObjects(mhc.DeepCopy(), other, otherCluster).Build()}
+
+		l, err := r.newRemediationRateLimiter(ctx, mhc, now)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(l.clusterRemediations).To(HaveLen(2))
+		g.Expect(l.remaining()).To(HaveValue(BeEquivalentTo(0)))
+		g.Expect(l.allow()).To(BeFalse())
+		g.Expect(l.message()).To(ContainSubstring("maxClusterRemediations: 3"))
+		// The next remediation is allowed when m3 exits the time window.
+		g.Expect(l.requeueAfter()).To(BeNumerically("~", 2*time.Minute+time.Second, time.Second))
+	})
+
+	t.Run("counts remediations of MachineHealthChecks without rateLimit for maxClusterRemediations", func(t *testing.T) {
+		g := NewWithT(t)
+
+		limited := newMachineHealthCheckWithLabels("limited", metav1.NamespaceDefault, testClusterName, labels)
+		limited.Spec.Remediation.RateLimit = clusterv1.MachineHealthCheckRemediationRateLimit{
+			WindowSeconds:          1200,
+			MaxClusterRemediations: ptr.To[int32](2),
+		}
+		unlimited := newMachineHealthCheckWithLabels("unlimited", metav1.NamespaceDefault, testClusterName, labels)
+		unlimited.Status.Remediations = []clusterv1.MachineHealthCheckRemediationRecord{
+			remediation("m1", 30*time.Minute),
+			remediation("m2", 15*time.Minute),
+		}
+
+		r := &Reconciler{Client: fake.NewClientBuilder().WithObjects(limited.DeepCopy(), unlimited.DeepCopy()).Build()}
+
+		// The MachineHealthCheck without rateLimit tracks its remediations within the time window of the
+		// MachineHealthCheck with maxClusterRemediations.
+		unlimitedLimiter, err := r.newRemediationRateLimiter(ctx, unlimited, now)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(unlimited.Status.Remediations).To(HaveLen(1))
+		g.Expect(unlimitedLimiter.remaining()).To(BeNil())
+		g.Expect(unlimitedLimiter.allow()).To(BeTrue())
+		unlimitedLimiter.record("m3")
+		g.Expect(unlimited.Status.Remediations).To(HaveLen(2))
+
+		// The remediations of the MachineHealthCheck without rateLimit are counted by maxClusterRemediations.
+		r = &Reconciler{Client: fake.NewClientBuilder().WithObjects(limited.DeepCopy(), unlimited).Build()}
+		limitedLimiter, err := r.newRemediationRateLimiter(ctx, limited, now)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(limitedLimiter.clusterRemediations).To(HaveLen(2))
+		g.Expect(limitedLimiter.remaining()).To(HaveValue(BeEquivalentTo(0)))
+		g.Expect(limitedLimiter.allow()).To(BeFalse())
+		g.Expect(limitedLimiter.message()).To(ContainSubstring("maxClusterRemediations: 2"))
+	})
+
+	t.Run("does not track remediations of MachineHealthChecks without rateLimit without maxClusterRemediations", func(t *testing.T) {
+		g := NewWithT(t)
+
+		limited := newMachineHealthCheckWithLabels("limited", metav1.NamespaceDefault, testClusterName, labels)
+		limited.Spec.Remediation.RateLimit = clusterv1.MachineHealthCheckRemediationRateLimit{
+			WindowSeconds:   1200,
+			MaxRemediations: ptr.To[int32](2),
+		}
+		unlimited := newMachineHealthCheckWithLabels("unlimited", metav1.NamespaceDefault, testClusterName, labels)
+		unlimited.Status.Remediations = []clusterv1.MachineHealthCheckRemediationRecord{remediation("m1", time.Minute)}
+
+		r := &Reconciler{Client: fake.NewClientBuilder().WithObjects(limited, unlimited.DeepCopy()).Build()}
+
+		l, err := r.newRemediationRateLimiter(ctx, unlimited, now)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(unlimited.Status.Remediations).To(BeEmpty())
+		l.record("m2")
+		g.Expect(unlimited.Status.Remediations).To(BeEmpty())
+	})
+}
+
+func TestPatchUnhealthyTargetsRateLimited(t *testing.T) {
+	g := NewWithT(t)
+
+	namespace := metav1.NamespaceDefault
+	labels := map[string]string{"cluster": "foo", "nodepool": "bar"}
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: namespace}}
+
+	mhc := newMachineHealthCheckWithLabels("mhc", namespace, testClusterName, labels)
+	mhc.Spec.Remediation.RateLimit = clusterv1.MachineHealthCheckRemediationRateLimit{
+		WindowSeconds:   600,
+		MaxRemediations: ptr.To[int32](1),
+	}
+
+	machine1 := newTestMachine("machine1", namespace, testClusterName, "node1", labels)
+	machine2 := newTestMachine("machine2", namespace, testClusterName, "node2", labels)
+	cl := fake.NewClientBuilder().WithObjects(machine1, machine2, mhc).WithStatusSubresource(&clusterv1.Machine{}).Build()
+	r := &Reconciler{Client: cl, recorder: record.NewFakeRecorder(32)}
+
+	targets := []healthCheckTarget{}
+	for _, m := range []*clusterv1.Machine{machine1, machine2} {
+		patchHelper, err := patch.NewHelper(m, cl)
+		g.Expect(err).ToNot(HaveOccurred())
+		conditions.Set(m, metav1.Condition{
+			Type:   clusterv1.MachineHealthCheckSucceededCondition,
+			Status: metav1.ConditionFalse,
+			Reason: clusterv1.MachineHealthCheckUnhealthyNodeReason,
+		})
+		targets = append(targets, healthCheckTarget{MHC: mhc, Machine: m, Node: &corev1.Node{}, patchHelper: patchHelper})
+	}
+
+	rateLimiter, err := r.newRemediationRateLimiter(ctx, mhc, time.Now())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.patchUnhealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), targets, cluster, mhc, rateLimiter)).To(BeEmpty())
+
+	// Only the first Machine is remediated, the second one is rate limited.
+	g.Expect(rateLimiter.limited).To(BeTrue())
+	g.Expect(mhc.Status.Remediations).To(HaveLen(1))
+	g.Expect(mhc.Status.Remediations[0].MachineName).To(Equal(machine1.Name))
+
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(machine1), machine1)).To(Succeed())
+	g.Expect(conditions.Get(machine1, clusterv1.MachineOwnerRemediatedCondition)).ToNot(BeNil())
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(machine2), machine2)).To(Succeed())
+	g.Expect(conditions.Get(machine2, clusterv1.MachineOwnerRemediatedCondition)).To(BeNil())
+	g.Expect(conditions.IsFalse(machine2, clusterv1.MachineHealthCheckSucceededCondition)).To(BeTrue())
+}
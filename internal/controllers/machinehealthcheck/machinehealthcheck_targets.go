@@ -61,6 +61,9 @@ type healthCheckTarget struct {
 	MHC         *clusterv1.MachineHealthCheck
 	patchHelper *patch.Helper
 	nodeMissing bool
+
+	// probeFailures are the probes failing for the Node of the Machine.
+	probeFailures []probeFailure
 }
 
 // needsRemediation determines whether a given target needs remediation.
@@ -70,6 +73,7 @@ type healthCheckTarget struct {
 // - The Machine did not get a node before `timeoutForMachineToHaveNode` elapses
 // - The Node has been deleted but the Machine still references it
 // - Any condition on the node matches the configured checks and exceeds the timeout
+// - Any probe run against the workload cluster for the node is failing for longer than the timeout
 //
 // Machine conditions are always evaluated first and consistently across all scenarios
 // (node missing, node startup timeout, node exists) to ensure comprehensive health checking.
@@ -112,8 +116,11 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 	// Check node conditions
 	nodeConditionReason, nodeV1beta1ConditionReason, unhealthyNodeMessages, nextNodeCheck := t.nodeChecks(logger, timeoutForMachineToHaveNode)
 
+	// Check probes
+	unhealthyProbeMessages, nextProbeCheck := t.probeChecks(logger)
+
 	// Combine results
-	if len(unhealthyMachineMessages) == 0 && len(unhealthyNodeMessages) == 0 {
+	if len(unhealthyMachineMessages) == 0 && len(unhealthyNodeMessages) == 0 && len(unhealthyProbeMessages) == 0 {
 		var nextCheckTimes []time.Duration
 		if nextMachineCheck > 0 {
 			nextCheckTimes = append(nextCheckTimes, nextMachineCheck)
@@ -121,12 +128,19 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 		if nextNodeCheck > 0 {
 			nextCheckTimes = append(nextCheckTimes, nextNodeCheck)
 		}
+		if nextProbeCheck > 0 {
+			nextCheckTimes = append(nextCheckTimes, nextProbeCheck)
+		}
 		result := minDuration(nextCheckTimes)
 		return false, result
 	}
 
 	reason := nodeConditionReason
 	v1beta1Reason := nodeV1beta1ConditionReason
+	if len(unhealthyNodeMessages) == 0 && len(unhealthyProbeMessages) > 0 {
+		reason = clusterv1.MachineHealthCheckUnhealthyProbeReason
+		v1beta1Reason = clusterv1.UnhealthyProbeV1Beta1Reason
+	}
 	if len(unhealthyMachineMessages) > 0 {
 		reason = clusterv1.MachineHealthCheckUnhealthyMachineReason
 		v1beta1Reason = clusterv1.UnhealthyMachineConditionV1Beta1Reason
@@ -134,6 +148,7 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 
 	// Combine all messages into a single comprehensive message
 	allMessages := append(unhealthyMachineMessages, unhealthyNodeMessages...)
+	allMessages = append(allMessages, unhealthyProbeMessages...)
 
 	conditionMessage := "Health check failed:\n"
 	for i, m := range allMessages {
@@ -294,6 +309,36 @@ func (t *healthCheckTarget) nodeChecks(logger logr.Logger, timeoutForMachineToHa
 	return "", "", nil, minDuration(nextCheckTimes)
 }
 
+func (t *healthCheckTarget) probeChecks(logger logr.Logger) ([]string, time.Duration) {
+	var unhealthyProbeMessages []string
+	var nextCheckTimes []time.Duration
+	now := time.Now()
+
+	for _, f := range t.probeFailures {
+		// If the probe has been failing for longer than the timeout, mark as unhealthy and collect the message.
+		timeoutSecondsDuration := time.Duration(ptr.Deref(f.probe.TimeoutSeconds, 0)) * time.Second
+
+		if f.since.Add(timeoutSecondsDuration).Before(now) {
+			unhealthyProbeMessages = append(unhealthyProbeMessages, fmt.Sprintf("Probe %s is failing for more than %s: %s",
+				f.probe.Name, timeoutSecondsDuration.String(), f.message))
+			logger.V(3).Info(fmt.Sprintf("Target is unhealthy: probe is failing for more than %s", timeoutSecondsDuration.String()),
+				"probe", f.probe.Name, "message", f.message)
+			continue
+		}
+
+		durationUnhealthy := now.Sub(f.since)
+		nextCheck := timeoutSecondsDuration - durationUnhealthy + time.Second
+		if nextCheck > 0 {
+			nextCheckTimes = append(nextCheckTimes, nextCheck)
+		}
+	}
+
+	if len(unhealthyProbeMessages) > 0 {
+		return unhealthyProbeMessages, time.Duration(0)
+	}
+	return nil, minDuration(nextCheckTimes)
+}
+
 // getTargetsFromMHC uses the MachineHealthCheck's selector to fetch machines
 // and their nodes targeted by the health check, ready for health checking.
 func (r *Reconciler) getTargetsFromMHC(ctx context.Context, logger logr.Logger, clusterClient client.Reader, cluster *clusterv1.Cluster, mhc *clusterv1.MachineHealthCheck) ([]healthCheckTarget, error) {
@@ -231,6 +231,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		wrapErrMachinePoolReconcileFunc(r.getMachinesForMachinePool, "failed to get Machines for MachinePool"),
 		wrapErrMachinePoolReconcileFunc(r.reconcileNodeRefs, "failed to reconcile nodeRefs"),
 		wrapErrMachinePoolReconcileFunc(r.setMachinesUptoDate, "failed to set machines up to date"),
+		wrapErrMachinePoolReconcileFunc(r.reconcileRollout, "failed to reconcile rollout"),
 	)
 
 	return doReconcile(ctx, scope, reconcileNormal)
@@ -446,7 +447,18 @@ func (r *Reconciler) getMachinesForMachinePool(ctx context.Context, s *scope) (c
 }
 
 func (r *Reconciler) setMachinesUptoDate(ctx context.Context, s *scope) (ctrl.Result, error) {
+	// Note: The template is compared only when the infrastructure MachinePool is known, otherwise
+	// the hash would not include the infrastructure MachinePool spec.template.
+	var templateHash string
+	if s.infraMachinePool != nil {
+		var err error
+		if templateHash, err = machinePoolTemplateHash(s.machinePool, s.infraMachinePool); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	var errs []error
+	now := time.Now()
 	for _, machine := range s.machines {
 		patchHelper, err := patch.NewHelper(machine, r.Client)
 		if err != nil {
@@ -458,13 +470,17 @@ func (r *Reconciler) setMachinesUptoDate(ctx context.Context, s *scope) (ctrl.Re
 			Type: clusterv1.MachineUpToDateCondition,
 		}
 
-		if machine.DeletionTimestamp.IsZero() {
-			upToDateCondition.Status = metav1.ConditionTrue
-			upToDateCondition.Reason = clusterv1.MachineUpToDateReason
-		} else {
+		if !machine.DeletionTimestamp.IsZero() {
 			upToDateCondition.Status = metav1.ConditionFalse
 			upToDateCondition.Reason = clusterv1.MachineNotUpToDateReason
 			upToDateCondition.Message = "Machine is being deleted"
+		} else if upToDate, message := isMachineUpToDate(s.machinePool, machine, templateHash, now); !upToDate {
+			upToDateCondition.Status = metav1.ConditionFalse
+			upToDateCondition.Reason = clusterv1.MachineNotUpToDateReason
+			upToDateCondition.Message = message
+		} else {
+			upToDateCondition.Status = metav1.ConditionTrue
+			upToDateCondition.Reason = clusterv1.MachineUpToDateReason
 		}
 		conditions.Set(machine, *upToDateCondition)
 
@@ -405,7 +405,12 @@ func (r *Reconciler) reconcileMachines(ctx context.Context, s *scope, infraMachi
 		return err
 	}
 
-	if err := r.createOrUpdateMachines(ctx, s, machineList.Items, infraMachineList.Items); err != nil {
+	templateHash, err := machinePoolTemplateHash(mp, infraMachinePool)
+	if err != nil {
+		return err
+	}
+
+	if err := r.createOrUpdateMachines(ctx, s, machineList.Items, infraMachineList.Items, templateHash); err != nil {
 		return errors.Wrapf(err, "failed to create machines for MachinePool %q in namespace %q", mp.Name, mp.Namespace)
 	}
 
@@ -413,7 +418,7 @@ func (r *Reconciler) reconcileMachines(ctx context.Context, s *scope, infraMachi
 }
 
 // createOrUpdateMachines creates a MachinePool Machine for each infraMachine if it doesn't already exist and sets the owner reference and infraRef.
-func (r *Reconciler) createOrUpdateMachines(ctx context.Context, s *scope, machines []clusterv1.Machine, infraMachines []unstructured.Unstructured) error {
+func (r *Reconciler) createOrUpdateMachines(ctx context.Context, s *scope, machines []clusterv1.Machine, infraMachines []unstructured.Unstructured, templateHash string) error {
 	log := ctrl.LoggerFrom(ctx)
 
 	// Construct a set of names of infraMachines that already have a Machine.
@@ -442,7 +447,7 @@ func (r *Reconciler) createOrUpdateMachines(ctx context.Context, s *scope, machi
 		if existingMachine, ok := infraMachineToMachine[infraMachine.GetName()]; ok {
 			log.V(2).Info("Patching existing Machine for infraMachine", infraMachine.GetKind(), klog.KObj(infraMachine), "Machine", klog.KObj(&existingMachine))
 
-			desiredMachine := r.computeDesiredMachine(s.machinePool, infraMachine, &existingMachine, node)
+			desiredMachine := r.computeDesiredMachine(s.machinePool, infraMachine, &existingMachine, node, templateHash)
 			if err := ssa.Patch(ctx, r.Client, MachinePoolControllerName, desiredMachine, ssa.WithCachingProxy{Cache: r.ssaCache, Original: &existingMachine}); err != nil {
 				log.Error(err, "failed to update Machine", "Machine", klog.KObj(desiredMachine))
 				errs = append(errs, errors.Wrapf(err, "failed to update Machine %q", klog.KObj(desiredMachine)))
@@ -450,7 +455,7 @@ func (r *Reconciler) createOrUpdateMachines(ctx context.Context, s *scope, machi
 		} else {
 			// Otherwise create a new Machine for the infraMachine.
 			log.Info("Creating new Machine for infraMachine", "infraMachine", klog.KObj(infraMachine))
-			machine := r.computeDesiredMachine(s.machinePool, infraMachine, nil, node)
+			machine := r.computeDesiredMachine(s.machinePool, infraMachine, nil, node, templateHash)
 
 			if err := ssa.Patch(ctx, r.Client, MachinePoolControllerName, machine); err != nil {
 				errs = append(errs, errors.Wrapf(err, "failed to create new Machine for infraMachine %q in namespace %q", infraMachine.GetName(), infraMachine.GetNamespace()))
@@ -472,7 +477,8 @@ func (r *Reconciler) createOrUpdateMachines(ctx context.Context, s *scope, machi
 
 // computeDesiredMachine constructs the desired Machine for an infraMachine.
 // If the Machine exists, it ensures the Machine always owned by the MachinePool.
-func (r *Reconciler) computeDesiredMachine(mp *clusterv1.MachinePool, infraMachine *unstructured.Unstructured, existingMachine *clusterv1.Machine, existingNode *corev1.Node) *clusterv1.Machine {
+// Note: The template hash annotation is set to the current template hash when the Machine is created, and then preserved.
+func (r *Reconciler) computeDesiredMachine(mp *clusterv1.MachinePool, infraMachine *unstructured.Unstructured, existingMachine *clusterv1.Machine, existingNode *corev1.Node, templateHash string) *clusterv1.Machine {
 	infraRef := clusterv1.ContractVersionedObjectReference{
 		APIGroup: infraMachine.GroupVersionKind().Group,
 		Kind:     infraMachine.GetKind(),
@@ -512,6 +518,13 @@ func (r *Reconciler) computeDesiredMachine(mp *clusterv1.MachinePool, infraMachi
 		machine.Annotations[k] = v
 	}
 
+	machine.Annotations[clusterv1.MachinePoolTemplateHashAnnotation] = templateHash
+	if existingMachine != nil {
+		if existingTemplateHash, ok := existingMachine.Annotations[clusterv1.MachinePoolTemplateHashAnnotation]; ok {
+			machine.Annotations[clusterv1.MachinePoolTemplateHashAnnotation] = existingTemplateHash
+		}
+	}
+
 	// Set the labels from machinePool.Spec.Template.Labels as labels for the new Machine.
 	// Note: We can't just set `machinePool.Spec.Template.Labels` directly and thus "share" the labels
 	// map between Machine and machinePool.Spec.Template.Labels. This would mean that adding the
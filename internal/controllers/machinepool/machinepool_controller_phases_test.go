@@ -2334,5 +2334,6 @@ func reconcileNormalFuncsForTest(mpr *Reconciler) []machinePoolReconcileFunc {
 		mpr.getMachinesForMachinePool,
 		mpr.reconcileNodeRefs,
 		mpr.setMachinesUptoDate,
+		mpr.reconcileRollout,
 	}
 }
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/internal/util/hash"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/version"
+)
+
+// reconcileRollout deletes MachinePool Machines which are not up-to-date according to the MachinePool
+// rollout strategy, so that the infrastructure provider replaces them with up-to-date instances.
+// Note: Rollout is enforced only for infrastructure providers implementing MachinePool Machines, and only if
+// the RollingUpdate strategy is explicitly set; otherwise the rollout is left to the infrastructure provider.
+func (r *Reconciler) reconcileRollout(ctx context.Context, s *scope) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	mp := s.machinePool
+
+	if mp.Spec.Rollout.Strategy.Type != clusterv1.RollingUpdateMachinePoolStrategyType {
+		return ctrl.Result{}, nil
+	}
+
+	if s.infraMachinePool == nil {
+		return ctrl.Result{}, nil
+	}
+	hasMachinePoolMachines, err := s.hasMachinePoolMachines()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !hasMachinePoolMachines {
+		return ctrl.Result{}, nil
+	}
+
+	templateHash, err := machinePoolTemplateHash(mp, s.infraMachinePool)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	outdatedMachines := []*clusterv1.Machine{}
+	availableMachines := int32(0)
+	for _, machine := range s.machines {
+		if !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if conditions.IsTrue(machine, clusterv1.MachineAvailableCondition) {
+			availableMachines++
+		}
+		if upToDate, _ := isMachineUpToDate(mp, machine, templateHash, now); !upToDate {
+			outdatedMachines = append(outdatedMachines, machine)
+		}
+	}
+	if len(outdatedMachines) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	maxUnavailable, err := machinePoolMaxUnavailable(mp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	minAvailable := ptr.Deref(mp.Spec.Replicas, 1) - maxUnavailable
+	// Note: If maxUnavailable is 0, Machines are deleted only when the infrastructure provider creates Machines
+	// above the desired number of replicas.
+	budget := availableMachines - minAvailable
+	if budget <= 0 {
+		log.V(4).Info(fmt.Sprintf("Waiting for MachinePool Machines to become available before continuing the rollout, %d Machines are not up-to-date", len(outdatedMachines)))
+		// Note: The MachinePool controller does not watch Machines, so requeue to check again for available Machines.
+		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	}
+
+	// Delete the oldest Machines first.
+	sort.SliceStable(outdatedMachines, func(i, j int) bool {
+		if !outdatedMachines[i].CreationTimestamp.Equal(&outdatedMachines[j].CreationTimestamp) {
+			return outdatedMachines[i].CreationTimestamp.Before(&outdatedMachines[j].CreationTimestamp)
+		}
+		return outdatedMachines[i].Name < outdatedMachines[j].Name
+	})
+
+	for i := 0; i < len(outdatedMachines) && int32(i) < budget; i++ {
+		machine := outdatedMachines[i]
+		log.Info("Deleting MachinePool Machine because it is not up-to-date", "Machine", klog.KObj(machine))
+		if err := r.Client.Delete(ctx, machine); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to delete Machine %s", klog.KObj(machine))
+		}
+		r.recorder.Eventf(mp, corev1.EventTypeNormal, "SuccessfulDelete", "Deleted Machine %q because it is not up-to-date", machine.Name)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// isMachineUpToDate returns true if the Machine matches the MachinePool spec; if the Machine is not up-to-date,
+// a message with the reason why is returned.
+// Note: templateHash is the current hash computed by machinePoolTemplateHash; if empty, the template is not compared.
+func isMachineUpToDate(mp *clusterv1.MachinePool, machine *clusterv1.Machine, templateHash string, now time.Time) (bool, string) {
+	if desiredVersion := mp.Spec.Template.Spec.Version; desiredVersion != "" && machine.Spec.Version != "" && !isSameVersion(desiredVersion, machine.Spec.Version) {
+		return false, fmt.Sprintf("Version %s, %s required", machine.Spec.Version, desiredVersion)
+	}
+
+	if machineTemplateHash, ok := machine.Annotations[clusterv1.MachinePoolTemplateHashAnnotation]; ok && templateHash != "" && machineTemplateHash != templateHash {
+		return false, "MachinePool template changed"
+	}
+
+	if rolloutAfter := mp.Spec.Rollout.After; !rolloutAfter.IsZero() && rolloutAfter.Time.Before(now) && machine.CreationTimestamp.Before(&rolloutAfter) {
+		return false, "MachinePool spec.rollout.after expired"
+	}
+
+	return true, ""
+}
+
+// machinePoolTemplateHash returns the hash of the MachinePool spec.template fields whose changes require a rollout,
+// i.e. the bootstrap config and the infrastructure reference, and of the infrastructure MachinePool spec.template, if any.
+// Note: Infrastructure MachinePools are mutated in place, so changes to their spec.template are detected by including
+// it in the hash; other fields of the infrastructure MachinePool, e.g. replicas, should never be the reason for a rollout.
+func machinePoolTemplateHash(mp *clusterv1.MachinePool, infraMachinePool *unstructured.Unstructured) (string, error) {
+	bootstrap := mp.Spec.Template.Spec.Bootstrap.DeepCopy()
+	if bootstrap.ConfigRef.IsDefined() {
+		// The data secret name is set from the bootstrap config status, so it is not compared.
+		bootstrap.DataSecretName = nil
+	}
+
+	var infraTemplate interface{}
+	if infraMachinePool != nil {
+		infraTemplate, _, _ = unstructured.NestedFieldNoCopy(infraMachinePool.Object, "spec", "template")
+	}
+
+	templateHash, err := hash.Compute(struct {
+		Bootstrap         *clusterv1.Bootstrap
+		InfrastructureRef clusterv1.ContractVersionedObjectReference
+		InfraTemplate     interface{}
+	}{
+		Bootstrap:         bootstrap,
+		InfrastructureRef: mp.Spec.Template.Spec.InfrastructureRef,
+		InfraTemplate:     infraTemplate,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to compute template hash for MachinePool %s", klog.KObj(mp))
+	}
+	return fmt.Sprintf("%d", templateHash), nil
+}
+
+func isSameVersion(a, b string) bool {
+	aVersion, err := semver.ParseTolerant(a)
+	if err != nil {
+		return a == b
+	}
+	bVersion, err := semver.ParseTolerant(b)
+	if err != nil {
+		return a == b
+	}
+	return version.Compare(aVersion, bVersion) == 0
+}
+
+// machinePoolMaxUnavailable returns the absolute value of maxUnavailable for a MachinePool;
+// if not set, maxUnavailable defaults to 1.
+func machinePoolMaxUnavailable(mp *clusterv1.MachinePool) (int32, error) {
+	maxUnavailable := mp.Spec.Rollout.Strategy.RollingUpdate.MaxUnavailable
+	if maxUnavailable == nil {
+		return 1, nil
+	}
+	value, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, int(ptr.Deref(mp.Spec.Replicas, 1)), false)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to compute maxUnavailable for MachinePool %s", klog.KObj(mp))
+	}
+	return int32(value), nil
+}
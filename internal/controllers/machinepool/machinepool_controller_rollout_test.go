@@ -0,0 +1,395 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+)
+
+func TestIsMachineUpToDate(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		mp           func(mp *clusterv1.MachinePool)
+		machine      func(m *clusterv1.Machine)
+		wantUpToDate bool
+		wantMessage  string
+	}{
+		{
+			name:         "machine with the same version is up-to-date",
+			wantUpToDate: true,
+		},
+		{
+			name: "machine without a version is up-to-date",
+			machine: func(m *clusterv1.Machine) {
+				m.Spec.Version = ""
+			},
+			wantUpToDate: true,
+		},
+		{
+			name: "machine with a version with build metadata is up-to-date",
+			machine: func(m *clusterv1.Machine) {
+				m.Spec.Version = "v1.31.0+build.1"
+			},
+			wantUpToDate: true,
+		},
+		{
+			name: "machine with a different version is not up-to-date",
+			machine: func(m *clusterv1.Machine) {
+				m.Spec.Version = "v1.30.0"
+			},
+			wantUpToDate: false,
+			wantMessage:  "Version v1.30.0, v1.31.0 required",
+		},
+		{
+			name: "machine created before rollout.after is not up-to-date",
+			mp: func(mp *clusterv1.MachinePool) {
+				mp.Spec.Rollout.After = metav1.NewTime(now.Add(-time.Minute))
+			},
+			wantUpToDate: false,
+			wantMessage:  "MachinePool spec.rollout.after expired",
+		},
+		{
+			name: "machine created before rollout.after in the future is up-to-date",
+			mp: func(mp *clusterv1.MachinePool) {
+				mp.Spec.Rollout.After = metav1.NewTime(now.Add(time.Hour))
+			},
+			wantUpToDate: true,
+		},
+		{
+			name: "machine created after rollout.after is up-to-date",
+			mp: func(mp *clusterv1.MachinePool) {
+				mp.Spec.Rollout.After = metav1.NewTime(now.Add(-2 * time.Hour))
+			},
+			wantUpToDate: true,
+		},
+		{
+			name: "machine with the same template hash is up-to-date",
+			machine: func(m *clusterv1.Machine) {
+				m.Annotations = map[string]string{clusterv1.MachinePoolTemplateHashAnnotation: "current"}
+			},
+			wantUpToDate: true,
+		},
+		{
+			name: "machine with a different template hash is not up-to-date",
+			machine: func(m *clusterv1.Machine) {
+				m.Annotations = map[string]string{clusterv1.MachinePoolTemplateHashAnnotation: "old"}
+			},
+			wantUpToDate: false,
+			wantMessage:  "MachinePool template changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mp := newRolloutTestMachinePool(3)
+			if tt.mp != nil {
+				tt.mp(mp)
+			}
+			machine := newRolloutTestMachine(mp, "machine", now.Add(-time.Hour))
+			if tt.machine != nil {
+				tt.machine(machine)
+			}
+
+			upToDate, message := isMachineUpToDate(mp, machine, "current", now)
+			g.Expect(upToDate).To(Equal(tt.wantUpToDate))
+			g.Expect(message).To(Equal(tt.wantMessage))
+		})
+	}
+}
+
+func TestMachinePoolTemplateHash(t *testing.T) {
+	newMachinePool := func() *clusterv1.MachinePool {
+		mp := newRolloutTestMachinePool(3)
+		mp.Spec.Template.Spec.Bootstrap = clusterv1.Bootstrap{
+			ConfigRef:      clusterv1.ContractVersionedObjectReference{APIGroup: "bootstrap.cluster.x-k8s.io", Kind: "KubeadmConfig", Name: "config"},
+			DataSecretName: ptr.To("config"),
+		}
+		mp.Spec.Template.Spec.InfrastructureRef = clusterv1.ContractVersionedObjectReference{APIGroup: "infrastructure.cluster.x-k8s.io", Kind: "GenericInfrastructureMachinePool", Name: "infra"}
+		return mp
+	}
+	newInfraMachinePool := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"replicas": int64(3),
+				"template": map[string]interface{}{"image": "image-1"},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name             string
+		mp               func(mp *clusterv1.MachinePool)
+		infraMachinePool func(infraMachinePool *unstructured.Unstructured)
+		wantChanged      bool
+	}{
+		{
+			name: "hash does not change if nothing changes",
+		},
+		{
+			name: "hash changes if the bootstrap config changes",
+			mp: func(mp *clusterv1.MachinePool) {
+				mp.Spec.Template.Spec.Bootstrap.ConfigRef.Name = "config-2"
+			},
+			wantChanged: true,
+		},
+		{
+			name: "hash changes if the infrastructure reference changes",
+			mp: func(mp *clusterv1.MachinePool) {
+				mp.Spec.Template.Spec.InfrastructureRef.Name = "infra-2"
+			},
+			wantChanged: true,
+		},
+		{
+			name: "hash changes if the infrastructure MachinePool template changes",
+			infraMachinePool: func(infraMachinePool *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(infraMachinePool.Object, "image-2", "spec", "template", "image")
+			},
+			wantChanged: true,
+		},
+		{
+			name: "hash does not change if the data secret name changes when using a bootstrap config",
+			mp: func(mp *clusterv1.MachinePool) {
+				mp.Spec.Template.Spec.Bootstrap.DataSecretName = ptr.To("config-2")
+			},
+		},
+		{
+			name: "hash does not change if the version changes",
+			mp: func(mp *clusterv1.MachinePool) {
+				mp.Spec.Template.Spec.Version = "v1.32.0"
+			},
+		},
+		{
+			name: "hash does not change if fields of the infrastructure MachinePool other than the template change",
+			infraMachinePool: func(infraMachinePool *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(infraMachinePool.Object, int64(5), "spec", "replicas")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			currentHash, err := machinePoolTemplateHash(newMachinePool(), newInfraMachinePool())
+			g.Expect(err).ToNot(HaveOccurred())
+
+			mp := newMachinePool()
+			if tt.mp != nil {
+				tt.mp(mp)
+			}
+			infraMachinePool := newInfraMachinePool()
+			if tt.infraMachinePool != nil {
+				tt.infraMachinePool(infraMachinePool)
+			}
+			newHash, err := machinePoolTemplateHash(mp, infraMachinePool)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(newHash != currentHash).To(Equal(tt.wantChanged))
+		})
+	}
+}
+
+func TestReconcileRollout(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name                       string
+		mp                         func(mp *clusterv1.MachinePool)
+		outdatedMachines           int
+		upToDateMachines           int
+		unavailableMachines        int
+		withoutMachinePoolMachines bool
+		wantDeletedMachines        []string
+	}{
+		{
+			name:             "does nothing if all the machines are up-to-date",
+			upToDateMachines: 3,
+		},
+		{
+			name: "does nothing if the rollout strategy is not set",
+			mp: func(mp *clusterv1.MachinePool) {
+				mp.Spec.Rollout.Strategy = clusterv1.MachinePoolRolloutStrategy{}
+			},
+			outdatedMachines: 3,
+		},
+		{
+			name:                "deletes the oldest outdated machine with the default maxUnavailable",
+			outdatedMachines:    3,
+			wantDeletedMachines: []string{"outdated-0"},
+		},
+		{
+			name: "deletes outdated machines up to maxUnavailable",
+			mp: func(mp *clusterv1.MachinePool) {
+				mp.Spec.Rollout.Strategy.RollingUpdate.MaxUnavailable = ptr.To(intstr.FromInt32(2))
+			},
+			outdatedMachines:    3,
+			wantDeletedMachines: []string{"outdated-0", "outdated-1"},
+		},
+		{
+			name: "takes machines above the desired replicas into account",
+			mp: func(mp *clusterv1.MachinePool) {
+				mp.Spec.Rollout.Strategy.RollingUpdate.MaxUnavailable = ptr.To(intstr.FromInt32(0))
+			},
+			outdatedMachines:    3,
+			upToDateMachines:    1,
+			wantDeletedMachines: []string{"outdated-0"},
+		},
+		{
+			name:                "does not delete machines if unavailable machines exceed maxUnavailable",
+			outdatedMachines:    2,
+			upToDateMachines:    1,
+			unavailableMachines: 1,
+		},
+		{
+			name: "does nothing with the OnDelete strategy",
+			mp: func(mp *clusterv1.MachinePool) {
+				mp.Spec.Rollout.Strategy.Type = clusterv1.OnDeleteMachinePoolStrategyType
+			},
+			outdatedMachines: 3,
+		},
+		{
+			name:                       "does nothing if the infrastructure provider does not implement MachinePool Machines",
+			outdatedMachines:           3,
+			withoutMachinePoolMachines: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mp := newRolloutTestMachinePool(3)
+			if tt.mp != nil {
+				tt.mp(mp)
+			}
+
+			machines := []*clusterv1.Machine{}
+			objs := []client.Object{}
+			for i := range tt.outdatedMachines {
+				machine := newRolloutTestMachine(mp, fmt.Sprintf("outdated-%d", i), now.Add(-time.Duration(10-i)*time.Minute))
+				machine.Spec.Version = "v1.30.0"
+				machines = append(machines, machine)
+			}
+			for i := range tt.upToDateMachines {
+				machines = append(machines, newRolloutTestMachine(mp, fmt.Sprintf("uptodate-%d", i), now.Add(-time.Minute)))
+			}
+			for i := range machines {
+				if i < tt.unavailableMachines {
+					machines[i].Status.Conditions = []metav1.Condition{{Type: clusterv1.MachineAvailableCondition, Status: metav1.ConditionFalse}}
+				}
+				objs = append(objs, machines[i])
+			}
+
+			infraMachinePool := &unstructured.Unstructured{Object: map[string]interface{}{
+				"status": map[string]interface{}{
+					"infrastructureMachineKind": builder.GenericInfrastructureMachineKind,
+				},
+			}}
+			if tt.withoutMachinePoolMachines {
+				infraMachinePool = &unstructured.Unstructured{Object: map[string]interface{}{}}
+			}
+
+			c := fake.NewClientBuilder().WithObjects(objs...).Build()
+			r := &Reconciler{
+				Client:   c,
+				recorder: record.NewFakeRecorder(32),
+			}
+			s := &scope{
+				machinePool:      mp,
+				infraMachinePool: infraMachinePool,
+				machines:         machines,
+			}
+
+			_, err := r.reconcileRollout(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			deletedMachines := []string{}
+			for _, machine := range machines {
+				err := c.Get(ctx, client.ObjectKeyFromObject(machine), &clusterv1.Machine{})
+				if apierrors.IsNotFound(err) {
+					deletedMachines = append(deletedMachines, machine.Name)
+					continue
+				}
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(deletedMachines).To(ConsistOf(tt.wantDeletedMachines))
+		})
+	}
+}
+
+func newRolloutTestMachinePool(replicas int32) *clusterv1.MachinePool {
+	return &clusterv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mp",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.MachinePoolSpec{
+			ClusterName: "cluster",
+			Replicas:    ptr.To(replicas),
+			Rollout: clusterv1.MachinePoolRolloutSpec{
+				Strategy: clusterv1.MachinePoolRolloutStrategy{
+					Type: clusterv1.RollingUpdateMachinePoolStrategyType,
+				},
+			},
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					ClusterName: "cluster",
+					Version:     "v1.31.0",
+				},
+			},
+		},
+	}
+}
+
+func newRolloutTestMachine(mp *clusterv1.MachinePool, name string, creationTimestamp time.Time) *clusterv1.Machine {
+	return &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         mp.Namespace,
+			CreationTimestamp: metav1.NewTime(creationTimestamp),
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:     mp.Spec.ClusterName,
+				clusterv1.MachinePoolNameLabel: mp.Name,
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: mp.Spec.ClusterName,
+			Version:     "v1.31.0",
+		},
+		Status: clusterv1.MachineStatus{
+			Conditions: []metav1.Condition{{Type: clusterv1.MachineAvailableCondition, Status: metav1.ConditionTrue}},
+		},
+	}
+}
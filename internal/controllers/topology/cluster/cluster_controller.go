@@ -561,6 +561,22 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope.Scope) (ctrl.
 	log := ctrl.LoggerFrom(ctx)
 	if feature.Gates.Enabled(feature.RuntimeSDK) {
 		if !hooks.IsOkToDelete(cluster) {
+			// Track the intent to call the AfterClusterDelete hook before unblocking deletion, so that the
+			// Cluster controller waits for the hook to be called before removing the Cluster finalizer.
+			// Note: The intent is tracked only if there are extensions registered for the hook, so deletion
+			// of Clusters is not delayed when the hook is not used.
+			if !hooks.IsPending(runtimehooksv1.AfterClusterDelete, cluster) {
+				extensionHandlers, err := r.RuntimeClient.GetAllExtensions(ctx, runtimehooksv1.AfterClusterDelete, cluster)
+				if err != nil {
+					return ctrl.Result{}, err
+				}
+				if len(extensionHandlers) > 0 {
+					if err := hooks.MarkAsPending(ctx, r.Client, cluster, false, runtimehooksv1.AfterClusterDelete); err != nil {
+						return ctrl.Result{}, err
+					}
+				}
+			}
+
 			// Return quickly if the hook is not defined.
 			extensionHandlers, err := r.RuntimeClient.GetAllExtensions(ctx, runtimehooksv1.BeforeClusterDelete, s.Current.Cluster)
 			if err != nil {
@@ -603,10 +619,49 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope.Scope) (ctrl.
 			}
 			log.Info(fmt.Sprintf("Cluster deletion is unblocked by %s hook", runtimecatalog.HookName(runtimehooksv1.BeforeClusterDelete)))
 		}
+
+		if err := r.callAfterClusterDeleteHook(ctx, s); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 	return ctrl.Result{}, nil
 }
 
+// callAfterClusterDeleteHook calls the AfterClusterDelete hook once all the objects of the Cluster have been deleted.
+// Note: The Cluster controller surfaces that all the objects of the Cluster have been deleted and that it is waiting
+// for the hook to be called by setting the WaitingForAfterDeleteHook reason on the Deleting condition.
+func (r *Reconciler) callAfterClusterDeleteHook(ctx context.Context, s *scope.Scope) error {
+	cluster := s.Current.Cluster
+
+	// Call the hook only if we are tracking the intent to do so. If it is not tracked it means we don't need to call the
+	// hook because no extensions were registered when deletion started or we already called the hook.
+	if !hooks.IsPending(runtimehooksv1.AfterClusterDelete, cluster) {
+		return nil
+	}
+
+	deletingCondition := conditions.Get(cluster, clusterv1.ClusterDeletingCondition)
+	if deletingCondition == nil || deletingCondition.Reason != clusterv1.ClusterDeletingWaitingForAfterDeleteHookReason {
+		return nil
+	}
+
+	hookRequest := &runtimehooksv1.AfterClusterDeleteRequest{
+		Cluster: *cleanupCluster(cluster),
+	}
+	hookResponse := &runtimehooksv1.AfterClusterDeleteResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.AfterClusterDelete, cluster, hookRequest, hookResponse); err != nil {
+		return err
+	}
+	s.HookResponseTracker.Add(runtimehooksv1.AfterClusterDelete, hookResponse)
+
+	// The hook is successfully called; we can remove this hook from the list of pending-hooks so
+	// the Cluster controller can complete deletion.
+	if err := hooks.MarkAsDone(ctx, r.Client, cluster, false, runtimehooksv1.AfterClusterDelete); err != nil {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).Info(fmt.Sprintf("Cluster deletion is completed and %s hook has been called", runtimecatalog.HookName(runtimehooksv1.AfterClusterDelete)))
+	return nil
+}
+
 func cleanupCluster(cluster *clusterv1.Cluster) *clusterv1.Cluster {
 	cluster = cluster.DeepCopy()
 
@@ -704,6 +704,185 @@ func TestClusterReconciler_reconcileDelete(t *testing.T) {
 	}
 }
 
+func TestClusterReconciler_reconcileDeleteMarksAfterClusterDeleteHook(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	afterClusterDeleteGVH, err := catalog.GroupVersionHook(runtimehooksv1.AfterClusterDelete)
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name            string
+		extensions      map[runtimecatalog.GroupVersionHook][]string
+		wantHookPending bool
+	}{
+		{
+			name: "should mark the AfterClusterDelete hook if there are extensions registered for the hook",
+			extensions: map[runtimecatalog.GroupVersionHook][]string{
+				afterClusterDeleteGVH: {"foo"},
+			},
+			wantHookPending: true,
+		},
+		{
+			name:            "should not mark the AfterClusterDelete hook if there are no extensions registered for the hook",
+			wantHookPending: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().WithObjects(cluster).Build()
+			fakeRuntimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithGetAllExtensionResponses(tt.extensions).
+				WithCatalog(catalog).
+				Build()
+
+			r := &Reconciler{
+				Client:        fakeClient,
+				APIReader:     fakeClient,
+				RuntimeClient: fakeRuntimeClient,
+				hookCache:     cache.New[cache.HookEntry](ctx, cache.HookCacheDefaultTTL),
+			}
+
+			_, err := r.reconcileDelete(ctx, scope.New(cluster))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(hooks.IsOkToDelete(cluster)).To(BeTrue())
+			g.Expect(hooks.IsPending(runtimehooksv1.AfterClusterDelete, cluster)).To(Equal(tt.wantHookPending))
+			g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.AfterClusterDelete)).To(Equal(0))
+		})
+	}
+}
+
+func TestClusterReconciler_callAfterClusterDeleteHook(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	afterClusterDeleteGVH, err := catalog.GroupVersionHook(runtimehooksv1.AfterClusterDelete)
+	if err != nil {
+		panic(err)
+	}
+
+	successResponse := &runtimehooksv1.AfterClusterDeleteResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusSuccess,
+		},
+	}
+	failureResponse := &runtimehooksv1.AfterClusterDeleteResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusFailure,
+		},
+	}
+
+	cluster := func(pendingHooks string, deletingReason string) *clusterv1.Cluster {
+		c := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "test-ns",
+				Annotations: map[string]string{
+					runtimev1.OkToDeleteAnnotation: "",
+				},
+			},
+			Status: clusterv1.ClusterStatus{
+				Conditions: []metav1.Condition{
+					{Type: clusterv1.ClusterDeletingCondition, Status: metav1.ConditionTrue, Reason: deletingReason},
+				},
+			},
+		}
+		if pendingHooks != "" {
+			c.Annotations[runtimev1.PendingHooksAnnotation] = pendingHooks
+		}
+		return c
+	}
+
+	tests := []struct {
+		name               string
+		cluster            *clusterv1.Cluster
+		hookResponse       *runtimehooksv1.AfterClusterDeleteResponse
+		wantMarked         bool
+		wantHookToBeCalled bool
+		wantError          bool
+	}{
+		{
+			name:               "hook should not be called if it is not marked",
+			cluster:            cluster("", clusterv1.ClusterDeletingWaitingForAfterDeleteHookReason),
+			hookResponse:       successResponse,
+			wantMarked:         false,
+			wantHookToBeCalled: false,
+		},
+		{
+			name:               "hook should not be called if objects of the cluster are still being deleted - the hook should remain marked",
+			cluster:            cluster("AfterClusterDelete", clusterv1.ClusterDeletingWaitingForWorkersDeletionReason),
+			hookResponse:       successResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: false,
+		},
+		{
+			name:               "hook should be called if it is marked and all the objects of the cluster are deleted - the hook should become unmarked for a success response",
+			cluster:            cluster("AfterClusterDelete", clusterv1.ClusterDeletingWaitingForAfterDeleteHookReason),
+			hookResponse:       successResponse,
+			wantMarked:         false,
+			wantHookToBeCalled: true,
+		},
+		{
+			name:               "hook should be called if it is marked and all the objects of the cluster are deleted - the hook should remain marked for a failure response",
+			cluster:            cluster("AfterClusterDelete", clusterv1.ClusterDeletingWaitingForAfterDeleteHookReason),
+			hookResponse:       failureResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: true,
+			wantError:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := fake.NewClientBuilder().WithObjects(tt.cluster).Build()
+			fakeRuntimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+					afterClusterDeleteGVH: tt.hookResponse,
+				}).
+				WithCallAllExtensionValidations(validateClusterParameter(tt.cluster)).
+				WithCatalog(catalog).
+				Build()
+
+			r := &Reconciler{
+				Client:        fakeClient,
+				APIReader:     fakeClient,
+				RuntimeClient: fakeRuntimeClient,
+			}
+
+			err := r.callAfterClusterDeleteHook(ctx, scope.New(tt.cluster))
+			if tt.wantError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			if tt.wantHookToBeCalled {
+				g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.AfterClusterDelete)).To(Equal(1), "Expected hook to be called once")
+			} else {
+				g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.AfterClusterDelete)).To(Equal(0), "Did not expect hook to be called")
+			}
+
+			g.Expect(hooks.IsPending(runtimehooksv1.AfterClusterDelete, tt.cluster)).To(Equal(tt.wantMarked))
+		})
+	}
+}
+
 // TestClusterReconciler_deleteClusterClass tests the correct deletion behaviour for a ClusterClass with references in existing Clusters.
 // In this case deletion of the ClusterClass should be blocked by the webhook.
 func TestClusterReconciler_deleteClusterClass(t *testing.T) {
@@ -1857,10 +2036,14 @@ func validateClusterParameter(originalCluster *clusterv1.Cluster) func(req runti
 			cluster = req.Cluster
 		case *runtimehooksv1.AfterControlPlaneInitializedRequest:
 			cluster = req.Cluster
+		case *runtimehooksv1.AfterClusterCreateRequest:
+			cluster = req.Cluster
 		case *runtimehooksv1.AfterClusterUpgradeRequest:
 			cluster = req.Cluster
 		case *runtimehooksv1.BeforeClusterDeleteRequest:
 			cluster = req.Cluster
+		case *runtimehooksv1.AfterClusterDeleteRequest:
+			cluster = req.Cluster
 		default:
 			return fmt.Errorf("unhandled request type %T", req)
 		}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/exp/topology/desiredstate"
+	"sigs.k8s.io/cluster-api/exp/topology/scope"
+	"sigs.k8s.io/cluster-api/internal/webhooks"
+)
+
+// ComputeState reads the blueprint and the current state of a Cluster topology and computes its desired state
+// using the given generator, exactly like the topology controller does, but without reconciling any object.
+// NOTE: This is used by clusterctl alpha topology plan to preview the changes to a Cluster topology;
+// the ClusterClass is expected to have an up-to-date status, and the Cluster is defaulted in place.
+func ComputeState(ctx context.Context, c client.Client, generator desiredstate.Generator, cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) (*scope.Scope, error) {
+	r := &Reconciler{Client: c, APIReader: c}
+
+	// Default and Validate the Cluster variables based on information from the ClusterClass.
+	if errs := webhooks.DefaultAndValidateVariables(ctx, cluster, nil, clusterClass); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("Cluster").GroupKind(), cluster.Name, errs)
+	}
+
+	s := scope.New(cluster)
+
+	var err error
+	s.Blueprint, err = r.getBlueprint(ctx, s.Current.Cluster, clusterClass)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading the ClusterClass")
+	}
+
+	s.Current, err = r.getCurrentState(ctx, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading current state of the Cluster topology")
+	}
+
+	s.Desired, err = generator.Generate(ctx, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "error computing the desired state of the Cluster topology")
+	}
+	return s, nil
+}
@@ -34,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/storage/names"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -51,6 +52,7 @@ import (
 	"sigs.k8s.io/cluster-api/internal/topology/ownerrefs"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/cache"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	capicontrollerutil "sigs.k8s.io/cluster-api/util/controller"
 )
 
@@ -184,13 +186,18 @@ func (r *Reconciler) callAfterHooks(ctx context.Context, s *scope.Scope) error {
 		return err
 	}
 
+	if err := r.callAfterClusterCreate(ctx, s); err != nil {
+		return err
+	}
+
 	return r.callAfterClusterUpgrade(ctx, s)
 }
 
 func (r *Reconciler) callAfterControlPlaneInitialized(ctx context.Context, s *scope.Scope) error {
-	// If the cluster topology is being created then track to intent to call the AfterControlPlaneInitialized hook so that we can call it later.
+	// If the cluster topology is being created then track to intent to call the AfterControlPlaneInitialized and
+	// the AfterClusterCreate hooks so that we can call them later.
 	if !s.Current.Cluster.Spec.InfrastructureRef.IsDefined() && !s.Current.Cluster.Spec.ControlPlaneRef.IsDefined() {
-		if err := hooks.MarkAsPending(ctx, r.Client, s.Current.Cluster, false, runtimehooksv1.AfterControlPlaneInitialized); err != nil {
+		if err := hooks.MarkAsPending(ctx, r.Client, s.Current.Cluster, false, runtimehooksv1.AfterControlPlaneInitialized, runtimehooksv1.AfterClusterCreate); err != nil {
 			return err
 		}
 	}
@@ -228,6 +235,59 @@ func isControlPlaneInitialized(cluster *clusterv1.Cluster) bool {
 	return false
 }
 
+func (r *Reconciler) callAfterClusterCreate(ctx context.Context, s *scope.Scope) error {
+	// Call the hook only if we are tracking the intent to do so. If it is not tracked it means we don't need to call the
+	// hook because the Cluster already existed or because we already called the hook after the Cluster was fully provisioned.
+	// Note: also check that the AfterControlPlaneInitialized hook already has been called.
+	if hooks.IsPending(runtimehooksv1.AfterClusterCreate, s.Current.Cluster) && !hooks.IsPending(runtimehooksv1.AfterControlPlaneInitialized, s.Current.Cluster) {
+		if isClusterFullyProvisioned(s) {
+			// The cluster is fully provisioned for the first time. Call all the registered extensions for the hook.
+			hookRequest := &runtimehooksv1.AfterClusterCreateRequest{
+				Cluster: *cleanupCluster(s.Current.Cluster),
+			}
+			hookResponse := &runtimehooksv1.AfterClusterCreateResponse{}
+			if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.AfterClusterCreate, s.Current.Cluster, hookRequest, hookResponse); err != nil {
+				return err
+			}
+			s.HookResponseTracker.Add(runtimehooksv1.AfterClusterCreate, hookResponse)
+			if err := hooks.MarkAsDone(ctx, r.Client, s.Current.Cluster, false, runtimehooksv1.AfterClusterCreate); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isClusterFullyProvisioned returns true if the control plane and all the MachineDeployments and MachinePools
+// of the Cluster's topology have been created and are available.
+func isClusterFullyProvisioned(s *scope.Scope) bool {
+	if !s.UpgradeTracker.ControlPlane.IsControlPlaneStable() ||
+		s.UpgradeTracker.MachineDeployments.IsAnyPendingCreate() ||
+		s.UpgradeTracker.MachinePools.IsAnyPendingCreate() {
+		return false
+	}
+
+	if !conditions.IsTrue(s.Current.Cluster, clusterv1.ClusterControlPlaneAvailableCondition) {
+		return false
+	}
+
+	for _, md := range s.Current.MachineDeployments {
+		if !conditions.IsTrue(md.Object, clusterv1.MachineDeploymentAvailableCondition) {
+			return false
+		}
+	}
+
+	// Note: The Available condition is not yet implemented for MachinePools, so the replica counters are used instead.
+	for _, mp := range s.Current.MachinePools {
+		if ptr.Deref(mp.Object.Status.AvailableReplicas, 0) < ptr.Deref(mp.Object.Spec.Replicas, 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (r *Reconciler) callAfterClusterUpgrade(ctx context.Context, s *scope.Scope) error {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -550,6 +550,186 @@ func TestReconcile_callAfterControlPlaneInitialized(t *testing.T) {
 	}
 }
 
+func TestReconcile_callAfterClusterCreate(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	afterClusterCreateGVH, err := catalog.GroupVersionHook(runtimehooksv1.AfterClusterCreate)
+	if err != nil {
+		panic(err)
+	}
+
+	successResponse := &runtimehooksv1.AfterClusterCreateResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusSuccess,
+		},
+	}
+	failureResponse := &runtimehooksv1.AfterClusterCreateResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusFailure,
+		},
+	}
+
+	cluster := func(pendingHooks string, controlPlaneAvailable metav1.ConditionStatus) *clusterv1.Cluster {
+		c := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "test-ns",
+			},
+			Status: clusterv1.ClusterStatus{
+				Conditions: []metav1.Condition{
+					{Type: clusterv1.ClusterControlPlaneAvailableCondition, Status: controlPlaneAvailable},
+				},
+			},
+		}
+		if pendingHooks != "" {
+			c.Annotations = map[string]string{
+				runtimev1.PendingHooksAnnotation: pendingHooks,
+			}
+		}
+		return c
+	}
+	machineDeployments := func(available metav1.ConditionStatus) scope.MachineDeploymentsStateMap {
+		return scope.MachineDeploymentsStateMap{
+			"md1": &scope.MachineDeploymentState{
+				Object: builder.MachineDeployment("test-ns", "md1").
+					WithStatus(clusterv1.MachineDeploymentStatus{
+						Conditions: []metav1.Condition{
+							{Type: clusterv1.MachineDeploymentAvailableCondition, Status: available},
+						},
+					}).
+					Build(),
+			},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		cluster            *clusterv1.Cluster
+		machineDeployments scope.MachineDeploymentsStateMap
+		upgradeTracker     *scope.UpgradeTracker
+		hookResponse       *runtimehooksv1.AfterClusterCreateResponse
+		wantMarked         bool
+		wantHookToBeCalled bool
+		wantError          bool
+	}{
+		{
+			name:               "hook should not be called if it is not marked",
+			cluster:            cluster("", metav1.ConditionTrue),
+			machineDeployments: machineDeployments(metav1.ConditionTrue),
+			upgradeTracker:     scope.NewUpgradeTracker(),
+			hookResponse:       successResponse,
+			wantMarked:         false,
+			wantHookToBeCalled: false,
+		},
+		{
+			name:               "hook should not be called if the AfterControlPlaneInitialized hook is still pending - the hook should remain marked",
+			cluster:            cluster("AfterControlPlaneInitialized,AfterClusterCreate", metav1.ConditionTrue),
+			machineDeployments: machineDeployments(metav1.ConditionTrue),
+			upgradeTracker:     scope.NewUpgradeTracker(),
+			hookResponse:       successResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: false,
+		},
+		{
+			name:               "hook should not be called if the control plane is not available - the hook should remain marked",
+			cluster:            cluster("AfterClusterCreate", metav1.ConditionFalse),
+			machineDeployments: machineDeployments(metav1.ConditionTrue),
+			upgradeTracker:     scope.NewUpgradeTracker(),
+			hookResponse:       successResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: false,
+		},
+		{
+			name:               "hook should not be called if a MachineDeployment is not available - the hook should remain marked",
+			cluster:            cluster("AfterClusterCreate", metav1.ConditionTrue),
+			machineDeployments: machineDeployments(metav1.ConditionFalse),
+			upgradeTracker:     scope.NewUpgradeTracker(),
+			hookResponse:       successResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: false,
+		},
+		{
+			name:               "hook should not be called if a MachineDeployment is pending create - the hook should remain marked",
+			cluster:            cluster("AfterClusterCreate", metav1.ConditionTrue),
+			machineDeployments: machineDeployments(metav1.ConditionTrue),
+			upgradeTracker: func() *scope.UpgradeTracker {
+				ut := scope.NewUpgradeTracker()
+				ut.MachineDeployments.MarkPendingCreate("md2")
+				return ut
+			}(),
+			hookResponse:       successResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: false,
+		},
+		{
+			name:               "hook should be called if it is marked and the cluster is fully provisioned - the hook should become unmarked for a success response",
+			cluster:            cluster("AfterClusterCreate", metav1.ConditionTrue),
+			machineDeployments: machineDeployments(metav1.ConditionTrue),
+			upgradeTracker:     scope.NewUpgradeTracker(),
+			hookResponse:       successResponse,
+			wantMarked:         false,
+			wantHookToBeCalled: true,
+		},
+		{
+			name:               "hook should be called if it is marked and the cluster is fully provisioned - the hook should remain marked for a failure response",
+			cluster:            cluster("AfterClusterCreate", metav1.ConditionTrue),
+			machineDeployments: machineDeployments(metav1.ConditionTrue),
+			upgradeTracker:     scope.NewUpgradeTracker(),
+			hookResponse:       failureResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: true,
+			wantError:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := &scope.Scope{
+				Current: &scope.ClusterState{
+					Cluster:            tt.cluster,
+					MachineDeployments: tt.machineDeployments,
+				},
+				HookResponseTracker: scope.NewHookResponseTracker(),
+				UpgradeTracker:      tt.upgradeTracker,
+			}
+
+			fakeRuntimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+					afterClusterCreateGVH: tt.hookResponse,
+				}).
+				WithCallAllExtensionValidations(validateClusterParameter(tt.cluster)).
+				WithCatalog(catalog).
+				Build()
+
+			fakeClient := fake.NewClientBuilder().WithObjects(tt.cluster).Build()
+
+			r := &Reconciler{
+				Client:        fakeClient,
+				APIReader:     fakeClient,
+				RuntimeClient: fakeRuntimeClient,
+			}
+
+			err := r.callAfterClusterCreate(ctx, s)
+			if tt.wantError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			if tt.wantHookToBeCalled {
+				g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.AfterClusterCreate)).To(Equal(1), "Expected hook to be called once")
+			} else {
+				g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.AfterClusterCreate)).To(Equal(0), "Did not expect hook to be called")
+			}
+
+			g.Expect(hooks.IsPending(runtimehooksv1.AfterClusterCreate, tt.cluster)).To(Equal(tt.wantMarked))
+		})
+	}
+}
+
 func TestReconcile_callAfterClusterUpgrade(t *testing.T) {
 	catalog := runtimecatalog.New()
 	_ = runtimehooksv1.AddToCatalog(catalog)
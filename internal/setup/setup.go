@@ -55,7 +55,7 @@ func ManagerCacheOptions(scheme *runtime.Scheme, controllerName string, watchNam
 		DefaultNamespaces: watchNamespaces,
 		SyncPeriod:        &syncPeriod,
 		ByObject: map[client.Object]cache.ByObject{
-			// Note: Only Secrets with the cluster name label are cached.
+			// Note: Only Secrets with the cluster name label are cached, e.g. kubeconfig Secrets and their mirrors.
 			// The default client of the manager won't use the cache for secrets at all (see Client.Cache.DisableFor).
 			// The cached secrets will only be used by the secretCachingClient we create below.
 			&corev1.Secret{}: {
@@ -64,7 +64,7 @@ func ManagerCacheOptions(scheme *runtime.Scheme, controllerName string, watchNam
 				Transform: func(in any) (any, error) {
 					if s, ok := in.(*corev1.Secret); ok {
 						s.SetManagedFields(nil)
-						if !strings.HasSuffix(s.Name, fmt.Sprintf("-%s", secret.Kubeconfig)) {
+						if _, isMirror := s.Labels[clusterv1.KubeconfigMirrorClusterNameLabel]; !isMirror && !strings.HasSuffix(s.Name, fmt.Sprintf("-%s", secret.Kubeconfig)) {
 							s.Data = nil
 						}
 					}
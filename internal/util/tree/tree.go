@@ -17,6 +17,7 @@ limitations under the License.
 package tree
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -38,6 +39,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
@@ -157,6 +159,116 @@ func PrintObjectTreeV1Beta1(tree *tree.ObjectTree) error {
 	return nil
 }
 
+// PrintObjectTreeJSON prints the cluster status in JSON format.
+// Note: this function is exposed only for usage in clusterctl and Cluster API E2E tests.
+func PrintObjectTreeJSON(tree *tree.ObjectTree, w io.Writer) error {
+	node := newObjectNode(tree, tree.GetRoot())
+
+	out, err := json.MarshalIndent(node, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal object tree to JSON")
+	}
+	if _, err := fmt.Fprintln(w, string(out)); err != nil {
+		return errors.Wrap(err, "failed to write object tree")
+	}
+	return nil
+}
+
+// PrintObjectTreeYAML prints the cluster status in YAML format.
+// Note: this function is exposed only for usage in clusterctl and Cluster API E2E tests.
+func PrintObjectTreeYAML(tree *tree.ObjectTree, w io.Writer) error {
+	node := newObjectNode(tree, tree.GetRoot())
+
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal object tree to YAML")
+	}
+	if _, err := fmt.Fprint(w, string(out)); err != nil {
+		return errors.Wrap(err, "failed to write object tree")
+	}
+	return nil
+}
+
+// objectNode is the machine-readable representation of an object in the ObjectTree.
+type objectNode struct {
+	// kind of the object, e.g. Machine; for group objects, it is the kind of the objects in the group.
+	Kind string `json:"kind"`
+
+	// apiVersion of the object, if any.
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// namespace of the object, if any.
+	Namespace string `json:"namespace,omitempty"`
+
+	// name of the object.
+	Name string `json:"name"`
+
+	// metaName is the name used in the tree view to describe the role of the object, e.g. ClusterInfrastructure.
+	MetaName string `json:"metaName,omitempty"`
+
+	// virtual is true for objects which do not exist in the cluster but are added to the tree to improve readability, e.g. Workers.
+	Virtual bool `json:"virtual,omitempty"`
+
+	// group is true for objects grouping many objects with the same state, e.g. Machines with Ready true.
+	Group bool `json:"group,omitempty"`
+
+	// groupItems is the list of names of the objects in the group.
+	GroupItems []string `json:"groupItems,omitempty"`
+
+	// deleting is true if the object is being deleted.
+	Deleting bool `json:"deleting,omitempty"`
+
+	// replicas is the current and desired number of replicas, e.g. 2/3.
+	Replicas string `json:"replicas,omitempty"`
+
+	// availableReplicas is the number of available replicas.
+	AvailableReplicas string `json:"availableReplicas,omitempty"`
+
+	// readyReplicas is the number of ready replicas.
+	ReadyReplicas string `json:"readyReplicas,omitempty"`
+
+	// upToDateReplicas is the number of up-to-date replicas.
+	UpToDateReplicas string `json:"upToDateReplicas,omitempty"`
+
+	// conditions of the object.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// children of the object, in the same order as in the tree view.
+	Children []objectNode `json:"children,omitempty"`
+}
+
+// newObjectNode returns the objectNode for a given object, and recursively for all the object's children.
+func newObjectNode(objectTree *tree.ObjectTree, obj ctrlclient.Object) objectNode {
+	rowDescriptor := newRowDescriptor(obj)
+
+	node := objectNode{
+		Kind:              obj.GetObjectKind().GroupVersionKind().Kind,
+		APIVersion:        obj.GetObjectKind().GroupVersionKind().GroupVersion().String(),
+		Namespace:         obj.GetNamespace(),
+		Name:              obj.GetName(),
+		MetaName:          tree.GetMetaName(obj),
+		Virtual:           tree.IsVirtualObject(obj),
+		Group:             tree.IsGroupObject(obj),
+		Deleting:          !obj.GetDeletionTimestamp().IsZero(),
+		Replicas:          rowDescriptor.replicas,
+		AvailableReplicas: rowDescriptor.availableCounters,
+		ReadyReplicas:     rowDescriptor.readyCounters,
+		UpToDateReplicas:  rowDescriptor.upToDateCounters,
+		Conditions:        tree.GetConditions(obj),
+	}
+
+	if node.Group {
+		node.Kind = strings.TrimSuffix(node.Kind, "Group")
+		node.GroupItems = strings.Split(tree.GetGroupItems(obj), tree.GroupItemsSeparator)
+	}
+
+	for _, child := range orderChildrenObjects(objectTree.GetObjectsByParent(obj.GetUID())) {
+		node.Children = append(node.Children, newObjectNode(objectTree, child))
+	}
+
+	return node
+}
+
 // addObjectRow add a row for a given object, and recursively for all the object's children.
 // NOTE: each row name gets a prefix, that generates a tree view like representation.
 func addObjectRow(prefix string, tbl *tablewriter.Table, objectTree *tree.ObjectTree, obj ctrlclient.Object) error {
@@ -18,6 +18,7 @@ package tree
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -29,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
@@ -639,6 +641,63 @@ func (t *Table) NegatedFailureMessage(actual interface{}) string {
 	return fmt.Sprintf("Expected %v and received %v", t.tableData, actualTable)
 }
 
+func Test_PrintObjectTreeJSONAndYAML(t *testing.T) {
+	g := NewWithT(t)
+
+	root := fakeObject("root",
+		withCondition(trueCondition()),
+	)
+	objectTree := tree.NewObjectTree(root, tree.ObjectTreeOptions{})
+
+	workers := fakeObject("workers",
+		withAnnotation(tree.VirtualObjectAnnotation, "True"),
+		withAnnotation(tree.ObjectMetaNameAnnotation, "Workers"),
+	)
+	group := fakeObject("group",
+		withAnnotation(tree.GroupObjectAnnotation, "True"),
+		withAnnotation(tree.GroupItemsAnnotation, "m1, m2"),
+		withCondition(falseCondition("Ready", "not ready")),
+	)
+	group.GetObjectKind().SetGroupVersionKind(clusterv1.GroupVersion.WithKind("MachineGroup"))
+	deleting := fakeObject("deleting", withDeletionTimestamp)
+	objectTree.Add(root, workers)
+	objectTree.Add(root, deleting)
+	objectTree.Add(workers, group)
+
+	var jsonOutput bytes.Buffer
+	g.Expect(PrintObjectTreeJSON(objectTree, &jsonOutput)).To(Succeed())
+
+	var node objectNode
+	g.Expect(json.Unmarshal(jsonOutput.Bytes(), &node)).To(Succeed())
+	g.Expect(node.Name).To(Equal("root"))
+	g.Expect(node.Conditions).To(HaveLen(1))
+	g.Expect(node.Conditions[0].Type).To(Equal("Available"))
+	g.Expect(node.Children).To(HaveLen(2))
+
+	// Children are sorted like in the tree view.
+	g.Expect(node.Children[0].Name).To(Equal("deleting"))
+	g.Expect(node.Children[0].Deleting).To(BeTrue())
+	g.Expect(node.Children[1].Name).To(Equal("workers"))
+	g.Expect(node.Children[1].Virtual).To(BeTrue())
+	g.Expect(node.Children[1].MetaName).To(Equal("Workers"))
+	g.Expect(node.Children[1].Children).To(HaveLen(1))
+
+	groupNode := node.Children[1].Children[0]
+	g.Expect(groupNode.Kind).To(Equal("Machine"))
+	g.Expect(groupNode.APIVersion).To(Equal(clusterv1.GroupVersion.String()))
+	g.Expect(groupNode.Group).To(BeTrue())
+	g.Expect(groupNode.GroupItems).To(Equal([]string{"m1", "m2"}))
+	g.Expect(groupNode.Conditions).To(HaveLen(1))
+	g.Expect(groupNode.Conditions[0].Message).To(Equal("not ready"))
+
+	var yamlOutput bytes.Buffer
+	g.Expect(PrintObjectTreeYAML(objectTree, &yamlOutput)).To(Succeed())
+
+	var yamlNode objectNode
+	g.Expect(yaml.Unmarshal(yamlOutput.Bytes(), &yamlNode)).To(Succeed())
+	g.Expect(yamlNode).To(Equal(node))
+}
+
 func Test_formatParagraph(t *testing.T) {
 	tests := []struct {
 		text     string
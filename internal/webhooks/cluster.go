@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -27,6 +28,7 @@ import (
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -207,6 +209,9 @@ func (webhook *Cluster) validate(ctx context.Context, oldCluster, newCluster *cl
 	allErrs = append(allErrs, validateCIDRBlocks(specPath.Child("clusterNetwork", "services", "cidrBlocks"),
 		newCluster.Spec.ClusterNetwork.Services.CIDRBlocks)...)
 
+	// Validate the kubeconfig mirrors, if defined.
+	allErrs = append(allErrs, validateKubeconfig(oldCluster, newCluster, specPath.Child("kubeconfig"))...)
+
 	topologyPath := specPath.Child("topology")
 
 	// Validate the managed topology, if defined.
@@ -233,6 +238,32 @@ func (webhook *Cluster) validate(ctx context.Context, oldCluster, newCluster *cl
 	return allWarnings, nil
 }
 
+func validateKubeconfig(oldCluster, newCluster *clusterv1.Cluster, fldPath *field.Path) field.ErrorList {
+	if reflect.DeepEqual(newCluster.Spec.Kubeconfig, clusterv1.ClusterKubeconfig{}) {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	// NOTE: Kubeconfig mirroring is behind the KubeconfigMirroring feature gate flag; the web hook
+	// must prevent setting or changing spec.kubeconfig in case the feature flag is disabled.
+	if !feature.Gates.Enabled(feature.KubeconfigMirroring) &&
+		(oldCluster == nil || !reflect.DeepEqual(oldCluster.Spec.Kubeconfig, newCluster.Spec.Kubeconfig)) {
+		return append(allErrs, field.Forbidden(fldPath, "can be set only if the KubeconfigMirroring feature flag is enabled"))
+	}
+
+	for i, mirror := range newCluster.Spec.Kubeconfig.Mirrors {
+		if mirror.NamespaceSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(mirror.NamespaceSelector); err != nil {
+				allErrs = append(allErrs,
+					field.Invalid(fldPath.Child("mirrors").Index(i).Child("namespaceSelector"), mirror.NamespaceSelector, err.Error()),
+				)
+			}
+		}
+	}
+	return allErrs
+}
+
 func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newCluster *clusterv1.Cluster, fldPath *field.Path) (admission.Warnings, field.ErrorList) {
 	var allWarnings admission.Warnings
 
@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -1671,6 +1672,46 @@ func TestClusterValidation(t *testing.T) {
 	}
 }
 
+func TestClusterKubeconfigValidation(t *testing.T) {
+	mirrors := clusterv1.ClusterKubeconfig{
+		Mirrors: []clusterv1.ClusterKubeconfigMirror{{Namespace: "argocd"}},
+	}
+	newCluster := func(kubeconfig clusterv1.ClusterKubeconfig) *clusterv1.Cluster {
+		cluster := builder.Cluster("fooNamespace", "cluster1").
+			WithControlPlane(builder.ControlPlane("fooNamespace", "cp1").Build()).
+			Build()
+		cluster.Spec.Kubeconfig = kubeconfig
+		return cluster
+	}
+
+	t.Run("fails if mirrors are set but feature flag is disabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		allErrs := validateKubeconfig(nil, newCluster(mirrors), field.NewPath("spec", "kubeconfig"))
+		g.Expect(allErrs.ToAggregate()).To(MatchError(ContainSubstring("spec.kubeconfig: Forbidden: can be set only if the KubeconfigMirroring feature flag is enabled")))
+
+		// Existing mirrors are preserved when the feature flag is disabled.
+		allErrs = validateKubeconfig(newCluster(mirrors), newCluster(mirrors), field.NewPath("spec", "kubeconfig"))
+		g.Expect(allErrs).To(BeEmpty())
+	})
+
+	t.Run("validates mirrors if feature flag is enabled", func(t *testing.T) {
+		utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeconfigMirroring, true)
+		g := NewWithT(t)
+
+		allErrs := validateKubeconfig(nil, newCluster(mirrors), field.NewPath("spec", "kubeconfig"))
+		g.Expect(allErrs).To(BeEmpty())
+
+		invalid := clusterv1.ClusterKubeconfig{
+			Mirrors: []clusterv1.ClusterKubeconfigMirror{{
+				NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Invalid"}}},
+			}},
+		}
+		allErrs = validateKubeconfig(nil, newCluster(invalid), field.NewPath("spec", "kubeconfig"))
+		g.Expect(allErrs.ToAggregate()).To(MatchError(ContainSubstring("spec.kubeconfig.mirrors[0].namespaceSelector")))
+	})
+}
+
 func TestClusterTopologyValidation(t *testing.T) {
 	// NOTE: ClusterTopology feature flag is disabled by default, thus preventing to set Cluster.Topologies.
 	// Enabling the feature flag temporarily for this test.
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+func (webhook *ClusterKubeconfigRequest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &clusterv1.ClusterKubeconfigRequest{}).
+		WithValidator(webhook).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-cluster-x-k8s-io-v1beta2-clusterkubeconfigrequest,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=clusterkubeconfigrequests,versions=v1beta2,name=validation.clusterkubeconfigrequest.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1
+
+// ClusterKubeconfigRequest implements a validation webhook for ClusterKubeconfigRequest.
+type ClusterKubeconfigRequest struct{}
+
+const (
+	// reservedIdentityPrefix is the prefix of users and groups reserved for Kubernetes components,
+	// e.g. system:masters, system:nodes or system:kube-controller-manager.
+	reservedIdentityPrefix = "system:"
+
+	// kubeadmClusterAdminsGroup is the group bound to the cluster-admin ClusterRole by kubeadm.
+	kubeadmClusterAdminsGroup = "kubeadm:cluster-admins"
+)
+
+var _ admission.Validator[*clusterv1.ClusterKubeconfigRequest] = &ClusterKubeconfigRequest{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *ClusterKubeconfigRequest) ValidateCreate(_ context.Context, r *clusterv1.ClusterKubeconfigRequest) (admission.Warnings, error) {
+	return nil, webhook.validate(nil, r)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *ClusterKubeconfigRequest) ValidateUpdate(_ context.Context, oldR, newR *clusterv1.ClusterKubeconfigRequest) (admission.Warnings, error) {
+	return nil, webhook.validate(oldR, newR)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *ClusterKubeconfigRequest) ValidateDelete(_ context.Context, _ *clusterv1.ClusterKubeconfigRequest) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (webhook *ClusterKubeconfigRequest) validate(oldR, newR *clusterv1.ClusterKubeconfigRequest) error {
+	specPath := field.NewPath("spec")
+
+	// NOTE: ClusterKubeconfigRequest is behind the ClusterKubeconfigRequest feature gate flag; the webhook
+	// must prevent creating new objects when the feature flag is disabled.
+	if !feature.Gates.Enabled(feature.ClusterKubeconfigRequest) {
+		return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("ClusterKubeconfigRequest").GroupKind(), newR.Name, field.ErrorList{
+			field.Forbidden(specPath, "can be set only if the ClusterKubeconfigRequest feature flag is enabled"),
+		})
+	}
+
+	var allErrs field.ErrorList
+
+	oidc := newR.Spec.OIDC.IssuerURL != "" || newR.Spec.OIDC.ClientID != ""
+	if !oidc && newR.Spec.User == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("user"), "user must be set if oidc is not set"))
+	}
+
+	// Client certificates cannot be revoked, so kubeconfigs for identities reserved for Kubernetes components
+	// or granted cluster-admin by kubeadm must not be issued; those kubeconfigs would bypass RBAC in the workload cluster.
+	if strings.HasPrefix(newR.Spec.User, reservedIdentityPrefix) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("user"), newR.Spec.User, fmt.Sprintf("users with the %q prefix are reserved for Kubernetes components", reservedIdentityPrefix)))
+	}
+	for i, group := range newR.Spec.Groups {
+		if strings.HasPrefix(group, reservedIdentityPrefix) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("groups").Index(i), group, fmt.Sprintf("groups with the %q prefix are reserved for Kubernetes components", reservedIdentityPrefix)))
+		}
+		if group == kubeadmClusterAdminsGroup {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("groups").Index(i), group, "group is bound to the cluster-admin ClusterRole by kubeadm"))
+		}
+	}
+	if oidc {
+		if len(newR.Spec.Groups) > 0 {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("groups"), "groups must not be set if oidc is set, groups are provided by the OpenID Connect issuer"))
+		}
+		if newR.Spec.TTLSeconds != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("ttlSeconds"), "ttlSeconds must not be set if oidc is set, kubeconfigs using OpenID Connect do not expire"))
+		}
+		if newR.Spec.AutoRenew != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("autoRenew"), "autoRenew must not be set if oidc is set, kubeconfigs using OpenID Connect do not expire"))
+		}
+		if u, err := url.Parse(newR.Spec.OIDC.IssuerURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("oidc", "issuerURL"), newR.Spec.OIDC.IssuerURL, "must be a valid https URL"))
+		}
+	}
+
+	if oldR != nil {
+		if oldR.Spec.ClusterName != newR.Spec.ClusterName {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("clusterName"), "field is immutable"))
+		}
+		if oldR.Spec.User != newR.Spec.User {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("user"), "field is immutable"))
+		}
+		if !reflect.DeepEqual(oldR.Spec.Groups, newR.Spec.Groups) {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("groups"), "field is immutable"))
+		}
+		if !reflect.DeepEqual(oldR.Spec.OIDC, newR.Spec.OIDC) {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("oidc"), "field is immutable"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("ClusterKubeconfigRequest").GroupKind(), newR.Name, allErrs)
+}
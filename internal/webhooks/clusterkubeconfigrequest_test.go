@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+func TestClusterKubeconfigRequestFeatureGate(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &clusterv1.ClusterKubeconfigRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: metav1.NamespaceDefault},
+		Spec:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "alice"},
+	}
+	webhook := &ClusterKubeconfigRequest{}
+
+	_, err := webhook.ValidateCreate(ctx, r)
+	g.Expect(err).To(MatchError(ContainSubstring("ClusterKubeconfigRequest feature flag is enabled")))
+
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterKubeconfigRequest, true)
+	_, err = webhook.ValidateCreate(ctx, r)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestClusterKubeconfigRequestValidate(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterKubeconfigRequest, true)
+
+	oidc := clusterv1.ClusterKubeconfigRequestOIDCSpec{IssuerURL: "https://issuer.example.com", ClientID: "capi"}
+
+	tests := []struct {
+		name      string
+		old       *clusterv1.ClusterKubeconfigRequestSpec
+		new       clusterv1.ClusterKubeconfigRequestSpec
+		expectErr bool
+	}{
+		{
+			name: "allow request for a user",
+			new:  clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "alice", Groups: []string{"developers"}, TTLSeconds: ptr.To[int32](600), AutoRenew: ptr.To(true)},
+		},
+		{
+			name:      "reject request for a user reserved for Kubernetes components",
+			new:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "system:kube-controller-manager"},
+			expectErr: true,
+		},
+		{
+			name:      "reject request for the system:masters group",
+			new:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "alice", Groups: []string{"developers", "system:masters"}},
+			expectErr: true,
+		},
+		{
+			name:      "reject request for the system:nodes group",
+			new:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "alice", Groups: []string{"system:nodes"}},
+			expectErr: true,
+		},
+		{
+			name:      "reject request for the kubeadm cluster admins group",
+			new:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "alice", Groups: []string{"kubeadm:cluster-admins"}},
+			expectErr: true,
+		},
+		{
+			name:      "reject request without user",
+			new:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test"},
+			expectErr: true,
+		},
+		{
+			name: "allow request using OIDC",
+			new:  clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", OIDC: oidc},
+		},
+		{
+			name:      "reject request using OIDC with an invalid issuer",
+			new:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", OIDC: clusterv1.ClusterKubeconfigRequestOIDCSpec{IssuerURL: "http://issuer.example.com", ClientID: "capi"}},
+			expectErr: true,
+		},
+		{
+			name:      "reject request using OIDC with TTL",
+			new:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", OIDC: oidc, TTLSeconds: ptr.To[int32](600)},
+			expectErr: true,
+		},
+		{
+			name:      "reject request using OIDC with groups",
+			new:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", OIDC: oidc, Groups: []string{"developers"}},
+			expectErr: true,
+		},
+		{
+			name: "allow changing TTL and autoRenew",
+			old:  &clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "alice"},
+			new:  clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "alice", TTLSeconds: ptr.To[int32](7200), AutoRenew: ptr.To(true)},
+		},
+		{
+			name:      "reject changing user",
+			old:       &clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "alice"},
+			new:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "bob"},
+			expectErr: true,
+		},
+		{
+			name:      "reject changing groups",
+			old:       &clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "alice"},
+			new:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "alice", Groups: []string{"admins"}},
+			expectErr: true,
+		},
+		{
+			name:      "reject changing clusterName",
+			old:       &clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "test", User: "alice"},
+			new:       clusterv1.ClusterKubeconfigRequestSpec{ClusterName: "other", User: "alice"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			webhook := &ClusterKubeconfigRequest{}
+			newR := &clusterv1.ClusterKubeconfigRequest{ObjectMeta: metav1.ObjectMeta{Name: "r"}, Spec: tt.new}
+
+			var err error
+			if tt.old == nil {
+				_, err = webhook.ValidateCreate(ctx, newR)
+			} else {
+				oldR := &clusterv1.ClusterKubeconfigRequest{ObjectMeta: metav1.ObjectMeta{Name: "r"}, Spec: *tt.old}
+				_, err = webhook.ValidateUpdate(ctx, oldR, newR)
+			}
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
@@ -105,6 +105,15 @@ func (webhook *ClusterResourceSet) validate(oldCRS, newCRS *addonsv1.ClusterReso
 		)
 	}
 
+	for i, dependency := range newCRS.Spec.DependsOn {
+		if dependency.Name == newCRS.Name {
+			allErrs = append(
+				allErrs,
+				field.Invalid(field.NewPath("spec", "dependsOn").Index(i).Child("name"), dependency.Name, "ClusterResourceSet cannot depend on itself"),
+			)
+		}
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
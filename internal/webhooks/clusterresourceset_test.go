@@ -210,3 +210,22 @@ func TestClusterResourceSetSelectorNotEmptyValidation(t *testing.T) {
 	g.Expect(err).To(HaveOccurred())
 	g.Expect(err.Error()).To(ContainSubstring("selector must not be empty"))
 }
+
+func TestClusterResourceSetDependsOnValidation(t *testing.T) {
+	g := NewWithT(t)
+	clusterResourceSet := &addonsv1.ClusterResourceSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "crs"},
+		Spec: addonsv1.ClusterResourceSetSpec{
+			ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+			DependsOn:       []addonsv1.ClusterResourceSetDependency{{Name: "cni"}},
+		},
+	}
+	webhook := ClusterResourceSet{}
+	g.Expect(webhook.validate(nil, clusterResourceSet)).To(Succeed())
+
+	clusterResourceSet.Spec.DependsOn = append(clusterResourceSet.Spec.DependsOn, addonsv1.ClusterResourceSetDependency{Name: "crs"})
+	err := webhook.validate(nil, clusterResourceSet)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("spec.dependsOn[1].name"))
+	g.Expect(err.Error()).To(ContainSubstring("ClusterResourceSet cannot depend on itself"))
+}
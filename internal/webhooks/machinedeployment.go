@@ -107,8 +107,9 @@ func (webhook *MachineDeployment) Default(ctx context.Context, m *clusterv1.Mach
 		m.Spec.Template.Labels = make(map[string]string)
 	}
 
-	// Default RollingUpdate strategy only if strategy type is RollingUpdate.
-	if m.Spec.Rollout.Strategy.Type == clusterv1.RollingUpdateMachineDeploymentStrategyType {
+	// Default RollingUpdate strategy only if strategy type is RollingUpdate or Staged.
+	if m.Spec.Rollout.Strategy.Type == clusterv1.RollingUpdateMachineDeploymentStrategyType ||
+		m.Spec.Rollout.Strategy.Type == clusterv1.StagedMachineDeploymentStrategyType {
 		if m.Spec.Rollout.Strategy.RollingUpdate.MaxSurge == nil {
 			m.Spec.Rollout.Strategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(1))
 		}
@@ -224,6 +225,7 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 	}
 
 	allErrs = append(allErrs, validateRolloutStrategy(specPath.Child("rollout", "strategy"), newMD.Spec.Rollout.Strategy.RollingUpdate.MaxUnavailable, newMD.Spec.Rollout.Strategy.RollingUpdate.MaxSurge)...)
+	allErrs = append(allErrs, validateStagedRolloutStrategy(specPath.Child("rollout", "strategy"), newMD.Spec.Rollout.Strategy)...)
 	allErrs = append(allErrs, validateRemediationMaxInFlight(specPath.Child("remediation"), newMD.Spec.Remediation.MaxInFlight)...)
 
 	if newMD.Spec.Template.Spec.Version != "" {
@@ -281,6 +283,64 @@ func validateRolloutStrategy(fldPath *field.Path, maxUnavailable, maxSurge *ints
 	return allErrs
 }
 
+func validateStagedRolloutStrategy(fldPath *field.Path, strategy clusterv1.MachineDeploymentRolloutStrategy) field.ErrorList {
+	var allErrs field.ErrorList
+	if strategy.Type != clusterv1.StagedMachineDeploymentStrategyType {
+		if len(strategy.Staged.Steps) > 0 {
+			allErrs = append(allErrs,
+				field.Forbidden(fldPath.Child("staged"), "can only be set if type is Staged"),
+			)
+		}
+		return allErrs
+	}
+
+	if len(strategy.Staged.Steps) == 0 {
+		allErrs = append(allErrs,
+			field.Required(fldPath.Child("staged", "steps"), "must be set if type is Staged"),
+		)
+		return allErrs
+	}
+
+	previousReplicas := int32(0)
+	for i, step := range strategy.Staged.Steps {
+		stepPath := fldPath.Child("staged", "steps").Index(i)
+		if step.Replicas == nil {
+			allErrs = append(allErrs, field.Required(stepPath.Child("replicas"), "must be set"))
+			continue
+		}
+		// Note: Percentages are validated against 100 replicas, so they must be > 0% and <= 100%.
+		replicas, err := intstr.GetScaledValueFromIntOrPercent(step.Replicas, 100, true)
+		if err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(stepPath.Child("replicas"), step.Replicas.String(), fmt.Sprintf("must be either an int or a percentage: %v", err.Error())),
+			)
+			continue
+		}
+		if replicas <= 0 || (step.Replicas.Type == intstr.String && replicas > 100) {
+			allErrs = append(allErrs,
+				field.Invalid(stepPath.Child("replicas"), step.Replicas.String(), "must be greater than 0 and, if a percentage, not greater than 100%"),
+			)
+			continue
+		}
+		// Note: Steps using an absolute number and steps using a percentage cannot be compared without knowing spec.replicas.
+		if step.Replicas.Type == intstr.Int {
+			if int32(replicas) <= previousReplicas {
+				allErrs = append(allErrs,
+					field.Invalid(stepPath.Child("replicas"), step.Replicas.String(), "must be greater than the replicas of the previous steps"),
+				)
+			}
+			previousReplicas = int32(replicas)
+		}
+
+		if step.Gate.ExtensionName != "" && !feature.Gates.Enabled(feature.RuntimeSDK) {
+			allErrs = append(allErrs,
+				field.Forbidden(stepPath.Child("gate", "extensionName"), "can be set only if the RuntimeSDK feature flag is enabled"),
+			)
+		}
+	}
+	return allErrs
+}
+
 func validateRemediationMaxInFlight(fldPath *field.Path, maxInFlight *intstr.IntOrString) field.ErrorList {
 	var allErrs field.ErrorList
 	if maxInFlight != nil {
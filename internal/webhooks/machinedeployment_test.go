@@ -511,6 +511,73 @@ func TestMachineDeploymentValidation(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name:      "should not return error for valid staged steps",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.StagedMachineDeploymentStrategyType,
+				Staged: clusterv1.MachineDeploymentRolloutStrategyStaged{
+					Steps: []clusterv1.MachineDeploymentRolloutStep{
+						{Replicas: ptr.To(intstr.FromInt32(1)), Gate: clusterv1.MachineDeploymentRolloutStepGate{SoakSeconds: ptr.To[int32](600)}},
+						{Replicas: ptr.To(intstr.FromString("50%")), Gate: clusterv1.MachineDeploymentRolloutStepGate{ConditionType: "AnalysisPassed"}},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "should return error for staged type without steps",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.StagedMachineDeploymentStrategyType,
+			},
+			expectErr: true,
+		},
+		{
+			name:      "should return error for staged steps with type RollingUpdate",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+				Staged: clusterv1.MachineDeploymentRolloutStrategyStaged{
+					Steps: []clusterv1.MachineDeploymentRolloutStep{
+						{Replicas: ptr.To(intstr.FromInt32(1))},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "should return error for staged steps with decreasing replicas",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.StagedMachineDeploymentStrategyType,
+				Staged: clusterv1.MachineDeploymentRolloutStrategyStaged{
+					Steps: []clusterv1.MachineDeploymentRolloutStep{
+						{Replicas: ptr.To(intstr.FromInt32(2))},
+						{Replicas: ptr.To(intstr.FromInt32(2))},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "should return error for staged steps with invalid replicas",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.StagedMachineDeploymentStrategyType,
+				Staged: clusterv1.MachineDeploymentRolloutStrategyStaged{
+					Steps: []clusterv1.MachineDeploymentRolloutStep{
+						{Replicas: ptr.To(intstr.FromString("0%"))},
+					},
+				},
+			},
+			expectErr: true,
+		},
 		{
 			name: "should not return error when MachineNamingSpec have {{ .random }}",
 			machineNaming: clusterv1.MachineNamingSpec{
@@ -75,6 +75,38 @@ func (webhook *MachineDrainRule) validate(newMDR *clusterv1.MachineDrainRule) er
 		}
 	}
 
+	evictAfterSet := newMDR.Spec.Drain.EvictAfter != clusterv1.MachineDrainRuleEvictAfterConfig{}
+	if newMDR.Spec.Drain.Behavior == clusterv1.MachineDrainRuleDrainBehaviorEvictAfter && !evictAfterSet {
+		allErrs = append(allErrs,
+			field.Required(field.NewPath("spec", "drain", "evictAfter"),
+				fmt.Sprintf("evictAfter must be set if drain behavior is %q", clusterv1.MachineDrainRuleDrainBehaviorEvictAfter),
+			),
+		)
+	}
+	if newMDR.Spec.Drain.Behavior != clusterv1.MachineDrainRuleDrainBehaviorEvictAfter && evictAfterSet {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec", "drain", "evictAfter"),
+				fmt.Sprintf("evictAfter must not be set if drain behavior is not %q", clusterv1.MachineDrainRuleDrainBehaviorEvictAfter),
+			),
+		)
+	}
+
+	forceDeleteSet := newMDR.Spec.Drain.ForceDelete.TimeoutSeconds != nil || newMDR.Spec.Drain.ForceDelete.GracePeriodSeconds != nil
+	if newMDR.Spec.Drain.Behavior == clusterv1.MachineDrainRuleDrainBehaviorForceDelete && !forceDeleteSet {
+		allErrs = append(allErrs,
+			field.Required(field.NewPath("spec", "drain", "forceDelete"),
+				fmt.Sprintf("forceDelete must be set if drain behavior is %q", clusterv1.MachineDrainRuleDrainBehaviorForceDelete),
+			),
+		)
+	}
+	if newMDR.Spec.Drain.Behavior != clusterv1.MachineDrainRuleDrainBehaviorForceDelete && forceDeleteSet {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec", "drain", "forceDelete"),
+				fmt.Sprintf("forceDelete must not be set if drain behavior is not %q", clusterv1.MachineDrainRuleDrainBehaviorForceDelete),
+			),
+		)
+	}
+
 	allErrs = append(allErrs, ValidateMachineDrainRulesSelectors(newMDR)...)
 
 	if len(allErrs) == 0 {
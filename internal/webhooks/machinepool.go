@@ -172,6 +172,11 @@ func (webhook *MachinePool) validate(oldObj, newObj *clusterv1.MachinePool) erro
 		}
 	}
 
+	// Note: The rollout strategy is not defaulted, so that the rollout of existing MachinePools is left to the
+	// infrastructure provider until a strategy is explicitly set.
+	// Note: MachinePools do not support maxSurge, because Cluster API does not create MachinePool Machines.
+	allErrs = append(allErrs, validateRolloutStrategy(specPath.Child("rollout", "strategy"), newObj.Spec.Rollout.Strategy.RollingUpdate.MaxUnavailable, nil)...)
+
 	// Validate the metadata of the MachinePool template.
 	allErrs = append(allErrs, newObj.Spec.Template.Validate(specPath.Child("template", "metadata"))...)
 
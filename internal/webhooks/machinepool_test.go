@@ -23,6 +23,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -459,6 +460,75 @@ func TestMachinePoolVersionValidation(t *testing.T) {
 	}
 }
 
+func TestMachinePoolRolloutStrategyValidation(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxUnavailable *intstr.IntOrString
+		expectErr      bool
+	}{
+		{
+			name: "should succeed if maxUnavailable is not set",
+		},
+		{
+			name:           "should succeed for valid int maxUnavailable",
+			maxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+		{
+			name:           "should succeed for maxUnavailable 0",
+			maxUnavailable: ptr.To(intstr.FromInt32(0)),
+		},
+		{
+			name:           "should succeed for valid percentage maxUnavailable",
+			maxUnavailable: ptr.To(intstr.FromString("25%")),
+		},
+		{
+			name:           "should fail for invalid maxUnavailable",
+			maxUnavailable: ptr.To(intstr.FromString("1")),
+			expectErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mp := &clusterv1.MachinePool{
+				Spec: clusterv1.MachinePoolSpec{
+					Rollout: clusterv1.MachinePoolRolloutSpec{
+						Strategy: clusterv1.MachinePoolRolloutStrategy{
+							Type: clusterv1.RollingUpdateMachinePoolStrategyType,
+							RollingUpdate: clusterv1.MachinePoolRolloutStrategyRollingUpdate{
+								MaxUnavailable: tt.maxUnavailable,
+							},
+						},
+					},
+					Template: clusterv1.MachineTemplateSpec{
+						Spec: clusterv1.MachineSpec{
+							Bootstrap: clusterv1.Bootstrap{ConfigRef: clusterv1.ContractVersionedObjectReference{
+								Name: "bootstrap",
+							}},
+						},
+					},
+				},
+			}
+			webhook := &MachinePool{}
+
+			_, err := webhook.ValidateCreate(ctx, mp)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			_, err = webhook.ValidateUpdate(ctx, mp, mp)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestMachinePoolMetadataValidation(t *testing.T) {
 	tests := []struct {
 		name        string
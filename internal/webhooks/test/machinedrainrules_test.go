@@ -119,6 +119,79 @@ func Test_validate(t *testing.T) {
 				"MachineDrainRule.cluster.x-k8s.io \"mdr\" is invalid: " +
 				"spec.drain.order: Invalid value: 5: order must not be set if drain behavior is \"Skip\" or \"WaitCompleted\"",
 		},
+		{
+			name: "Return no error if MachineDrainRule with drain behavior EvictAfter is valid",
+			machineDrainRule: &clusterv1.MachineDrainRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mdr",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineDrainRuleSpec{
+					Drain: clusterv1.MachineDrainRuleDrainConfig{
+						Behavior: clusterv1.MachineDrainRuleDrainBehaviorEvictAfter,
+						Order:    ptr.To[int32](5),
+						EvictAfter: clusterv1.MachineDrainRuleEvictAfterConfig{
+							DelaySeconds: 30,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Return no error if MachineDrainRule with drain behavior ForceDelete is valid",
+			machineDrainRule: &clusterv1.MachineDrainRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mdr",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineDrainRuleSpec{
+					Drain: clusterv1.MachineDrainRuleDrainConfig{
+						Behavior: clusterv1.MachineDrainRuleDrainBehaviorForceDelete,
+						ForceDelete: clusterv1.MachineDrainRuleForceDeleteConfig{
+							TimeoutSeconds:     ptr.To[int32](600),
+							GracePeriodSeconds: ptr.To[int32](0),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Return error if evictAfter is not set with drain behavior EvictAfter",
+			machineDrainRule: &clusterv1.MachineDrainRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mdr",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineDrainRuleSpec{
+					Drain: clusterv1.MachineDrainRuleDrainConfig{
+						Behavior: clusterv1.MachineDrainRuleDrainBehaviorEvictAfter,
+					},
+				},
+			},
+			wantErr: "admission webhook \"validation.machinedrainrule.cluster.x-k8s.io\" denied the request: " +
+				"MachineDrainRule.cluster.x-k8s.io \"mdr\" is invalid: " +
+				"spec.drain.evictAfter: Required value: evictAfter must be set if drain behavior is \"EvictAfter\"",
+		},
+		{
+			name: "Return error if forceDelete is set with drain behavior Drain",
+			machineDrainRule: &clusterv1.MachineDrainRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mdr",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineDrainRuleSpec{
+					Drain: clusterv1.MachineDrainRuleDrainConfig{
+						Behavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						ForceDelete: clusterv1.MachineDrainRuleForceDeleteConfig{
+							TimeoutSeconds: ptr.To[int32](600),
+						},
+					},
+				},
+			},
+			wantErr: "admission webhook \"validation.machinedrainrule.cluster.x-k8s.io\" denied the request: " +
+				"MachineDrainRule.cluster.x-k8s.io \"mdr\" is invalid: " +
+				"spec.drain.forceDelete: Forbidden: forceDelete must not be set if drain behavior is not \"ForceDelete\"",
+		},
 		{
 			name: "Return error for MachineDrainRules with invalid selector",
 			machineDrainRule: &clusterv1.MachineDrainRule{
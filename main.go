@@ -124,6 +124,7 @@ var (
 	machinePoolConcurrency           int
 	clusterResourceSetConcurrency    int
 	machineHealthCheckConcurrency    int
+	kubeconfigRequestConcurrency     int
 	machineSetPreflightChecks        []string
 	skipCRDMigrationPhases           []string
 	additionalSyncMachineLabels      []string
@@ -221,6 +222,9 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&machineHealthCheckConcurrency, "machinehealthcheck-concurrency", 50,
 		"Number of machine health checks to process simultaneously")
 
+	fs.IntVar(&kubeconfigRequestConcurrency, "clusterkubeconfigrequest-concurrency", 10,
+		"Number of cluster kubeconfig requests to process simultaneously")
+
 	fs.StringSliceVar(&machineSetPreflightChecks, "machineset-preflight-checks", []string{
 		string(clusterv1.MachineSetPreflightCheckAll)},
 		"List of MachineSet preflight checks that should be run. Per default all of them are enabled."+
@@ -283,7 +287,7 @@ func InitFlags(fs *pflag.FlagSet) {
 // +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 // ADD CRD RBAC for CRD Migrator.
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
-// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions;customresourcedefinitions/status,verbs=update;patch,resourceNames=clusterclasses.cluster.x-k8s.io;clusterresourcesetbindings.addons.cluster.x-k8s.io;clusterresourcesets.addons.cluster.x-k8s.io;clusters.cluster.x-k8s.io;extensionconfigs.runtime.cluster.x-k8s.io;ipaddressclaims.ipam.cluster.x-k8s.io;ipaddresses.ipam.cluster.x-k8s.io;machinedeployments.cluster.x-k8s.io;machinedrainrules.cluster.x-k8s.io;machinehealthchecks.cluster.x-k8s.io;machinepools.cluster.x-k8s.io;machines.cluster.x-k8s.io;machinesets.cluster.x-k8s.io
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions;customresourcedefinitions/status,verbs=update;patch,resourceNames=clusterclasses.cluster.x-k8s.io;clusterkubeconfigrequests.cluster.x-k8s.io;clusterresourcesetbindings.addons.cluster.x-k8s.io;clusterresourcesets.addons.cluster.x-k8s.io;clusters.cluster.x-k8s.io;extensionconfigs.runtime.cluster.x-k8s.io;ipaddressclaims.ipam.cluster.x-k8s.io;ipaddresses.ipam.cluster.x-k8s.io;machinedeployments.cluster.x-k8s.io;machinedrainrules.cluster.x-k8s.io;machinehealthchecks.cluster.x-k8s.io;machinepools.cluster.x-k8s.io;machines.cluster.x-k8s.io;machinesets.cluster.x-k8s.io
 // ADD CR RBAC for CRD Migrator.
 // +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses;ipaddressclaims,verbs=get;list;watch;patch;update
 // +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims/status,verbs=patch;update
@@ -465,6 +469,9 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespace stri
 	if feature.Gates.Enabled(feature.MachinePool) {
 		crdMigratorConfig[&clusterv1.MachinePool{}] = crdmigrator.ByObjectConfig{UseCache: true, UseStatusForStorageVersionMigration: true}
 	}
+	if feature.Gates.Enabled(feature.ClusterKubeconfigRequest) {
+		crdMigratorConfig[&clusterv1.ClusterKubeconfigRequest{}] = crdmigrator.ByObjectConfig{UseCache: true, UseStatusForStorageVersionMigration: true}
+	}
 	crdMigratorSkipPhases := []crdmigrator.Phase{}
 	for _, p := range skipCRDMigrationPhases {
 		crdMigratorSkipPhases = append(crdMigratorSkipPhases, crdmigrator.Phase(p))
@@ -598,6 +605,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespace stri
 		Client:                      mgr.GetClient(),
 		APIReader:                   mgr.GetAPIReader(),
 		ClusterCache:                clusterCache,
+		SecretCachingClient:         secretCachingClient,
 		WatchFilterValue:            watchFilterValue,
 		RemoteConnectionGracePeriod: remoteConnectionGracePeriod,
 	}).SetupWithManager(ctx, mgr, concurrency(clusterConcurrency)); err != nil {
@@ -720,6 +728,16 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespace stri
 		os.Exit(1)
 	}
 
+	if feature.Gates.Enabled(feature.ClusterKubeconfigRequest) {
+		if err := (&controllers.ClusterKubeconfigRequestReconciler{
+			Client:           mgr.GetClient(),
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, concurrency(kubeconfigRequestConcurrency)); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "ClusterKubeconfigRequest")
+			os.Exit(1)
+		}
+	}
+
 	return clusterCache
 }
 
@@ -788,6 +806,13 @@ func setupWebhooks(_ context.Context, mgr ctrl.Manager, clusterCacheReader webho
 		os.Exit(1)
 	}
 
+	// NOTE: ClusterKubeconfigRequest is behind the ClusterKubeconfigRequest feature gate flag; the webhook
+	// is going to prevent creating or updating new objects in case the feature flag is disabled.
+	if err := (&webhooks.ClusterKubeconfigRequest{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "Unable to create webhook", "webhook", "ClusterKubeconfigRequest")
+		os.Exit(1)
+	}
+
 	// NOTE: ExtensionConfig is behind the RuntimeSDK feature gate flag. The webhook will prevent creating or updating
 	// new objects if the feature flag is disabled.
 	if err := (&webhooks.ExtensionConfig{}).SetupWebhookWithManager(mgr); err != nil {
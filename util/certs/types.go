@@ -45,6 +45,8 @@ type Config struct {
 	Organization []string
 	AltNames     AltNames
 	Usages       []x509.ExtKeyUsage
+	// NotAfter is the expiration time of the certificate; if not set, the certificate expires after DefaultCertDuration.
+	NotAfter time.Time
 }
 
 // NewSignedCert creates a signed certificate using the given CA certificate and key.
@@ -62,6 +64,11 @@ func (cfg *Config) NewSignedCert(key crypto.Signer, caCert *x509.Certificate, ca
 		return nil, errors.New("must specify at least one ExtKeyUsage")
 	}
 
+	notAfter := time.Now().Add(DefaultCertDuration)
+	if !cfg.NotAfter.IsZero() {
+		notAfter = cfg.NotAfter
+	}
+
 	tmpl := x509.Certificate{
 		Subject: pkix.Name{
 			CommonName:   cfg.CommonName,
@@ -71,7 +78,7 @@ func (cfg *Config) NewSignedCert(key crypto.Signer, caCert *x509.Certificate, ca
 		IPAddresses:  cfg.AltNames.IPs,
 		SerialNumber: serial,
 		NotBefore:    caCert.NotBefore,
-		NotAfter:     time.Now().Add(DefaultCertDuration).UTC(),
+		NotAfter:     notAfter.UTC(),
 		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsage:  cfg.Usages,
 	}
@@ -18,6 +18,7 @@ limitations under the License.
 package kubeconfig
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/x509"
@@ -31,6 +32,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -54,32 +56,53 @@ func FromSecret(ctx context.Context, c client.Reader, cluster client.ObjectKey)
 }
 
 // New creates a new Kubeconfig using the cluster name and specified endpoint.
+// By default the kubeconfig authenticates as the admin user; use the User, NotAfter and OIDC options
+// to generate a kubeconfig for a different user.
 func New(clusterName, endpoint string, caCert *x509.Certificate, caKey crypto.Signer, options ...KubeConfigOption) (*api.Config, error) {
+	kubeConfigOptions := &KubeConfigOptions{}
+	kubeConfigOptions.ApplyOptions(options)
+
+	userName := fmt.Sprintf("%s-admin", clusterName)
 	cfg := &certs.Config{
 		CommonName:   "kubernetes-admin",
 		Organization: []string{"system:masters"},
 		Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		NotAfter:     kubeConfigOptions.notAfter,
+	}
+	if kubeConfigOptions.user != nil {
+		userName = kubeConfigOptions.user.Name
+		cfg.CommonName = kubeConfigOptions.user.Name
+		cfg.Organization = kubeConfigOptions.user.Groups
 	}
 
-	kubeConfigOptions := &KubeConfigOptions{}
-	kubeConfigOptions.ApplyOptions(options)
+	var authInfo *api.AuthInfo
+	if kubeConfigOptions.oidc != nil {
+		if kubeConfigOptions.user == nil {
+			userName = "oidc"
+		}
+		authInfo = oidcAuthInfo(kubeConfigOptions.oidc)
+	} else {
+		clientKey, err := certs.NewSigner(kubeConfigOptions.keyEncryptionAlgorithm)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to create private key")
+		}
 
-	clientKey, err := certs.NewSigner(kubeConfigOptions.keyEncryptionAlgorithm)
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to create private key")
-	}
+		clientCert, err := cfg.NewSignedCert(clientKey, caCert, caKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to sign certificate")
+		}
 
-	clientCert, err := cfg.NewSignedCert(clientKey, caCert, caKey)
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to sign certificate")
-	}
+		encodedClientKey, err := certs.EncodePrivateKeyPEMFromSigner(clientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to encode private key")
+		}
 
-	encodedClientKey, err := certs.EncodePrivateKeyPEMFromSigner(clientKey)
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to encode private key")
+		authInfo = &api.AuthInfo{
+			ClientKeyData:         encodedClientKey,
+			ClientCertificateData: certs.EncodeCertPEM(clientCert),
+		}
 	}
 
-	userName := fmt.Sprintf("%s-admin", clusterName)
 	contextName := fmt.Sprintf("%s@%s", userName, clusterName)
 
 	return &api.Config{
@@ -96,15 +119,33 @@ func New(clusterName, endpoint string, caCert *x509.Certificate, caKey crypto.Si
 			},
 		},
 		AuthInfos: map[string]*api.AuthInfo{
-			userName: {
-				ClientKeyData:         encodedClientKey,
-				ClientCertificateData: certs.EncodeCertPEM(clientCert),
-			},
+			userName: authInfo,
 		},
 		CurrentContext: contextName,
 	}, nil
 }
 
+// oidcAuthInfo returns an AuthInfo getting tokens from an OpenID Connect issuer using the kubelogin credential plugin.
+func oidcAuthInfo(oidc *OIDC) *api.AuthInfo {
+	args := []string{
+		"oidc-login",
+		"get-token",
+		fmt.Sprintf("--oidc-issuer-url=%s", oidc.IssuerURL),
+		fmt.Sprintf("--oidc-client-id=%s", oidc.ClientID),
+	}
+	for _, scope := range oidc.ExtraScopes {
+		args = append(args, fmt.Sprintf("--oidc-extra-scope=%s", scope))
+	}
+	return &api.AuthInfo{
+		Exec: &api.ExecConfig{
+			APIVersion:      "client.authentication.k8s.io/v1",
+			Command:         "kubectl",
+			Args:            args,
+			InteractiveMode: api.IfAvailableExecInteractiveMode,
+		},
+	}
+}
+
 // CreateSecret creates the Kubeconfig secret for the given cluster.
 func CreateSecret(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, options ...KubeConfigOption) error {
 	name := util.ObjectKey(cluster)
@@ -122,7 +163,7 @@ func CreateSecretWithOwner(ctx context.Context, c client.Client, clusterName cli
 	if err != nil {
 		return err
 	}
-	out, err := generateKubeconfig(ctx, c, clusterName, server, options...)
+	out, err := Generate(ctx, c, clusterName, server, options...)
 	if err != nil {
 		return err
 	}
@@ -188,6 +229,69 @@ func NeedsClientCertRotation(configSecret *corev1.Secret, threshold time.Duratio
 	return false, nil
 }
 
+// ClientCertificateExpiration returns the earliest expiration time of the client certificates in the Kubeconfig secret.
+// A zero time is returned if the Kubeconfig does not use client certificates, e.g. when it is provided by the user
+// and it uses tokens or exec credential plugins.
+func ClientCertificateExpiration(configSecret *corev1.Secret) (time.Time, error) {
+	data, err := toKubeconfigBytes(configSecret)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
+	}
+
+	var expiration time.Time
+	for _, authInfo := range config.AuthInfos {
+		if len(authInfo.ClientCertificateData) == 0 {
+			continue
+		}
+		cert, err := certs.DecodeCertPEM(authInfo.ClientCertificateData)
+		if err != nil {
+			return time.Time{}, errors.Wrap(err, "failed to decode kubeconfig client certificate")
+		}
+		if expiration.IsZero() || cert.NotAfter.Before(expiration) {
+			expiration = cert.NotAfter
+		}
+	}
+
+	return expiration, nil
+}
+
+// NeedsCertificateAuthorityUpdate returns whether the certificate authorities trusted by the Kubeconfig secret
+// differ from the ones in the cluster CA secret, e.g. because certificate authorities are being rotated.
+func NeedsCertificateAuthorityUpdate(configSecret, caSecret *corev1.Secret) (bool, error) {
+	data, err := toKubeconfigBytes(configSecret)
+	if err != nil {
+		return false, err
+	}
+
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
+	}
+
+	caCerts, err := certutil.ParseCertsPEM(caSecret.Data[secret.TLSCrtDataName])
+	if err != nil {
+		return false, errors.Wrap(err, "failed to decode CA Cert")
+	}
+	expected := encodeCertsPEM(caCerts)
+
+	for _, cluster := range config.Clusters {
+		trustedCerts, err := certutil.ParseCertsPEM(cluster.CertificateAuthorityData)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to decode kubeconfig certificate authority data")
+		}
+		if !bytes.Equal(encodeCertsPEM(trustedCerts), expected) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // RegenerateSecret creates and stores a new Kubeconfig in the given secret.
 func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1.Secret, options ...KubeConfigOption) error {
 	clusterName, _, err := secret.ParseSecretName(configSecret.Name)
@@ -205,7 +309,7 @@ func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1
 	}
 	endpoint := config.Clusters[clusterName].Server
 	key := client.ObjectKey{Name: clusterName, Namespace: configSecret.Namespace}
-	out, err := generateKubeconfig(ctx, c, key, endpoint, options...)
+	out, err := Generate(ctx, c, key, endpoint, options...)
 	if err != nil {
 		return err
 	}
@@ -213,7 +317,9 @@ func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1
 	return c.Update(ctx, configSecret)
 }
 
-func generateKubeconfig(ctx context.Context, c client.Client, clusterName client.ObjectKey, endpoint string, options ...KubeConfigOption) ([]byte, error) {
+// Generate returns a new serialized Kubeconfig for the given cluster and endpoint, using the cluster CA
+// to sign the client certificate.
+func Generate(ctx context.Context, c client.Reader, clusterName client.ObjectKey, endpoint string, options ...KubeConfigOption) ([]byte, error) {
 	clusterCA, err := secret.GetFromNamespacedName(ctx, c, clusterName, secret.ClusterCA)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -241,6 +347,17 @@ func generateKubeconfig(ctx context.Context, c client.Client, clusterName client
 		return nil, errors.Wrap(err, "failed to generate a kubeconfig")
 	}
 
+	// If the CA Secret contains more than one certificate, e.g. while rotating certificate authorities,
+	// trust all of them, so the kubeconfig keeps working when the API server starts using a certificate
+	// signed by a different certificate authority.
+	caCerts, err := certutil.ParseCertsPEM(clusterCA.Data[secret.TLSCrtDataName])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode CA Cert")
+	}
+	if len(caCerts) > 1 {
+		cfg.Clusters[clusterName.Name].CertificateAuthorityData = encodeCertsPEM(caCerts)
+	}
+
 	out, err := clientcmd.Write(*cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to serialize config to yaml")
@@ -248,6 +365,15 @@ func generateKubeconfig(ctx context.Context, c client.Client, clusterName client
 	return out, nil
 }
 
+// encodeCertsPEM returns the PEM-encoded bundle of the given certificates.
+func encodeCertsPEM(certificates []*x509.Certificate) []byte {
+	out := []byte{}
+	for _, c := range certificates {
+		out = append(out, certs.EncodeCertPEM(c)...)
+	}
+	return out
+}
+
 func toKubeconfigBytes(out *corev1.Secret) ([]byte, error) {
 	data, ok := out.Data[secret.KubeconfigDataName]
 	if !ok {
@@ -177,6 +177,62 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewWithUser(t *testing.T) {
+	g := NewWithT(t)
+
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+	config, err := New("foo", "https://127.0.0.1:6443", caCert, caKey, User{Name: "alice", Groups: []string{"developers"}}, NotAfter(notAfter))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(config.CurrentContext).To(Equal("alice@foo"))
+	g.Expect(config.Contexts["alice@foo"].AuthInfo).To(Equal("alice"))
+	g.Expect(config.AuthInfos).To(HaveKey("alice"))
+
+	cert, err := certs.DecodeCertPEM(config.AuthInfos["alice"].ClientCertificateData)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cert.Subject.CommonName).To(Equal("alice"))
+	g.Expect(cert.Subject.Organization).To(Equal([]string{"developers"}))
+	g.Expect(cert.NotAfter).To(BeTemporally("==", notAfter))
+}
+
+func TestNewWithOIDC(t *testing.T) {
+	g := NewWithT(t)
+
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	config, err := New("foo", "https://127.0.0.1:6443", caCert, caKey, OIDC{IssuerURL: "https://issuer.example.com", ClientID: "capi", ExtraScopes: []string{"groups"}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(config.CurrentContext).To(Equal("oidc@foo"))
+	authInfo := config.AuthInfos["oidc"]
+	g.Expect(authInfo).ToNot(BeNil())
+	g.Expect(authInfo.ClientCertificateData).To(BeEmpty())
+	g.Expect(authInfo.ClientKeyData).To(BeEmpty())
+	g.Expect(authInfo.Exec).ToNot(BeNil())
+	g.Expect(authInfo.Exec.Command).To(Equal("kubectl"))
+	g.Expect(authInfo.Exec.Args).To(Equal([]string{
+		"oidc-login",
+		"get-token",
+		"--oidc-issuer-url=https://issuer.example.com",
+		"--oidc-client-id=capi",
+		"--oidc-extra-scope=groups",
+	}))
+
+	// Ensure the kubeconfig can be serialized and loaded.
+	out, err := clientcmd.Write(*config)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = clientcmd.Load(out)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
 func TestGenerateSecretWithOwner(t *testing.T) {
 	g := NewWithT(t)
 
@@ -435,6 +491,33 @@ func TestNeedsClientCertRotation(t *testing.T) {
 	g.Expect(NeedsClientCertRotation(kubeconfigSecret, certs.DefaultCertDuration-time.Hour)).To(BeFalse())
 }
 
+func TestClientCertificateExpiration(t *testing.T) {
+	g := NewWithT(t)
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+	config, err := New("foo", "https://127:0.0.1:4003", caCert, caKey, NotAfter(notAfter))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	out, err := clientcmd.Write(*config)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	kubeconfigSecret := GenerateSecretWithOwner(client.ObjectKey{Name: "test1", Namespace: "test"}, out, metav1.OwnerReference{})
+	g.Expect(ClientCertificateExpiration(kubeconfigSecret)).To(BeTemporally("==", notAfter))
+
+	// Kubeconfigs without client certificates, e.g. using tokens, do not expire.
+	config.AuthInfos[config.Contexts[config.CurrentContext].AuthInfo] = &api.AuthInfo{Token: "token"}
+	out, err = clientcmd.Write(*config)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	kubeconfigSecret = GenerateSecretWithOwner(client.ObjectKey{Name: "test1", Namespace: "test"}, out, metav1.OwnerReference{})
+	g.Expect(ClientCertificateExpiration(kubeconfigSecret)).To(BeZero())
+}
+
 func TestRegenerateClientCerts(t *testing.T) {
 	g := NewWithT(t)
 	caKey, err := certs.NewPrivateKey()
@@ -472,3 +555,58 @@ func TestRegenerateClientCerts(t *testing.T) {
 
 	g.Expect(newCert.NotAfter).To(BeTemporally(">", oldCert.NotAfter))
 }
+
+func TestNeedsCertificateAuthorityUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	oldCAKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+	oldCACert, err := getTestCACert(oldCAKey)
+	g.Expect(err).ToNot(HaveOccurred())
+	newCAKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+	newCACert, err := getTestCACert(newCAKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1-ca",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			secret.TLSKeyDataName: certs.EncodePrivateKeyPEM(oldCAKey),
+			secret.TLSCrtDataName: certs.EncodeCertPEM(oldCACert),
+		},
+	}
+	c := fake.NewClientBuilder().WithObjects(caSecret).Build()
+
+	g.Expect(CreateSecretWithOwner(ctx, c, client.ObjectKey{Name: "test1", Namespace: "test"}, "localhost:6443", metav1.OwnerReference{})).To(Succeed())
+	kubeconfigSecret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "test1-kubeconfig", Namespace: "test"}, kubeconfigSecret)).To(Succeed())
+
+	needsUpdate, err := NeedsCertificateAuthorityUpdate(kubeconfigSecret, caSecret)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(needsUpdate).To(BeFalse())
+
+	// Trust the new certificate authority in addition to the old one.
+	caSecret.Data[secret.TLSCrtDataName] = append(certs.EncodeCertPEM(oldCACert), certs.EncodeCertPEM(newCACert)...)
+	g.Expect(c.Update(ctx, caSecret)).To(Succeed())
+
+	needsUpdate, err = NeedsCertificateAuthorityUpdate(kubeconfigSecret, caSecret)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(needsUpdate).To(BeTrue())
+
+	g.Expect(RegenerateSecret(ctx, c, kubeconfigSecret)).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "test1-kubeconfig", Namespace: "test"}, kubeconfigSecret)).To(Succeed())
+
+	needsUpdate, err = NeedsCertificateAuthorityUpdate(kubeconfigSecret, caSecret)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(needsUpdate).To(BeFalse())
+
+	config, err := clientcmd.Load(kubeconfigSecret.Data[secret.KubeconfigDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(config.Clusters["test1"].CertificateAuthorityData).To(Equal(caSecret.Data[secret.TLSCrtDataName]))
+	clientCert, err := certs.DecodeCertPEM(config.AuthInfos["test1-admin"].ClientCertificateData)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(clientCert.CheckSignatureFrom(oldCACert)).To(Succeed())
+}
@@ -16,7 +16,11 @@ limitations under the License.
 
 package kubeconfig
 
-import bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+import (
+	"time"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+)
 
 // KubeConfigOption helps to modify KubeConfigOptions.
 type KubeConfigOption interface { //nolint:revive
@@ -27,6 +31,9 @@ type KubeConfigOption interface { //nolint:revive
 // KubeConfigOptions allows to set options for generating a kubeconfig.
 type KubeConfigOptions struct { //nolint:revive
 	keyEncryptionAlgorithm bootstrapv1.EncryptionAlgorithmType
+	user                   *User
+	notAfter               time.Time
+	oidc                   *OIDC
 }
 
 // ApplyOptions applies the given list options on these options,
@@ -44,3 +51,41 @@ type KeyEncryptionAlgorithm bootstrapv1.EncryptionAlgorithmType
 func (t KeyEncryptionAlgorithm) ApplyKubeConfigOption(opts *KubeConfigOptions) {
 	opts.keyEncryptionAlgorithm = bootstrapv1.EncryptionAlgorithmType(t)
 }
+
+// User allows to specify the user a kubeconfig is generated for; if not set, the kubeconfig
+// is generated for the admin user.
+type User struct {
+	// Name is the name of the user, used as the common name of the client certificate.
+	Name string
+	// Groups are the groups of the user, used as the organizations of the client certificate.
+	Groups []string
+}
+
+// ApplyKubeConfigOption applies this configuration to the given kube configuration options.
+func (u User) ApplyKubeConfigOption(opts *KubeConfigOptions) {
+	opts.user = &u
+}
+
+// NotAfter allows to specify the expiration time of the client certificate.
+type NotAfter time.Time
+
+// ApplyKubeConfigOption applies this configuration to the given kube configuration options.
+func (t NotAfter) ApplyKubeConfigOption(opts *KubeConfigOptions) {
+	opts.notAfter = time.Time(t)
+}
+
+// OIDC allows to generate a kubeconfig authenticating with OpenID Connect via the kubelogin
+// credential plugin instead of a client certificate.
+type OIDC struct {
+	// IssuerURL is the URL of the OpenID Connect issuer.
+	IssuerURL string
+	// ClientID is the OpenID Connect client ID.
+	ClientID string
+	// ExtraScopes are the scopes to request in addition to openid.
+	ExtraScopes []string
+}
+
+// ApplyKubeConfigOption applies this configuration to the given kube configuration options.
+func (o OIDC) ApplyKubeConfigOption(opts *KubeConfigOptions) {
+	opts.oidc = &o
+}
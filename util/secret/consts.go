@@ -27,6 +27,14 @@ const (
 
 	// TLSCrtDataName is the key used to store a TLS certificate in the secret's data field.
 	TLSCrtDataName = "tls.crt"
+
+	// NextTLSKeyDataName is the key used to store the private key replacing the one in TLSKeyDataName
+	// while a rotation is in progress.
+	NextTLSKeyDataName = "next.tls.key"
+
+	// NextTLSCrtDataName is the key used to store the certificate replacing the one in TLSCrtDataName
+	// while a rotation is in progress.
+	NextTLSCrtDataName = "next.tls.crt"
 )
 
 // Purpose is the name to append to the secret generated for a cluster.
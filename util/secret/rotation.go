@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/util/certs"
+)
+
+// A rotation replaces the key pair stored in a Secret created for a Certificate in three steps, so the
+// consumers of the Secret can be updated before the old key pair stops being trusted:
+//   - StartKeyPairRotation adds the new certificate (or public key) to the trusted ones, while the old
+//     key pair is still used for signing.
+//   - SwitchKeyPair starts using the new key pair for signing, while the old certificate is still trusted.
+//   - CompleteKeyPairRotation removes the old certificate.
+//
+// The signing certificate is always the first PEM block in TLSCrtDataName, so the Secret can be consumed
+// by code unaware of a rotation in progress.
+
+// GenerateKeyPair generates a new key pair for the given purpose; a CA certificate is generated for all the purposes
+// except ServiceAccount, for which a public and private key pair is generated.
+func GenerateKeyPair(purpose Purpose, validityPeriodDays int32, keyEncryptionAlgorithm bootstrapv1.EncryptionAlgorithmType) (*certs.KeyPair, error) {
+	if purpose == ServiceAccount {
+		return generateServiceAccountKeys(validityPeriodDays, keyEncryptionAlgorithm)
+	}
+	return generateCACert(validityPeriodDays, keyEncryptionAlgorithm)
+}
+
+// StartKeyPairRotation adds the next key pair to a Secret, and appends the next certificate to the trusted ones.
+// It returns true if the Secret has been changed, false if the rotation was already started.
+func StartKeyPairRotation(s *corev1.Secret, next *certs.KeyPair) (bool, error) {
+	if _, ok := s.Data[NextTLSCrtDataName]; ok {
+		return false, nil
+	}
+
+	current, err := pemBlocks(s.Data[TLSCrtDataName])
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse %s in Secret %s", TLSCrtDataName, s.Name)
+	}
+	if len(current) != 1 {
+		return false, errors.Errorf("failed to start rotation: Secret %s is expected to contain exactly one certificate, got %d", s.Name, len(current))
+	}
+	if _, ok := s.Data[TLSKeyDataName]; !ok {
+		return false, errors.Wrapf(ErrMissingKey, "failed to start rotation of Secret %s", s.Name)
+	}
+
+	s.Data[TLSCrtDataName] = bytes.Join([][]byte{current[0], next.Cert}, nil)
+	s.Data[NextTLSCrtDataName] = next.Cert
+	s.Data[NextTLSKeyDataName] = next.Key
+	return true, nil
+}
+
+// SwitchKeyPair makes the next key pair stored in a Secret the signing key pair, while keeping the previous
+// certificate in the trusted ones.
+// It returns true if the Secret has been changed, false if the key pair was already switched.
+func SwitchKeyPair(s *corev1.Secret) (bool, error) {
+	current, err := pemBlocks(s.Data[TLSCrtDataName])
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse %s in Secret %s", TLSCrtDataName, s.Name)
+	}
+
+	nextCrt, hasNextCrt := s.Data[NextTLSCrtDataName]
+	nextKey, hasNextKey := s.Data[NextTLSKeyDataName]
+	if !hasNextCrt || !hasNextKey {
+		if len(current) > 1 {
+			return false, nil
+		}
+		return false, errors.Errorf("failed to switch key pair: Secret %s does not contain the next key pair", s.Name)
+	}
+
+	s.Data[TLSCrtDataName] = bytes.Join([][]byte{nextCrt, current[0]}, nil)
+	s.Data[TLSKeyDataName] = nextKey
+	delete(s.Data, NextTLSCrtDataName)
+	delete(s.Data, NextTLSKeyDataName)
+	return true, nil
+}
+
+// CompleteKeyPairRotation removes all the certificates except the signing one from a Secret.
+// It returns true if the Secret has been changed, false if the rotation was already completed.
+func CompleteKeyPairRotation(s *corev1.Secret) (bool, error) {
+	if _, ok := s.Data[NextTLSCrtDataName]; ok {
+		return false, errors.Errorf("failed to complete rotation: Secret %s still contains the next key pair", s.Name)
+	}
+
+	current, err := pemBlocks(s.Data[TLSCrtDataName])
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse %s in Secret %s", TLSCrtDataName, s.Name)
+	}
+	if len(current) == 1 {
+		return false, nil
+	}
+
+	s.Data[TLSCrtDataName] = current[0]
+	return true, nil
+}
+
+// pemBlocks splits PEM encoded data into PEM encoded blocks.
+func pemBlocks(data []byte) ([][]byte, error) {
+	var blocks [][]byte
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, pem.EncodeToMemory(block))
+	}
+	if len(blocks) == 0 {
+		return nil, errors.New("no PEM data found")
+	}
+	return blocks, nil
+}
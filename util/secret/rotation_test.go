@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/cert"
+
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestKeyPairRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	current, err := secret.GenerateKeyPair(secret.ClusterCA, 0, "")
+	g.Expect(err).ToNot(HaveOccurred())
+	next, err := secret.GenerateKeyPair(secret.ClusterCA, 0, "")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	s := &corev1.Secret{
+		Data: map[string][]byte{
+			secret.TLSCrtDataName: current.Cert,
+			secret.TLSKeyDataName: current.Key,
+		},
+	}
+
+	// Completing or switching before starting a rotation is a no-op or an error.
+	changed, err := secret.CompleteKeyPairRotation(s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changed).To(BeFalse())
+	_, err = secret.SwitchKeyPair(s)
+	g.Expect(err).To(HaveOccurred())
+
+	// Start: both certificates are trusted, the current key pair is used for signing.
+	changed, err = secret.StartKeyPairRotation(s, next)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changed).To(BeTrue())
+	g.Expect(parseCerts(g, s.Data[secret.TLSCrtDataName])).To(Equal(parseCerts(g, current.Cert, next.Cert)))
+	g.Expect(s.Data[secret.TLSKeyDataName]).To(Equal(current.Key))
+
+	changed, err = secret.StartKeyPairRotation(s, next)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changed).To(BeFalse())
+	_, err = secret.CompleteKeyPairRotation(s)
+	g.Expect(err).To(HaveOccurred())
+
+	// Switch: both certificates are trusted, the next key pair is used for signing.
+	changed, err = secret.SwitchKeyPair(s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changed).To(BeTrue())
+	g.Expect(parseCerts(g, s.Data[secret.TLSCrtDataName])).To(Equal(parseCerts(g, next.Cert, current.Cert)))
+	g.Expect(s.Data[secret.TLSKeyDataName]).To(Equal(next.Key))
+	g.Expect(s.Data).ToNot(HaveKey(secret.NextTLSCrtDataName))
+	g.Expect(s.Data).ToNot(HaveKey(secret.NextTLSKeyDataName))
+
+	changed, err = secret.SwitchKeyPair(s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changed).To(BeFalse())
+
+	// Complete: only the next certificate is trusted.
+	changed, err = secret.CompleteKeyPairRotation(s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changed).To(BeTrue())
+	g.Expect(parseCerts(g, s.Data[secret.TLSCrtDataName])).To(Equal(parseCerts(g, next.Cert)))
+	g.Expect(s.Data[secret.TLSKeyDataName]).To(Equal(next.Key))
+
+	changed, err = secret.CompleteKeyPairRotation(s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changed).To(BeFalse())
+}
+
+func TestGenerateKeyPairServiceAccount(t *testing.T) {
+	g := NewWithT(t)
+
+	kp, err := secret.GenerateKeyPair(secret.ServiceAccount, 0, "")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(kp.Cert)).To(ContainSubstring("PUBLIC KEY"))
+}
+
+func parseCerts(g *WithT, data ...[]byte) []string {
+	var out []string
+	for _, d := range data {
+		certificates, err := cert.ParseCertsPEM(d)
+		g.Expect(err).ToNot(HaveOccurred())
+		for _, c := range certificates {
+			out = append(out, string(c.Raw))
+		}
+	}
+	return out
+}
@@ -110,6 +110,14 @@ func (webhook *MachineDrainRule) SetupWebhookWithManager(mgr ctrl.Manager) error
 	return (&webhooks.MachineDrainRule{}).SetupWebhookWithManager(mgr)
 }
 
+// ClusterKubeconfigRequest implements a validating webhook for ClusterKubeconfigRequest.
+type ClusterKubeconfigRequest struct{}
+
+// SetupWebhookWithManager sets up ClusterKubeconfigRequest webhooks.
+func (webhook *ClusterKubeconfigRequest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return (&webhooks.ClusterKubeconfigRequest{}).SetupWebhookWithManager(mgr)
+}
+
 // ClusterResourceSet implements a validating and defaulting webhook for ClusterResourceSet.
 type ClusterResourceSet struct{}
 
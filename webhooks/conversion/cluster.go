@@ -71,6 +71,12 @@ func ConvertClusterV1Beta1ToHub(_ context.Context, src *clusterv1beta1.Cluster,
 	if !reflect.DeepEqual(initialization, clusterv1.ClusterInitializationStatus{}) {
 		dst.Status.Initialization = initialization
 	}
+
+	// Recover other values.
+	if ok {
+		dst.Spec.Kubeconfig = restored.Spec.Kubeconfig
+		dst.Status.Kubeconfig = restored.Status.Kubeconfig
+	}
 	return nil
 }
 
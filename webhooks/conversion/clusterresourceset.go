@@ -23,6 +23,7 @@ import (
 
 	addonsv1beta1 "sigs.k8s.io/cluster-api/api/addons/v1beta1"
 	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 )
 
 // ClusterResourceSet is a HubSpokeConverter for the ClusterResourceSet API type.
@@ -32,10 +33,43 @@ var ClusterResourceSet = conversion.NewHubSpokeConverter(&addonsv1.ClusterResour
 
 // ConvertClusterResourceSetV1Beta1ToHub converts a v1beta1 ClusterResourceSet to a hub ClusterResourceSet.
 func ConvertClusterResourceSetV1Beta1ToHub(_ context.Context, src *addonsv1beta1.ClusterResourceSet, dst *addonsv1.ClusterResourceSet) error {
-	return addonsv1beta1.Convert_v1beta1_ClusterResourceSet_To_v1beta2_ClusterResourceSet(src, dst, nil)
+	if err := addonsv1beta1.Convert_v1beta1_ClusterResourceSet_To_v1beta2_ClusterResourceSet(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &addonsv1.ClusterResourceSet{}
+	ok, err := utilconversion.UnmarshalData(src, restored)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		dst.Spec.DependsOn = restored.Spec.DependsOn
+		dst.Spec.ReadinessChecks = restored.Spec.ReadinessChecks
+		for i := range dst.Spec.Resources {
+			if i >= len(restored.Spec.Resources) {
+				break
+			}
+			resource, restoredResource := &dst.Spec.Resources[i], restored.Spec.Resources[i]
+			if resource.Name != restoredResource.Name || resource.Kind != restoredResource.Kind {
+				continue
+			}
+			resource.OCI = restoredResource.OCI
+			resource.HTTP = restoredResource.HTTP
+			resource.HelmChart = restoredResource.HelmChart
+			resource.Templating = restoredResource.Templating
+		}
+	}
+
+	return nil
 }
 
 // ConvertClusterResourceSetHubToV1Beta1 converts a hub ClusterResourceSet to a v1beta1 ClusterResourceSet.
 func ConvertClusterResourceSetHubToV1Beta1(_ context.Context, src *addonsv1.ClusterResourceSet, dst *addonsv1beta1.ClusterResourceSet) error {
-	return addonsv1beta1.Convert_v1beta2_ClusterResourceSet_To_v1beta1_ClusterResourceSet(src, dst, nil)
+	if err := addonsv1beta1.Convert_v1beta2_ClusterResourceSet_To_v1beta1_ClusterResourceSet(src, dst, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalDataUnsafeNoCopy(src, dst)
 }
@@ -23,6 +23,7 @@ import (
 
 	addonsv1beta1 "sigs.k8s.io/cluster-api/api/addons/v1beta1"
 	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 )
 
 // ClusterResourceSetBinding is a HubSpokeConverter for the ClusterResourceSetBinding API type.
@@ -32,10 +33,48 @@ var ClusterResourceSetBinding = conversion.NewHubSpokeConverter(&addonsv1.Cluste
 
 // ConvertClusterResourceSetBindingV1Beta1ToHub converts a v1beta1 ClusterResourceSetBinding to a hub ClusterResourceSetBinding.
 func ConvertClusterResourceSetBindingV1Beta1ToHub(_ context.Context, src *addonsv1beta1.ClusterResourceSetBinding, dst *addonsv1.ClusterResourceSetBinding) error {
-	return addonsv1beta1.Convert_v1beta1_ClusterResourceSetBinding_To_v1beta2_ClusterResourceSetBinding(src, dst, nil)
+	if err := addonsv1beta1.Convert_v1beta1_ClusterResourceSetBinding_To_v1beta2_ClusterResourceSetBinding(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &addonsv1.ClusterResourceSetBinding{}
+	ok, err := utilconversion.UnmarshalData(src, restored)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		for i := range dst.Spec.Bindings {
+			if i >= len(restored.Spec.Bindings) || dst.Spec.Bindings[i].ClusterResourceSetName != restored.Spec.Bindings[i].ClusterResourceSetName {
+				continue
+			}
+			dst.Spec.Bindings[i].Ready = restored.Spec.Bindings[i].Ready
+			dst.Spec.Bindings[i].Message = restored.Spec.Bindings[i].Message
+			resources, restoredResources := dst.Spec.Bindings[i].Resources, restored.Spec.Bindings[i].Resources
+			for j := range resources {
+				if j >= len(restoredResources) {
+					break
+				}
+				if resources[j].Name != restoredResources[j].Name || resources[j].Kind != restoredResources[j].Kind {
+					continue
+				}
+				resources[j].ResourceRef = restoredResources[j].ResourceRef
+				resources[j].Digest = restoredResources[j].Digest
+				resources[j].Objects = restoredResources[j].Objects
+				resources[j].LastDriftCorrectedTime = restoredResources[j].LastDriftCorrectedTime
+			}
+		}
+	}
+
+	return nil
 }
 
 // ConvertClusterResourceSetBindingHubToV1Beta1 converts a hub ClusterResourceSetBinding to a v1beta1 ClusterResourceSetBinding.
 func ConvertClusterResourceSetBindingHubToV1Beta1(_ context.Context, src *addonsv1.ClusterResourceSetBinding, dst *addonsv1beta1.ClusterResourceSetBinding) error {
-	return addonsv1beta1.Convert_v1beta2_ClusterResourceSetBinding_To_v1beta1_ClusterResourceSetBinding(src, dst, nil)
+	if err := addonsv1beta1.Convert_v1beta2_ClusterResourceSetBinding_To_v1beta1_ClusterResourceSetBinding(src, dst, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalDataUnsafeNoCopy(src, dst)
 }
@@ -52,6 +52,11 @@ func ConvertMachineDeploymentV1Beta1ToHub(_ context.Context, src *clusterv1beta1
 	// Recover intent for bool values converted to *bool.
 	clusterv1.Convert_bool_To_Pointer_bool(src.Spec.Paused, ok, restored.Spec.Paused, &dst.Spec.Paused)
 
+	// Recover other values.
+	if ok {
+		dst.Spec.Rollout.Strategy.Staged = restored.Spec.Rollout.Strategy.Staged
+	}
+
 	return nil
 }
 
@@ -48,6 +48,13 @@ func ConvertMachineHealthCheckV1Beta1ToHub(_ context.Context, src *clusterv1beta
 	clusterv1.Convert_int32_To_Pointer_int32(src.Status.CurrentHealthy, ok, restored.Status.CurrentHealthy, &dst.Status.CurrentHealthy)
 	clusterv1.Convert_int32_To_Pointer_int32(src.Status.RemediationsAllowed, ok, restored.Status.RemediationsAllowed, &dst.Status.RemediationsAllowed)
 
+	if ok {
+		dst.Spec.Checks.Probes = restored.Spec.Checks.Probes
+		dst.Spec.Remediation.RateLimit = restored.Spec.Remediation.RateLimit
+		dst.Spec.Remediation.Escalation = restored.Spec.Remediation.Escalation
+		dst.Status.Remediations = restored.Status.Remediations
+	}
+
 	return nil
 }
 
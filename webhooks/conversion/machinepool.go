@@ -60,6 +60,11 @@ func ConvertMachinePoolV1Beta1ToHub(_ context.Context, src *clusterv1beta1.Machi
 		dst.Status.Initialization = initialization
 	}
 
+	// Recover other values.
+	if ok {
+		dst.Spec.Rollout = restored.Spec.Rollout
+	}
+
 	return nil
 }
 